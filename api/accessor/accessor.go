@@ -1,6 +1,9 @@
 package accessor
 
 import (
+	"sort"
+
+	"github.com/concourse/atc"
 	jwt "github.com/dgrijalva/jwt-go"
 )
 
@@ -9,9 +12,12 @@ import (
 type Access interface {
 	IsAuthenticated() bool
 	IsAuthorized(string) bool
+	HasRole(string, string) bool
 	IsAdmin() bool
 	IsSystem() bool
 	TeamNames() []string
+	TeamRoles() map[string][]string
+	UserName() string
 	CSRFToken() string
 }
 
@@ -23,6 +29,7 @@ func (a *access) IsAuthenticated() bool {
 	return a.Token.Valid
 }
 
+// IsAuthorized returns true if the requester has any role in the team.
 func (a *access) IsAuthorized(team string) bool {
 	for _, teamName := range a.TeamNames() {
 		if teamName == team {
@@ -32,6 +39,17 @@ func (a *access) IsAuthorized(team string) bool {
 	return false
 }
 
+// HasRole returns true if the requester has a role in the team which grants
+// at least the access of the given role.
+func (a *access) HasRole(role string, team string) bool {
+	for _, teamRole := range a.TeamRoles()[team] {
+		if atc.RoleSatisfies(teamRole, role) {
+			return true
+		}
+	}
+	return false
+}
+
 func (a *access) IsAdmin() bool {
 	if claims, ok := a.Token.Claims.(jwt.MapClaims); ok {
 		if isAdminClaim, ok := claims["is_admin"]; ok {
@@ -55,6 +73,7 @@ func (a *access) IsSystem() bool {
 func (a *access) TeamNames() []string {
 	if claims, ok := a.Token.Claims.(jwt.MapClaims); ok {
 		if teamsClaim, ok := claims["teams"]; ok {
+			// tokens issued before roles existed list team names only
 			if teamsArr, ok := teamsClaim.([]interface{}); ok {
 				var teams []string
 				for _, teamObj := range teamsArr {
@@ -64,11 +83,66 @@ func (a *access) TeamNames() []string {
 				}
 				return teams
 			}
+
+			if teamsMap, ok := teamsClaim.(map[string]interface{}); ok {
+				var teams []string
+				for team := range teamsMap {
+					teams = append(teams, team)
+				}
+				sort.Strings(teams)
+				return teams
+			}
 		}
 	}
 	return []string{}
 }
 
+// TeamRoles returns the roles the requester has in each of their teams, as
+// carried in the 'teams' claim, e.g. {"main": ["owner"]}.
+//
+// Tokens issued before roles existed only list team names; the requester is
+// treated as an owner of each of them, as they were before roles existed.
+func (a *access) TeamRoles() map[string][]string {
+	teamRoles := map[string][]string{}
+
+	if claims, ok := a.Token.Claims.(jwt.MapClaims); ok {
+		if teamsClaim, ok := claims["teams"]; ok {
+			if teamsArr, ok := teamsClaim.([]interface{}); ok {
+				for _, teamObj := range teamsArr {
+					if team, ok := teamObj.(string); ok {
+						teamRoles[team] = []string{atc.OwnerRole}
+					}
+				}
+			}
+
+			if teamsMap, ok := teamsClaim.(map[string]interface{}); ok {
+				for team, rolesObj := range teamsMap {
+					if rolesArr, ok := rolesObj.([]interface{}); ok {
+						for _, roleObj := range rolesArr {
+							if role, ok := roleObj.(string); ok {
+								teamRoles[team] = append(teamRoles[team], role)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return teamRoles
+}
+
+func (a *access) UserName() string {
+	if claims, ok := a.Token.Claims.(jwt.MapClaims); ok {
+		if userNameClaim, ok := claims["user_name"]; ok {
+			if userName, ok := userNameClaim.(string); ok {
+				return userName
+			}
+		}
+	}
+	return ""
+}
+
 func (a *access) CSRFToken() string {
 	if claims, ok := a.Token.Claims.(jwt.MapClaims); ok {
 		if csrfTokenClaim, ok := claims["csrf"]; ok {
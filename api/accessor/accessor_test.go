@@ -259,6 +259,34 @@ var _ = Describe("Accessor", func() {
 		})
 	})
 
+	Describe("Get User Name", func() {
+		JustBeforeEach(func() {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+			tokenString, err := token.SignedString(key)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", fmt.Sprintf("BEARER %s", tokenString))
+			access = accessorFactory.Create(req)
+		})
+
+		Context("when request has user_name claim set", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{"user_name": "some-user"}
+			})
+			It("returns the user name", func() {
+				Expect(access.UserName()).To(Equal("some-user"))
+			})
+		})
+
+		Context("when request does not have user_name claim set", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{}
+			})
+			It("returns empty", func() {
+				Expect(access.UserName()).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("Get Team Names", func() {
 		JustBeforeEach(func() {
 			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
@@ -301,4 +329,45 @@ var _ = Describe("Accessor", func() {
 			})
 		})
 	})
+
+	Describe("Get Team Roles", func() {
+		JustBeforeEach(func() {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+			tokenString, err := token.SignedString(key)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", fmt.Sprintf("BEARER %s", tokenString))
+			access = accessorFactory.Create(req)
+		})
+
+		Context("when request has teams claim set with roles", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{"teams": map[string][]string{
+					"some-team":  {"owner"},
+					"other-team": {"viewer"},
+				}}
+			})
+			It("returns the roles for each team", func() {
+				Expect(access.TeamRoles()).To(Equal(map[string][]string{
+					"some-team":  {"owner"},
+					"other-team": {"viewer"},
+				}))
+			})
+			It("only grants each team's role", func() {
+				Expect(access.HasRole("member", "some-team")).To(BeTrue())
+				Expect(access.HasRole("member", "other-team")).To(BeFalse())
+			})
+		})
+		Context("when request has teams claim set without roles", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{"teams": []string{"some-team"}}
+			})
+			It("grants the owner role", func() {
+				Expect(access.TeamRoles()).To(Equal(map[string][]string{
+					"some-team": {"owner"},
+				}))
+				Expect(access.HasRole("owner", "some-team")).To(BeTrue())
+				Expect(access.HasRole("owner", "other-team")).To(BeFalse())
+			})
+		})
+	})
 })
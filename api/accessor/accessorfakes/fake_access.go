@@ -28,6 +28,18 @@ type FakeAccess struct {
 	isAuthorizedReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	HasRoleStub        func(string, string) bool
+	hasRoleMutex       sync.RWMutex
+	hasRoleArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	hasRoleReturns struct {
+		result1 bool
+	}
+	hasRoleReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	IsAdminStub        func() bool
 	isAdminMutex       sync.RWMutex
 	isAdminArgsForCall []struct{}
@@ -55,6 +67,24 @@ type FakeAccess struct {
 	teamNamesReturnsOnCall map[int]struct {
 		result1 []string
 	}
+	TeamRolesStub        func() map[string][]string
+	teamRolesMutex       sync.RWMutex
+	teamRolesArgsForCall []struct{}
+	teamRolesReturns     struct {
+		result1 map[string][]string
+	}
+	teamRolesReturnsOnCall map[int]struct {
+		result1 map[string][]string
+	}
+	UserNameStub        func() string
+	userNameMutex       sync.RWMutex
+	userNameArgsForCall []struct{}
+	userNameReturns     struct {
+		result1 string
+	}
+	userNameReturnsOnCall map[int]struct {
+		result1 string
+	}
 	CSRFTokenStub        func() string
 	cSRFTokenMutex       sync.RWMutex
 	cSRFTokenArgsForCall []struct{}
@@ -156,6 +186,55 @@ func (fake *FakeAccess) IsAuthorizedReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeAccess) HasRole(arg1 string, arg2 string) bool {
+	fake.hasRoleMutex.Lock()
+	ret, specificReturn := fake.hasRoleReturnsOnCall[len(fake.hasRoleArgsForCall)]
+	fake.hasRoleArgsForCall = append(fake.hasRoleArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("HasRole", []interface{}{arg1, arg2})
+	fake.hasRoleMutex.Unlock()
+	if fake.HasRoleStub != nil {
+		return fake.HasRoleStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.hasRoleReturns.result1
+}
+
+func (fake *FakeAccess) HasRoleCallCount() int {
+	fake.hasRoleMutex.RLock()
+	defer fake.hasRoleMutex.RUnlock()
+	return len(fake.hasRoleArgsForCall)
+}
+
+func (fake *FakeAccess) HasRoleArgsForCall(i int) (string, string) {
+	fake.hasRoleMutex.RLock()
+	defer fake.hasRoleMutex.RUnlock()
+	return fake.hasRoleArgsForCall[i].arg1, fake.hasRoleArgsForCall[i].arg2
+}
+
+func (fake *FakeAccess) HasRoleReturns(result1 bool) {
+	fake.HasRoleStub = nil
+	fake.hasRoleReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeAccess) HasRoleReturnsOnCall(i int, result1 bool) {
+	fake.HasRoleStub = nil
+	if fake.hasRoleReturnsOnCall == nil {
+		fake.hasRoleReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.hasRoleReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeAccess) IsAdmin() bool {
 	fake.isAdminMutex.Lock()
 	ret, specificReturn := fake.isAdminReturnsOnCall[len(fake.isAdminArgsForCall)]
@@ -276,6 +355,86 @@ func (fake *FakeAccess) TeamNamesReturnsOnCall(i int, result1 []string) {
 	}{result1}
 }
 
+func (fake *FakeAccess) TeamRoles() map[string][]string {
+	fake.teamRolesMutex.Lock()
+	ret, specificReturn := fake.teamRolesReturnsOnCall[len(fake.teamRolesArgsForCall)]
+	fake.teamRolesArgsForCall = append(fake.teamRolesArgsForCall, struct{}{})
+	fake.recordInvocation("TeamRoles", []interface{}{})
+	fake.teamRolesMutex.Unlock()
+	if fake.TeamRolesStub != nil {
+		return fake.TeamRolesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.teamRolesReturns.result1
+}
+
+func (fake *FakeAccess) TeamRolesCallCount() int {
+	fake.teamRolesMutex.RLock()
+	defer fake.teamRolesMutex.RUnlock()
+	return len(fake.teamRolesArgsForCall)
+}
+
+func (fake *FakeAccess) TeamRolesReturns(result1 map[string][]string) {
+	fake.TeamRolesStub = nil
+	fake.teamRolesReturns = struct {
+		result1 map[string][]string
+	}{result1}
+}
+
+func (fake *FakeAccess) TeamRolesReturnsOnCall(i int, result1 map[string][]string) {
+	fake.TeamRolesStub = nil
+	if fake.teamRolesReturnsOnCall == nil {
+		fake.teamRolesReturnsOnCall = make(map[int]struct {
+			result1 map[string][]string
+		})
+	}
+	fake.teamRolesReturnsOnCall[i] = struct {
+		result1 map[string][]string
+	}{result1}
+}
+
+func (fake *FakeAccess) UserName() string {
+	fake.userNameMutex.Lock()
+	ret, specificReturn := fake.userNameReturnsOnCall[len(fake.userNameArgsForCall)]
+	fake.userNameArgsForCall = append(fake.userNameArgsForCall, struct{}{})
+	fake.recordInvocation("UserName", []interface{}{})
+	fake.userNameMutex.Unlock()
+	if fake.UserNameStub != nil {
+		return fake.UserNameStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.userNameReturns.result1
+}
+
+func (fake *FakeAccess) UserNameCallCount() int {
+	fake.userNameMutex.RLock()
+	defer fake.userNameMutex.RUnlock()
+	return len(fake.userNameArgsForCall)
+}
+
+func (fake *FakeAccess) UserNameReturns(result1 string) {
+	fake.UserNameStub = nil
+	fake.userNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeAccess) UserNameReturnsOnCall(i int, result1 string) {
+	fake.UserNameStub = nil
+	if fake.userNameReturnsOnCall == nil {
+		fake.userNameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.userNameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeAccess) CSRFToken() string {
 	fake.cSRFTokenMutex.Lock()
 	ret, specificReturn := fake.cSRFTokenReturnsOnCall[len(fake.cSRFTokenArgsForCall)]
@@ -323,6 +482,8 @@ func (fake *FakeAccess) Invocations() map[string][][]interface{} {
 	defer fake.isAuthenticatedMutex.RUnlock()
 	fake.isAuthorizedMutex.RLock()
 	defer fake.isAuthorizedMutex.RUnlock()
+	fake.hasRoleMutex.RLock()
+	defer fake.hasRoleMutex.RUnlock()
 	fake.isAdminMutex.RLock()
 	defer fake.isAdminMutex.RUnlock()
 	fake.isSystemMutex.RLock()
@@ -52,6 +52,7 @@ var (
 	dbWorkerLifecycle       *dbfakes.FakeWorkerLifecycle
 	build                   *dbfakes.FakeBuild
 	dbBuildFactory          *dbfakes.FakeBuildFactory
+	dbKeyRotationFactory    *dbfakes.FakeKeyRotationFactory
 	dbTeam                  *dbfakes.FakeTeam
 	fakeSchedulerFactory    *jobserverfakes.FakeSchedulerFactory
 	fakeScannerFactory      *resourceserverfakes.FakeScannerFactory
@@ -97,6 +98,7 @@ var _ = BeforeEach(func() {
 	dbJobFactory = new(dbfakes.FakeJobFactory)
 	dbResourceFactory = new(dbfakes.FakeResourceFactory)
 	dbBuildFactory = new(dbfakes.FakeBuildFactory)
+	dbKeyRotationFactory = new(dbfakes.FakeKeyRotationFactory)
 
 	interceptTimeoutFactory = new(containerserverfakes.FakeInterceptTimeoutFactory)
 	interceptTimeout = new(containerserverfakes.FakeInterceptTimeout)
@@ -177,6 +179,7 @@ var _ = BeforeEach(func() {
 		fakeContainerRepository,
 		fakeDestroyer,
 		dbBuildFactory,
+		dbKeyRotationFactory,
 
 		peerURL,
 		constructedEventHandler.Construct,
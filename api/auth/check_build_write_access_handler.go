@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/db"
 )
@@ -67,7 +68,7 @@ func (h checkBuildWriteAccessHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	if !acc.IsAuthorized(build.TeamName()) {
+	if !acc.HasRole(atc.OperatorRole, build.TeamName()) {
 		h.rejector.Forbidden(w, r)
 		return
 	}
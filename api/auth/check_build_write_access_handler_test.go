@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/atc/api/auth"
@@ -64,7 +65,7 @@ var _ = Describe("CheckBuildWriteAccessHandler", func() {
 	Context("when authenticated and accessing same team's build", func() {
 		BeforeEach(func() {
 			fakeaccess.IsAuthenticatedReturns(true)
-			fakeaccess.IsAuthorizedReturns(true)
+			fakeaccess.HasRoleReturns(true)
 		})
 
 		Context("when build exists", func() {
@@ -80,6 +81,12 @@ var _ = Describe("CheckBuildWriteAccessHandler", func() {
 				Expect(delegate.IsCalled).To(BeTrue())
 				Expect(delegate.ContextBuild).To(BeIdenticalTo(build))
 			})
+
+			It("requires the operator role in the build's team", func() {
+				role, teamName := fakeaccess.HasRoleArgsForCall(0)
+				Expect(role).To(Equal(atc.OperatorRole))
+				Expect(teamName).To(Equal("some-team"))
+			})
 		})
 
 		Context("when build is not found", func() {
@@ -103,10 +110,10 @@ var _ = Describe("CheckBuildWriteAccessHandler", func() {
 		})
 	})
 
-	Context("when authenticated but accessing different team's build", func() {
+	Context("when authenticated but without a role which can modify the team's builds", func() {
 		BeforeEach(func() {
 			fakeaccess.IsAuthenticatedReturns(true)
-			fakeaccess.IsAuthorizedReturns(false)
+			fakeaccess.HasRoleReturns(false)
 			buildFactory.BuildReturns(build, true, nil)
 		})
 
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/api/accessor"
+)
+
+type checkTeamRoleHandler struct {
+	handler  http.Handler
+	rejector Rejector
+	role     string
+}
+
+// CheckTeamRoleHandler only lets through requests from admins and users who
+// have at least the given role in the team named in the request.
+func CheckTeamRoleHandler(
+	handler http.Handler,
+	rejector Rejector,
+	role string,
+) http.Handler {
+	return checkTeamRoleHandler{
+		handler:  handler,
+		rejector: rejector,
+		role:     role,
+	}
+}
+
+func (h checkTeamRoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	acc := accessor.GetAccessor(r)
+
+	if !acc.IsAuthenticated() {
+		h.rejector.Unauthorized(w, r)
+		return
+	}
+
+	teamName := r.URL.Query().Get(":team_name")
+
+	if !acc.IsAdmin() && !acc.HasRole(h.role, teamName) {
+		h.rejector.Forbidden(w, r)
+		return
+	}
+
+	h.handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,150 @@
+package auth_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
+	"github.com/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/atc/api/auth"
+	"github.com/concourse/atc/api/auth/authfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckTeamRoleHandler", func() {
+	var (
+		fakeAccessor *accessorfakes.FakeAccessFactory
+		fakeaccess   *accessorfakes.FakeAccess
+		fakeRejector *authfakes.FakeRejector
+
+		server *httptest.Server
+		client *http.Client
+	)
+
+	simpleHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buffer := bytes.NewBufferString("simple ")
+
+		io.Copy(w, buffer)
+		io.Copy(w, r.Body)
+	})
+
+	BeforeEach(func() {
+		fakeAccessor = new(accessorfakes.FakeAccessFactory)
+		fakeaccess = new(accessorfakes.FakeAccess)
+		fakeRejector = new(authfakes.FakeRejector)
+
+		fakeRejector.UnauthorizedStub = func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "nope", http.StatusUnauthorized)
+		}
+
+		fakeRejector.ForbiddenStub = func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "nope", http.StatusForbidden)
+		}
+
+		server = httptest.NewServer(accessor.NewHandler(auth.CheckTeamRoleHandler(
+			simpleHandler,
+			fakeRejector,
+			atc.MemberRole,
+		), fakeAccessor),
+		)
+
+		client = &http.Client{
+			Transport: &http.Transport{},
+		}
+	})
+
+	JustBeforeEach(func() {
+		fakeAccessor.CreateReturns(fakeaccess)
+	})
+
+	Context("when a request is made", func() {
+		var request *http.Request
+		var response *http.Response
+
+		BeforeEach(func() {
+			var err error
+			request, err = http.NewRequest("GET", server.URL+"/teams/some-team/pipelines", bytes.NewBufferString("hello"))
+			Expect(err).NotTo(HaveOccurred())
+			urlValues := url.Values{":team_name": []string{"some-team"}}
+			request.URL.RawQuery = urlValues.Encode()
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the request is authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the requester has the role in the request's team", func() {
+				BeforeEach(func() {
+					fakeaccess.HasRoleReturns(true)
+				})
+
+				It("checks for the role in the request's team", func() {
+					role, teamName := fakeaccess.HasRoleArgsForCall(0)
+					Expect(role).To(Equal(atc.MemberRole))
+					Expect(teamName).To(Equal("some-team"))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("proxies to the handler", func() {
+					responseBody, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(responseBody)).To(Equal("simple hello"))
+				})
+			})
+
+			Context("when the requester does not have the role in the request's team", func() {
+				BeforeEach(func() {
+					fakeaccess.HasRoleReturns(false)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					responseBody, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(responseBody)).To(Equal("nope\n"))
+				})
+
+				Context("when the requester is an admin", func() {
+					BeforeEach(func() {
+						fakeaccess.IsAdminReturns(true)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+			})
+		})
+
+		Context("when the request is not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				responseBody, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(responseBody)).To(Equal("nope\n"))
+			})
+		})
+	})
+})
@@ -3,6 +3,7 @@ package auth
 import (
 	"net/http"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/db"
 )
@@ -66,7 +67,7 @@ func (h checkWorkerTeamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	}
 
 	if worker.TeamName() != "" {
-		if !acc.IsAuthorized(worker.TeamName()) {
+		if !acc.HasRole(atc.OperatorRole, worker.TeamName()) {
 			h.rejector.Forbidden(w, r)
 			return
 		}
@@ -88,7 +88,7 @@ var _ = Describe("CheckWorkerTeamAccessHandler", func() {
 	Context("when authenticated", func() {
 		BeforeEach(func() {
 			fakeaccess.IsAuthenticatedReturns(true)
-			fakeaccess.IsAuthorizedReturns(true)
+			fakeaccess.HasRoleReturns(true)
 		})
 
 		Context("when worker exists and belongs to a team", func() {
@@ -114,24 +114,30 @@ var _ = Describe("CheckWorkerTeamAccessHandler", func() {
 				})
 			})
 
-			Context("when team in auth matches worker team", func() {
+			Context("when the requester has a role in the worker team which may manage workers", func() {
 				BeforeEach(func() {
-					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 				})
 
 				It("fetches worker by the correct name", func() {
 					Expect(workerFactory.GetWorkerArgsForCall(0)).To(Equal("some-worker"))
 				})
 
+				It("requires at least the operator role in the worker's team", func() {
+					role, teamName := fakeaccess.HasRoleArgsForCall(0)
+					Expect(role).To(Equal(atc.OperatorRole))
+					Expect(teamName).To(Equal("some-team"))
+				})
+
 				It("calls worker delegate", func() {
 					Expect(delegate.IsCalled).To(BeTrue())
 					Expect(response.StatusCode).To(Equal(http.StatusOK))
 				})
 			})
 
-			Context("when team in auth does not match worker team", func() {
+			Context("when the requester has no role in the worker team which may manage workers", func() {
 				BeforeEach(func() {
-					fakeaccess.IsAuthorizedReturns(false)
+					fakeaccess.HasRoleReturns(false)
 				})
 
 				It("fetches worker by the correct name", func() {
@@ -150,7 +156,7 @@ var _ = Describe("CheckWorkerTeamAccessHandler", func() {
 
 		Context("when worker is not owned by a team", func() {
 			BeforeEach(func() {
-				fakeaccess.IsAuthorizedReturns(false)
+				fakeaccess.HasRoleReturns(false)
 				fakeWorker = new(dbfakes.FakeWorker)
 				fakeWorker.NameReturns("some-worker")
 
@@ -69,6 +69,7 @@ var _ = Describe("Builds API", func() {
 		Context("when authorized", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			Context("when creating a one-off build succeeds", func() {
@@ -577,6 +578,7 @@ var _ = Describe("Builds API", func() {
 					Context("when user is authorized", func() {
 						BeforeEach(func() {
 							fakeaccess.IsAuthorizedReturns(true)
+							fakeaccess.HasRoleReturns(true)
 						})
 
 						It("returns 200 OK", func() {
@@ -686,6 +688,7 @@ var _ = Describe("Builds API", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthenticatedReturns(true)
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 				})
 
 				It("returns 200 OK", func() {
@@ -892,6 +895,7 @@ var _ = Describe("Builds API", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthenticatedReturns(true)
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 				})
 
 				It("returns 200", func() {
@@ -1063,6 +1067,7 @@ var _ = Describe("Builds API", func() {
 				Context("when accessing same team's build", func() {
 					BeforeEach(func() {
 						fakeaccess.IsAuthorizedReturns(true)
+						fakeaccess.HasRoleReturns(true)
 					})
 
 					Context("when the engine returns a build", func() {
@@ -1287,6 +1292,7 @@ var _ = Describe("Builds API", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthenticatedReturns(true)
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 				})
 
 				It("fetches data from the db", func() {
@@ -1449,6 +1455,7 @@ var _ = Describe("Builds API", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthenticatedReturns(true)
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 				})
 
 				Context("when the build returns a plan", func() {
@@ -1546,6 +1553,7 @@ var _ = Describe("Builds API", func() {
 				Context("when accessing same teams build", func() {
 					BeforeEach(func() {
 						fakeaccess.IsAuthorizedReturns(true)
+						fakeaccess.HasRoleReturns(true)
 					})
 
 					Context("when the build is tracked by the current ATC", func() {
@@ -1739,6 +1747,7 @@ var _ = Describe("Builds API", func() {
 				Context("when accessing same team's build", func() {
 					BeforeEach(func() {
 						fakeaccess.IsAuthorizedReturns(true)
+						fakeaccess.HasRoleReturns(true)
 					})
 
 					Context("when the build is tracked by the current ATC", func() {
@@ -8,6 +8,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/accessor/accessorfakes"
@@ -146,6 +147,7 @@ var _ = Describe("Config API", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			Context("when the team is found", func() {
@@ -286,6 +288,40 @@ var _ = Describe("Config API", func() {
 										RawConfig: atc.RawConfig(rawConfig),
 									}))
 								})
+
+								Context("when the saved config has notifications", func() {
+									var notifications atc.NotificationConfigs
+
+									BeforeEach(func() {
+										notifications = atc.NotificationConfigs{
+											{
+												Name: "some-notification",
+												URL:  "https://example.com/hook",
+												On:   []atc.BuildStatus{atc.StatusFailed},
+											},
+										}
+
+										fakePipeline.ConfigReturns(atc.Config{Notifications: notifications}, true, nil)
+									})
+
+									It("includes them in the config", func() {
+										var actualConfigResponse atc.ConfigResponse
+										err := json.NewDecoder(response.Body).Decode(&actualConfigResponse)
+										Expect(err).NotTo(HaveOccurred())
+
+										Expect(actualConfigResponse.Config.Notifications).To(Equal(notifications))
+									})
+								})
+
+								Context("when getting the saved config fails", func() {
+									BeforeEach(func() {
+										fakePipeline.ConfigReturns(atc.Config{}, false, errors.New("failed"))
+									})
+
+									It("returns 500", func() {
+										Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+									})
+								})
 							})
 
 							Context("when finding the resource types fails", func() {
@@ -399,6 +435,8 @@ var _ = Describe("Config API", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
+				fakeaccess.UserNameReturns("some-user")
 			})
 
 			Context("when a config version is specified", func() {
@@ -431,7 +469,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+							Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(Equal(0))
 						})
 					})
 
@@ -459,7 +497,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+							Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(Equal(0))
 						})
 					})
 				})
@@ -484,9 +522,10 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("saves it", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+							author, name, savedConfig, id, pipelineState := dbTeam.SavePipelineWithAuthorArgsForCall(0)
+							Expect(author).To(Equal("some-user"))
 							Expect(name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -495,7 +534,7 @@ var _ = Describe("Config API", func() {
 
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
+								dbTeam.SavePipelineWithAuthorReturns(nil, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -510,7 +549,7 @@ var _ = Describe("Config API", func() {
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbfakes.FakePipeline)
-								dbTeam.SavePipelineReturns(returnedPipeline, true, nil)
+								dbTeam.SavePipelineWithAuthorReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -544,7 +583,7 @@ var _ = Describe("Config API", func() {
 							})
 
 							It("does not save it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+								Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(Equal(0))
 							})
 						})
 					})
@@ -568,9 +607,9 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("saves it", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+							_, name, savedConfig, id, pipelineState := dbTeam.SavePipelineWithAuthorArgsForCall(0)
 							Expect(name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -578,9 +617,9 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not give the DB a map of empty interfaces to empty interfaces", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(Equal(1))
 
-							_, savedConfig, _, _ := dbTeam.SavePipelineArgsForCall(0)
+							_, _, savedConfig, _, _ := dbTeam.SavePipelineWithAuthorArgsForCall(0)
 							Expect(savedConfig).To(Equal(pipelineConfig))
 
 							_, err := json.Marshal(pipelineConfig)
@@ -621,9 +660,9 @@ jobs:
 							})
 
 							It("saves it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+								Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+								_, name, savedConfig, id, pipelineState := dbTeam.SavePipelineWithAuthorArgsForCall(0)
 								Expect(name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(atc.Config{
 									Resources: []atc.ResourceConfig{
@@ -670,7 +709,7 @@ jobs:
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbfakes.FakePipeline)
-								dbTeam.SavePipelineReturns(returnedPipeline, true, nil)
+								dbTeam.SavePipelineWithAuthorReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -680,7 +719,7 @@ jobs:
 
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
+								dbTeam.SavePipelineWithAuthorReturns(nil, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -718,7 +757,7 @@ jobs:
 							})
 
 							It("does not save it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+								Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(BeZero())
 							})
 						})
 					})
@@ -768,9 +807,9 @@ jobs:
 							})
 
 							It("saves it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+								Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+								_, name, savedConfig, id, pipelineState := dbTeam.SavePipelineWithAuthorArgsForCall(0)
 								Expect(name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(pipelineConfig))
 								Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -780,7 +819,7 @@ jobs:
 							Context("when it's the first time the pipeline has been created", func() {
 								BeforeEach(func() {
 									returnedPipeline := new(dbfakes.FakePipeline)
-									dbTeam.SavePipelineReturns(returnedPipeline, true, nil)
+									dbTeam.SavePipelineWithAuthorReturns(returnedPipeline, true, nil)
 								})
 
 								It("returns 201", func() {
@@ -790,7 +829,7 @@ jobs:
 
 							Context("and saving it fails", func() {
 								BeforeEach(func() {
-									dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
+									dbTeam.SavePipelineWithAuthorReturns(nil, false, errors.New("oh no!"))
 								})
 
 								It("returns 500", func() {
@@ -825,7 +864,7 @@ jobs:
 								})
 
 								It("does not save it", func() {
-									Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+									Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(BeZero())
 								})
 							})
 
@@ -960,7 +999,7 @@ jobs:
 								})
 
 								It("does not save anything", func() {
-									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+									Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(Equal(0))
 								})
 							})
 
@@ -1003,7 +1042,7 @@ jobs:
 								})
 
 								It("does not save anything", func() {
-									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+									Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(Equal(0))
 								})
 							})
 						})
@@ -1025,7 +1064,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+						Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(Equal(0))
 					})
 				})
 
@@ -1062,9 +1101,9 @@ jobs:
 					})
 
 					It("saves it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+						Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(Equal(1))
 
-						name, savedConfig, id, _ := dbTeam.SavePipelineArgsForCall(0)
+						_, name, savedConfig, id, _ := dbTeam.SavePipelineWithAuthorArgsForCall(0)
 						Expect(name).To(Equal("a-pipeline"))
 						Expect(savedConfig).To(Equal(atc.Config{
 							Jobs: atc.JobConfigs{
@@ -1117,7 +1156,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+						Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(Equal(0))
 					})
 				})
 			})
@@ -1145,7 +1184,7 @@ jobs:
 				})
 
 				It("does not save it", func() {
-					Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+					Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(Equal(0))
 				})
 			})
 		})
@@ -1160,7 +1199,339 @@ jobs:
 			})
 
 			It("does not save the config", func() {
-				Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+				Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/versions", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.ListConfigVersions, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the config history is found", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigHistoryReturns([]db.PipelineConfigVersion{
+						{
+							Version:   2,
+							Author:    "some-user",
+							CreatedAt: time.Unix(200, 0),
+						},
+						{
+							Version:   1,
+							CreatedAt: time.Unix(100, 0),
+						},
+					}, db.Pagination{}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("looks up the pipeline in the team", func() {
+					Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("a-team"))
+					Expect(dbTeam.PipelineArgsForCall(0)).To(Equal("a-pipeline"))
+				})
+
+				It("gets the first page of versions", func() {
+					Expect(fakePipeline.ConfigHistoryArgsForCall(0)).To(Equal(db.Page{
+						Limit: atc.PaginationAPIDefaultLimit,
+					}))
+				})
+
+				It("returns the versions without their configs or diffs", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+						{
+							"version": 2,
+							"author": "some-user",
+							"created_at": 200
+						},
+						{
+							"version": 1,
+							"created_at": 100
+						}
+					]`))
+				})
+			})
+
+			Context("when there are more versions", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigHistoryReturns([]db.PipelineConfigVersion{
+						{Version: 3},
+					}, db.Pagination{
+						Previous: &db.Page{Until: 3, Limit: 1},
+						Next:     &db.Page{Since: 3, Limit: 1},
+					}, nil)
+				})
+
+				It("links to them", func() {
+					Expect(response.Header["Link"]).To(ConsistOf([]string{
+						`<https://example.com/api/v1/teams/a-team/pipelines/a-pipeline/config/versions?since=3&limit=100>; rel="next"`,
+						`<https://example.com/api/v1/teams/a-team/pipelines/a-pipeline/config/versions?until=3&limit=100>; rel="previous"`,
+					}))
+				})
+			})
+
+			Context("when getting the config history fails", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigHistoryReturns(nil, db.Pagination{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the pipeline is not found", func() {
+				BeforeEach(func() {
+					dbTeam.PipelineReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config/versions/:config_version", func() {
+		var (
+			configVersion string
+			response      *http.Response
+		)
+
+		BeforeEach(func() {
+			configVersion = "2"
+		})
+
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.GetConfigVersion, rata.Params{
+				"team_name":      "a-team",
+				"pipeline_name":  "a-pipeline",
+				"config_version": configVersion,
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the config version is found", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigAtVersionReturns(db.PipelineConfigVersion{
+						Version:   2,
+						Config:    pipelineConfig,
+						Author:    "some-user",
+						CreatedAt: time.Unix(200, 0),
+						Diff:      "some-diff",
+					}, true, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("looks up the requested version", func() {
+					Expect(fakePipeline.ConfigAtVersionArgsForCall(0)).To(Equal(db.ConfigVersion(2)))
+				})
+
+				It("returns the version with its config", func() {
+					var version atc.PipelineConfigVersion
+					err := json.NewDecoder(response.Body).Decode(&version)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(version.Version).To(Equal(2))
+					Expect(version.Author).To(Equal("some-user"))
+					Expect(version.CreatedAt).To(Equal(int64(200)))
+					Expect(version.Diff).To(Equal("some-diff"))
+					Expect(version.Config).To(Equal(&pipelineConfig))
+				})
+			})
+
+			Context("when the config version is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigAtVersionReturns(db.PipelineConfigVersion{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the config version is malformed", func() {
+				BeforeEach(func() {
+					configVersion = "nope"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:name/config/versions/:config_version/rollback", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			var err error
+			request, err = requestGenerator.CreateRequest(atc.RollbackConfig, rata.Params{
+				"team_name":      "a-team",
+				"pipeline_name":  "a-pipeline",
+				"config_version": "2",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			fakePipeline.NameReturns("a-pipeline")
+			fakePipeline.TeamNameReturns("a-team")
+			fakePipeline.ConfigVersionReturns(5)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized as a member", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
+				fakeaccess.UserNameReturns("some-user")
+			})
+
+			Context("when the config version is found", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigAtVersionReturns(db.PipelineConfigVersion{
+						Version: 2,
+						Config:  pipelineConfig,
+					}, true, nil)
+
+					dbTeam.SavePipelineWithAuthorReturns(fakePipeline, false, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("saves the old config over the current version", func() {
+					Expect(fakePipeline.ConfigAtVersionArgsForCall(0)).To(Equal(db.ConfigVersion(2)))
+
+					Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(Equal(1))
+
+					author, name, savedConfig, from, pipelineState := dbTeam.SavePipelineWithAuthorArgsForCall(0)
+					Expect(author).To(Equal("some-user"))
+					Expect(name).To(Equal("a-pipeline"))
+					Expect(savedConfig).To(Equal(pipelineConfig))
+					Expect(from).To(Equal(db.ConfigVersion(5)))
+					Expect(pipelineState).To(Equal(db.PipelineNoChange))
+				})
+
+				Context("when a config version is specified", func() {
+					BeforeEach(func() {
+						request.Header.Set(atc.ConfigVersionHeader, "4")
+					})
+
+					It("saves against that version", func() {
+						_, _, _, from, _ := dbTeam.SavePipelineWithAuthorArgsForCall(0)
+						Expect(from).To(Equal(db.ConfigVersion(4)))
+					})
+				})
+
+				Context("when the old config is no longer valid", func() {
+					BeforeEach(func() {
+						pipelineConfig.Jobs[0].Plan[0].Resource = "some-missing-resource"
+
+						fakePipeline.ConfigAtVersionReturns(db.PipelineConfigVersion{
+							Version: 2,
+							Config:  pipelineConfig,
+						}, true, nil)
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not save it", func() {
+						Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when saving fails", func() {
+					BeforeEach(func() {
+						dbTeam.SavePipelineWithAuthorReturns(nil, false, db.ErrConfigComparisonFailed)
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the config version is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigAtVersionReturns(db.PipelineConfigVersion{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+
+				It("does not save anything", func() {
+					Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when not a member", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not save anything", func() {
+				Expect(dbTeam.SavePipelineWithAuthorCallCount()).To(BeZero())
 			})
 		})
 	})
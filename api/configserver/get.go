@@ -70,6 +70,17 @@ func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 		Jobs:          jobs.Configs(),
 	}
 
+	savedConfig, found, err := pipeline.Config()
+	if err != nil {
+		logger.Error("failed-to-get-saved-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if found {
+		config.Notifications = savedConfig.Notifications
+	}
+
 	rawConfig, err := json.Marshal(config)
 	if err != nil {
 		logger.Error("failed-to-marshal-config", err)
@@ -0,0 +1,73 @@
+package configserver
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+// RollbackConfig saves a config from the pipeline's history as a new version,
+// validating it just as if it had been submitted to SaveConfig.
+//
+// The save is made against the config version given in the config version
+// header if present, or the pipeline's current config version otherwise.
+func (s *Server) RollbackConfig(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("rollback-config")
+
+	configVersion, err := strconv.Atoi(rata.Param(r, "config_version"))
+	if err != nil {
+		s.handleBadRequest(w, []string{fmt.Sprintf("config version is malformed: %s", err)}, session)
+		return
+	}
+
+	pipeline, found, err := s.findPipeline(session, r)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	from := pipeline.ConfigVersion()
+	if configVersionStr := r.Header.Get(atc.ConfigVersionHeader); len(configVersionStr) != 0 {
+		_, err := fmt.Sscanf(configVersionStr, "%d", &from)
+		if err != nil {
+			session.Error("malformed-config-version", err)
+			s.handleBadRequest(w, []string{fmt.Sprintf("config version is malformed: %s", err)}, session)
+			return
+		}
+	}
+
+	version, found, err := pipeline.ConfigAtVersion(db.ConfigVersion(configVersion))
+	if err != nil {
+		session.Error("failed-to-get-config-version", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		session.Debug("config-version-not-found", lager.Data{"version": configVersion})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	s.saveConfig(
+		w,
+		session,
+		accessor.GetAccessor(r).UserName(),
+		pipeline.TeamName(),
+		pipeline.Name(),
+		version.Config,
+		from,
+		db.PipelineNoChange,
+	)
+}
@@ -10,12 +10,11 @@ import (
 	"mime"
 	"mime/multipart"
 	"net/http"
-	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/db"
-	"github.com/mitchellh/mapstructure"
 	"github.com/tedsuo/rata"
 	"gopkg.in/yaml.v2"
 )
@@ -24,7 +23,6 @@ var (
 	ErrStatusUnsupportedMediaType = errors.New("content-type is not supported")
 	ErrCannotParseContentType     = errors.New("content-type header could not be parsed")
 	ErrMalformedRequestPayload    = errors.New("data in body could not be decoded")
-	ErrFailedToConstructDecoder   = errors.New("decoder could not be constructed")
 	ErrCouldNotDecode             = errors.New("data could not be decoded into config structure")
 	ErrInvalidPausedValue         = errors.New("invalid paused value")
 )
@@ -74,10 +72,6 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 		s.handleBadRequest(w, []string{"malformed config"}, session)
 		return
-	case ErrFailedToConstructDecoder:
-		session.Error("failed-to-construct-decoder", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
 	case ErrCouldNotDecode:
 		session.Error("could-not-decode", err)
 		s.handleBadRequest(w, []string{"failed to decode config"}, session)
@@ -99,18 +93,39 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	s.saveConfig(
+		w,
+		session,
+		accessor.GetAccessor(r).UserName(),
+		rata.Param(r, "team_name"),
+		rata.Param(r, "pipeline_name"),
+		config,
+		version,
+		pausedState,
+	)
+}
+
+// saveConfig validates the config and saves it to the pipeline, responding
+// with any errors or warnings from validation.
+func (s *Server) saveConfig(
+	w http.ResponseWriter,
+	session lager.Logger,
+	author string,
+	teamName string,
+	pipelineName string,
+	config atc.Config,
+	version db.ConfigVersion,
+	pausedState db.PipelinePausedState,
+) {
 	warnings, errorMessages := config.Validate()
 	if len(errorMessages) > 0 {
-		session.Error("ignoring-invalid-config", err)
+		session.Error("ignoring-invalid-config", errors.New("invalid config"))
 		s.handleBadRequest(w, errorMessages, session)
 		return
 	}
 
 	session.Info("saving")
 
-	pipelineName := rata.Param(r, "pipeline_name")
-	teamName := rata.Param(r, "team_name")
-
 	team, found, err := s.teamFactory.FindTeam(teamName)
 	if err != nil {
 		session.Error("failed-to-find-team", err)
@@ -124,7 +139,7 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, created, err := team.SavePipeline(pipelineName, config, version, pausedState)
+	_, created, err := team.SavePipelineWithAuthor(author, pipelineName, config, version, pausedState)
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -244,34 +259,11 @@ func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, db.PipelinePause
 		return atc.Config{}, db.PipelineNoChange, err
 	}
 
-	var config atc.Config
-	var md mapstructure.Metadata
-	msConfig := &mapstructure.DecoderConfig{
-		Metadata:         &md,
-		Result:           &config,
-		WeaklyTypedInput: true,
-		DecodeHook: mapstructure.ComposeDecodeHookFunc(
-			atc.SanitizeDecodeHook,
-			atc.VersionConfigDecodeHook,
-		),
-	}
-
-	decoder, err := mapstructure.NewDecoder(msConfig)
+	config, nestedUnused, err := atc.DecodeConfig(configStructure)
 	if err != nil {
-		return atc.Config{}, db.PipelineNoChange, ErrFailedToConstructDecoder
-	}
-
-	if err := decoder.Decode(configStructure); err != nil {
 		return atc.Config{}, db.PipelineNoChange, ErrCouldNotDecode
 	}
 
-	nestedUnused := []string{}
-	for _, unused := range md.Unused {
-		if strings.Contains(unused, ".") {
-			nestedUnused = append(nestedUnused, unused)
-		}
-	}
-
 	if len(nestedUnused) != 0 {
 		return atc.Config{}, db.PipelineNoChange, ExtraKeysError{extraKeys: nestedUnused}
 	}
@@ -0,0 +1,156 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ListConfigVersions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-config-versions")
+
+	until, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryUntil))
+	since, _ := strconv.Atoi(r.FormValue(atc.PaginationQuerySince))
+
+	limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if limit == 0 {
+		limit = atc.PaginationAPIDefaultLimit
+	}
+
+	pipeline, found, err := s.findPipeline(logger, r)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	history, pagination, err := pipeline.ConfigHistory(db.Page{
+		Since: since,
+		Until: until,
+		Limit: limit,
+	})
+	if err != nil {
+		logger.Error("failed-to-get-config-history", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if pagination.Next != nil {
+		s.addConfigVersionsLink(w, r, atc.PaginationQuerySince, pagination.Next.Since, limit, atc.LinkRelNext)
+	}
+
+	if pagination.Previous != nil {
+		s.addConfigVersionsLink(w, r, atc.PaginationQueryUntil, pagination.Previous.Until, limit, atc.LinkRelPrevious)
+	}
+
+	versions := []atc.PipelineConfigVersion{}
+	for _, version := range history {
+		presented := present.PipelineConfigVersion(version)
+		presented.Config = nil
+		versions = append(versions, presented)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(versions)
+	if err != nil {
+		logger.Error("failed-to-encode-config-versions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) GetConfigVersion(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-config-version")
+
+	configVersion, err := strconv.Atoi(rata.Param(r, "config_version"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	pipeline, found, err := s.findPipeline(logger, r)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	version, found, err := pipeline.ConfigAtVersion(db.ConfigVersion(configVersion))
+	if err != nil {
+		logger.Error("failed-to-get-config-version", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Debug("config-version-not-found", lager.Data{"version": configVersion})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(present.PipelineConfigVersion(version))
+	if err != nil {
+		logger.Error("failed-to-encode-config-version", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) addConfigVersionsLink(w http.ResponseWriter, r *http.Request, query string, version int, limit int, rel string) {
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/teams/%s/pipelines/%s/config/versions?%s=%d&%s=%d>; rel="%s"`,
+		s.externalURL,
+		rata.Param(r, "team_name"),
+		rata.Param(r, "pipeline_name"),
+		query,
+		version,
+		atc.PaginationQueryLimit,
+		limit,
+		rel,
+	))
+}
+
+func (s *Server) findPipeline(logger lager.Logger, r *http.Request) (db.Pipeline, bool, error) {
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamName := rata.Param(r, "team_name")
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		return nil, false, err
+	}
+
+	if !found {
+		logger.Debug("team-not-found", lager.Data{"team": teamName})
+		return nil, false, nil
+	}
+
+	pipeline, found, err := team.Pipeline(pipelineName)
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		return nil, false, err
+	}
+
+	if !found {
+		logger.Debug("pipeline-not-found", lager.Data{"pipeline": pipelineName})
+		return nil, false, nil
+	}
+
+	return pipeline, true, nil
+}
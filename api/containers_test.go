@@ -107,6 +107,7 @@ var _ = Describe("Containers API", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			Context("with no params", func() {
@@ -398,6 +399,7 @@ var _ = Describe("Containers API", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			Context("when the container is not found", func() {
@@ -534,6 +536,7 @@ var _ = Describe("Containers API", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			Context("and the worker client returns a container", func() {
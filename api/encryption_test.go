@@ -0,0 +1,117 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Encryption API", func() {
+	Describe("GET /api/v1/encryption/key-rotation", func() {
+		var (
+			fakeaccess *accessorfakes.FakeAccess
+
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			fakeaccess = new(accessorfakes.FakeAccess)
+		})
+
+		JustBeforeEach(func() {
+			fakeAccessor.CreateReturns(fakeaccess)
+
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/encryption/key-rotation")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(true)
+			})
+
+			Context("when a rotation has run", func() {
+				BeforeEach(func() {
+					dbKeyRotationFactory.LatestKeyRotationReturns(db.KeyRotation{
+						ID:         1,
+						StartedAt:  time.Unix(100, 0),
+						FinishedAt: time.Unix(200, 0),
+						Progress: map[string]db.KeyRotationProgress{
+							"resources": {Total: 3, Scanned: 3, Rotated: 2},
+						},
+					}, true, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the progress of the latest rotation", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"id": 1,
+						"start_time": 100,
+						"end_time": 200,
+						"tables": {
+							"resources": {
+								"total": 3,
+								"scanned": 3,
+								"rotated": 2
+							}
+						}
+					}`))
+				})
+			})
+
+			Context("when no rotation has run", func() {
+				BeforeEach(func() {
+					dbKeyRotationFactory.LatestKeyRotationReturns(db.KeyRotation{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when finding the rotation fails", func() {
+				BeforeEach(func() {
+					dbKeyRotationFactory.LatestKeyRotationReturns(db.KeyRotation{}, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated but not an admin", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
@@ -0,0 +1,33 @@
+package encryptionserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc/api/present"
+)
+
+func (s *Server) GetKeyRotation(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-key-rotation")
+
+	rotation, found, err := s.keyRotationFactory.LatestKeyRotation()
+	if err != nil {
+		logger.Error("failed-to-get-latest-key-rotation", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(present.EncryptionKeyRotation(rotation))
+	if err != nil {
+		logger.Error("failed-to-encode-key-rotation", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,20 @@
+package encryptionserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+type Server struct {
+	logger lager.Logger
+
+	keyRotationFactory db.KeyRotationFactory
+}
+
+func NewServer(logger lager.Logger, keyRotationFactory db.KeyRotationFactory) *Server {
+	return &Server{
+		logger: logger,
+
+		keyRotationFactory: keyRotationFactory,
+	}
+}
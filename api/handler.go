@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/atc/api/cliserver"
 	"github.com/concourse/atc/api/configserver"
 	"github.com/concourse/atc/api/containerserver"
+	"github.com/concourse/atc/api/encryptionserver"
 	"github.com/concourse/atc/api/infoserver"
 	"github.com/concourse/atc/api/jobserver"
 	"github.com/concourse/atc/api/loglevelserver"
@@ -46,6 +47,7 @@ func NewHandler(
 	containerRepository db.ContainerRepository,
 	destroyer gc.Destroyer,
 	dbBuildFactory db.BuildFactory,
+	dbKeyRotationFactory db.KeyRotationFactory,
 
 	peerURL string,
 	eventHandlerFactory buildserver.EventHandlerFactory,
@@ -80,10 +82,10 @@ func NewHandler(
 
 	buildServer := buildserver.NewServer(logger, externalURL, peerURL, engine, workerClient, dbTeamFactory, dbBuildFactory, eventHandlerFactory, drain)
 	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL, variablesFactory, dbJobFactory)
-	resourceServer := resourceserver.NewServer(logger, scannerFactory, variablesFactory, dbResourceFactory)
+	resourceServer := resourceserver.NewServer(logger, scannerFactory, variablesFactory, dbResourceFactory, dbTeamFactory)
 	versionServer := versionserver.NewServer(logger, externalURL)
-	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, externalURL, engine)
-	configServer := configserver.NewServer(logger, dbTeamFactory)
+	pipelineServer := pipelineserver.NewServer(logger, dbTeamFactory, dbPipelineFactory, externalURL, engine, variablesFactory)
+	configServer := configserver.NewServer(logger, dbTeamFactory, externalURL)
 	workerServer := workerserver.NewServer(logger, dbTeamFactory, dbWorkerFactory, workerProvider)
 	logLevelServer := loglevelserver.NewServer(logger, sink)
 	cliServer := cliserver.NewServer(logger, absCLIDownloadsDir)
@@ -91,10 +93,14 @@ func NewHandler(
 	volumesServer := volumeserver.NewServer(logger, volumeRepository, destroyer)
 	teamServer := teamserver.NewServer(logger, dbTeamFactory, externalURL)
 	infoServer := infoserver.NewServer(logger, version, workerVersion)
+	encryptionServer := encryptionserver.NewServer(logger, dbKeyRotationFactory)
 
 	handlers := map[string]http.Handler{
-		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
-		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
+		atc.GetConfig:          http.HandlerFunc(configServer.GetConfig),
+		atc.SaveConfig:         http.HandlerFunc(configServer.SaveConfig),
+		atc.ListConfigVersions: http.HandlerFunc(configServer.ListConfigVersions),
+		atc.GetConfigVersion:   http.HandlerFunc(configServer.GetConfigVersion),
+		atc.RollbackConfig:     http.HandlerFunc(configServer.RollbackConfig),
 
 		atc.ListBuilds:              http.HandlerFunc(buildServer.ListBuilds),
 		atc.CreateBuild:             teamHandlerFactory.HandlerFor(buildServer.CreateBuild),
@@ -107,17 +113,18 @@ func NewHandler(
 		atc.SendInputToBuildPlan:    buildHandlerFactory.HandlerFor(buildServer.SendInputToBuildPlan),
 		atc.ReadOutputFromBuildPlan: buildHandlerFactory.HandlerFor(buildServer.ReadOutputFromBuildPlan),
 
-		atc.ListAllJobs:    http.HandlerFunc(jobServer.ListAllJobs),
-		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
-		atc.GetJob:         pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
-		atc.ListJobBuilds:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
-		atc.ListJobInputs:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
-		atc.GetJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
-		atc.CreateJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
-		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
-		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
-		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
-		atc.MainJobBadge:   mainredirect.Handler{atc.Routes, atc.JobBadge},
+		atc.ListAllJobs:           http.HandlerFunc(jobServer.ListAllJobs),
+		atc.ListJobs:              pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
+		atc.GetJob:                pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
+		atc.ListJobBuilds:         pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
+		atc.ListJobInputs:         pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
+		atc.GetJobBuild:           pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
+		atc.CreateJobBuild:        pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
+		atc.PauseJob:              pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
+		atc.UnpauseJob:            pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
+		atc.JobBadge:              pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
+		atc.MainJobBadge:          mainredirect.Handler{atc.Routes, atc.JobBadge},
+		atc.GetJobInputResolution: pipelineHandlerFactory.HandlerFor(jobServer.GetJobInputResolution),
 
 		atc.ListAllPipelines:    http.HandlerFunc(pipelineServer.ListAllPipelines),
 		atc.ListPipelines:       http.HandlerFunc(pipelineServer.ListPipelines),
@@ -134,19 +141,24 @@ func NewHandler(
 		atc.CreatePipelineBuild: pipelineHandlerFactory.HandlerFor(pipelineServer.CreateBuild),
 		atc.PipelineBadge:       pipelineHandlerFactory.HandlerFor(pipelineServer.PipelineBadge),
 
+		atc.ListPipelineCredentials: pipelineHandlerFactory.HandlerFor(pipelineServer.ListCredentials),
+
 		atc.ListAllResources:     http.HandlerFunc(resourceServer.ListAllResources),
 		atc.ListResources:        pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
 		atc.ListResourceTypes:    pipelineHandlerFactory.HandlerFor(resourceServer.ListVersionedResourceTypes),
 		atc.GetResource:          pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
 		atc.PauseResource:        pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource),
 		atc.UnpauseResource:      pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource),
+		atc.UnpinResource:        pipelineHandlerFactory.HandlerFor(resourceServer.UnpinResource),
 		atc.CheckResource:        pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
 		atc.CheckResourceWebHook: pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebHook),
+		atc.CheckTeamWebHook:     http.HandlerFunc(resourceServer.CheckTeamWebHook),
 
 		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
 		atc.GetResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.GetResourceVersion),
 		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
 		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
+		atc.PinResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.PinResourceVersion),
 		atc.ListBuildsWithVersionAsInput:  pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsInput),
 		atc.ListBuildsWithVersionAsOutput: pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
 		atc.GetResourceCausality:          pipelineHandlerFactory.HandlerFor(versionServer.GetCausality),
@@ -165,6 +177,8 @@ func NewHandler(
 		atc.DownloadCLI: http.HandlerFunc(cliServer.Download),
 		atc.GetInfo:     http.HandlerFunc(infoServer.Info),
 
+		atc.GetEncryptionKeyRotation: http.HandlerFunc(encryptionServer.GetKeyRotation),
+
 		atc.ListContainers:           teamHandlerFactory.HandlerFor(containerServer.ListContainers),
 		atc.GetContainer:             teamHandlerFactory.HandlerFor(containerServer.GetContainer),
 		atc.HijackContainer:          teamHandlerFactory.HandlerFor(containerServer.HijackContainer),
@@ -175,11 +189,15 @@ func NewHandler(
 		atc.ListDestroyingVolumes: http.HandlerFunc(volumesServer.ListDestroyingVolumes),
 		atc.ReportWorkerVolumes:   http.HandlerFunc(volumesServer.ReportWorkerVolumes),
 
-		atc.ListTeams:      http.HandlerFunc(teamServer.ListTeams),
-		atc.SetTeam:        http.HandlerFunc(teamServer.SetTeam),
-		atc.RenameTeam:     http.HandlerFunc(teamServer.RenameTeam),
-		atc.DestroyTeam:    http.HandlerFunc(teamServer.DestroyTeam),
-		atc.ListTeamBuilds: http.HandlerFunc(teamServer.ListTeamBuilds),
+		atc.ListTeams:                  http.HandlerFunc(teamServer.ListTeams),
+		atc.SetTeam:                    http.HandlerFunc(teamServer.SetTeam),
+		atc.RenameTeam:                 http.HandlerFunc(teamServer.RenameTeam),
+		atc.DestroyTeam:                http.HandlerFunc(teamServer.DestroyTeam),
+		atc.ListTeamBuilds:             http.HandlerFunc(teamServer.ListTeamBuilds),
+		atc.ListNotificationDeliveries: http.HandlerFunc(teamServer.ListNotificationDeliveries),
+		atc.TeamEvents:                 http.HandlerFunc(teamServer.TeamEvents),
+
+		atc.GetUserRoles: http.HandlerFunc(teamServer.GetUserRoles),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
@@ -325,6 +325,7 @@ var _ = Describe("Jobs API", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			Context("when getting the build succeeds", func() {
@@ -479,6 +480,46 @@ var _ = Describe("Jobs API", func() {
 						})
 					})
 
+					Context("when the job has triggers", func() {
+						BeforeEach(func() {
+							fakeJob.ConfigReturns(atc.JobConfig{
+								Name: "some-job",
+								Triggers: atc.TriggerConfigs{
+									{Cron: "0 * * * *"},
+									{Cron: "*/30 * * * *", Timezone: "Asia/Kolkata"},
+								},
+							})
+
+							build2.TriggerCauseReturns(&atc.TriggerCause{
+								Cron:          "0 * * * *",
+								ScheduledTime: 3600,
+							})
+						})
+
+						It("returns when the job is next scheduled", func() {
+							var job atc.Job
+							err := json.NewDecoder(response.Body).Decode(&job)
+							Expect(err).NotTo(HaveOccurred())
+
+							// the half-hourly trigger is always next
+							now := time.Now().Unix()
+							Expect(job.NextScheduledTime % 1800).To(BeZero())
+							Expect(job.NextScheduledTime).To(BeNumerically(">", now-60))
+							Expect(job.NextScheduledTime).To(BeNumerically("<=", now+1800))
+						})
+
+						It("returns the trigger which caused the next build", func() {
+							var job atc.Job
+							err := json.NewDecoder(response.Body).Decode(&job)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(job.NextBuild.TriggerCause).To(Equal(&atc.TriggerCause{
+								Cron:          "0 * * * *",
+								ScheduledTime: 3600,
+							}))
+						})
+					})
+
 					Context("when getting the job's builds fails", func() {
 						BeforeEach(func() {
 							fakeJob.FinishedAndNextBuildReturns(nil, nil, errors.New("oh no!"))
@@ -545,6 +586,7 @@ var _ = Describe("Jobs API", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 
 				fakePipeline.JobReturns(fakeJob, true, nil)
 				fakeJob.NameReturns("some-job")
@@ -985,6 +1027,7 @@ var _ = Describe("Jobs API", func() {
 			Context("when authorized", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 					fakeaccess.IsAuthenticatedReturns(true)
 				})
 
@@ -1235,6 +1278,7 @@ var _ = Describe("Jobs API", func() {
 		Context("when authorized", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			Context("when getting the job succeeds", func() {
@@ -1419,6 +1463,7 @@ var _ = Describe("Jobs API", func() {
 		Context("when authorized and authenticated", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 				fakeaccess.IsAuthenticatedReturns(true)
 			})
 
@@ -1578,6 +1623,7 @@ var _ = Describe("Jobs API", func() {
 		Context("when authorized", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 				fakeaccess.IsAuthenticatedReturns(true)
 			})
 
@@ -1610,6 +1656,10 @@ var _ = Describe("Jobs API", func() {
 									Passed:   []string{"job-c", "job-d"},
 									Params:   atc.Params{"some": "other-params"},
 									Tags:     []string{"some-tag"},
+									Version: &atc.VersionConfig{
+										Every:  true,
+										Semver: map[string]string{"tag": ">=1.2 <2"},
+									},
 								},
 							},
 						})
@@ -1670,7 +1720,7 @@ var _ = Describe("Jobs API", func() {
 						})
 
 						It("determined the inputs with the correct job config", func() {
-							_, receivedJob := fakeScheduler.SaveNextInputMappingArgsForCall(0)
+							_, _, receivedJob := fakeScheduler.SaveNextInputMappingArgsForCall(0)
 							Expect(receivedJob.Name()).To(Equal(fakeJob.Name()))
 						})
 
@@ -1693,6 +1743,7 @@ var _ = Describe("Jobs API", func() {
 										"type": "some-other-type",
 										"source": {"some": "other-source"},
 										"version": {"some": "other-version"},
+										"version_filter": {"every": true, "semver": {"tag": ">=1.2 <2"}},
 										"params": {"some": "other-params"},
 										"tags": ["some-tag"]
 									}
@@ -1766,6 +1817,115 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/input-resolution", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/input-resolution")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the pipeline contains the requested job", func() {
+				var (
+					fakeJob       *dbfakes.FakeJob
+					fakeScheduler *schedulerfakes.FakeBuildScheduler
+				)
+
+				BeforeEach(func() {
+					fakeJob = new(dbfakes.FakeJob)
+					fakeJob.NameReturns("some-job")
+					fakePipeline.JobReturns(fakeJob, true, nil)
+
+					fakeScheduler = new(schedulerfakes.FakeBuildScheduler)
+					fakeSchedulerFactory.BuildSchedulerReturns(fakeScheduler)
+
+					fakeJob.InputResolutionReturns(db.InputResolution{
+						Inputs: map[string]db.BuildPreparationStatus{
+							"some-input":       db.BuildPreparationStatusBlocking,
+							"some-other-input": db.BuildPreparationStatusNotBlocking,
+						},
+						InputsSatisfied: db.BuildPreparationStatusBlocking,
+						MissingInputReasons: db.MissingInputReasons{
+							"some-input": "no versions have passed job-a",
+						},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("looks up the job", func() {
+					Expect(dbTeam.PipelineArgsForCall(0)).To(Equal("some-pipeline"))
+					Expect(fakePipeline.JobArgsForCall(0)).To(Equal("some-job"))
+				})
+
+				It("does not resolve the job's inputs itself", func() {
+					Expect(fakeScheduler.SaveNextInputMappingCallCount()).To(BeZero())
+				})
+
+				It("returns the input resolution", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"inputs": {
+							"some-input": "blocking",
+							"some-other-input": "not_blocking"
+						},
+						"inputs_satisfied": "blocking",
+						"missing_input_reasons": {
+							"some-input": "no versions have passed job-a"
+						}
+					}`))
+				})
+
+				Context("when getting the input resolution fails", func() {
+					BeforeEach(func() {
+						fakeJob.InputResolutionReturns(db.InputResolution{}, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the pipeline does not contain the requested job", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
 		var response *http.Response
 
@@ -1779,6 +1939,7 @@ var _ = Describe("Jobs API", func() {
 		Context("when authorized", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 				fakeaccess.IsAuthenticatedReturns(true)
 			})
 
@@ -1947,6 +2108,7 @@ var _ = Describe("Jobs API", func() {
 			Context("when authorized", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 
 					fakePipeline.JobReturns(fakeJob, true, nil)
 					fakeJob.PauseReturns(nil)
@@ -2020,6 +2182,7 @@ var _ = Describe("Jobs API", func() {
 		Context("when authorized", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			Context("when authenticated", func() {
@@ -0,0 +1,46 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+// GetJobInputResolution reports which of the job's inputs were blocking its
+// next build, and why, as of when the scheduler last resolved them.
+func (s *Server) GetJobInputResolution(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("get-job-input-resolution")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		resolution, err := job.InputResolution()
+		if err != nil {
+			logger.Error("failed-to-get-input-resolution", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(present.InputResolution(resolution))
+		if err != nil {
+			logger.Error("failed-to-encode-input-resolution", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -12,11 +12,13 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/cloudfoundry/bosh-cli/director/template"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/dbfakes"
@@ -234,6 +236,7 @@ var _ = Describe("Pipelines API", func() {
 		Context("when authenticated as requested team", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
 			})
 
@@ -583,6 +586,7 @@ var _ = Describe("Pipelines API", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			It("returns 200 OK", func() {
@@ -784,6 +788,7 @@ var _ = Describe("Pipelines API", func() {
 			Context("when requester belongs to the team", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 
 					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
 					dbPipeline.NameReturns("a-pipeline-name")
@@ -864,6 +869,7 @@ var _ = Describe("Pipelines API", func() {
 			Context("when requester belongs to the team", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
 				})
 
@@ -942,6 +948,7 @@ var _ = Describe("Pipelines API", func() {
 			Context("when requester belongs to the team", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 
 					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
 					fakeTeam.PipelineReturns(dbPipeline, true, nil)
@@ -1023,6 +1030,7 @@ var _ = Describe("Pipelines API", func() {
 			Context("when requester belongs to the team", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
 				})
 
@@ -1102,6 +1110,7 @@ var _ = Describe("Pipelines API", func() {
 			Context("when requester belongs to the team", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
 				})
 
@@ -1194,6 +1203,7 @@ var _ = Describe("Pipelines API", func() {
 			Context("when requester belonbgs to the team", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
 				})
 
@@ -1288,6 +1298,7 @@ var _ = Describe("Pipelines API", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
 				fakeTeam.PipelineReturns(dbPipeline, true, nil)
 				//construct Version db
@@ -1400,6 +1411,141 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/credentials", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/credentials", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			var fakeVariables *credsfakes.FakeVariables
+
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				fakeTeam.PipelineReturns(dbPipeline, true, nil)
+				dbPipeline.TeamNameReturns("a-team")
+				dbPipeline.NameReturns("a-pipeline")
+
+				fakeResource := new(dbfakes.FakeResource)
+				fakeResource.NameReturns("some-resource")
+				fakeResource.SourceReturns(atc.Source{"password": "((some-password))"})
+				fakeResource.WebhookTokenReturns("((some-token))")
+				dbPipeline.ResourcesReturns(db.Resources{fakeResource}, nil)
+
+				fakeResourceType := new(dbfakes.FakeResourceType)
+				fakeResourceType.NameReturns("some-type")
+				fakeResourceType.SourceReturns(atc.Source{"password": "((some-password))"})
+				dbPipeline.ResourceTypesReturns(db.ResourceTypes{fakeResourceType}, nil)
+
+				fakeJob := new(dbfakes.FakeJob)
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{
+							Task: "some-task",
+							Params: atc.Params{
+								"API_KEY": "((missing-key))",
+							},
+						},
+					},
+				})
+				dbPipeline.JobsReturns(db.Jobs{fakeJob}, nil)
+
+				fakeVariables = new(credsfakes.FakeVariables)
+				fakeVariables.GetStub = func(varDef template.VariableDefinition) (interface{}, bool, error) {
+					switch varDef.Name {
+					case "some-password":
+						return "super-secret", true, nil
+					case "some-token":
+						return nil, false, errors.New("permission denied")
+					default:
+						return nil, false, nil
+					}
+				}
+				fakeVariablesFactory.NewVariablesReturns(fakeVariables)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns application/json", func() {
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("resolves the variables for the pipeline", func() {
+				Expect(fakeVariablesFactory.NewVariablesCallCount()).To(Equal(1))
+				teamName, pipelineName := fakeVariablesFactory.NewVariablesArgsForCall(0)
+				Expect(teamName).To(Equal("a-team"))
+				Expect(pipelineName).To(Equal("a-pipeline"))
+			})
+
+			It("returns each referenced variable and whether it resolves, without its value", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"name": "missing-key",
+						"locations": ["jobs.some-job.plan.some-task.params"],
+						"resolved": false
+					},
+					{
+						"name": "some-password",
+						"locations": ["resources.some-resource.source", "resource_types.some-type.source"],
+						"resolved": true
+					},
+					{
+						"name": "some-token",
+						"locations": ["resources.some-resource.webhook_token"],
+						"resolved": false,
+						"error": "lookup failed"
+					}
+				]`))
+			})
+
+			Context("when getting the jobs fails", func() {
+				BeforeEach(func() {
+					dbPipeline.JobsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/rename", func() {
 		var response *http.Response
 
@@ -1420,6 +1566,7 @@ var _ = Describe("Pipelines API", func() {
 			Context("when requester belongs to the team", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 
 					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
 					fakeTeam.PipelineReturns(dbPipeline, true, nil)
@@ -1520,6 +1667,7 @@ var _ = Describe("Pipelines API", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			Context("when no params are passed", func() {
@@ -1699,6 +1847,7 @@ var _ = Describe("Pipelines API", func() {
 			Context("when requester belongs to the team", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
 					fakeTeam.PipelineReturns(dbPipeline, true, nil)
 				})
@@ -0,0 +1,71 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListCredentials(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("list-credentials")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobs, err := pipeline.Jobs()
+		if err != nil {
+			logger.Error("failed-to-get-jobs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		resources, err := pipeline.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		resourceTypes, err := pipeline.ResourceTypes()
+		if err != nil {
+			logger.Error("failed-to-get-resource-types", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		references, err := creds.References(atc.Config{
+			Resources:     resources.Configs(),
+			ResourceTypes: resourceTypes.Configs(),
+			Jobs:          jobs.Configs(),
+		})
+		if err != nil {
+			logger.Error("failed-to-find-credential-references", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		variables := s.variablesFactory.NewVariables(pipeline.TeamName(), pipeline.Name())
+
+		presentedReferences := []atc.CredentialReference{}
+		for _, reference := range references {
+			_, found, err := variables.Get(template.VariableDefinition{Name: reference.Name})
+			if err != nil {
+				logger.Error("failed-to-look-up-credential", err, lager.Data{"name": reference.Name})
+			}
+
+			presentedReferences = append(presentedReferences, present.CredentialReference(reference, found, err))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(presentedReferences)
+		if err != nil {
+			logger.Error("failed-to-encode-credential-references", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
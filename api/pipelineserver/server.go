@@ -3,17 +3,19 @@ package pipelineserver
 import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/api/auth"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 )
 
 type Server struct {
-	logger          lager.Logger
-	teamFactory     db.TeamFactory
-	rejector        auth.Rejector
-	pipelineFactory db.PipelineFactory
-	engine          engine.Engine
-	externalURL     string
+	logger           lager.Logger
+	teamFactory      db.TeamFactory
+	rejector         auth.Rejector
+	pipelineFactory  db.PipelineFactory
+	engine           engine.Engine
+	externalURL      string
+	variablesFactory creds.VariablesFactory
 }
 
 func NewServer(
@@ -22,13 +24,15 @@ func NewServer(
 	pipelineFactory db.PipelineFactory,
 	externalURL string,
 	engine engine.Engine,
+	variablesFactory creds.VariablesFactory,
 ) *Server {
 	return &Server{
-		logger:          logger,
-		teamFactory:     teamFactory,
-		rejector:        auth.UnauthorizedRejector{},
-		pipelineFactory: pipelineFactory,
-		externalURL:     externalURL,
-		engine:          engine,
+		logger:           logger,
+		teamFactory:      teamFactory,
+		rejector:         auth.UnauthorizedRejector{},
+		pipelineFactory:  pipelineFactory,
+		externalURL:      externalURL,
+		engine:           engine,
+		variablesFactory: variablesFactory,
 	}
 }
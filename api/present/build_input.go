@@ -6,13 +6,19 @@ import (
 )
 
 func BuildInput(input db.BuildInput, config atc.JobInput, source atc.Source) atc.BuildInput {
+	var versionFilter *atc.VersionConfig
+	if config.Version != nil && config.Version.IsFiltered() {
+		versionFilter = config.Version
+	}
+
 	return atc.BuildInput{
-		Name:     input.Name,
-		Resource: input.Resource,
-		Type:     input.Type,
-		Source:   source,
-		Params:   config.Params,
-		Version:  atc.Version(input.Version),
-		Tags:     config.Tags,
+		Name:          input.Name,
+		Resource:      input.Resource,
+		Type:          input.Type,
+		Source:        source,
+		Params:        config.Params,
+		Version:       atc.Version(input.Version),
+		VersionFilter: versionFilter,
+		Tags:          config.Tags,
 	}
 }
@@ -0,0 +1,20 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+)
+
+func CredentialReference(reference creds.Reference, resolved bool, err error) atc.CredentialReference {
+	presented := atc.CredentialReference{
+		Name:      reference.Name,
+		Locations: reference.Locations,
+		Resolved:  resolved,
+	}
+
+	if err != nil {
+		presented.Error = "lookup failed"
+	}
+
+	return presented
+}
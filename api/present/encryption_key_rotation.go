@@ -0,0 +1,30 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func EncryptionKeyRotation(rotation db.KeyRotation) atc.EncryptionKeyRotation {
+	tables := map[string]atc.EncryptionKeyRotationTable{}
+	for table, progress := range rotation.Progress {
+		tables[table] = atc.EncryptionKeyRotationTable{
+			Total:   progress.Total,
+			Scanned: progress.Scanned,
+			Rotated: progress.Rotated,
+		}
+	}
+
+	presented := atc.EncryptionKeyRotation{
+		ID:        rotation.ID,
+		StartTime: rotation.StartedAt.Unix(),
+		Tables:    tables,
+		Error:     rotation.Error,
+	}
+
+	if rotation.Finished() {
+		presented.EndTime = rotation.FinishedAt.Unix()
+	}
+
+	return presented
+}
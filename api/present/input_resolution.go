@@ -0,0 +1,20 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func InputResolution(resolution db.InputResolution) atc.InputResolution {
+	inputs := make(map[string]atc.BuildPreparationStatus)
+
+	for k, v := range resolution.Inputs {
+		inputs[k] = atc.BuildPreparationStatus(v)
+	}
+
+	return atc.InputResolution{
+		Inputs:              inputs,
+		InputsSatisfied:     atc.BuildPreparationStatus(resolution.InputsSatisfied),
+		MissingInputReasons: atc.MissingInputReasons(resolution.MissingInputReasons),
+	}
+}
@@ -1,6 +1,8 @@
 package present
 
 import (
+	"time"
+
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 )
@@ -47,6 +49,11 @@ func Job(
 		})
 	}
 
+	var nextScheduledTime int64
+	if _, next, found := job.Config().Triggers.Next(time.Now()); found {
+		nextScheduledTime = next.Unix()
+	}
+
 	return atc.Job{
 		ID: job.ID(),
 
@@ -59,6 +66,7 @@ func Job(
 		FinishedBuild:        presentedFinishedBuild,
 		NextBuild:            presentedNextBuild,
 		TransitionBuild:      presentedTransitionBuild,
+		NextScheduledTime:    nextScheduledTime,
 
 		Inputs:  sanitizedInputs,
 		Outputs: sanitizedOutputs,
@@ -0,0 +1,29 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func NotificationDelivery(delivery db.NotificationDelivery) atc.NotificationDelivery {
+	presented := atc.NotificationDelivery{
+		ID:           delivery.ID,
+		Name:         delivery.Name,
+		TeamName:     delivery.TeamName,
+		PipelineName: delivery.PipelineName,
+		JobName:      delivery.JobName,
+		BuildID:      delivery.BuildID,
+		BuildName:    delivery.BuildName,
+		Status:       string(delivery.Status),
+		Attempts:     delivery.Attempts,
+		ResponseCode: delivery.ResponseCode,
+		Error:        delivery.Error,
+		CreatedAt:    delivery.CreatedAt.Unix(),
+	}
+
+	if !delivery.DeliveredAt.IsZero() {
+		presented.DeliveredAt = delivery.DeliveredAt.Unix()
+	}
+
+	return presented
+}
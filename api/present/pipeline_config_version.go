@@ -0,0 +1,18 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func PipelineConfigVersion(version db.PipelineConfigVersion) atc.PipelineConfigVersion {
+	config := version.Config
+
+	return atc.PipelineConfigVersion{
+		Version:   int(version.Version),
+		Author:    version.Author,
+		CreatedAt: version.CreatedAt.Unix(),
+		Diff:      version.Diff,
+		Config:    &config,
+	}
+}
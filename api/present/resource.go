@@ -19,6 +19,9 @@ func Resource(resource db.Resource, showCheckError bool, teamName string) atc.Re
 
 		Paused: resource.Paused(),
 
+		PinnedVersion: resource.PinnedVersion(),
+		PinComment:    resource.PinComment(),
+
 		FailingToCheck: resource.FailingToCheck(),
 		CheckError:     checkErrString,
 	}
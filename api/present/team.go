@@ -6,8 +6,15 @@ import (
 )
 
 func Team(team db.Team) atc.Team {
-	return atc.Team{
+	presented := atc.Team{
 		ID:   team.ID(),
 		Name: team.Name(),
 	}
+
+	if team.RedactSecrets() {
+		redactSecrets := true
+		presented.RedactSecrets = &redactSecrets
+	}
+
+	return presented
 }
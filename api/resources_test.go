@@ -2,13 +2,19 @@ package api_test
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"sync"
 	"time"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/google/jsonapi"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -16,9 +22,11 @@ import (
 	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/atc/api/resourceserver"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/radar"
 	"github.com/concourse/atc/radar/radarfakes"
 	"github.com/concourse/atc/resource"
 )
@@ -264,6 +272,7 @@ var _ = Describe("Resources API", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthenticatedReturns(true)
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 				})
 
 				It("returns 200 OK", func() {
@@ -438,6 +447,7 @@ var _ = Describe("Resources API", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthenticatedReturns(true)
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 				})
 
 				It("returns 200 OK", func() {
@@ -586,6 +596,7 @@ var _ = Describe("Resources API", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			It("looks it up in the database", func() {
@@ -652,6 +663,35 @@ var _ = Describe("Resources API", func() {
 							}`))
 				})
 			})
+
+			Context("when the resource is pinned", func() {
+				BeforeEach(func() {
+					resource1 := new(dbfakes.FakeResource)
+					resource1.PipelineNameReturns("a-pipeline")
+					resource1.NameReturns("resource-1")
+					resource1.TypeReturns("type-1")
+					resource1.PinnedVersionIDReturns(42)
+					resource1.PinnedVersionReturns(atc.Version{"ref": "abc"})
+					resource1.PinCommentReturns("broken after abc")
+
+					fakePipeline.ResourceReturns(resource1, true, nil)
+				})
+
+				It("returns the resource json with the pinned version and comment", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`
+							{
+								"name": "resource-1",
+								"pipeline_name": "a-pipeline",
+								"team_name": "a-team",
+								"type": "type-1",
+								"pinned_version": {"ref": "abc"},
+								"pin_comment": "broken after abc"
+							}`))
+				})
+			})
 		})
 	})
 
@@ -685,6 +725,7 @@ var _ = Describe("Resources API", func() {
 			Context("when authorized", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 				})
 
 				It("injects the proper pipelineDB", func() {
@@ -736,6 +777,85 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", func() {
+		var (
+			response     *http.Response
+			fakeResource *dbfakes.FakeResource
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(dbfakes.FakeResource)
+			fakeResource.NameReturns("resource-name")
+
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/unpin", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized as an operator", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
+			})
+
+			Context("when unpinning the resource succeeds", func() {
+				It("unpins the resource", func() {
+					Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("resource-name"))
+					Expect(fakeResource.UnpinVersionCallCount()).To(Equal(1))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when resource can not be found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when unpinning the resource fails", func() {
+				BeforeEach(func() {
+					fakeResource.UnpinVersionReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not an operator", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(false)
+			})
+
+			It("returns Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not unpin the resource", func() {
+				Expect(fakeResource.UnpinVersionCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpause", func() {
 		var (
 			response     *http.Response
@@ -766,6 +886,7 @@ var _ = Describe("Resources API", func() {
 			Context("when authorized", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 				})
 
 				It("injects the proper pipelineDB", func() {
@@ -845,6 +966,7 @@ var _ = Describe("Resources API", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			It("injects the proper pipelineDB", func() {
@@ -951,6 +1073,19 @@ var _ = Describe("Resources API", func() {
 				})
 			})
 
+			Context("when the resource is pinned to a version", func() {
+				BeforeEach(func() {
+					fakeScanner.ScanFromVersionReturns(radar.ErrResourcePinned)
+				})
+
+				It("returns 409 with the reason", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(Equal("resource is pinned to a version"))
+				})
+			})
+
 			Context("when checking the resource fails internally", func() {
 				BeforeEach(func() {
 					fakeScanner.ScanFromVersionReturns(errors.New("welp"))
@@ -1134,6 +1269,16 @@ var _ = Describe("Resources API", func() {
 				})
 			})
 
+			Context("when the resource is pinned to a version", func() {
+				BeforeEach(func() {
+					fakeScanner.ScanFromVersionReturns(radar.ErrResourcePinned)
+				})
+
+				It("returns 409", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
 			Context("when checking the resource fails internally", func() {
 				BeforeEach(func() {
 					fakeScanner.ScanFromVersionReturns(errors.New("welp"))
@@ -1164,4 +1309,294 @@ var _ = Describe("Resources API", func() {
 			})
 		})
 	})
+
+	Describe("POST /api/v1/teams/:team_name/webhook", func() {
+		var (
+			fakeScanner   *radarfakes.FakeScanner
+			matchingRes   *dbfakes.FakeResource
+			otherRes      *dbfakes.FakeResource
+			otherBranch   *dbfakes.FakeResource
+			payload       []byte
+			signature     string
+			signatureName string
+			response      *http.Response
+		)
+
+		sign := func(body []byte, secret string) string {
+			mac := hmac.New(sha1.New, []byte(secret))
+			mac.Write(body)
+			return "sha1=" + hex.EncodeToString(mac.Sum(nil))
+		}
+
+		BeforeEach(func() {
+			fakeScanner = new(radarfakes.FakeScanner)
+			fakeScannerFactory.NewResourceScannerReturns(fakeScanner)
+
+			fakeVariablesFactory.NewVariablesReturns(template.StaticVariables{
+				"webhook_secret": "some-secret",
+				"repo-uri":       "git@github.com:concourse/atc.git",
+			})
+
+			matchingRes = new(dbfakes.FakeResource)
+			matchingRes.NameReturns("matching-resource")
+			matchingRes.SourceReturns(atc.Source{"uri": "((repo-uri))", "branch": "master"})
+
+			otherRes = new(dbfakes.FakeResource)
+			otherRes.NameReturns("other-resource")
+			otherRes.SourceReturns(atc.Source{"uri": "https://github.com/concourse/fly.git"})
+
+			otherBranch = new(dbfakes.FakeResource)
+			otherBranch.NameReturns("other-branch-resource")
+			otherBranch.SourceReturns(atc.Source{"uri": "https://github.com/concourse/atc", "branch": "develop"})
+
+			fakePipeline.NameReturns("a-pipeline")
+			fakePipeline.ResourcesReturns(db.Resources{matchingRes, otherRes, otherBranch}, nil)
+			dbTeam.PipelinesReturns([]db.Pipeline{fakePipeline}, nil)
+
+			payload = []byte(`{
+				"ref": "refs/heads/master",
+				"repository": {
+					"clone_url": "https://github.com/concourse/atc.git",
+					"ssh_url": "git@github.com:concourse/atc.git"
+				}
+			}`)
+
+			signatureName = "X-Hub-Signature"
+			signature = sign(payload, "some-secret")
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/webhook", bytes.NewBuffer(payload))
+			Expect(err).NotTo(HaveOccurred())
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set(signatureName, signature)
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the signature is valid", func() {
+			It("returns 200 with the resources being checked", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`[{"pipeline_name":"a-pipeline","resource_name":"matching-resource"}]`))
+			})
+
+			It("looks up the team and its secret", func() {
+				Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("a-team"))
+
+				teamName, pipelineName := fakeVariablesFactory.NewVariablesArgsForCall(0)
+				Expect(teamName).To(Equal("a-team"))
+				Expect(pipelineName).To(BeEmpty())
+			})
+
+			It("only checks the resources matching the repository and branch, before responding", func() {
+				Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+				Consistently(fakeScanner.ScanFromVersionCallCount).Should(Equal(1))
+
+				_, resourceName, fromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+				Expect(resourceName).To(Equal("matching-resource"))
+				Expect(fromVersion).To(BeNil())
+			})
+
+			Context("when the resource already has versions", func() {
+				BeforeEach(func() {
+					fakePipeline.GetLatestVersionedResourceReturns(db.SavedVersionedResource{
+						VersionedResource: db.VersionedResource{
+							Version: db.ResourceVersion{"some": "version"},
+						},
+					}, true, nil)
+				})
+
+				It("checks from the latest version", func() {
+					Eventually(fakeScanner.ScanFromVersionCallCount).Should(Equal(1))
+
+					_, _, fromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+					Expect(fromVersion).To(Equal(atc.Version{"some": "version"}))
+				})
+			})
+
+			Context("when many resources match", func() {
+				var (
+					lock          sync.Mutex
+					active        int
+					mostActive    int
+					manyResources db.Resources
+				)
+
+				BeforeEach(func() {
+					active, mostActive = 0, 0
+
+					manyResources = db.Resources{}
+					for i := 0; i < 3*resourceserver.MaxConcurrentWebhookChecks; i++ {
+						manyResources = append(manyResources, matchingRes)
+					}
+					fakePipeline.ResourcesReturns(manyResources, nil)
+
+					fakeScanner.ScanFromVersionStub = func(lager.Logger, string, atc.Version) error {
+						lock.Lock()
+						active++
+						if active > mostActive {
+							mostActive = active
+						}
+						lock.Unlock()
+
+						time.Sleep(10 * time.Millisecond)
+
+						lock.Lock()
+						active--
+						lock.Unlock()
+
+						return nil
+					}
+				})
+
+				It("checks a few at a time", func() {
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(len(manyResources)))
+
+					lock.Lock()
+					defer lock.Unlock()
+					Expect(mostActive).To(BeNumerically("<=", resourceserver.MaxConcurrentWebhookChecks))
+				})
+			})
+
+			Context("when the payload is a tag push", func() {
+				BeforeEach(func() {
+					payload = []byte(`{
+						"ref": "refs/tags/v1.0.0",
+						"repository": {"clone_url": "https://github.com/concourse/atc.git"}
+					}`)
+					signature = sign(payload, "some-secret")
+				})
+
+				It("checks every resource for the repository", func() {
+					Eventually(fakeScanner.ScanFromVersionCallCount).Should(Equal(2))
+				})
+			})
+
+			Context("when the payload is from GitLab", func() {
+				BeforeEach(func() {
+					payload = []byte(`{
+						"ref": "refs/heads/develop",
+						"project": {"git_http_url": "https://github.com/concourse/atc.git"},
+						"changes": {"title": {"previous": "a", "current": "b"}}
+					}`)
+					signatureName = "X-Gitlab-Token"
+					signature = "some-secret"
+				})
+
+				It("checks the resources for the branch", func() {
+					Eventually(fakeScanner.ScanFromVersionCallCount).Should(Equal(1))
+
+					_, resourceName, _ := fakeScanner.ScanFromVersionArgsForCall(0)
+					Expect(resourceName).To(Equal("other-branch-resource"))
+				})
+			})
+
+			Context("when the payload is from Bitbucket Server", func() {
+				BeforeEach(func() {
+					payload = []byte(`{
+						"repository": {
+							"links": {
+								"clone": [{"href": "ssh://git@github.com/concourse/atc.git", "name": "ssh"}],
+								"self": [{"href": "https://github.com/projects/concourse/repos/atc/browse"}]
+							}
+						},
+						"changes": [{"ref": {"id": "refs/heads/master", "type": "BRANCH"}}]
+					}`)
+
+					mac := hmac.New(sha256.New, []byte("some-secret"))
+					mac.Write(payload)
+					signature = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+				})
+
+				It("checks the resources for the branch", func() {
+					Eventually(fakeScanner.ScanFromVersionCallCount).Should(Equal(1))
+
+					_, resourceName, _ := fakeScanner.ScanFromVersionArgsForCall(0)
+					Expect(resourceName).To(Equal("matching-resource"))
+				})
+			})
+
+			Context("when the payload is malformed", func() {
+				BeforeEach(func() {
+					payload = []byte(`nope`)
+					signature = sign(payload, "some-secret")
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when getting the pipelines fails", func() {
+				BeforeEach(func() {
+					dbTeam.PipelinesReturns(nil, errors.New("disaster"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the signature is invalid", func() {
+			BeforeEach(func() {
+				signature = sign(payload, "wrong-secret")
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not check anything", func() {
+				Consistently(fakeScanner.ScanFromVersionCallCount).Should(BeZero())
+			})
+		})
+
+		Context("when the request is not signed", func() {
+			BeforeEach(func() {
+				signatureName = "X-Something-Else"
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when the team has no webhook secret", func() {
+			BeforeEach(func() {
+				fakeVariablesFactory.NewVariablesReturns(template.StaticVariables{})
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when the team does not exist", func() {
+			BeforeEach(func() {
+				dbTeamFactory.FindTeamReturns(nil, false, nil)
+			})
+
+			Context("and the request is signed with the secret for the team's name", func() {
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("and the request is not signed with the secret", func() {
+				BeforeEach(func() {
+					signature = sign(payload, "wrong-secret")
+				})
+
+				It("returns 401 without looking up the team", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(dbTeamFactory.FindTeamCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
 })
@@ -7,6 +7,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/radar"
 	"github.com/concourse/atc/resource"
 	"github.com/google/jsonapi"
 	"github.com/tedsuo/rata"
@@ -44,6 +45,12 @@ func (s *Server) CheckResource(dbPipeline db.Pipeline) http.Handler {
 		scanner := s.scannerFactory.NewResourceScanner(dbPipeline)
 
 		err = scanner.ScanFromVersion(logger, resourceName, fromVersion)
+		if err == radar.ErrResourcePinned {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
 		switch scanErr := err.(type) {
 		case resource.ErrResourceScriptFailed:
 			checkResponseBody := atc.CheckResponseBody{
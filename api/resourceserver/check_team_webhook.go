@@ -0,0 +1,201 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/radar"
+	"github.com/tedsuo/rata"
+)
+
+// TeamWebhookSecretVar is the name of the team-level credential used to
+// verify the signatures of requests made to the team webhook.
+const TeamWebhookSecretVar = "webhook_secret"
+
+// maxWebhookPayloadSize bounds how much of a webhook request body is read.
+const maxWebhookPayloadSize = 10 * 1024 * 1024
+
+// MaxConcurrentWebhookChecks is how many of the resources matching a webhook
+// request are checked at once.
+const MaxConcurrentWebhookChecks = 4
+
+type WebhookCheck struct {
+	PipelineName string `json:"pipeline_name"`
+	ResourceName string `json:"resource_name"`
+}
+
+// CheckTeamWebHook verifies the signature of a push event from a git hosting
+// service and checks every resource in the team whose source matches the
+// repository and branch that were pushed to. Requests for teams which do not
+// exist fail verification like any other, as they have no webhook secret.
+//
+// The checks are run before responding, a few at a time, and any which have
+// yet to start are skipped if the request goes away.
+func (s *Server) CheckTeamWebHook(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("check-team-webhook")
+
+	teamName := rata.Param(r, "team_name")
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookPayloadSize))
+	if err != nil {
+		logger.Info("failed-to-read-body", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	teamVariables := s.variablesFactory.NewVariables(teamName, "")
+
+	secret, err := creds.NewString(teamVariables, "(("+TeamWebhookSecretVar+"))").Evaluate()
+	if err != nil {
+		logger.Info("webhook-secret-not-configured", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !verifyWebhookSignature(r.Header, body, secret) {
+		logger.Info("invalid-signature", lager.Data{"team": teamName})
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	// the team is only looked up once the request is known to be from its
+	// webhook, so that the existence of teams is not revealed
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		logger.Info("team-not-found", lager.Data{"team": teamName})
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	payload, err := parseWebhookPayload(body)
+	if err != nil {
+		logger.Info("malformed-payload", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	pipelines, err := team.Pipelines()
+	if err != nil {
+		logger.Error("failed-to-get-pipelines", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	type match struct {
+		pipeline     db.Pipeline
+		resourceName string
+	}
+
+	checks := []WebhookCheck{}
+	matches := []match{}
+
+	for _, pipeline := range pipelines {
+		resources, err := pipeline.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-resources", err, lager.Data{"pipeline": pipeline.Name()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		variables := s.variablesFactory.NewVariables(teamName, pipeline.Name())
+
+		for _, resource := range resources {
+			source, err := creds.NewSource(variables, resource.Source()).Evaluate()
+			if err != nil {
+				logger.Info("failed-to-evaluate-source", lager.Data{
+					"pipeline": pipeline.Name(),
+					"resource": resource.Name(),
+					"error":    err.Error(),
+				})
+				continue
+			}
+
+			if !payload.Matches(source) {
+				continue
+			}
+
+			checks = append(checks, WebhookCheck{
+				PipelineName: pipeline.Name(),
+				ResourceName: resource.Name(),
+			})
+
+			matches = append(matches, match{pipeline, resource.Name()})
+		}
+	}
+
+	logger.Info("checking", lager.Data{"checks": checks})
+
+	ctx := r.Context()
+	inFlight := make(chan struct{}, MaxConcurrentWebhookChecks)
+	wg := new(sync.WaitGroup)
+
+	for _, m := range matches {
+		select {
+		case inFlight <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			logger.Info("request-gone", lager.Data{"error": ctx.Err().Error()})
+			break
+		}
+
+		wg.Add(1)
+		go func(m match) {
+			defer func() {
+				<-inFlight
+				wg.Done()
+			}()
+
+			s.checkFromLatestVersion(logger, m.pipeline, m.resourceName)
+		}(m)
+	}
+
+	wg.Wait()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(checks)
+	if err != nil {
+		logger.Error("failed-to-encode-checks", err)
+	}
+}
+
+func (s *Server) checkFromLatestVersion(logger lager.Logger, pipeline db.Pipeline, resourceName string) {
+	logger = logger.WithData(lager.Data{
+		"pipeline": pipeline.Name(),
+		"resource": resourceName,
+	})
+
+	var fromVersion atc.Version
+	latestVersion, found, err := pipeline.GetLatestVersionedResource(resourceName)
+	if err != nil {
+		logger.Error("failed-to-get-latest-versioned-resource", err)
+		return
+	}
+
+	if found {
+		fromVersion = atc.Version(latestVersion.Version)
+	}
+
+	scanner := s.scannerFactory.NewResourceScanner(pipeline)
+	err = scanner.ScanFromVersion(logger, resourceName, fromVersion)
+	if err == radar.ErrResourcePinned {
+		logger.Info("resource-pinned")
+	} else if err != nil {
+		logger.Error("failed-to-scan", err)
+	}
+}
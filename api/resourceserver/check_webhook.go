@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/radar"
 	"github.com/tedsuo/rata"
 )
 
@@ -59,6 +60,12 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 
 		scanner := s.scannerFactory.NewResourceScanner(dbPipeline)
 		err = scanner.ScanFromVersion(logger, resourceName, fromVersion)
+		if err == radar.ErrResourcePinned {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
 		switch err.(type) {
 		case db.ResourceNotFoundError:
 			w.WriteHeader(http.StatusNotFound)
@@ -18,6 +18,7 @@ type Server struct {
 	scannerFactory   ScannerFactory
 	variablesFactory creds.VariablesFactory
 	resourceFactory  db.ResourceFactory
+	teamFactory      db.TeamFactory
 }
 
 func NewServer(
@@ -25,11 +26,13 @@ func NewServer(
 	scannerFactory ScannerFactory,
 	variablesFactory creds.VariablesFactory,
 	resourceFactory db.ResourceFactory,
+	teamFactory db.TeamFactory,
 ) *Server {
 	return &Server{
 		logger:           logger,
 		scannerFactory:   scannerFactory,
 		variablesFactory: variablesFactory,
 		resourceFactory:  resourceFactory,
+		teamFactory:      teamFactory,
 	}
 }
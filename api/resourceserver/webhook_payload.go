@@ -0,0 +1,245 @@
+package resourceserver
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"net/http"
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+// verifyWebhookSignature checks the request against the secret using
+// whichever scheme the sender used. GitHub and Bitbucket Server sign the body
+// with an HMAC, sent as X-Hub-Signature (sha1= or sha256=) or
+// X-Hub-Signature-256. GitLab sends the secret itself as X-Gitlab-Token.
+func verifyWebhookSignature(header http.Header, body []byte, secret string) bool {
+	if secret == "" {
+		return false
+	}
+
+	if token := header.Get("X-Gitlab-Token"); token != "" {
+		return subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+	}
+
+	if signature := header.Get("X-Hub-Signature-256"); signature != "" {
+		return validHMAC(sha256.New, strings.TrimPrefix(signature, "sha256="), body, secret)
+	}
+
+	signature := header.Get("X-Hub-Signature")
+	switch {
+	case strings.HasPrefix(signature, "sha1="):
+		return validHMAC(sha1.New, strings.TrimPrefix(signature, "sha1="), body, secret)
+	case strings.HasPrefix(signature, "sha256="):
+		return validHMAC(sha256.New, strings.TrimPrefix(signature, "sha256="), body, secret)
+	}
+
+	return false
+}
+
+func validHMAC(h func() hash.Hash, signature string, body []byte, secret string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(body)
+
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// webhookPayload is what we care about from a push event: which repository
+// was pushed to, and which branches changed.
+type webhookPayload struct {
+	Repositories []string
+	Branches     []string
+}
+
+type rawWebhookPayload struct {
+	// GitHub and GitLab
+	Ref string `json:"ref"`
+
+	Repository struct {
+		// GitHub
+		CloneURL string `json:"clone_url"`
+		SSHURL   string `json:"ssh_url"`
+		GitURL   string `json:"git_url"`
+		HTMLURL  string `json:"html_url"`
+
+		// GitLab
+		GitHTTPURL string `json:"git_http_url"`
+		GitSSHURL  string `json:"git_ssh_url"`
+		Homepage   string `json:"homepage"`
+
+		// GitHub and GitLab
+		URL string `json:"url"`
+
+		// Bitbucket, where the shape differs between Server and Cloud
+		Links map[string]json.RawMessage `json:"links"`
+	} `json:"repository"`
+
+	// GitLab
+	Project struct {
+		GitHTTPURL string `json:"git_http_url"`
+		GitSSHURL  string `json:"git_ssh_url"`
+		WebURL     string `json:"web_url"`
+	} `json:"project"`
+
+	// Bitbucket Server; GitLab uses this key for something else entirely
+	Changes json.RawMessage `json:"changes"`
+
+	// Bitbucket Cloud
+	Push struct {
+		Changes []struct {
+			New struct {
+				Type string `json:"type"`
+				Name string `json:"name"`
+			} `json:"new"`
+		} `json:"changes"`
+	} `json:"push"`
+}
+
+type bitbucketLink struct {
+	Href string `json:"href"`
+}
+
+type bitbucketServerChange struct {
+	Ref struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	} `json:"ref"`
+}
+
+func parseWebhookPayload(body []byte) (webhookPayload, error) {
+	var raw rawWebhookPayload
+	err := json.Unmarshal(body, &raw)
+	if err != nil {
+		return webhookPayload{}, err
+	}
+
+	payload := webhookPayload{}
+
+	payload.addRepository(
+		raw.Repository.CloneURL,
+		raw.Repository.SSHURL,
+		raw.Repository.GitURL,
+		raw.Repository.HTMLURL,
+		raw.Repository.GitHTTPURL,
+		raw.Repository.GitSSHURL,
+		raw.Repository.Homepage,
+		raw.Repository.URL,
+		raw.Project.GitHTTPURL,
+		raw.Project.GitSSHURL,
+		raw.Project.WebURL,
+	)
+
+	for _, rawLink := range raw.Repository.Links {
+		var link bitbucketLink
+		if json.Unmarshal(rawLink, &link) == nil {
+			payload.addRepository(link.Href)
+			continue
+		}
+
+		var links []bitbucketLink
+		if json.Unmarshal(rawLink, &links) == nil {
+			for _, link := range links {
+				payload.addRepository(link.Href)
+			}
+		}
+	}
+
+	payload.addRef(raw.Ref)
+
+	var serverChanges []bitbucketServerChange
+	if json.Unmarshal(raw.Changes, &serverChanges) == nil {
+		for _, change := range serverChanges {
+			payload.addRef(change.Ref.ID)
+		}
+	}
+
+	for _, change := range raw.Push.Changes {
+		if change.New.Type == "branch" {
+			payload.Branches = append(payload.Branches, change.New.Name)
+		}
+	}
+
+	return payload, nil
+}
+
+func (payload *webhookPayload) addRepository(uris ...string) {
+	for _, uri := range uris {
+		if uri == "" {
+			continue
+		}
+
+		payload.Repositories = append(payload.Repositories, normalizeRepositoryURI(uri))
+	}
+}
+
+func (payload *webhookPayload) addRef(ref string) {
+	if strings.HasPrefix(ref, "refs/heads/") {
+		payload.Branches = append(payload.Branches, strings.TrimPrefix(ref, "refs/heads/"))
+	}
+}
+
+// Matches returns true if the resource source refers to the repository of the
+// payload via its 'uri', and to one of the changed branches via its 'branch'.
+//
+// Sources without a 'branch' match any push to the repository, as do payloads
+// which did not change any branches (e.g. tag pushes).
+func (payload webhookPayload) Matches(source atc.Source) bool {
+	uri, ok := source["uri"].(string)
+	if !ok || uri == "" {
+		return false
+	}
+
+	if !containsString(payload.Repositories, normalizeRepositoryURI(uri)) {
+		return false
+	}
+
+	branch, ok := source["branch"].(string)
+	if !ok || branch == "" || len(payload.Branches) == 0 {
+		return true
+	}
+
+	return containsString(payload.Branches, branch)
+}
+
+// normalizeRepositoryURI reduces the various ways of referring to a
+// repository (https, ssh, scp-style) to host/path, so that e.g.
+// git@github.com:concourse/atc.git and https://github.com/concourse/atc
+// are considered the same.
+func normalizeRepositoryURI(uri string) string {
+	uri = strings.ToLower(strings.TrimSpace(uri))
+
+	if i := strings.Index(uri, "://"); i != -1 {
+		uri = uri[i+3:]
+	} else if i := strings.Index(uri, ":"); i != -1 && !strings.Contains(uri[:i], "/") {
+		uri = uri[:i] + "/" + uri[i+1:]
+	}
+
+	if i := strings.Index(uri, "@"); i != -1 && !strings.Contains(uri[:i], "/") {
+		uri = uri[i+1:]
+	}
+
+	uri = strings.TrimSuffix(uri, "/")
+	uri = strings.TrimSuffix(uri, ".git")
+
+	return uri
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,6 +2,8 @@ package api_test
 
 import (
 	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,11 +12,15 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
 	"github.com/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
+	jwt "github.com/dgrijalva/jwt-go"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/vito/go-sse/sse"
 )
 
 func jsonEncode(object interface{}) *bytes.Buffer {
@@ -81,14 +87,14 @@ var _ = Describe("Teams API", func() {
 
 				fakeTeamTwo.IDReturns(9)
 				fakeTeamTwo.NameReturns("aliens")
-				fakeTeamTwo.AuthReturns(map[string][]string{
-					"groups": []string{"github:org:team"},
+				fakeTeamTwo.AuthReturns(atc.TeamAuth{
+					"owner": {"groups": []string{"github:org:team"}},
 				})
 
 				fakeTeamThree.IDReturns(22)
 				fakeTeamThree.NameReturns("predators")
-				fakeTeamThree.AuthReturns(map[string][]string{
-					"users": []string{"local:username"},
+				fakeTeamThree.AuthReturns(atc.TeamAuth{
+					"owner": {"users": []string{"local:username"}},
 				})
 
 				dbTeamFactory.GetTeamsReturns([]db.Team{fakeTeamOne, fakeTeamTwo, fakeTeamThree}, nil)
@@ -152,8 +158,8 @@ var _ = Describe("Teams API", func() {
 			Context("when the team exists", func() {
 				BeforeEach(func() {
 					atcTeam = atc.Team{
-						Auth: map[string][]string{
-							"users": []string{"local:username"},
+						Auth: atc.TeamAuth{
+							"owner": {"users": []string{"local:username"}},
 						},
 					}
 					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
@@ -161,21 +167,42 @@ var _ = Describe("Teams API", func() {
 
 				It("updates provider auth", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusOK))
-					Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(1))
+					Expect(fakeTeam.UpdateSettingsCallCount()).To(Equal(1))
 
-					updatedProviderAuth := fakeTeam.UpdateProviderAuthArgsForCall(0)
+					updatedProviderAuth, _ := fakeTeam.UpdateSettingsArgsForCall(0)
 					Expect(updatedProviderAuth).To(Equal(atcTeam.Auth))
 				})
 
+				It("leaves secret redaction as it was", func() {
+					_, redactSecrets := fakeTeam.UpdateSettingsArgsForCall(0)
+					Expect(redactSecrets).To(BeNil())
+				})
+
 				Context("when updating provider auth fails", func() {
 					BeforeEach(func() {
-						fakeTeam.UpdateProviderAuthReturns(errors.New("stop trying to make fetch happen"))
+						fakeTeam.UpdateSettingsReturns(errors.New("stop trying to make fetch happen"))
 					})
 
 					It("returns 500 Internal Server error", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 					})
 				})
+
+				Context("when setting secret redaction", func() {
+					BeforeEach(func() {
+						redactSecrets := false
+						atcTeam.RedactSecrets = &redactSecrets
+					})
+
+					It("updates secret redaction along with provider auth", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateSettingsCallCount()).To(Equal(1))
+
+						updatedProviderAuth, redactSecrets := fakeTeam.UpdateSettingsArgsForCall(0)
+						Expect(updatedProviderAuth).To(Equal(atcTeam.Auth))
+						Expect(redactSecrets).To(Equal(atcTeam.RedactSecrets))
+					})
+				})
 			})
 		}
 
@@ -216,14 +243,36 @@ var _ = Describe("Teams API", func() {
 			})
 		})
 
-		Context("when the requester team is authorized as the team being set", func() {
+		Context("when the requester is an owner of the team being set", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
-				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			authorizedTeamTests()
 
+			It("checks for the owner role", func() {
+				role, teamName := fakeaccess.HasRoleArgsForCall(0)
+				Expect(role).To(Equal(atc.OwnerRole))
+				Expect(teamName).To(Equal("some-team"))
+			})
+
+			Context("when the auth has an unknown role", func() {
+				BeforeEach(func() {
+					atcTeam = atc.Team{
+						Auth: atc.TeamAuth{
+							"overlord": {"users": []string{"local:username"}},
+						},
+					}
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.UpdateProviderAuthCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when the team is not found", func() {
 				BeforeEach(func() {
 					dbTeamFactory.FindTeamReturns(nil, false, nil)
@@ -359,6 +408,7 @@ var _ = Describe("Teams API", func() {
 	Describe("PUT /api/v1/teams/:team_name/rename", func() {
 		var response *http.Response
 		var teamName string
+		var authorization string
 
 		JustBeforeEach(func() {
 			request, err := http.NewRequest(
@@ -368,12 +418,45 @@ var _ = Describe("Teams API", func() {
 			)
 			Expect(err).NotTo(HaveOccurred())
 
+			if authorization != "" {
+				request.Header.Set("Authorization", authorization)
+			}
+
 			response, err = client.Do(request)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
 		BeforeEach(func() {
 			fakeTeam.IDReturns(2)
+			authorization = ""
+		})
+
+		Context("when authenticated with a token that only lists team names", func() {
+			BeforeEach(func() {
+				key, err := rsa.GenerateKey(rand.Reader, 2048)
+				Expect(err).NotTo(HaveOccurred())
+
+				token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+					"teams": []string{"a-team"},
+				})
+
+				tokenString, err := token.SignedString(key)
+				Expect(err).NotTo(HaveOccurred())
+
+				authorization = "Bearer " + tokenString
+
+				realAccessor := accessor.NewAccessFactory(&key.PublicKey)
+				fakeAccessor.CreateStub = realAccessor.Create
+
+				teamName = "a-team"
+				fakeTeam.NameReturns(teamName)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			It("treats the requester as an owner of the team", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				Expect(fakeTeam.RenameCallCount()).To(Equal(1))
+			})
 		})
 
 		Context("when authenticated", func() {
@@ -403,11 +486,11 @@ var _ = Describe("Teams API", func() {
 				})
 			})
 
-			Context("when requester belongs to the team", func() {
+			Context("when requester is an owner of the team", func() {
 				BeforeEach(func() {
 					teamName = "a-team"
 					fakeTeam.NameReturns(teamName)
-					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
 				})
 
@@ -426,11 +509,11 @@ var _ = Describe("Teams API", func() {
 				})
 			})
 
-			Context("when requester does not belong to the team", func() {
+			Context("when requester is not an owner of the team", func() {
 				BeforeEach(func() {
 					teamName = "a-team"
 					fakeTeam.NameReturns(teamName)
-					fakeaccess.IsAuthorizedReturns(false)
+					fakeaccess.HasRoleReturns(false)
 					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
 				})
 
@@ -615,4 +698,362 @@ var _ = Describe("Teams API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/user/roles", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			path := fmt.Sprintf("%s/api/v1/user/roles", server.URL)
+
+			request, err := http.NewRequest("GET", path, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAdminReturns(true)
+				fakeaccess.TeamRolesReturns(map[string][]string{
+					"main":      {"owner"},
+					"some-team": {"viewer", "member", "operator"},
+				})
+			})
+
+			It("returns 200 OK", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns application/json", func() {
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("returns the most privileged role in each team", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"is_admin": true,
+					"teams": {
+						"main": "owner",
+						"some-team": "member"
+					}
+				}`))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/notification-deliveries", func() {
+		var (
+			response    *http.Response
+			queryParams string
+		)
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/notification-deliveries" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			})
+
+			Context("when getting the deliveries succeeds", func() {
+				BeforeEach(func() {
+					queryParams = "?limit=2"
+
+					fakeTeam.NotificationDeliveriesReturns([]db.NotificationDelivery{
+						{
+							ID:           2,
+							Name:         "on-failure",
+							TeamName:     "some-team",
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							BuildID:      4,
+							BuildName:    "2",
+							Status:       db.NotificationDeliveryStatusPending,
+							Attempts:     1,
+							ResponseCode: 503,
+							Error:        "unexpected response: 503 Service Unavailable",
+							CreatedAt:    time.Unix(100, 0),
+						},
+						{
+							ID:           1,
+							Name:         "on-failure",
+							TeamName:     "some-team",
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							BuildID:      3,
+							BuildName:    "1",
+							Status:       db.NotificationDeliveryStatusSucceeded,
+							Attempts:     1,
+							ResponseCode: 200,
+							CreatedAt:    time.Unix(50, 0),
+							DeliveredAt:  time.Unix(60, 0),
+						},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("gets the team's deliveries with the limit", func() {
+					Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+					Expect(fakeTeam.NotificationDeliveriesArgsForCall(0)).To(Equal(2))
+				})
+
+				It("returns the deliveries", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 2,
+							"name": "on-failure",
+							"team_name": "some-team",
+							"pipeline_name": "some-pipeline",
+							"job_name": "some-job",
+							"build_id": 4,
+							"build_name": "2",
+							"status": "pending",
+							"attempts": 1,
+							"response_code": 503,
+							"error": "unexpected response: 503 Service Unavailable",
+							"created_at": 100
+						},
+						{
+							"id": 1,
+							"name": "on-failure",
+							"team_name": "some-team",
+							"pipeline_name": "some-pipeline",
+							"job_name": "some-job",
+							"build_id": 3,
+							"build_name": "1",
+							"status": "succeeded",
+							"attempts": 1,
+							"response_code": 200,
+							"created_at": 50,
+							"delivered_at": 60
+						}
+					]`))
+				})
+			})
+
+			Context("when no limit is given", func() {
+				It("uses the default limit", func() {
+					Expect(fakeTeam.NotificationDeliveriesArgsForCall(0)).To(Equal(atc.PaginationAPIDefaultLimit))
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the deliveries fails", func() {
+				BeforeEach(func() {
+					fakeTeam.NotificationDeliveriesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.NotificationDeliveriesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/events", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+
+			fakeEventSource *dbfakes.FakeTeamEventSource
+		)
+
+		BeforeEach(func() {
+			var err error
+
+			request, err = http.NewRequest("GET", server.URL+"/api/v1/teams/some-team/events", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeEventSource = new(dbfakes.FakeTeamEventSource)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+				fakeTeam.LatestEventIDReturns(41, nil)
+				fakeTeam.EventsReturns(fakeEventSource, nil)
+
+				for i := 0; i < 2; i++ {
+					data := json.RawMessage(fmt.Sprintf(`{"pipeline_name":"some-pipeline","job_name":"job-%d"}`, i))
+
+					fakeEventSource.NextReturnsOnCall(i, db.TeamEvent{
+						ID: 42 + i,
+						Envelope: event.Envelope{
+							Data:    &data,
+							Event:   event.EventTypeJobPaused,
+							Version: "1.0",
+						},
+					}, nil)
+				}
+
+				fakeEventSource.NextReturnsOnCall(2, db.TeamEvent{}, db.ErrTeamEventStreamClosed)
+			})
+
+			It("returns 200 OK as an event stream", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("text/event-stream; charset=utf-8"))
+			})
+
+			It("streams events after the latest one", func() {
+				defer db.Close(response.Body)
+				reader := sse.NewReadCloser(response.Body)
+
+				Expect(reader.Next()).To(Equal(sse.Event{
+					ID:   "42",
+					Name: "event",
+					Data: []byte(`{"data":{"pipeline_name":"some-pipeline","job_name":"job-0"},"event":"job-paused","version":"1.0"}`),
+				}))
+
+				Expect(reader.Next()).To(Equal(sse.Event{
+					ID:   "43",
+					Name: "event",
+					Data: []byte(`{"data":{"pipeline_name":"some-pipeline","job_name":"job-1"},"event":"job-paused","version":"1.0"}`),
+				}))
+
+				Expect(dbTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+				Expect(fakeTeam.EventsArgsForCall(0)).To(Equal(41))
+			})
+
+			It("closes the event source", func() {
+				_, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(fakeEventSource.CloseCallCount).Should(BeNumerically(">=", 1))
+			})
+
+			Context("when resuming from the Last-Event-ID", func() {
+				BeforeEach(func() {
+					request.Header.Set("Last-Event-ID", "10")
+				})
+
+				It("streams events after it", func() {
+					_, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeTeam.EventsArgsForCall(0)).To(Equal(10))
+					Expect(fakeTeam.LatestEventIDCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the Last-Event-ID is invalid", func() {
+				BeforeEach(func() {
+					request.Header.Set("Last-Event-ID", "bogus")
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.EventsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the events fails", func() {
+				BeforeEach(func() {
+					fakeTeam.EventsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeTeam.EventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
 })
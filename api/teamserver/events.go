@@ -0,0 +1,107 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/vito/go-sse/sse"
+)
+
+// TeamEvents streams the team's activity as server-sent events. Clients
+// resume with the Last-Event-ID header; without one, only events which happen
+// after connecting are streamed.
+func (s *Server) TeamEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("team-events")
+
+	teamName := r.FormValue(":team_name")
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var cursor int
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		cursor, err = strconv.Atoi(lastEventID)
+		if err != nil {
+			logger.Info("failed-to-parse-last-event-id", lager.Data{"last-event-id": lastEventID})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	} else {
+		cursor, err = team.LatestEventID()
+		if err != nil {
+			logger.Error("failed-to-get-latest-event-id", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	events, err := team.Events(cursor)
+	if err != nil {
+		logger.Error("failed-to-get-team-events", err, lager.Data{"cursor": cursor})
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	defer db.Close(events)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	// the stream never ends, so stop waiting for events once the client leaves
+	go func() {
+		select {
+		case <-w.(http.CloseNotifier).CloseNotify():
+			db.Close(events)
+		case <-done:
+		}
+	}()
+
+	w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
+	w.Header().Add("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Header().Add("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	flusher := w.(http.Flusher)
+	flusher.Flush()
+
+	for {
+		ev, err := events.Next()
+		if err != nil {
+			if err != db.ErrTeamEventStreamClosed {
+				logger.Error("failed-to-get-next-team-event", err)
+			}
+
+			return
+		}
+
+		payload, err := json.Marshal(ev.Envelope)
+		if err != nil {
+			logger.Error("failed-to-marshal-team-event", err)
+			return
+		}
+
+		err = sse.Event{
+			ID:   strconv.Itoa(ev.ID),
+			Name: "event",
+			Data: payload,
+		}.Write(w)
+		if err != nil {
+			logger.Info("failed-to-write-event", lager.Data{"error": err.Error()})
+			return
+		}
+
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,54 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+)
+
+func (s *Server) ListNotificationDeliveries(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-notification-deliveries")
+
+	teamName := r.FormValue(":team_name")
+
+	limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if limit <= 0 {
+		limit = atc.PaginationAPIDefaultLimit
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	deliveries, err := team.NotificationDeliveries(limit)
+	if err != nil {
+		logger.Error("failed-to-get-notification-deliveries", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presented := make([]atc.NotificationDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		presented[i] = present.NotificationDelivery(delivery)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-notification-deliveries", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
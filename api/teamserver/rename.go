@@ -9,13 +9,13 @@ import (
 	"github.com/concourse/atc/api/accessor"
 )
 
-// RenameTeam allows an authenticated user with authority or admin to rename a team
+// RenameTeam allows an owner of the team or an admin to rename a team
 func (s *Server) RenameTeam(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("rename-team")
 	acc := accessor.GetAccessor(r)
 
 	teamName := r.FormValue(":team_name")
-	if !acc.IsAdmin() && !acc.IsAuthorized(teamName) {
+	if !acc.IsAdmin() && !acc.HasRole(atc.OwnerRole, teamName) {
 		w.WriteHeader(http.StatusForbidden)
 		return
 	}
@@ -2,6 +2,7 @@ package teamserver
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
@@ -28,11 +29,19 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	atcTeam.Name = teamName
-	if !acc.IsAdmin() && !acc.IsAuthorized(teamName) {
+	if !acc.IsAdmin() && !acc.HasRole(atc.OwnerRole, teamName) {
 		w.WriteHeader(http.StatusForbidden)
 		return
 	}
 
+	err = atcTeam.Auth.Validate()
+	if err != nil {
+		hLog.Info("invalid-auth", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%s", err)
+		return
+	}
+
 	team, found, err := s.teamFactory.FindTeam(teamName)
 	if err != nil {
 		hLog.Error("failed-to-lookup-team", err, lager.Data{"teamName": teamName})
@@ -42,7 +51,7 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 
 	if found {
 		hLog.Debug("updating-credentials")
-		err = team.UpdateProviderAuth(atcTeam.Auth)
+		err = team.UpdateSettings(atcTeam.Auth, atcTeam.RedactSecrets)
 		if err != nil {
 			hLog.Error("failed-to-update-team", err, lager.Data{"teamName": teamName})
 			w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,39 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/accessor"
+)
+
+// GetUserRoles reports the most privileged role the requester has in each of
+// their teams.
+func (s *Server) GetUserRoles(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-user-roles")
+
+	acc := accessor.GetAccessor(r)
+
+	userRoles := atc.UserRoles{
+		IsAdmin: acc.IsAdmin(),
+		Teams:   map[string]string{},
+	}
+
+	for teamName, roles := range acc.TeamRoles() {
+		for _, role := range roles {
+			current, found := userRoles.Teams[teamName]
+			if !found || !atc.RoleSatisfies(current, role) {
+				userRoles.Teams[teamName] = role
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(userRoles)
+	if err != nil {
+		logger.Error("failed-to-encode-user-roles", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
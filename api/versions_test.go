@@ -3,8 +3,10 @@ package api_test
 import (
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -95,6 +97,7 @@ var _ = Describe("Versions API", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			Context("when no params are passed", func() {
@@ -281,6 +284,7 @@ var _ = Describe("Versions API", func() {
 			Context("when authorized", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 				})
 
 				Context("when enabling the resource succeeds", func() {
@@ -349,6 +353,7 @@ var _ = Describe("Versions API", func() {
 			Context("when authorized", func() {
 				BeforeEach(func() {
 					fakeaccess.IsAuthorizedReturns(true)
+					fakeaccess.HasRoleReturns(true)
 				})
 
 				Context("when enabling the resource succeeds", func() {
@@ -396,6 +401,134 @@ var _ = Describe("Versions API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", func() {
+		var (
+			response     *http.Response
+			body         io.Reader
+			fakeResource *dbfakes.FakeResource
+		)
+
+		BeforeEach(func() {
+			body = nil
+
+			fakeResource = new(dbfakes.FakeResource)
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/pin", body)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized as an operator", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
+			})
+
+			Context("when pinning the version succeeds", func() {
+				BeforeEach(func() {
+					fakeResource.PinVersionReturns(true, nil)
+				})
+
+				It("pins the right version of the right resource", func() {
+					Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("resource-name"))
+
+					versionedResourceID, comment := fakeResource.PinVersionArgsForCall(0)
+					Expect(versionedResourceID).To(Equal(42))
+					Expect(comment).To(BeEmpty())
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				Context("when a comment is given", func() {
+					BeforeEach(func() {
+						body = strings.NewReader(`{"comment":"v43 is broken"}`)
+					})
+
+					It("pins the version with the comment", func() {
+						_, comment := fakeResource.PinVersionArgsForCall(0)
+						Expect(comment).To(Equal("v43 is broken"))
+					})
+				})
+			})
+
+			Context("when the body is malformed", func() {
+				BeforeEach(func() {
+					body = strings.NewReader(`{`)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not pin anything", func() {
+					Expect(fakeResource.PinVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the version does not belong to the resource", func() {
+				BeforeEach(func() {
+					fakeResource.PinVersionReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the resource can not be found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when pinning the version fails", func() {
+				BeforeEach(func() {
+					fakeResource.PinVersionReturns(false, errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not an operator", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(false)
+			})
+
+			It("returns Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", func() {
 		var response *http.Response
 		var stringVersionID string
@@ -460,6 +593,7 @@ var _ = Describe("Versions API", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			It("looks up the given version ID", func() {
@@ -620,6 +754,7 @@ var _ = Describe("Versions API", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(true)
 				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			It("looks up the given version ID", func() {
@@ -527,7 +527,7 @@ var _ = Describe("Workers API", func() {
 
 		Context("when the request is authorized as the worker's owner", func() {
 			BeforeEach(func() {
-				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			It("returns 200", func() {
@@ -537,7 +537,7 @@ var _ = Describe("Workers API", func() {
 
 		Context("when the request is authorized as the wrong team", func() {
 			BeforeEach(func() {
-				fakeaccess.IsAuthorizedReturns(false)
+				fakeaccess.HasRoleReturns(false)
 			})
 
 			It("returns 403", func() {
@@ -628,7 +628,7 @@ var _ = Describe("Workers API", func() {
 
 		Context("when authorized as as the worker's owner", func() {
 			BeforeEach(func() {
-				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
 			})
 
 			It("returns 200", func() {
@@ -638,7 +638,7 @@ var _ = Describe("Workers API", func() {
 
 		Context("when authorized as some other team", func() {
 			BeforeEach(func() {
-				fakeaccess.IsAuthorizedReturns(false)
+				fakeaccess.HasRoleReturns(false)
 			})
 
 			It("returns 403", func() {
@@ -684,7 +684,7 @@ var _ = Describe("Workers API", func() {
 
 			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
 			fakeaccess.IsAuthenticatedReturns(true)
-			fakeaccess.IsAuthorizedReturns(true)
+			fakeaccess.HasRoleReturns(true)
 			fakeWorker.PruneReturns(nil)
 		})
 
@@ -954,7 +954,12 @@ var _ = Describe("Workers API", func() {
 		Context("when user is authorized for team", func() {
 			BeforeEach(func() {
 				fakeWorker.TeamNameReturns("some-team")
-				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.HasRoleReturns(true)
+			})
+			It("requires at least the operator role in the worker's team", func() {
+				role, teamName := fakeaccess.HasRoleArgsForCall(0)
+				Expect(role).To(Equal(atc.OperatorRole))
+				Expect(teamName).To(Equal("some-team"))
 			})
 			It("deletes the worker from the DB", func() {
 				Expect(dbWorkerFactory.GetWorkerCallCount()).To(Equal(1))
@@ -967,6 +972,16 @@ var _ = Describe("Workers API", func() {
 			})
 		})
 
+		Context("when user only has a lesser role in the team", func() {
+			BeforeEach(func() {
+				fakeWorker.TeamNameReturns("some-team")
+				fakeaccess.HasRoleReturns(false)
+			})
+			It("does not delete the worker", func() {
+				Expect(fakeWorker.DeleteCallCount()).To(BeZero())
+			})
+		})
+
 		Context("when not authenticated", func() {
 			BeforeEach(func() {
 				fakeaccess.IsAuthenticatedReturns(false)
@@ -3,6 +3,7 @@ package workerserver
 import (
 	"net/http"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/accessor"
 )
 
@@ -22,7 +23,7 @@ func (s *Server) DeleteWorker(w http.ResponseWriter, r *http.Request) {
 	teamName := worker.TeamName()
 	var teamAuthorized bool
 	if teamName != "" {
-		teamAuthorized = acc.IsAuthorized(teamName)
+		teamAuthorized = acc.HasRole(atc.OperatorRole, teamName)
 	}
 
 	if found && (acc.IsAdmin() || acc.IsSystem() || teamAuthorized) {
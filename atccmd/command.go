@@ -25,6 +25,7 @@ import (
 	"github.com/concourse/atc/creds/noop"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/encryption"
+	"github.com/concourse/atc/db/encryption/vaulttransit"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/db/migration"
 	"github.com/concourse/atc/engine"
@@ -32,10 +33,13 @@ import (
 	"github.com/concourse/atc/gc"
 	"github.com/concourse/atc/lockrunner"
 	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/notifications"
 	"github.com/concourse/atc/pipelines"
 	"github.com/concourse/atc/radar"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/taskcache"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/image"
 	"github.com/concourse/atc/wrappa"
@@ -58,6 +62,7 @@ import (
 
 	// dynamically registered credential managers
 	_ "github.com/concourse/atc/creds/credhub"
+	_ "github.com/concourse/atc/creds/dir"
 	_ "github.com/concourse/atc/creds/kubernetes"
 	_ "github.com/concourse/atc/creds/secretsmanager"
 	_ "github.com/concourse/atc/creds/ssm"
@@ -84,20 +89,31 @@ type ATCCommand struct {
 
 	Postgres flag.PostgresConfig `group:"PostgreSQL Configuration" namespace:"postgres"`
 
-	CredentialManagement struct{} `group:"Credential Management"`
-	CredentialManagers   creds.Managers
+	CredentialManagement struct {
+		Cache creds.SecretCacheConfig
+		Retry creds.SecretRetryConfig
+	} `group:"Credential Management"`
+	CredentialManagers creds.Managers
 
-	EncryptionKey    flag.Cipher `long:"encryption-key"     description:"A 16 or 32 length key used to encrypt sensitive information before storing it in the database."`
-	OldEncryptionKey flag.Cipher `long:"old-encryption-key" description:"Encryption key previously used for encrypting sensitive information. If provided without a new key, data is encrypted. If provided with a new key, data is re-encrypted."`
+	EncryptionKey     flag.Cipher   `long:"encryption-key"     description:"A 16 or 32 length key used to encrypt sensitive information before storing it in the database."`
+	OldEncryptionKeys []flag.Cipher `long:"old-encryption-key" description:"Encryption key previously used for encrypting sensitive information. If provided without a new key, data is decrypted. If provided with a new key, data is re-encrypted. Data is rotated in the background and remains readable meanwhile. Can be specified multiple times."`
+
+	EncryptionKeyManagement struct {
+		File  flag.File           `long:"encryption-master-key-file" description:"File containing a 16 or 32 length master key used to wrap generated data keys, in place of a key management service. Intended for testing."`
+		Vault vaulttransit.Config `group:"Vault Transit" namespace:"encryption-vault"`
+	} `group:"Encryption Key Management"`
 
 	DebugBindIP   flag.IP `long:"debug-bind-ip"   default:"127.0.0.1" description:"IP address on which to listen for the pprof debugger endpoints."`
 	DebugBindPort uint16  `long:"debug-bind-port" default:"8079"      description:"Port on which to listen for the pprof debugger endpoints."`
 
 	InterceptIdleTimeout              time.Duration `long:"intercept-idle-timeout" default:"0m" description:"Length of time for a intercepted session to be idle before terminating."`
 	ResourceCheckingInterval          time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
-	ContainerPlacementStrategy        string        `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" description:"Method by which a worker is selected during container placement."`
+	ContainerPlacementStrategy        string        `long:"container-placement-strategy" default:"volume-locality" description:"Method by which a worker is selected during container placement. One of 'volume-locality', 'random' or 'fewest-build-containers'. Multiple strategies may be chained with commas (e.g. 'fewest-build-containers,volume-locality'), each breaking ties left by the previous one."`
 	BaggageclaimResponseHeaderTimeout time.Duration `long:"baggageclaim-response-header-timeout" default:"1m" description:"How long to wait for Baggageclaim to send the response header."`
 
+	DefaultTaskCPULimit    uint64 `long:"default-task-cpu-limit"    description:"Default CPU shares to give task containers which do not set 'container_limits.cpu'. Zero means unlimited."`
+	DefaultTaskMemoryLimit uint64 `long:"default-task-memory-limit" description:"Default memory limit, in bytes, for task containers which do not set 'container_limits.memory'. Zero means unlimited."`
+
 	CLIArtifactsDir flag.Dir `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
 	Developer struct {
@@ -118,6 +134,8 @@ type ATCCommand struct {
 		YellerEnvironment string `long:"yeller-environment" description:"Environment to tag on all Yeller events emitted."`
 	} `group:"Metrics & Diagnostics"`
 
+	Tracing tracing.Config `group:"Tracing" namespace:"tracing"`
+
 	Server struct {
 		XFrameOptions string `long:"x-frame-options" description:"The value to set for X-Frame-Options. If omitted, the header is not set."`
 	} `group:"Web Server"`
@@ -128,8 +146,13 @@ type ATCCommand struct {
 		Interval               time.Duration `long:"interval" default:"30s" description:"Interval on which to perform garbage collection."`
 		OneOffBuildGracePeriod time.Duration `long:"one-off-grace-period" default:"5m" description:"Grace period before reaping one-off task containers"`
 		WorkerConcurrency      int           `long:"worker-concurrency" default:"50" description:"Maximum number of delete operations to have in flight per worker."`
+		TeamEventRetention     time.Duration `long:"team-event-retention" default:"1h" description:"How long to keep team events for clients resuming a team's event stream."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
+	TaskCache taskcache.Config `group:"Task Caches" namespace:"task-cache"`
+
+	Notifications notifications.Config `group:"Notifications" namespace:"notifications"`
+
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
 
 	TelemetryOptIn bool `long:"telemetry-opt-in" hidden:"true" description:"Enable anonymous concourse version reporting."`
@@ -203,16 +226,14 @@ func (cmd *ATCCommand) supportedDBVersion() error {
 func (cmd *ATCCommand) migrateDBToVersion() error {
 	version := cmd.Migration.MigrateDBToVersion
 
-	var newKey *encryption.Key
-	if cmd.EncryptionKey.AEAD != nil {
-		newKey = encryption.NewKey(cmd.EncryptionKey.AEAD)
+	keyring, err := cmd.encryptionKeyring(lager.NewLogger("migrate"))
+	if err != nil {
+		return err
 	}
 
-	var strategy encryption.Strategy
-	if newKey != nil {
-		strategy = newKey
-	} else {
-		strategy = encryption.NewNoEncryption()
+	var strategy encryption.Strategy = encryption.NewNoEncryption()
+	if keyring != nil {
+		strategy = keyring
 	}
 
 	lockConn, err := cmd.constructLockConn(defaultDriverName)
@@ -345,14 +366,22 @@ func (cmd *ATCCommand) constructMembers(
 		break
 	}
 
-	var newKey *encryption.Key
-	if cmd.EncryptionKey.AEAD != nil {
-		newKey = encryption.NewKey(cmd.EncryptionKey.AEAD)
+	if cmd.CredentialManagement.Retry.IsConfigured() {
+		variablesFactory = creds.NewRetryableVariablesFactory(variablesFactory, cmd.CredentialManagement.Retry)
 	}
 
-	var oldKey *encryption.Key
-	if cmd.OldEncryptionKey.AEAD != nil {
-		oldKey = encryption.NewKey(cmd.OldEncryptionKey.AEAD)
+	if cmd.CredentialManagement.Cache.Enabled {
+		variablesFactory = creds.NewCachedVariablesFactory(variablesFactory, cmd.CredentialManagement.Cache)
+	}
+
+	keyring, err := cmd.encryptionKeyring(logger.Session("encryption"))
+	if err != nil {
+		return nil, err
+	}
+
+	var strategy encryption.Strategy = encryption.NewNoEncryption()
+	if keyring != nil {
+		strategy = keyring
 	}
 
 	lockConn, err := cmd.constructLockConn(retryingDriverName)
@@ -362,7 +391,7 @@ func (cmd *ATCCommand) constructMembers(
 
 	lockFactory := lock.NewLockFactory(lockConn)
 
-	dbConn, err := cmd.constructDBConn(retryingDriverName, logger, newKey, oldKey, maxConns, connectionName, lockFactory)
+	dbConn, err := cmd.constructDBConn(retryingDriverName, logger, strategy, maxConns, connectionName, lockFactory)
 	if err != nil {
 		return nil, err
 	}
@@ -409,14 +438,27 @@ func (cmd *ATCCommand) constructMembers(
 		cmd.BaggageclaimResponseHeaderTimeout,
 	)
 
-	workerClient := cmd.constructWorkerPool(
+	workerClient, err := cmd.constructWorkerPool(
 		logger,
 		workerProvider,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	taskCacheStore, err := cmd.TaskCache.NewStore()
+	if err != nil {
+		return nil, err
+	}
+
+	notificationsClient, err := cmd.Notifications.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
 
 	resourceFetcher := resourceFetcherFactory.FetcherFor(workerClient)
 	resourceFactory := resource.NewResourceFactory(workerClient)
-	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, variablesFactory)
+	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, teamFactory, variablesFactory, taskCacheStore)
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		resourceFactory,
@@ -514,6 +556,7 @@ func (cmd *ATCCommand) constructMembers(
 		dbContainerRepository,
 		gcContainerDestroyer,
 		dbBuildFactory,
+		db.NewKeyRotationFactory(dbConn),
 		engine,
 		workerClient,
 		workerProvider,
@@ -669,6 +712,36 @@ func (cmd *ATCCommand) constructMembers(
 			clock.NewClock(),
 			30*time.Second,
 		)},
+
+		{"notifier", lockrunner.NewRunner(
+			logger.Session("notifier"),
+			notifications.NewNotifier(
+				db.NewNotificationFactory(dbConn),
+				dbPipelineFactory,
+				variablesFactory,
+				notificationsClient,
+				clock.NewClock(),
+				cmd.ExternalURL.String(),
+				100,
+				cmd.Notifications.MaxInFlight,
+			),
+			"notifier",
+			lockFactory,
+			clock.NewClock(),
+			10*time.Second,
+		)},
+
+		{"team-event-collector", lockrunner.NewRunner(
+			logger.Session("team-event-collector"),
+			gc.NewTeamEventCollector(
+				db.NewTeamEventLifecycle(dbConn),
+				cmd.GC.TeamEventRetention,
+			),
+			"team-event-collector",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)},
 	}
 
 	if cmd.TelemetryOptIn {
@@ -685,6 +758,34 @@ func (cmd *ATCCommand) constructMembers(
 		members = cmd.appendStaticWorker(logger, dbWorkerFactory, members)
 	}
 
+	if keyring != nil {
+		// rotates data in the background so as to not block startup
+		members = append(members, grouper.Member{"encryption-key-rotator", lockrunner.NewRunner(
+			logger.Session("encryption-key-rotator"),
+			db.NewKeyRotator(dbConn, keyring, 500),
+			"encryption-key-rotator",
+			lockFactory,
+			clock.NewClock(),
+			30*time.Second,
+		)})
+	}
+
+	if taskCacheStore != nil {
+		// run separately as listing the store may be slow
+		members = append(members, grouper.Member{"task-cache-collector", lockrunner.NewRunner(
+			logger.Session("task-cache-collector"),
+			gc.NewTaskCacheCollector(
+				taskCacheStore,
+				cmd.TaskCache.MaxAge,
+				cmd.TaskCache.MaxTotalSize,
+			),
+			"task-cache-collector",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)})
+	}
+
 	if httpsHandler != nil {
 		members = append(members, grouper.Member{"web-tls", http_server.NewTLSServer(
 			cmd.tlsBindAddr(),
@@ -750,6 +851,10 @@ func (cmd *ATCCommand) Runner(positionalArguments []string) (ifrit.Runner, bool,
 	}
 	go metric.PeriodicallyEmit(logger.Session("periodic-metrics"), 10*time.Second)
 
+	if err := cmd.Tracing.Prepare(); err != nil {
+		return nil, false, err
+	}
+
 	apiMembers, err := cmd.constructMembers(positionalArguments, []string{
 		"debug",
 		"web-tls",
@@ -770,6 +875,10 @@ func (cmd *ATCCommand) Runner(positionalArguments []string) (ifrit.Runner, bool,
 		"builds",
 		"collector",
 		"build-log-collector",
+		"notifier",
+		"team-event-collector",
+		"encryption-key-rotator",
+		"task-cache-collector",
 		"static-worker",
 	},
 		32,
@@ -847,9 +956,60 @@ func (cmd *ATCCommand) validate() error {
 		)
 	}
 
+	if err := cmd.defaultTaskLimits().Validate(); err != nil {
+		errs = multierror.Append(
+			errs,
+			fmt.Errorf("invalid --default-task-memory-limit: %s", err),
+		)
+	}
+
+	if err := cmd.TaskCache.Validate(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	if err := cmd.Notifications.Validate(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	encryptionKeyCount := 0
+	if cmd.EncryptionKey.AEAD != nil {
+		encryptionKeyCount++
+	}
+	if cmd.EncryptionKeyManagement.File != "" {
+		encryptionKeyCount++
+	}
+	if cmd.EncryptionKeyManagement.Vault.IsConfigured() {
+		encryptionKeyCount++
+
+		if err := cmd.EncryptionKeyManagement.Vault.Validate(); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("invalid encryption vault configuration: %s", err))
+		}
+	}
+
+	if encryptionKeyCount > 1 {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify only one of --encryption-key, --encryption-master-key-file or --encryption-vault-url"),
+		)
+	}
+
 	return errs.ErrorOrNil()
 }
 
+func (cmd *ATCCommand) defaultTaskLimits() atc.ContainerLimits {
+	var limits atc.ContainerLimits
+
+	if cmd.DefaultTaskCPULimit != 0 {
+		limits.CPU = &cmd.DefaultTaskCPULimit
+	}
+
+	if cmd.DefaultTaskMemoryLimit != 0 {
+		limits.Memory = &cmd.DefaultTaskMemoryLimit
+	}
+
+	return limits
+}
+
 func (cmd *ATCCommand) nonTLSBindAddr() string {
 	return fmt.Sprintf("%s:%d", cmd.BindIP, cmd.BindPort)
 }
@@ -882,16 +1042,60 @@ func (cmd *ATCCommand) configureMetrics(logger lager.Logger) error {
 	return metric.Initialize(logger.Session("metrics"), host, cmd.Metrics.Attributes)
 }
 
+// encryptionKeyring returns a keyring of the configured encryption keys, or
+// nil if none are configured. If a key management service is configured, data
+// is envelope encrypted with a data key wrapped by it.
+func (cmd *ATCCommand) encryptionKeyring(logger lager.Logger) (*encryption.Keyring, error) {
+	var current encryption.Strategy
+	if cmd.EncryptionKey.AEAD != nil {
+		current = encryption.NewKey(cmd.EncryptionKey.AEAD)
+	}
+
+	keyManager, err := cmd.encryptionKeyManager(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyManager != nil {
+		current, err = encryption.NewEnvelope(keyManager)
+		if err != nil {
+			return nil, fmt.Errorf("failed to wrap data key: %s", err)
+		}
+	}
+
+	var old []encryption.Strategy
+	for _, key := range cmd.OldEncryptionKeys {
+		old = append(old, encryption.NewKey(key.AEAD))
+	}
+
+	if current == nil && len(old) == 0 {
+		return nil, nil
+	}
+
+	return encryption.NewKeyring(current, old...), nil
+}
+
+func (cmd *ATCCommand) encryptionKeyManager(logger lager.Logger) (encryption.KeyManager, error) {
+	if cmd.EncryptionKeyManagement.Vault.IsConfigured() {
+		return cmd.EncryptionKeyManagement.Vault.NewKeyManager(logger.Session("vault-transit"))
+	}
+
+	if cmd.EncryptionKeyManagement.File != "" {
+		return encryption.NewFileKeyManager(cmd.EncryptionKeyManagement.File.Path())
+	}
+
+	return nil, nil
+}
+
 func (cmd *ATCCommand) constructDBConn(
 	driverName string,
 	logger lager.Logger,
-	newKey *encryption.Key,
-	oldKey *encryption.Key,
+	strategy encryption.Strategy,
 	maxConn int,
 	connectionName string,
 	lockFactory lock.LockFactory,
 ) (db.Conn, error) {
-	dbConn, err := db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), newKey, oldKey, connectionName, lockFactory)
+	dbConn, err := db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), strategy, connectionName, lockFactory)
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %s", err)
 	}
@@ -927,20 +1131,16 @@ func (cmd *ATCCommand) constructLockConn(driverName string) (*sql.DB, error) {
 func (cmd *ATCCommand) constructWorkerPool(
 	logger lager.Logger,
 	workerProvider worker.WorkerProvider,
-) worker.Client {
-
-	var strategy worker.ContainerPlacementStrategy
-	switch cmd.ContainerPlacementStrategy {
-	case "random":
-		strategy = worker.NewRandomPlacementStrategy()
-	default:
-		strategy = worker.NewVolumeLocalityPlacementStrategy()
+) (worker.Client, error) {
+	strategy, err := worker.NewContainerPlacementStrategy(cmd.ContainerPlacementStrategy)
+	if err != nil {
+		return nil, err
 	}
 
 	return worker.NewPool(
 		workerProvider,
 		strategy,
-	)
+	), nil
 }
 
 func (cmd *ATCCommand) configureAuthForDefaultTeam(teamFactory db.TeamFactory) error {
@@ -958,7 +1158,7 @@ func (cmd *ATCCommand) configureAuthForDefaultTeam(teamFactory db.TeamFactory) e
 		return fmt.Errorf("default team auth not configured: %v", err)
 	}
 
-	err = team.UpdateProviderAuth(auth)
+	err = team.UpdateProviderAuth(atc.TeamAuth{atc.OwnerRole: auth})
 	if err != nil {
 		return err
 	}
@@ -971,19 +1171,24 @@ func (cmd *ATCCommand) constructEngine(
 	resourceFetcher resource.Fetcher,
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory db.ResourceCacheFactory,
+	teamFactory db.TeamFactory,
 	variablesFactory creds.VariablesFactory,
+	taskCacheStore taskcache.Store,
 ) engine.Engine {
 	gardenFactory := exec.NewGardenFactory(
 		workerClient,
 		resourceFetcher,
 		resourceFactory,
 		dbResourceCacheFactory,
+		teamFactory,
 		variablesFactory,
+		cmd.defaultTaskLimits(),
+		taskCacheStore,
 	)
 
 	execV2Engine := engine.NewExecEngine(
 		gardenFactory,
-		engine.NewBuildDelegateFactory(),
+		engine.NewBuildDelegateFactory(teamFactory),
 		cmd.ExternalURL.String(),
 	)
 
@@ -1035,6 +1240,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 	dbContainerRepository db.ContainerRepository,
 	gcContainerDestroyer gc.Destroyer,
 	dbBuildFactory db.BuildFactory,
+	dbKeyRotationFactory db.KeyRotationFactory,
 	engine engine.Engine,
 	workerClient worker.Client,
 	workerProvider worker.WorkerProvider,
@@ -1058,6 +1264,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 			checkWorkerTeamAccessHandlerFactory,
 		),
 		wrappa.NewConcourseVersionWrappa(Version),
+		wrappa.NewAPITracingWrappa(),
 	}
 
 	return api.NewHandler(
@@ -1074,6 +1281,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 		dbContainerRepository,
 		gcContainerDestroyer,
 		dbBuildFactory,
+		dbKeyRotationFactory,
 
 		cmd.PeerURL.String(),
 		buildserver.NewEventHandler,
@@ -22,6 +22,8 @@ type Build struct {
 	StartTime    int64  `json:"start_time,omitempty"`
 	EndTime      int64  `json:"end_time,omitempty"`
 	ReapTime     int64  `json:"reap_time,omitempty"`
+
+	TriggerCause *TriggerCause `json:"trigger_cause,omitempty"`
 }
 
 func (b Build) IsRunning() bool {
@@ -60,3 +62,9 @@ type BuildPreparation struct {
 	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
 	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
 }
+
+type InputResolution struct {
+	Inputs              map[string]BuildPreparationStatus `json:"inputs"`
+	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
+	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
+}
@@ -19,11 +19,24 @@ type ConfigResponse struct {
 	RawConfig RawConfig `json:"raw_config"`
 }
 
+// PipelineConfigVersion is an entry in a pipeline's config history. Diff is a
+// unified diff against the previous entry's config; it and Config are only
+// included when a single version is requested.
+type PipelineConfigVersion struct {
+	Version   int     `json:"version"`
+	Author    string  `json:"author,omitempty"`
+	CreatedAt int64   `json:"created_at"`
+	Diff      string  `json:"diff,omitempty"`
+	Config    *Config `json:"config,omitempty"`
+}
+
 type Config struct {
 	Groups        GroupConfigs    `yaml:"groups" json:"groups" mapstructure:"groups"`
 	Resources     ResourceConfigs `yaml:"resources" json:"resources" mapstructure:"resources"`
 	ResourceTypes ResourceTypes   `yaml:"resource_types" json:"resource_types" mapstructure:"resource_types"`
 	Jobs          JobConfigs      `yaml:"jobs" json:"jobs" mapstructure:"jobs"`
+
+	Notifications NotificationConfigs `yaml:"notifications,omitempty" json:"notifications,omitempty" mapstructure:"notifications"`
 }
 
 type RawConfig string
@@ -104,10 +117,30 @@ type PlanSequence []PlanConfig
 
 // A VersionConfig represents the choice to include every version of a
 // resource, the latest version of a resource, or a pinned (specific) one.
+//
+// Matches and Semver restrict the versions to those whose fields match a
+// regular expression or are within a semver range, e.g.
+// `{matches: {tag: ^v}, semver: {tag: ">=1.2 <2"}}`. The latest such version
+// is used unless `every: true` is also given.
 type VersionConfig struct {
-	Every  bool    `yaml:"every,omitempty" json:"every,omitempty"`
-	Latest bool    `yaml:"latest,omitempty" json:"latest,omitempty"`
-	Pinned Version `yaml:"pinned,omitempty" json:"pinned,omitempty"`
+	Every   bool              `yaml:"every,omitempty" json:"every,omitempty"`
+	Latest  bool              `yaml:"latest,omitempty" json:"latest,omitempty"`
+	Pinned  Version           `yaml:"pinned,omitempty" json:"pinned,omitempty"`
+	Matches map[string]string `yaml:"matches,omitempty" json:"matches,omitempty"`
+	Semver  map[string]string `yaml:"semver,omitempty" json:"semver,omitempty"`
+}
+
+// versionFilterConfig is how a VersionConfig with filters is marshaled.
+type versionFilterConfig struct {
+	Every   bool              `yaml:"every,omitempty" json:"every,omitempty"`
+	Matches map[string]string `yaml:"matches,omitempty" json:"matches,omitempty"`
+	Semver  map[string]string `yaml:"semver,omitempty" json:"semver,omitempty"`
+}
+
+// IsFiltered returns true if the versions are restricted by Matches or
+// Semver.
+func (c VersionConfig) IsFiltered() bool {
+	return len(c.Matches) > 0 || len(c.Semver) > 0
 }
 
 func (c *VersionConfig) UnmarshalJSON(version []byte) error {
@@ -123,15 +156,12 @@ func (c *VersionConfig) UnmarshalJSON(version []byte) error {
 		c.Every = actual == "every"
 		c.Latest = actual == "latest"
 	case map[string]interface{}:
-		version := Version{}
-
-		for k, v := range actual {
-			if s, ok := v.(string); ok {
-				version[k] = strings.TrimSpace(s)
-			}
+		config, err := versionConfigFromMap(actual)
+		if err != nil {
+			return err
 		}
 
-		c.Pinned = version
+		*c = config
 	default:
 		return errors.New("unknown type for version")
 	}
@@ -152,17 +182,17 @@ func (c *VersionConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		c.Every = actual == "every"
 		c.Latest = actual == "latest"
 	case map[interface{}]interface{}:
-		version := Version{}
+		sanitized, err := sanitize(actual)
+		if err != nil {
+			return err
+		}
 
-		for k, v := range actual {
-			if ks, ok := k.(string); ok {
-				if vs, ok := v.(string); ok {
-					version[ks] = strings.TrimSpace(vs)
-				}
-			}
+		config, err := versionConfigFromMap(sanitized.(map[string]interface{}))
+		if err != nil {
+			return err
 		}
 
-		c.Pinned = version
+		*c = config
 	default:
 		return errors.New("unknown type for version")
 	}
@@ -171,6 +201,14 @@ func (c *VersionConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 }
 
 func (c *VersionConfig) MarshalYAML() (interface{}, error) {
+	if c.IsFiltered() {
+		return versionFilterConfig{
+			Every:   c.Every,
+			Matches: c.Matches,
+			Semver:  c.Semver,
+		}, nil
+	}
+
 	if c.Latest {
 		return VersionLatest, nil
 	}
@@ -187,6 +225,14 @@ func (c *VersionConfig) MarshalYAML() (interface{}, error) {
 }
 
 func (c *VersionConfig) MarshalJSON() ([]byte, error) {
+	if c.IsFiltered() {
+		return json.Marshal(versionFilterConfig{
+			Every:   c.Every,
+			Matches: c.Matches,
+			Semver:  c.Semver,
+		})
+	}
+
 	if c.Latest {
 		return json.Marshal(VersionLatest)
 	}
@@ -202,6 +248,85 @@ func (c *VersionConfig) MarshalJSON() ([]byte, error) {
 	return json.Marshal("")
 }
 
+// versionConfigFromMap parses a version given as a map. A map of strings is a
+// pinned version, whereas a map with nested maps filters the versions.
+func versionConfigFromMap(data map[string]interface{}) (VersionConfig, error) {
+	filtered := false
+	for _, v := range data {
+		if _, ok := v.(map[string]interface{}); ok {
+			filtered = true
+			break
+		}
+	}
+
+	if !filtered {
+		if _, ok := data["every"].(bool); ok {
+			return VersionConfig{}, errors.New("every can only be combined with matches or semver")
+		}
+
+		version := Version{}
+
+		for k, v := range data {
+			if s, ok := v.(string); ok {
+				version[k] = strings.TrimSpace(s)
+			}
+		}
+
+		return VersionConfig{Pinned: version}, nil
+	}
+
+	config := VersionConfig{}
+
+	for key, value := range data {
+		switch key {
+		case "every":
+			every, ok := value.(bool)
+			if !ok {
+				return VersionConfig{}, errors.New("every must be true or false")
+			}
+
+			config.Every = every
+		case "matches":
+			fields, err := versionFilterFields(key, value)
+			if err != nil {
+				return VersionConfig{}, err
+			}
+
+			config.Matches = fields
+		case "semver":
+			fields, err := versionFilterFields(key, value)
+			if err != nil {
+				return VersionConfig{}, err
+			}
+
+			config.Semver = fields
+		default:
+			return VersionConfig{}, fmt.Errorf("unknown version filter '%s'", key)
+		}
+	}
+
+	return config, nil
+}
+
+func versionFilterFields(filter string, value interface{}) (map[string]string, error) {
+	data, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must map version fields to strings", filter)
+	}
+
+	fields := map[string]string{}
+	for field, v := range data {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must map version fields to strings", filter)
+		}
+
+		fields[field] = strings.TrimSpace(s)
+	}
+
+	return fields, nil
+}
+
 // A PlanConfig is a flattened set of configuration corresponding to
 // a particular Plan, where Source and Version are populated lazily.
 type PlanConfig struct {
@@ -221,7 +346,8 @@ type PlanConfig struct {
 	// corresponds to Get and Put resource plans, respectively
 	// name of 'input', e.g. bosh-stemcell
 	Get string `yaml:"get,omitempty" json:"get,omitempty" mapstructure:"get"`
-	// jobs that this resource must have made it through
+	// jobs that this resource must have made it through, given as
+	// `pipeline/job` for jobs in other pipelines of the team
 	Passed []string `yaml:"passed,omitempty" json:"passed,omitempty" mapstructure:"passed"`
 	// whether to trigger based on this resource changing
 	Trigger bool `yaml:"trigger,omitempty" json:"trigger,omitempty" mapstructure:"trigger"`
@@ -242,6 +368,14 @@ type PlanConfig struct {
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
 
+	// corresponds to a SetPipeline plan
+	// name of the pipeline to configure, read from 'file'
+	SetPipeline string `yaml:"set_pipeline,omitempty" json:"set_pipeline,omitempty" mapstructure:"set_pipeline"`
+	// vars to interpolate into the pipeline config
+	Vars map[string]interface{} `yaml:"vars,omitempty" json:"vars,omitempty" mapstructure:"vars"`
+	// files containing vars to interpolate into the pipeline config
+	VarFiles []string `yaml:"var_files,omitempty" json:"var_files,omitempty" mapstructure:"var_files"`
+
 	// used by Get and Put for specifying params to the resource
 	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
 
@@ -282,9 +416,32 @@ type PlanConfig struct {
 	// repeat the step up to N times, until it works
 	Attempts int `yaml:"attempts,omitempty" json:"attempts,omitempty" mapstructure:"attempts"`
 
+	// how to wait between attempts, and which outcomes to retry
+	RetryPolicy *RetryPolicy `yaml:"retry_policy,omitempty" json:"retry_policy,omitempty" mapstructure:"retry_policy"`
+
 	Version *VersionConfig `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
 }
 
+const (
+	BackoffFixed       = "fixed"
+	BackoffExponential = "exponential"
+)
+
+// RetryPolicy configures how a step with multiple attempts is retried.
+type RetryPolicy struct {
+	// either "fixed" (the default) or "exponential"
+	Backoff string `yaml:"backoff,omitempty" json:"backoff,omitempty" mapstructure:"backoff"`
+
+	// how long to wait before the first retry, e.g. 10s
+	Delay string `yaml:"delay,omitempty" json:"delay,omitempty" mapstructure:"delay"`
+
+	// upper bound on the wait when backing off exponentially
+	MaxDelay string `yaml:"max_delay,omitempty" json:"max_delay,omitempty" mapstructure:"max_delay"`
+
+	// only retry attempts which errored, rather than ones which failed
+	ErroredOnly bool `yaml:"errored_only,omitempty" json:"errored_only,omitempty" mapstructure:"errored_only"`
+}
+
 func (config PlanConfig) Name() string {
 	if config.RawName != "" {
 		return config.RawName
@@ -302,6 +459,10 @@ func (config PlanConfig) Name() string {
 		return config.Task
 	}
 
+	if config.SetPipeline != "" {
+		return config.SetPipeline
+	}
+
 	return ""
 }
 
@@ -324,6 +485,17 @@ func (config PlanConfig) ResourceName() string {
 	panic("no resource name!")
 }
 
+// ParseCrossPipelinePassed splits a passed constraint of the form
+// `pipeline/job`, which refers to a job in another pipeline of the same team.
+func ParseCrossPipelinePassed(passed string) (string, string, bool) {
+	parts := strings.Split(passed, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
 func (config PlanConfig) Hooks() Hooks {
 	return Hooks{Abort: config.Abort, Failure: config.Failure, Ensure: config.Ensure, Success: config.Success}
 }
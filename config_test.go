@@ -45,5 +45,76 @@ var _ = Describe("Config", func() {
 				Expect(versionConfig).To(Equal(expected))
 			})
 		})
+
+		Context("when unmarshaling a filtered version from YAML", func() {
+			It("produces the correct version config without error", func() {
+				var versionConfig VersionConfig
+				bs := []byte(`{every: true, matches: {tag: ^v}, semver: {tag: ">=1.2 <2"}}`)
+				err := yaml.Unmarshal(bs, &versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := VersionConfig{
+					Every:   true,
+					Matches: map[string]string{"tag": "^v"},
+					Semver:  map[string]string{"tag": ">=1.2 <2"},
+				}
+
+				Expect(versionConfig).To(Equal(expected))
+			})
+		})
+
+		Context("when unmarshaling a filtered version from JSON", func() {
+			It("produces the correct version config without error", func() {
+				var versionConfig VersionConfig
+				bs := []byte(`{ "matches": { "tag": "^v" } }`)
+				err := json.Unmarshal(bs, &versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := VersionConfig{
+					Matches: map[string]string{"tag": "^v"},
+				}
+
+				Expect(versionConfig).To(Equal(expected))
+			})
+
+			It("fails on an unknown filter", func() {
+				var versionConfig VersionConfig
+				bs := []byte(`{ "matches": { "tag": "^v" }, "bogus": { "tag": "v1" } }`)
+				err := json.Unmarshal(bs, &versionConfig)
+				Expect(err).To(MatchError("unknown version filter 'bogus'"))
+			})
+
+			It("fails when a field is not a string", func() {
+				var versionConfig VersionConfig
+				bs := []byte(`{ "semver": { "tag": 1 } }`)
+				err := json.Unmarshal(bs, &versionConfig)
+				Expect(err).To(MatchError("semver must map version fields to strings"))
+			})
+
+			It("fails when every is given without a filter", func() {
+				var versionConfig VersionConfig
+				bs := []byte(`{ "every": true }`)
+				err := json.Unmarshal(bs, &versionConfig)
+				Expect(err).To(MatchError("every can only be combined with matches or semver"))
+			})
+		})
+
+		Context("when marshaling a filtered version", func() {
+			It("round-trips through JSON", func() {
+				versionConfig := VersionConfig{
+					Every:  true,
+					Semver: map[string]string{"tag": ">=1.2 <2"},
+				}
+
+				payload, err := json.Marshal(&versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload).To(MatchJSON(`{"every":true,"semver":{"tag":">=1.2 <2"}}`))
+
+				var unmarshaled VersionConfig
+				err = json.Unmarshal(payload, &unmarshaled)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(unmarshaled).To(Equal(versionConfig))
+			})
+		})
 	})
 })
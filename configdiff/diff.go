@@ -0,0 +1,223 @@
+// Package configdiff shows how a pipeline config changed between two of its
+// versions.
+package configdiff
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/concourse/atc"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// how many unchanged lines are shown around each change
+const contextLines = 3
+
+// MaxLines is the most lines of YAML that a changed region of a config may
+// have for it to be aligned line-by-line. Longer regions are shown as a
+// wholesale replacement instead.
+const MaxLines = 10000
+
+// changed regions are aligned with a table of this many cells at most, so
+// that diffing two large, very different configs stays cheap
+const maxAlignCells = 1024 * 1024
+
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// Diff returns a unified diff of the YAML of two configs. A nil from config is
+// diffed as if it were empty.
+func Diff(from *atc.Config, to atc.Config) (string, error) {
+	var fromLines []string
+	if from != nil {
+		fromYAML, err := yaml.Marshal(from)
+		if err != nil {
+			return "", err
+		}
+
+		fromLines = splitLines(string(fromYAML))
+	}
+
+	toYAML, err := yaml.Marshal(to)
+	if err != nil {
+		return "", err
+	}
+
+	toLines := splitLines(string(toYAML))
+
+	return unifiedDiff(diffLines(fromLines, toLines)), nil
+}
+
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+func diffLines(from []string, to []string) []diffLine {
+	prefix := 0
+	for prefix < len(from) && prefix < len(to) && from[prefix] == to[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(from)-prefix && suffix < len(to)-prefix &&
+		from[len(from)-1-suffix] == to[len(to)-1-suffix] {
+		suffix++
+	}
+
+	lines := []diffLine{}
+	for _, line := range from[:prefix] {
+		lines = append(lines, diffLine{' ', line})
+	}
+
+	lines = append(lines, alignLines(from[prefix:len(from)-suffix], to[prefix:len(to)-suffix])...)
+
+	for _, line := range from[len(from)-suffix:] {
+		lines = append(lines, diffLine{' ', line})
+	}
+
+	return lines
+}
+
+func alignLines(from []string, to []string) []diffLine {
+	lines := []diffLine{}
+
+	if len(from) > MaxLines || len(to) > MaxLines || len(from)*len(to) > maxAlignCells {
+		for _, line := range from {
+			lines = append(lines, diffLine{'-', line})
+		}
+
+		for _, line := range to {
+			lines = append(lines, diffLine{'+', line})
+		}
+
+		return lines
+	}
+
+	// common[i][j] is the length of the longest common subsequence of
+	// from[i:] and to[j:]
+	common := make([][]int, len(from)+1)
+	for i := range common {
+		common[i] = make([]int, len(to)+1)
+	}
+
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			lines = append(lines, diffLine{' ', from[i]})
+			i++
+			j++
+		case common[i+1][j] >= common[i][j+1]:
+			lines = append(lines, diffLine{'-', from[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', to[j]})
+			j++
+		}
+	}
+
+	for ; i < len(from); i++ {
+		lines = append(lines, diffLine{'-', from[i]})
+	}
+
+	for ; j < len(to); j++ {
+		lines = append(lines, diffLine{'+', to[j]})
+	}
+
+	return lines
+}
+
+func unifiedDiff(lines []diffLine) string {
+	// fromLine[k] and toLine[k] are the line numbers lines[k] would have in
+	// each config
+	fromLine := make([]int, len(lines)+1)
+	toLine := make([]int, len(lines)+1)
+	fromLine[0], toLine[0] = 1, 1
+	for k, line := range lines {
+		fromLine[k+1], toLine[k+1] = fromLine[k], toLine[k]
+		if line.op != '+' {
+			fromLine[k+1]++
+		}
+		if line.op != '-' {
+			toLine[k+1]++
+		}
+	}
+
+	diff := &bytes.Buffer{}
+
+	for start := 0; start < len(lines); {
+		first := start
+		for first < len(lines) && lines[first].op == ' ' {
+			first++
+		}
+
+		if first == len(lines) {
+			break
+		}
+
+		last := first
+		for k := first; k < len(lines); k++ {
+			if lines[k].op != ' ' {
+				last = k
+			} else if k-last > 2*contextLines {
+				break
+			}
+		}
+
+		hunkStart := first - contextLines
+		if hunkStart < start {
+			hunkStart = start
+		}
+
+		hunkEnd := last + contextLines + 1
+		if hunkEnd > len(lines) {
+			hunkEnd = len(lines)
+		}
+
+		fmt.Fprintf(
+			diff,
+			"@@ -%s +%s @@\n",
+			hunkRange(fromLine[hunkStart], fromLine[hunkEnd]),
+			hunkRange(toLine[hunkStart], toLine[hunkEnd]),
+		)
+
+		for _, line := range lines[hunkStart:hunkEnd] {
+			diff.WriteByte(line.op)
+			diff.WriteString(line.text)
+		}
+
+		start = hunkEnd
+	}
+
+	return diff.String()
+}
+
+func hunkRange(start int, end int) string {
+	count := end - start
+	if count == 0 {
+		// an empty range refers to the line before it
+		start--
+	}
+
+	return fmt.Sprintf("%d,%d", start, count)
+}
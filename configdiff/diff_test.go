@@ -0,0 +1,90 @@
+package configdiff_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/configdiff"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+	resources := func(count int, uri string) atc.Config {
+		config := atc.Config{}
+		for i := 0; i < count; i++ {
+			config.Resources = append(config.Resources, atc.ResourceConfig{
+				Name:   fmt.Sprintf("resource-%d", i),
+				Type:   "git",
+				Source: atc.Source{"uri": uri},
+			})
+		}
+
+		return config
+	}
+
+	It("diffs the first config against nothing", func() {
+		diff, err := configdiff.Diff(nil, resources(1, "some-uri"))
+		Expect(err).ToNot(HaveOccurred())
+
+		lines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+		Expect(lines[0]).To(Equal(fmt.Sprintf("@@ -0,0 +1,%d @@", len(lines)-1)))
+		for _, line := range lines[1:] {
+			Expect(line).To(HavePrefix("+"))
+		}
+	})
+
+	It("returns nothing for identical configs", func() {
+		config := resources(3, "some-uri")
+
+		diff, err := configdiff.Diff(&config, config)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diff).To(BeEmpty())
+	})
+
+	It("shows each change with the lines around it", func() {
+		from := resources(10, "some-uri")
+		to := resources(10, "some-uri")
+		to.Resources[5].Source = atc.Source{"uri": "other-uri"}
+
+		diff, err := configdiff.Diff(&from, to)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(diff).To(HavePrefix("@@ "))
+		Expect(strings.Count(diff, "@@ -")).To(Equal(1))
+		Expect(diff).To(MatchRegexp(`(?m)^-\s+uri: some-uri$`))
+		Expect(diff).To(MatchRegexp(`(?m)^\+\s+uri: other-uri$`))
+		Expect(diff).To(ContainSubstring(" - name: resource-5\n"))
+		Expect(diff).ToNot(ContainSubstring("resource-0\n"))
+		Expect(diff).ToNot(ContainSubstring("resource-9\n"))
+	})
+
+	Context("when the changed region is longer than MaxLines", func() {
+		It("shows it as a wholesale replacement", func() {
+			from := resources(configdiff.MaxLines, "some-uri")
+			to := resources(configdiff.MaxLines, "other-uri")
+
+			diff, err := configdiff.Diff(&from, to)
+			Expect(err).ToNot(HaveOccurred())
+
+			lines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+
+			first := 0
+			for !strings.HasPrefix(lines[first], "-") {
+				first++
+			}
+
+			last := first
+			for strings.HasPrefix(lines[last+1], "-") {
+				last++
+			}
+
+			Expect(lines[last+1]).To(HavePrefix("+"))
+			for _, line := range lines[last+1:] {
+				Expect(line).ToNot(HavePrefix("-"))
+			}
+		})
+	})
+})
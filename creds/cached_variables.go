@@ -0,0 +1,152 @@
+package creds
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+type SecretCacheConfig struct {
+	Enabled          bool          `long:"secret-cache-enabled"            description:"Cache secrets fetched from the credential manager in memory."`
+	Duration         time.Duration `long:"secret-cache-duration"           default:"1m"    description:"If the cache is enabled, how long to cache a secret for."`
+	DurationNotFound time.Duration `long:"secret-cache-duration-notfound"  default:"10s"   description:"If the cache is enabled, how long to remember that a secret was not found."`
+	MaxEntries       int           `long:"secret-cache-max-entries"        default:"10000" description:"If the cache is enabled, the maximum number of secrets to hold, evicting the least recently used. Zero means unlimited."`
+}
+
+type cachedSecret struct {
+	key      string
+	value    interface{}
+	found    bool
+	deadline time.Time
+	element  *list.Element
+}
+
+// secretCache is shared by every Variables created by a
+// cachedVariablesFactory, as they are otherwise created afresh for every
+// build and check.
+type secretCache struct {
+	lock    sync.Mutex
+	entries map[string]*cachedSecret
+	lru     *list.List
+
+	config SecretCacheConfig
+}
+
+type cachedVariablesFactory struct {
+	factory VariablesFactory
+	cache   *secretCache
+}
+
+// NewCachedVariablesFactory wraps the factory such that the values of
+// secrets, or the fact that they don't exist, are cached across all of the
+// Variables it creates.
+func NewCachedVariablesFactory(factory VariablesFactory, config SecretCacheConfig) VariablesFactory {
+	return &cachedVariablesFactory{
+		factory: factory,
+		cache: &secretCache{
+			entries: map[string]*cachedSecret{},
+			lru:     list.New(),
+			config:  config,
+		},
+	}
+}
+
+func (factory *cachedVariablesFactory) NewVariables(teamName string, pipelineName string) Variables {
+	return &cachedVariables{
+		variables:    factory.factory.NewVariables(teamName, pipelineName),
+		cache:        factory.cache,
+		teamName:     teamName,
+		pipelineName: pipelineName,
+	}
+}
+
+type cachedVariables struct {
+	variables Variables
+	cache     *secretCache
+
+	teamName     string
+	pipelineName string
+}
+
+func (v *cachedVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	// secrets are resolved per team and pipeline, so the same name may refer
+	// to different secrets
+	key := v.teamName + "/" + v.pipelineName + "/" + varDef.Name
+
+	value, found, cached := v.cache.get(key)
+	if cached {
+		return value, found, nil
+	}
+
+	value, found, err := v.variables.Get(varDef)
+	if err != nil {
+		return nil, false, err
+	}
+
+	v.cache.put(key, value, found)
+
+	return value, found, nil
+}
+
+func (v *cachedVariables) List() ([]template.VariableDefinition, error) {
+	return v.variables.List()
+}
+
+func (c *secretCache) get(key string) (interface{}, bool, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, cached := c.entries[key]
+	if !cached {
+		return nil, false, false
+	}
+
+	if time.Now().After(entry.deadline) {
+		c.remove(entry)
+		return nil, false, false
+	}
+
+	c.lru.MoveToFront(entry.element)
+
+	return entry.value, entry.found, true
+}
+
+func (c *secretCache) put(key string, value interface{}, found bool) {
+	duration := c.config.Duration
+	if !found {
+		duration = c.config.DurationNotFound
+	}
+
+	if duration <= 0 {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if existing, cached := c.entries[key]; cached {
+		c.remove(existing)
+	}
+
+	entry := &cachedSecret{
+		key:      key,
+		value:    value,
+		found:    found,
+		deadline: time.Now().Add(duration),
+	}
+
+	entry.element = c.lru.PushFront(entry)
+	c.entries[key] = entry
+
+	for c.config.MaxEntries > 0 && c.lru.Len() > c.config.MaxEntries {
+		c.remove(c.lru.Back().Value.(*cachedSecret))
+	}
+}
+
+// remove must be called with the lock held.
+func (c *secretCache) remove(entry *cachedSecret) {
+	delete(c.entries, entry.key)
+	c.lru.Remove(entry.element)
+}
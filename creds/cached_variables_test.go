@@ -0,0 +1,155 @@
+package creds_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CachedVariablesFactory", func() {
+	var (
+		fakeFactory   *credsfakes.FakeVariablesFactory
+		fakeVariables *credsfakes.FakeVariables
+
+		config    creds.SecretCacheConfig
+		variables creds.Variables
+	)
+
+	BeforeEach(func() {
+		fakeVariables = new(credsfakes.FakeVariables)
+		fakeVariables.GetReturns("some-value", true, nil)
+
+		fakeFactory = new(credsfakes.FakeVariablesFactory)
+		fakeFactory.NewVariablesReturns(fakeVariables)
+
+		config = creds.SecretCacheConfig{
+			Enabled:          true,
+			Duration:         time.Minute,
+			DurationNotFound: time.Minute,
+		}
+	})
+
+	JustBeforeEach(func() {
+		variables = creds.NewCachedVariablesFactory(fakeFactory, config).NewVariables("some-team", "some-pipeline")
+	})
+
+	It("creates variables for the same team and pipeline", func() {
+		Expect(fakeFactory.NewVariablesCallCount()).To(Equal(1))
+		teamName, pipelineName := fakeFactory.NewVariablesArgsForCall(0)
+		Expect(teamName).To(Equal("some-team"))
+		Expect(pipelineName).To(Equal("some-pipeline"))
+	})
+
+	It("caches secrets which were found", func() {
+		for i := 0; i < 2; i++ {
+			value, found, err := variables.Get(template.VariableDefinition{Name: "some-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+		}
+
+		Expect(fakeVariables.GetCallCount()).To(Equal(1))
+	})
+
+	It("shares the cache between variables for the same team and pipeline", func() {
+		factory := creds.NewCachedVariablesFactory(fakeFactory, config)
+
+		_, _, err := factory.NewVariables("some-team", "some-pipeline").Get(template.VariableDefinition{Name: "some-var"})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, _, err = factory.NewVariables("some-team", "some-pipeline").Get(template.VariableDefinition{Name: "some-var"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeVariables.GetCallCount()).To(Equal(1))
+
+		_, _, err = factory.NewVariables("some-team", "other-pipeline").Get(template.VariableDefinition{Name: "some-var"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeVariables.GetCallCount()).To(Equal(2))
+	})
+
+	Context("when the secret is not found", func() {
+		BeforeEach(func() {
+			fakeVariables.GetReturns(nil, false, nil)
+		})
+
+		It("caches that it was not found", func() {
+			for i := 0; i < 2; i++ {
+				_, found, err := variables.Get(template.VariableDefinition{Name: "some-var"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			}
+
+			Expect(fakeVariables.GetCallCount()).To(Equal(1))
+		})
+
+		Context("when caching missing secrets is disabled", func() {
+			BeforeEach(func() {
+				config.DurationNotFound = 0
+			})
+
+			It("does not cache it", func() {
+				variables.Get(template.VariableDefinition{Name: "some-var"})
+				variables.Get(template.VariableDefinition{Name: "some-var"})
+
+				Expect(fakeVariables.GetCallCount()).To(Equal(2))
+			})
+		})
+	})
+
+	Context("when fetching the secret fails", func() {
+		BeforeEach(func() {
+			fakeVariables.GetReturns(nil, false, errors.New("nope"))
+		})
+
+		It("returns the error and does not cache it", func() {
+			_, _, err := variables.Get(template.VariableDefinition{Name: "some-var"})
+			Expect(err).To(MatchError("nope"))
+
+			_, _, err = variables.Get(template.VariableDefinition{Name: "some-var"})
+			Expect(err).To(MatchError("nope"))
+
+			Expect(fakeVariables.GetCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when the cached secret has expired", func() {
+		BeforeEach(func() {
+			config.Duration = 10 * time.Millisecond
+		})
+
+		It("fetches it again", func() {
+			variables.Get(template.VariableDefinition{Name: "some-var"})
+			time.Sleep(20 * time.Millisecond)
+			variables.Get(template.VariableDefinition{Name: "some-var"})
+
+			Expect(fakeVariables.GetCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when the cache is full", func() {
+		BeforeEach(func() {
+			config.MaxEntries = 2
+		})
+
+		It("evicts the least recently used secret", func() {
+			variables.Get(template.VariableDefinition{Name: "var-1"})
+			variables.Get(template.VariableDefinition{Name: "var-2"})
+			variables.Get(template.VariableDefinition{Name: "var-1"})
+			variables.Get(template.VariableDefinition{Name: "var-3"})
+			Expect(fakeVariables.GetCallCount()).To(Equal(3))
+
+			variables.Get(template.VariableDefinition{Name: "var-1"})
+			Expect(fakeVariables.GetCallCount()).To(Equal(3))
+
+			variables.Get(template.VariableDefinition{Name: "var-2"})
+			Expect(fakeVariables.GetCallCount()).To(Equal(4))
+		})
+	})
+})
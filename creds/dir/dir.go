@@ -0,0 +1,55 @@
+package dir
+
+import (
+	"github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+// Dir looks up a variable first among the pipeline's secrets and then among
+// the team's, the same as Vault.
+type Dir struct {
+	Secrets *Secrets
+
+	TeamName     string
+	PipelineName string
+}
+
+func (d Dir) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	if d.PipelineName != "" {
+		value, found, err := d.Secrets.Lookup(d.TeamName, d.PipelineName, varDef.Name)
+		if err != nil || found {
+			return value, found, err
+		}
+	}
+
+	return d.Secrets.Lookup(d.TeamName, varDef.Name)
+}
+
+func (d Dir) List() ([]template.VariableDefinition, error) {
+	names, err := d.Secrets.Names(d.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.PipelineName != "" {
+		pipelineNames, err := d.Secrets.Names(d.TeamName, d.PipelineName)
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, pipelineNames...)
+	}
+
+	defs := []template.VariableDefinition{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+
+		seen[name] = true
+
+		defs = append(defs, template.VariableDefinition{Name: name})
+	}
+
+	return defs, nil
+}
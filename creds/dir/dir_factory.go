@@ -0,0 +1,23 @@
+package dir
+
+import (
+	"github.com/concourse/atc/creds"
+)
+
+type dirFactory struct {
+	secrets *Secrets
+}
+
+func NewDirFactory(secrets *Secrets) *dirFactory {
+	return &dirFactory{
+		secrets: secrets,
+	}
+}
+
+func (factory *dirFactory) NewVariables(teamName string, pipelineName string) creds.Variables {
+	return &Dir{
+		Secrets:      factory.secrets,
+		TeamName:     teamName,
+		PipelineName: pipelineName,
+	}
+}
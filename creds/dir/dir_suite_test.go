@@ -0,0 +1,13 @@
+package dir_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestDir(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Dir Creds Suite")
+}
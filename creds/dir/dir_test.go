@@ -0,0 +1,206 @@
+package dir_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/dir"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Dir", func() {
+	var (
+		secretsDir     string
+		reloadInterval time.Duration
+		logger         *lagertest.TestLogger
+
+		factory   creds.VariablesFactory
+		variables creds.Variables
+	)
+
+	writeSecret := func(path string, contents string) {
+		fullPath := filepath.Join(secretsDir, filepath.FromSlash(path))
+		Expect(os.MkdirAll(filepath.Dir(fullPath), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(fullPath, []byte(contents), 0644)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		secretsDir, err = ioutil.TempDir("", "dir-creds")
+		Expect(err).ToNot(HaveOccurred())
+
+		reloadInterval = 0
+		logger = lagertest.NewTestLogger("test")
+
+		writeSecret("some-team/some-pipeline/pipeline-var", "pipeline-value")
+		writeSecret("some-team/some-pipeline/shared-var.yml", "pipeline-shared-value")
+		writeSecret("some-team/shared-var", "team-shared-value")
+		writeSecret("some-team/team-var.yaml", "some-key: some-value\nother-key: 42\n")
+		writeSecret("other-team/other-var", "other-team-value")
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(secretsDir)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		factory = dir.NewDirFactory(dir.NewSecrets(logger, secretsDir, reloadInterval))
+		variables = factory.NewVariables("some-team", "some-pipeline")
+	})
+
+	Describe("Get", func() {
+		It("finds pipeline secrets", func() {
+			value, found, err := variables.Get(template.VariableDefinition{Name: "pipeline-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("pipeline-value"))
+		})
+
+		It("prefers pipeline secrets over team secrets", func() {
+			value, found, err := variables.Get(template.VariableDefinition{Name: "shared-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("pipeline-shared-value"))
+		})
+
+		It("falls back to team secrets", func() {
+			value, found, err := variables.Get(template.VariableDefinition{Name: "team-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(map[interface{}]interface{}{
+				"some-key":  "some-value",
+				"other-key": 42,
+			}))
+		})
+
+		It("finds only team secrets for variables without a pipeline", func() {
+			value, found, err := factory.NewVariables("some-team", "").Get(template.VariableDefinition{Name: "shared-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("team-shared-value"))
+
+			_, found, err = factory.NewVariables("some-team", "").Get(template.VariableDefinition{Name: "pipeline-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not find other teams' secrets", func() {
+			_, found, err := variables.Get(template.VariableDefinition{Name: "other-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			_, found, err = variables.Get(template.VariableDefinition{Name: "../other-team/other-var"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when a secret is not valid YAML", func() {
+			BeforeEach(func() {
+				writeSecret("some-team/broken-var", "{ nope")
+			})
+
+			It("returns an error for that secret only", func() {
+				_, _, err := variables.Get(template.VariableDefinition{Name: "broken-var"})
+				Expect(err).To(HaveOccurred())
+
+				_, found, err := variables.Get(template.VariableDefinition{Name: "pipeline-var"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when a secret is defined by more than one file", func() {
+			BeforeEach(func() {
+				writeSecret("some-team/some-pipeline/ambiguous-var", "some-value")
+				writeSecret("some-team/some-pipeline/ambiguous-var.yml", "other-value")
+			})
+
+			It("returns an error for that secret only", func() {
+				_, _, err := variables.Get(template.VariableDefinition{Name: "ambiguous-var"})
+				Expect(err).To(MatchError(ContainSubstring("more than one file")))
+
+				_, found, err := variables.Get(template.VariableDefinition{Name: "pipeline-var"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when an entry beneath the directory cannot be read", func() {
+			BeforeEach(func() {
+				Expect(os.Symlink(filepath.Join(secretsDir, "nonexistent"), filepath.Join(secretsDir, "other-team", "broken-var"))).To(Succeed())
+			})
+
+			It("skips it", func() {
+				value, found, err := variables.Get(template.VariableDefinition{Name: "pipeline-var"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("pipeline-value"))
+
+				Expect(logger).To(gbytes.Say("failed-to-scan-secret"))
+			})
+		})
+
+		Context("when a directory beneath the directory is a symlink", func() {
+			BeforeEach(func() {
+				Expect(os.Symlink(filepath.Join(secretsDir, "other-team"), filepath.Join(secretsDir, "some-team", "linked"))).To(Succeed())
+			})
+
+			It("skips it with a warning", func() {
+				_, found, err := variables.Get(template.VariableDefinition{Name: "pipeline-var"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(logger).To(gbytes.Say("skipping-symlinked-directory"))
+			})
+		})
+
+		Context("when the secrets change", func() {
+			JustBeforeEach(func() {
+				_, found, err := variables.Get(template.VariableDefinition{Name: "new-var"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				writeSecret("some-team/new-var", "new-value")
+			})
+
+			It("reloads them", func() {
+				value, found, err := variables.Get(template.VariableDefinition{Name: "new-var"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("new-value"))
+			})
+
+			Context("within the reload interval", func() {
+				BeforeEach(func() {
+					reloadInterval = time.Hour
+				})
+
+				It("does not reload them yet", func() {
+					_, found, err := variables.Get(template.VariableDefinition{Name: "new-var"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	Describe("List", func() {
+		It("lists the team's and pipeline's secrets", func() {
+			defs, err := variables.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(defs).To(ConsistOf(
+				template.VariableDefinition{Name: "shared-var"},
+				template.VariableDefinition{Name: "team-var"},
+				template.VariableDefinition{Name: "pipeline-var"},
+			))
+		})
+	})
+})
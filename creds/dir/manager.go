@@ -0,0 +1,37 @@
+package dir
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/atc/creds"
+)
+
+type DirManager struct {
+	Path           string        `long:"path"                         description:"Directory to look up secrets in, as YAML files named <path>/<team>/<pipeline>/<name> or <path>/<team>/<name>, optionally with a .yml extension."`
+	ReloadInterval time.Duration `long:"reload-interval" default:"5s" description:"How often to check the directory for changes to its secrets."`
+}
+
+func (manager DirManager) IsConfigured() bool {
+	return manager.Path != ""
+}
+
+func (manager DirManager) Validate() error {
+	info, err := os.Stat(manager.Path)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("not a directory: %s", manager.Path)
+	}
+
+	return nil
+}
+
+func (manager DirManager) NewVariablesFactory(logger lager.Logger) (creds.VariablesFactory, error) {
+	return NewDirFactory(NewSecrets(logger, manager.Path, manager.ReloadInterval)), nil
+}
@@ -0,0 +1,29 @@
+package dir
+
+import (
+	"github.com/concourse/atc/creds"
+	flags "github.com/jessevdk/go-flags"
+)
+
+type dirManagerFactory struct{}
+
+func init() {
+	creds.Register("dir", NewDirManagerFactory())
+}
+
+func NewDirManagerFactory() creds.ManagerFactory {
+	return &dirManagerFactory{}
+}
+
+func (factory *dirManagerFactory) AddConfig(group *flags.Group) creds.Manager {
+	manager := &DirManager{}
+
+	subGroup, err := group.AddGroup("Directory Credential Management", "", manager)
+	if err != nil {
+		panic(err)
+	}
+
+	subGroup.Namespace = "dir"
+
+	return manager
+}
@@ -0,0 +1,225 @@
+package dir
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var secretExtensions = []string{".yml", ".yaml"}
+
+type secret struct {
+	value interface{}
+	err   error
+}
+
+// Secrets holds every secret beneath a directory in memory. The directory is
+// checked for changes at most once per reload interval as secrets are looked
+// up, and reloaded if any file was added, removed or modified.
+type Secrets struct {
+	logger         lager.Logger
+	path           string
+	reloadInterval time.Duration
+
+	lock        sync.Mutex
+	lastChecked time.Time
+	fingerprint string
+	secrets     map[string]secret
+}
+
+func NewSecrets(logger lager.Logger, path string, reloadInterval time.Duration) *Secrets {
+	return &Secrets{
+		logger:         logger,
+		path:           path,
+		reloadInterval: reloadInterval,
+	}
+}
+
+// Lookup returns the secret at the path formed by the segments, e.g. team,
+// pipeline and variable name. A file which is not valid YAML results in an
+// error only when it is looked up.
+func (s *Secrets) Lookup(segments ...string) (interface{}, bool, error) {
+	for _, segment := range segments {
+		// don't let a variable name reach into another team's secrets
+		if segment == "" || segment == "." || segment == ".." || strings.Contains(segment, "/") {
+			return nil, false, nil
+		}
+	}
+
+	secrets, err := s.current()
+	if err != nil {
+		return nil, false, err
+	}
+
+	secret, found := secrets[strings.Join(segments, "/")]
+	if !found {
+		return nil, false, nil
+	}
+
+	if secret.err != nil {
+		return nil, false, secret.err
+	}
+
+	return secret.value, true, nil
+}
+
+// Names returns the names of the secrets directly beneath the path formed by
+// the segments.
+func (s *Secrets) Names(segments ...string) ([]string, error) {
+	secrets, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.Join(segments, "/") + "/"
+
+	names := []string{}
+	for key := range secrets {
+		name := strings.TrimPrefix(key, prefix)
+		if name == key || strings.Contains(name, "/") {
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (s *Secrets) current() (map[string]secret, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.secrets != nil && time.Since(s.lastChecked) < s.reloadInterval {
+		return s.secrets, nil
+	}
+
+	files, fingerprint, err := s.scan()
+	if err != nil {
+		return nil, err
+	}
+
+	s.lastChecked = time.Now()
+
+	if s.secrets != nil && fingerprint == s.fingerprint {
+		return s.secrets, nil
+	}
+
+	s.logger.Info("loading-secrets", lager.Data{"path": s.path, "files": len(files)})
+
+	secrets := map[string]secret{}
+	for key, paths := range files {
+		if len(paths) > 1 {
+			err := fmt.Errorf("secret %s is defined by more than one file: %s", key, strings.Join(paths, ", "))
+			s.logger.Error("ambiguous-secret", err)
+			secrets[key] = secret{err: err}
+			continue
+		}
+
+		secrets[key] = s.load(paths[0])
+	}
+
+	s.secrets = secrets
+	s.fingerprint = fingerprint
+
+	return s.secrets, nil
+}
+
+// scan finds every secret file, keyed by its path relative to the directory
+// without its extension. More than one file may share a key, e.g. "name" and
+// "name.yml". The fingerprint changes whenever any of the files do.
+//
+// Only failing to read the directory itself is an error; entries beneath it
+// which can't be read are logged and skipped so that they don't break lookups
+// for every team.
+func (s *Secrets) scan() (map[string][]string, string, error) {
+	files := map[string][]string{}
+	fingerprint := []string{}
+
+	err := filepath.Walk(s.path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if path == s.path {
+				return err
+			}
+
+			s.logger.Error("failed-to-scan-secret", err, lager.Data{"file": path})
+			return nil
+		}
+
+		if path != s.path && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		// follow symlinks, e.g. to files in a mounted Kubernetes secret
+		info, err = os.Stat(path)
+		if err != nil {
+			s.logger.Error("failed-to-scan-secret", err, lager.Data{"file": path})
+			return nil
+		}
+
+		if info.IsDir() {
+			s.logger.Info("skipping-symlinked-directory", lager.Data{"file": path})
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.path, path)
+		if err != nil {
+			return err
+		}
+
+		key := filepath.ToSlash(rel)
+		for _, ext := range secretExtensions {
+			if strings.HasSuffix(key, ext) {
+				key = strings.TrimSuffix(key, ext)
+				break
+			}
+		}
+
+		files[key] = append(files[key], path)
+		fingerprint = append(fingerprint, fmt.Sprintf("%s:%d:%d", path, info.Size(), info.ModTime().UnixNano()))
+
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	sort.Strings(fingerprint)
+
+	return files, strings.Join(fingerprint, "\n"), nil
+}
+
+func (s *Secrets) load(path string) secret {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		s.logger.Error("failed-to-read-secret", err, lager.Data{"file": path})
+		return secret{err: err}
+	}
+
+	var value interface{}
+	err = yaml.Unmarshal(contents, &value)
+	if err != nil {
+		s.logger.Error("failed-to-parse-secret", err, lager.Data{"file": path})
+		return secret{err: fmt.Errorf("invalid secret file %s: %s", path, err)}
+	}
+
+	return secret{value: value}
+}
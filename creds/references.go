@@ -0,0 +1,128 @@
+package creds
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc"
+)
+
+// Reference is a variable referenced by a pipeline config, along with each
+// place in the config which references it, e.g. "resources.some-resource.source".
+type Reference struct {
+	Name      string
+	Locations []string
+}
+
+// References returns every variable referenced by the resource sources and
+// webhook tokens, resource type sources, and step params of a pipeline
+// config, sorted by name. The variables are found the same way they are
+// evaluated, without resolving any of them.
+func References(config atc.Config) ([]Reference, error) {
+	collector := &referenceCollector{
+		references: map[string]*Reference{},
+	}
+
+	for _, resource := range config.Resources {
+		err := collector.collect("resources."+resource.Name+".source", resource.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		err = collector.collect("resources."+resource.Name+".webhook_token", resource.WebhookToken)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, resourceType := range config.ResourceTypes {
+		err := collector.collect("resource_types."+resourceType.Name+".source", resourceType.Source)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, job := range config.Jobs {
+		for _, plan := range job.Plans() {
+			prefix := "jobs." + job.Name + ".plan." + plan.Name()
+
+			err := collector.collect(prefix+".params", plan.Params)
+			if err != nil {
+				return nil, err
+			}
+
+			err = collector.collect(prefix+".get_params", plan.GetParams)
+			if err != nil {
+				return nil, err
+			}
+
+			if plan.TaskConfig != nil {
+				err = collector.collect(prefix+".config.params", plan.TaskConfig.Params)
+				if err != nil {
+					return nil, err
+				}
+
+				if plan.TaskConfig.ImageResource != nil {
+					err = collector.collect(prefix+".config.image_resource.source", plan.TaskConfig.ImageResource.Source)
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+
+	names := []string{}
+	for name := range collector.references {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	references := []Reference{}
+	for _, name := range names {
+		references = append(references, *collector.references[name])
+	}
+
+	return references, nil
+}
+
+type referenceCollector struct {
+	location   string
+	references map[string]*Reference
+}
+
+func (c *referenceCollector) collect(location string, in interface{}) error {
+	byteParams, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	c.location = location
+
+	_, err = template.NewTemplate(byteParams).Evaluate(c, nil, template.EvaluateOpts{})
+	return err
+}
+
+func (c *referenceCollector) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	reference, found := c.references[varDef.Name]
+	if !found {
+		reference = &Reference{Name: varDef.Name}
+		c.references[varDef.Name] = reference
+	}
+
+	for _, location := range reference.Locations {
+		if location == c.location {
+			return nil, false, nil
+		}
+	}
+
+	reference.Locations = append(reference.Locations, c.location)
+
+	return nil, false, nil
+}
+
+func (c *referenceCollector) List() ([]template.VariableDefinition, error) {
+	return nil, nil
+}
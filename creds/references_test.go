@@ -0,0 +1,103 @@
+package creds_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("References", func() {
+	var config atc.Config
+
+	BeforeEach(func() {
+		config = atc.Config{
+			Resources: atc.ResourceConfigs{
+				{
+					Name:         "some-resource",
+					WebhookToken: "((webhook-token))",
+					Source: atc.Source{
+						"username": "((username))",
+						"nested": map[string]interface{}{
+							"password": "((password))",
+						},
+					},
+				},
+				{
+					Name:   "plain-resource",
+					Source: atc.Source{"uri": "https://example.com"},
+				},
+			},
+			ResourceTypes: atc.ResourceTypes{
+				{
+					Name:   "some-type",
+					Source: atc.Source{"username": "((username))"},
+				},
+			},
+			Jobs: atc.JobConfigs{
+				{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{
+							Put:       "some-resource",
+							Params:    atc.Params{"token": "((put-token))"},
+							GetParams: atc.Params{"token": "((get-token))"},
+						},
+						{
+							Task: "some-task",
+							Params: atc.Params{
+								"SECRET": "((task-secret))",
+							},
+							TaskConfig: &atc.TaskConfig{
+								ImageResource: &atc.ImageResource{
+									Type:   "docker-image",
+									Source: atc.Source{"password": "((registry-password))"},
+								},
+								Params: map[string]string{
+									"SECRET": "((task-config-secret))",
+								},
+							},
+						},
+					},
+					Ensure: &atc.PlanConfig{
+						Task:   "cleanup",
+						Params: atc.Params{"SECRET": "((task-secret))"},
+					},
+				},
+			},
+		}
+	})
+
+	It("returns every referenced variable with where it is referenced", func() {
+		references, err := creds.References(config)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(references).To(Equal([]creds.Reference{
+			{Name: "get-token", Locations: []string{"jobs.some-job.plan.some-resource.get_params"}},
+			{Name: "password", Locations: []string{"resources.some-resource.source"}},
+			{Name: "put-token", Locations: []string{"jobs.some-job.plan.some-resource.params"}},
+			{Name: "registry-password", Locations: []string{"jobs.some-job.plan.some-task.config.image_resource.source"}},
+			{Name: "task-config-secret", Locations: []string{"jobs.some-job.plan.some-task.config.params"}},
+			{Name: "task-secret", Locations: []string{"jobs.some-job.plan.cleanup.params", "jobs.some-job.plan.some-task.params"}},
+			{Name: "username", Locations: []string{"resources.some-resource.source", "resource_types.some-type.source"}},
+			{Name: "webhook-token", Locations: []string{"resources.some-resource.webhook_token"}},
+		}))
+	})
+
+	Context("when nothing is referenced", func() {
+		BeforeEach(func() {
+			config = atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "plain-resource", Source: atc.Source{"uri": "https://example.com"}},
+				},
+			}
+		})
+
+		It("returns no references", func() {
+			references, err := creds.References(config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(references).To(BeEmpty())
+		})
+	})
+})
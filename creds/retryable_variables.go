@@ -0,0 +1,110 @@
+package creds
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+type SecretRetryConfig struct {
+	Attempts int           `long:"secret-retry-attempts"               description:"The number of attempts to make to fetch a secret when the credential manager fails with a transient error. Secrets are only fetched once unless this is greater than 1."`
+	Interval time.Duration `long:"secret-retry-interval" default:"1s" description:"The time to wait before retrying to fetch a secret, doubling with each attempt."`
+	Timeout  time.Duration `long:"secret-retry-timeout"  default:"5s" description:"Stop retrying to fetch a secret once this long has passed since the first attempt. Zero means no limit."`
+}
+
+// IsConfigured returns whether fetching a secret should be retried at all.
+func (config SecretRetryConfig) IsConfigured() bool {
+	return config.Attempts > 1
+}
+
+// throttling error codes returned by AWS services, as exposed by the Code
+// method of their errors
+var throttlingErrorCodes = map[string]bool{
+	"Throttling":                             true,
+	"ThrottlingException":                    true,
+	"ThrottledException":                     true,
+	"RequestThrottledException":              true,
+	"TooManyRequestsException":               true,
+	"ProvisionedThroughputExceededException": true,
+	"RequestLimitExceeded":                   true,
+	"RequestThrottled":                       true,
+	"SlowDown":                               true,
+}
+
+// IsRetryableError determines whether fetching a secret failed for a
+// transient reason, e.g. the backend throttling requests or being briefly
+// unreachable, as opposed to the secret being inaccessible.
+func IsRetryableError(err error) bool {
+	if coder, ok := err.(interface {
+		Code() string
+	}); ok && throttlingErrorCodes[coder.Code()] {
+		return true
+	}
+
+	if statusCoder, ok := err.(interface {
+		StatusCode() int
+	}); ok {
+		status := statusCoder.StatusCode()
+		if status == http.StatusTooManyRequests || status >= http.StatusInternalServerError {
+			return true
+		}
+	}
+
+	if netErr, ok := err.(net.Error); ok && (netErr.Timeout() || netErr.Temporary()) {
+		return true
+	}
+
+	return false
+}
+
+type retryableVariablesFactory struct {
+	factory VariablesFactory
+	config  SecretRetryConfig
+}
+
+// NewRetryableVariablesFactory wraps the factory such that fetching a secret
+// is retried, backing off exponentially, for as long as it fails with a
+// retryable error, up to the configured number of attempts and timeout.
+func NewRetryableVariablesFactory(factory VariablesFactory, config SecretRetryConfig) VariablesFactory {
+	return &retryableVariablesFactory{
+		factory: factory,
+		config:  config,
+	}
+}
+
+func (factory *retryableVariablesFactory) NewVariables(teamName string, pipelineName string) Variables {
+	return &retryableVariables{
+		variables: factory.factory.NewVariables(teamName, pipelineName),
+		config:    factory.config,
+	}
+}
+
+type retryableVariables struct {
+	variables Variables
+	config    SecretRetryConfig
+}
+
+func (v *retryableVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	interval := v.config.Interval
+	deadline := time.Now().Add(v.config.Timeout)
+
+	for attempt := 1; ; attempt++ {
+		value, found, err := v.variables.Get(varDef)
+		if err == nil || attempt >= v.config.Attempts || !IsRetryableError(err) {
+			return value, found, err
+		}
+
+		if v.config.Timeout != 0 && time.Now().Add(interval).After(deadline) {
+			return value, found, err
+		}
+
+		time.Sleep(interval)
+		interval *= 2
+	}
+}
+
+func (v *retryableVariables) List() ([]template.VariableDefinition, error) {
+	return v.variables.List()
+}
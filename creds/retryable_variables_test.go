@@ -0,0 +1,157 @@
+package creds_test
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type codedError struct {
+	code string
+}
+
+func (err codedError) Error() string { return err.code }
+func (err codedError) Code() string  { return err.code }
+
+type statusError struct {
+	status int
+}
+
+func (err statusError) Error() string   { return "bad status" }
+func (err statusError) StatusCode() int { return err.status }
+
+var _ = Describe("RetryableVariablesFactory", func() {
+	var (
+		fakeFactory   *credsfakes.FakeVariablesFactory
+		fakeVariables *credsfakes.FakeVariables
+
+		variables creds.Variables
+
+		value interface{}
+		found bool
+		err   error
+	)
+
+	BeforeEach(func() {
+		fakeVariables = new(credsfakes.FakeVariables)
+
+		fakeFactory = new(credsfakes.FakeVariablesFactory)
+		fakeFactory.NewVariablesReturns(fakeVariables)
+
+		variables = creds.NewRetryableVariablesFactory(fakeFactory, creds.SecretRetryConfig{
+			Attempts: 3,
+			Interval: time.Millisecond,
+		}).NewVariables("some-team", "some-pipeline")
+	})
+
+	JustBeforeEach(func() {
+		value, found, err = variables.Get(template.VariableDefinition{Name: "some-var"})
+	})
+
+	Context("when fetching the secret succeeds", func() {
+		BeforeEach(func() {
+			fakeVariables.GetReturns("some-value", true, nil)
+		})
+
+		It("returns it without retrying", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+			Expect(fakeVariables.GetCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when fetching the secret fails with a retryable error", func() {
+		BeforeEach(func() {
+			fakeVariables.GetReturnsOnCall(0, nil, false, codedError{"ThrottlingException"})
+			fakeVariables.GetReturnsOnCall(1, "some-value", true, nil)
+		})
+
+		It("retries", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+			Expect(fakeVariables.GetCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when fetching the secret keeps failing with a retryable error", func() {
+		BeforeEach(func() {
+			fakeVariables.GetReturns(nil, false, codedError{"ThrottlingException"})
+		})
+
+		It("gives up after the configured number of attempts", func() {
+			Expect(err).To(Equal(codedError{"ThrottlingException"}))
+			Expect(fakeVariables.GetCallCount()).To(Equal(3))
+		})
+
+		Context("when retrying would take longer than the timeout", func() {
+			BeforeEach(func() {
+				variables = creds.NewRetryableVariablesFactory(fakeFactory, creds.SecretRetryConfig{
+					Attempts: 10,
+					Interval: 20 * time.Millisecond,
+					Timeout:  50 * time.Millisecond,
+				}).NewVariables("some-team", "some-pipeline")
+			})
+
+			It("gives up before the wait would exceed it", func() {
+				Expect(err).To(Equal(codedError{"ThrottlingException"}))
+				Expect(fakeVariables.GetCallCount()).To(Equal(2))
+			})
+		})
+	})
+
+	Context("when fetching the secret fails with another error", func() {
+		BeforeEach(func() {
+			fakeVariables.GetReturns(nil, false, codedError{"AccessDeniedException"})
+		})
+
+		It("returns the error without retrying", func() {
+			Expect(err).To(Equal(codedError{"AccessDeniedException"}))
+			Expect(fakeVariables.GetCallCount()).To(Equal(1))
+		})
+	})
+})
+
+var _ = Describe("SecretRetryConfig", func() {
+	It("is configured only when more than one attempt is allowed", func() {
+		Expect(creds.SecretRetryConfig{}.IsConfigured()).To(BeFalse())
+		Expect(creds.SecretRetryConfig{Attempts: 1}.IsConfigured()).To(BeFalse())
+		Expect(creds.SecretRetryConfig{Attempts: 2}.IsConfigured()).To(BeTrue())
+	})
+})
+
+var _ = Describe("IsRetryableError", func() {
+	It("retries throttling errors", func() {
+		Expect(creds.IsRetryableError(codedError{"Throttling"})).To(BeTrue())
+		Expect(creds.IsRetryableError(codedError{"TooManyRequestsException"})).To(BeTrue())
+		Expect(creds.IsRetryableError(codedError{"ResourceNotFoundException"})).To(BeFalse())
+	})
+
+	It("retries server errors and too many requests", func() {
+		Expect(creds.IsRetryableError(statusError{429})).To(BeTrue())
+		Expect(creds.IsRetryableError(statusError{503})).To(BeTrue())
+		Expect(creds.IsRetryableError(statusError{403})).To(BeFalse())
+	})
+
+	It("retries network timeouts", func() {
+		Expect(creds.IsRetryableError(&net.OpError{Op: "dial", Err: timeoutError{}})).To(BeTrue())
+	})
+
+	It("does not retry other errors", func() {
+		Expect(creds.IsRetryableError(errors.New("nope"))).To(BeFalse())
+	})
+})
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
@@ -49,6 +49,12 @@ func (ac *APIClient) Read(path string) (*vaultapi.Secret, error) {
 	return ac.client().Logical().Read(path)
 }
 
+// Write must be called after a successful login has occurred or an
+// un-authorized client will be used.
+func (ac *APIClient) Write(path string, data map[string]interface{}) (*vaultapi.Secret, error) {
+	return ac.client().Logical().Write(path, data)
+}
+
 func (ac *APIClient) loginParams() map[string]interface{} {
 	loginParams := make(map[string]interface{})
 	for _, param := range ac.authConfig.Params {
@@ -1,40 +1,57 @@
 package vault
 
 import (
+	"container/list"
 	"context"
 	"sync"
 	"time"
 
+	"github.com/concourse/atc/metric"
 	vaultapi "github.com/hashicorp/vault/api"
 )
 
 type cachedSecret struct {
+	path     string
 	deadline time.Time
-	secret   *vaultapi.Secret
+	element  *list.Element
+
+	// nil if nothing was found at the path
+	secret *vaultapi.Secret
 }
 
 // A Cache caches secrets read from a SecretReader until the lease on
 // the secret expires. Once expired the credential is proactively
 // deleted from cache to maintain a smaller cache footprint.
+//
+// Paths at which no secret was found are cached for a shorter, fixed
+// duration, as every variable is looked up under both the pipeline and the
+// team path. Once the cache holds its maximum number of entries, the least
+// recently read entry is evicted.
 type Cache struct {
 	sync.RWMutex
 	cache    map[string]*cachedSecret
+	lru      *list.List
 	newItems chan time.Time
 	sr       SecretReader
 	context  context.Context
 	maxLease time.Duration
-}
 
-// TODO: Should a cache have a max size to
-// prevent unbounded growth?
+	maxEntries  int
+	notFoundTTL time.Duration
+}
 
-// NewCache using the underlying vault client.
-func NewCache(sr SecretReader, maxLease time.Duration) *Cache {
+// NewCache using the underlying vault client. A maxEntries of zero means
+// the cache is unbounded, and a notFoundTTL of zero means missing secrets
+// are not cached.
+func NewCache(sr SecretReader, maxLease time.Duration, maxEntries int, notFoundTTL time.Duration) *Cache {
 	c := &Cache{
-		cache:    make(map[string]*cachedSecret),
-		newItems: make(chan time.Time, 100),
-		sr:       sr,
-		maxLease: maxLease,
+		cache:       make(map[string]*cachedSecret),
+		lru:         list.New(),
+		newItems:    make(chan time.Time, 100),
+		sr:          sr,
+		maxLease:    maxLease,
+		maxEntries:  maxEntries,
+		notFoundTTL: notFoundTTL,
 	}
 	go c.reaperThread()
 	return c
@@ -45,18 +62,24 @@ func (c *Cache) reapCache() time.Time {
 	defer c.Unlock()
 
 	var smallestNext time.Time
-	for k, secret := range c.cache {
+	for _, secret := range c.cache {
 		if time.Now().After(secret.deadline) {
-			delete(c.cache, k)
+			c.remove(secret)
 			continue
 		}
-		if secret.deadline.Before(smallestNext) {
+		if smallestNext.IsZero() || secret.deadline.Before(smallestNext) {
 			smallestNext = secret.deadline
 		}
 	}
 	return smallestNext
 }
 
+// remove must be called with the lock held.
+func (c *Cache) remove(cs *cachedSecret) {
+	delete(c.cache, cs.path)
+	c.lru.Remove(cs.element)
+}
+
 func (c *Cache) reaperThread() {
 	sleep := time.NewTimer(1 * time.Second)
 	defer sleep.Stop()
@@ -69,7 +92,8 @@ func (c *Cache) reaperThread() {
 				sleep.Reset(nextWakeup.Sub(time.Now()))
 			}
 		case t := <-c.newItems:
-			if t.Before(nextWakeup) {
+			// already waking up in time to reap the new item
+			if !nextWakeup.IsZero() && nextWakeup.Before(t) {
 				continue
 			}
 			nextWakeup = t
@@ -81,39 +105,64 @@ func (c *Cache) reaperThread() {
 // Read a secret from the cache or the underlying client if not
 // present.
 func (c *Cache) Read(path string) (*vaultapi.Secret, error) {
-	// If we have the secret in our cache just return it
-	c.RLock() // don't use defer because we want to agressively release this lock
+	// If we have the secret in our cache just return it. Reads need the write
+	// lock as they mark the entry as recently used, so release it aggressively.
+	c.Lock()
 	cs, cached := c.cache[path]
-	c.RUnlock()
-
 	if cached && time.Now().Before(cs.deadline) {
+		c.lru.MoveToFront(cs.element)
+		c.Unlock()
+
+		metric.VaultCacheHits.Inc()
+
 		return cs.secret, nil
 	}
+	c.Unlock()
+
+	metric.VaultCacheMisses.Inc()
 
 	// Otherwise fetch the secret using the client. Clients are
 	// thread safe for read use.
 	secret, err := c.sr.Read(path)
-	if err != nil || secret == nil {
-		return secret, err
+	if err != nil {
+		return nil, err
 	}
 
-	// We will renew the item in half the lease duration to resolve an inherent race
-	// in this setup: What if the secret becomes invalid _during_ the build. We don't
-	// want to be issuing secrets that expire in (fex) 100ms.
-	// This is a problem in any implementation, as lease duration could be 1s and the
-	// build will _probably_ take longer  than that.
-	dur := time.Duration(secret.LeaseDuration) * time.Second / 2
-	if c.maxLease != 0 && dur > c.maxLease {
-		dur = c.maxLease
+	var dur time.Duration
+	if secret == nil {
+		if c.notFoundTTL == 0 {
+			return nil, nil
+		}
+
+		dur = c.notFoundTTL
+	} else {
+		// We will renew the item in half the lease duration to resolve an inherent race
+		// in this setup: What if the secret becomes invalid _during_ the build. We don't
+		// want to be issuing secrets that expire in (fex) 100ms.
+		// This is a problem in any implementation, as lease duration could be 1s and the
+		// build will _probably_ take longer  than that.
+		dur = time.Duration(secret.LeaseDuration) * time.Second / 2
+		if c.maxLease != 0 && dur > c.maxLease {
+			dur = c.maxLease
+		}
 	}
 
 	// Store the secret in cache
 	cs = &cachedSecret{
+		path:     path,
 		deadline: time.Now().Add(dur),
 		secret:   secret,
 	}
 	c.Lock()
+	if existing, found := c.cache[path]; found {
+		c.remove(existing)
+	}
+	cs.element = c.lru.PushFront(cs)
 	c.cache[path] = cs
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back().Value.(*cachedSecret))
+		metric.VaultCacheEvictions.Inc()
+	}
 	c.Unlock()
 
 	// Tell the reaper thread it has new items to cleanup
@@ -1,6 +1,7 @@
 package vault
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -38,7 +39,7 @@ func TestCache(t *testing.T) {
 		secrets: secrets,
 	}
 
-	cache := NewCache(msr, 5*time.Second)
+	cache := NewCache(msr, 5*time.Second, 0, 0)
 	// miss
 	secret, err := cache.Read("path1")
 	if err != nil {
@@ -107,3 +108,116 @@ func TestCache(t *testing.T) {
 	cache.RUnlock()
 
 }
+
+func TestCacheMaxEntries(t *testing.T) {
+	msr := &MockSecretReader{
+		secrets: []*vaultapi.Secret{
+			&vaultapi.Secret{RequestID: "1", LeaseDuration: 60},
+			&vaultapi.Secret{RequestID: "2", LeaseDuration: 60},
+			&vaultapi.Secret{RequestID: "3", LeaseDuration: 60},
+			&vaultapi.Secret{RequestID: "4", LeaseDuration: 60},
+		},
+	}
+
+	cache := NewCache(msr, 0, 2, 0)
+
+	cache.Read("path1")
+	cache.Read("path2")
+
+	// hit, making path2 the least recently used
+	cache.Read("path1")
+
+	// miss, evicting path2
+	cache.Read("path3")
+
+	cache.RLock()
+	if len(cache.cache) != 2 {
+		t.Errorf("Expected cache to hold 2 secrets, held %v", cache.cache)
+	}
+	cache.RUnlock()
+
+	// hit
+	secret, err := cache.Read("path1")
+	if err != nil {
+		t.Error("got error reading valid secret from cache", err)
+	}
+	if secret.RequestID != "1" {
+		t.Errorf("read secret %s expected %s", secret.RequestID, "1")
+	}
+
+	// miss
+	secret, err = cache.Read("path2")
+	if err != nil {
+		t.Error("got error reading valid secret", err)
+	}
+	if secret.RequestID != "4" {
+		t.Errorf("read secret %s expected %s", secret.RequestID, "4")
+	}
+
+	expectedReads := []string{"path1", "path2", "path3", "path2"}
+	if !reflect.DeepEqual(msr.reads, expectedReads) {
+		t.Errorf("Got reads %v, expected %v", msr.reads, expectedReads)
+	}
+}
+
+func TestCacheNotFound(t *testing.T) {
+	msr := &MockSecretReader{
+		secrets: []*vaultapi.Secret{
+			nil,
+			&vaultapi.Secret{RequestID: "1", LeaseDuration: 60},
+		},
+	}
+
+	cache := NewCache(msr, 0, 0, time.Second)
+
+	// miss
+	secret, err := cache.Read("path1")
+	if err != nil {
+		t.Error("got error reading missing secret", err)
+	}
+	if secret != nil {
+		t.Errorf("read secret %v expected nil", secret)
+	}
+
+	// hit
+	secret, err = cache.Read("path1")
+	if err != nil {
+		t.Error("got error reading missing secret from cache", err)
+	}
+	if secret != nil {
+		t.Errorf("read secret %v expected nil", secret)
+	}
+	if len(msr.reads) != 1 {
+		t.Errorf("Got reads %v, expected [\"%s\"]", msr.reads, "path1")
+	}
+
+	// expire
+	time.Sleep(time.Second + 100*time.Millisecond)
+
+	// miss
+	secret, err = cache.Read("path1")
+	if err != nil {
+		t.Error("got error reading valid secret", err)
+	}
+	if secret == nil || secret.RequestID != "1" {
+		t.Errorf("read secret %v expected %s", secret, "1")
+	}
+	if len(msr.reads) != 2 {
+		t.Errorf("Got reads %v, expected [\"%s\" \"%s\"]", msr.reads, "path1", "path1")
+	}
+}
+
+func TestCacheNotFoundDisabled(t *testing.T) {
+	msr := &MockSecretReader{
+		secrets: []*vaultapi.Secret{nil, nil},
+	}
+
+	cache := NewCache(msr, 0, 0, 0)
+
+	cache.Read("path1")
+	cache.Read("path1")
+
+	if len(msr.reads) != 2 {
+		t.Errorf("Got reads %v, expected [\"%s\" \"%s\"]", msr.reads, "path1", "path1")
+	}
+}
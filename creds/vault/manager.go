@@ -18,8 +18,10 @@ type VaultManager struct {
 
 	PathPrefix string `long:"path-prefix" default:"/concourse" description:"Path under which to namespace credential lookup."`
 
-	Cache    bool          `long:"cache" description:"Cache returned secrets for their lease duration in memory"`
-	MaxLease time.Duration `long:"max-lease" description:"If the cache is enabled, and this is set, override secrets lease duration with a maximum value"`
+	Cache            bool          `long:"cache" description:"Cache returned secrets for their lease duration in memory"`
+	MaxLease         time.Duration `long:"max-lease" description:"If the cache is enabled, and this is set, override secrets lease duration with a maximum value"`
+	CacheMaxEntries  int           `long:"cache-max-entries" default:"10000" description:"If the cache is enabled, the maximum number of secrets to hold, evicting the least recently used. Zero means unlimited."`
+	CacheNotFoundTTL time.Duration `long:"cache-not-found-ttl" default:"1m" description:"If the cache is enabled, how long to remember that no secret exists at a path. Zero disables caching of missing secrets."`
 
 	TLS struct {
 		CACert     string `long:"ca-cert"              description:"Path to a PEM-encoded CA cert file to use to verify the vault server SSL cert."`
@@ -84,7 +86,7 @@ func (manager VaultManager) NewVariablesFactory(logger lager.Logger) (creds.Vari
 	ra := NewReAuther(c, manager.Auth.BackendMaxTTL, manager.Auth.RetryInitial, manager.Auth.RetryMax)
 	var sr SecretReader = c
 	if manager.Cache {
-		sr = NewCache(c, manager.MaxLease)
+		sr = NewCache(c, manager.MaxLease, manager.CacheMaxEntries, manager.CacheNotFoundTTL)
 	}
 
 	return NewVaultFactory(sr, ra.LoggedIn(), manager.PathPrefix), nil
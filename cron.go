@@ -0,0 +1,226 @@
+package atc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A CronSchedule is a cron expression with five fields: minute, hour, day of
+// the month, month and day of the week. Each field is `*`, a value, a range
+// (`1-5`) or a list of them (`1,15`), optionally with a step (`*/15`).
+// Months and days of the week may be given by name (`jan`, `mon`).
+//
+// As with cron, when both the day of the month and the day of the week are
+// restricted a day matching either one is scheduled. Only a literal `*`
+// leaves a field unrestricted, so `*/2` restricts the day of the month.
+//
+// Times are on the location's wall clock, so a time which is skipped when
+// clocks go forward is not scheduled that day, and a time which happens twice
+// when clocks go back is only scheduled the first time.
+type CronSchedule struct {
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+
+	anyDay     bool
+	anyWeekday bool
+
+	location *time.Location
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+
+var cronWeekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// ParseCronSchedule parses a cron expression whose times are in the given
+// location.
+func ParseCronSchedule(expr string, location *time.Location) (CronSchedule, error) {
+	if macro, found := cronMacros[strings.TrimSpace(expr)]; found {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return CronSchedule{}, fmt.Errorf("expected 5 fields but found %d", len(fields))
+	}
+
+	schedule := CronSchedule{
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+		location:   location,
+	}
+
+	var err error
+
+	schedule.minutes, err = parseCronField(fields[0], "minute", 0, 59, nil)
+	if err != nil {
+		return CronSchedule{}, err
+	}
+
+	schedule.hours, err = parseCronField(fields[1], "hour", 0, 23, nil)
+	if err != nil {
+		return CronSchedule{}, err
+	}
+
+	schedule.days, err = parseCronField(fields[2], "day of month", 1, 31, nil)
+	if err != nil {
+		return CronSchedule{}, err
+	}
+
+	schedule.months, err = parseCronField(fields[3], "month", 1, 12, cronMonthNames)
+	if err != nil {
+		return CronSchedule{}, err
+	}
+
+	// 7 is also Sunday
+	schedule.weekdays, err = parseCronField(fields[4], "day of week", 0, 7, cronWeekdayNames)
+	if err != nil {
+		return CronSchedule{}, err
+	}
+
+	if schedule.weekdays&(1<<7) != 0 {
+		schedule.weekdays |= 1
+	}
+
+	return schedule, nil
+}
+
+// Next returns the first scheduled time after the given time, or the zero
+// time if the schedule never comes around, e.g. on the 30th of February.
+func (schedule CronSchedule) Next(after time.Time) time.Time {
+	// the wall clock is stepped through in UTC, which has no gaps or overlaps
+	// for time.Date to resolve
+	t := after.In(schedule.location)
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, time.UTC)
+
+	// every possible day comes around within a few years
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if schedule.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+
+		if !schedule.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+
+		if schedule.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+			continue
+		}
+
+		if schedule.minutes&(1<<uint(t.Minute())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, time.UTC)
+			continue
+		}
+
+		// time.Date moves a time the clocks skip, and picks the first of a time
+		// which happens twice
+		local := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, schedule.location)
+		if local.Hour() == t.Hour() && local.Minute() == t.Minute() && local.After(after) {
+			return local
+		}
+
+		t = t.Add(time.Minute)
+	}
+
+	return time.Time{}
+}
+
+func (schedule CronSchedule) dayMatches(t time.Time) bool {
+	dayMatches := schedule.days&(1<<uint(t.Day())) != 0
+	weekdayMatches := schedule.weekdays&(1<<uint(t.Weekday())) != 0
+
+	if schedule.anyDay || schedule.anyWeekday {
+		return dayMatches && weekdayMatches
+	}
+
+	return dayMatches || weekdayMatches
+}
+
+func parseCronField(field string, name string, min int, max int, names []string) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rangeExpr, step := part, 1
+
+		if i := strings.Index(part, "/"); i != -1 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %s '%s'", name, part)
+			}
+
+			rangeExpr = part[:i]
+		}
+
+		var start, end int
+		if rangeExpr == "*" {
+			start, end = min, max
+		} else if i := strings.Index(rangeExpr, "-"); i != -1 {
+			var err error
+			start, err = parseCronValue(rangeExpr[:i], min, max, names)
+			if err != nil {
+				return 0, fmt.Errorf("invalid %s '%s'", name, part)
+			}
+
+			end, err = parseCronValue(rangeExpr[i+1:], min, max, names)
+			if err != nil || end < start {
+				return 0, fmt.Errorf("invalid %s '%s'", name, part)
+			}
+		} else {
+			var err error
+			start, err = parseCronValue(rangeExpr, min, max, names)
+			if err != nil {
+				return 0, fmt.Errorf("invalid %s '%s'", name, part)
+			}
+
+			end = start
+			if step > 1 {
+				end = max
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+
+	return bits, nil
+}
+
+func parseCronValue(value string, min int, max int, names []string) (int, error) {
+	for i, name := range names {
+		if strings.EqualFold(value, name) {
+			return i + min, nil
+		}
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if number < min || number > max {
+		return 0, fmt.Errorf("%d is out of range", number)
+	}
+
+	return number, nil
+}
@@ -0,0 +1,118 @@
+package atc_test
+
+import (
+	"time"
+
+	. "github.com/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CronSchedule", func() {
+	parse := func(layout string, value string) time.Time {
+		t, err := time.Parse(layout, value)
+		Expect(err).NotTo(HaveOccurred())
+		return t
+	}
+
+	DescribeTable("Next",
+		func(expr string, after string, next string) {
+			schedule, err := ParseCronSchedule(expr, time.UTC)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Next(parse(time.RFC3339, after))).To(Equal(parse(time.RFC3339, next)))
+		},
+
+		Entry("every minute", "* * * * *", "2018-07-01T10:00:30Z", "2018-07-01T10:01:00Z"),
+		Entry("a fixed time later in the day", "0 2 * * *", "2018-07-01T01:59:00Z", "2018-07-01T02:00:00Z"),
+		Entry("a fixed time which has just passed", "0 2 * * *", "2018-07-01T02:00:00Z", "2018-07-02T02:00:00Z"),
+		Entry("a step", "*/15 * * * *", "2018-07-01T10:16:00Z", "2018-07-01T10:30:00Z"),
+		Entry("a step from a value", "5/20 * * * *", "2018-07-01T10:26:00Z", "2018-07-01T10:45:00Z"),
+		Entry("a list", "0 9,17 * * *", "2018-07-01T10:00:00Z", "2018-07-01T17:00:00Z"),
+		Entry("named days of the week", "0 9 * * mon-fri", "2018-07-06T10:00:00Z", "2018-07-09T09:00:00Z"),
+		Entry("sunday as 7", "0 0 * * 7", "2018-07-02T00:00:00Z", "2018-07-08T00:00:00Z"),
+		Entry("named months", "0 0 1 jan,jul *", "2018-07-02T00:00:00Z", "2019-01-01T00:00:00Z"),
+		Entry("a day of the month or of the week", "0 0 13 * fri", "2018-07-07T00:00:00Z", "2018-07-13T00:00:00Z"),
+		Entry("a day of the month or of the week, whichever is first", "0 0 20 * mon", "2018-07-10T00:00:00Z", "2018-07-16T00:00:00Z"),
+		Entry("a stepped day of the month or a day of the week", "0 0 */2 * mon", "2018-07-01T00:00:00Z", "2018-07-02T00:00:00Z"),
+		Entry("the end of february in a leap year", "0 0 29 2 *", "2018-03-01T00:00:00Z", "2020-02-29T00:00:00Z"),
+		Entry("a macro", "@daily", "2018-07-01T10:00:00Z", "2018-07-02T00:00:00Z"),
+	)
+
+	It("never fires on days which do not exist", func() {
+		schedule, err := ParseCronSchedule("0 0 30 2 *", time.UTC)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(schedule.Next(time.Now()).IsZero()).To(BeTrue())
+	})
+
+	It("fires in the schedule's location", func() {
+		location, err := time.LoadLocation("America/New_York")
+		Expect(err).NotTo(HaveOccurred())
+
+		schedule, err := ParseCronSchedule("0 2 * * *", location)
+		Expect(err).NotTo(HaveOccurred())
+
+		next := schedule.Next(parse(time.RFC3339, "2018-07-01T00:00:00Z"))
+		Expect(next.UTC()).To(Equal(parse(time.RFC3339, "2018-07-01T06:00:00Z")))
+	})
+
+	It("skips a time the clocks go forward past", func() {
+		location, err := time.LoadLocation("America/New_York")
+		Expect(err).NotTo(HaveOccurred())
+
+		schedule, err := ParseCronSchedule("30 2 * * *", location)
+		Expect(err).NotTo(HaveOccurred())
+
+		next := schedule.Next(parse(time.RFC3339, "2018-03-10T12:00:00Z"))
+		Expect(next.UTC()).To(Equal(parse(time.RFC3339, "2018-03-12T06:30:00Z")))
+	})
+
+	It("fires once at a time the clocks go back past", func() {
+		location, err := time.LoadLocation("America/New_York")
+		Expect(err).NotTo(HaveOccurred())
+
+		schedule, err := ParseCronSchedule("30 1 * * *", location)
+		Expect(err).NotTo(HaveOccurred())
+
+		next := schedule.Next(parse(time.RFC3339, "2018-11-03T12:00:00Z"))
+		Expect(next.UTC()).To(Equal(parse(time.RFC3339, "2018-11-04T05:30:00Z")))
+
+		next = schedule.Next(next)
+		Expect(next.UTC()).To(Equal(parse(time.RFC3339, "2018-11-05T06:30:00Z")))
+	})
+
+	DescribeTable("invalid expressions",
+		func(expr string, message string) {
+			_, err := ParseCronSchedule(expr, time.UTC)
+			Expect(err).To(MatchError(message))
+		},
+
+		Entry("too few fields", "0 2 * *", "expected 5 fields but found 4"),
+		Entry("a minute out of range", "60 * * * *", "invalid minute '60'"),
+		Entry("a backwards range", "* 5-1 * * *", "invalid hour '5-1'"),
+		Entry("a zero step", "*/0 * * * *", "invalid step in minute '*/0'"),
+		Entry("an unknown month", "0 0 1 foo *", "invalid month 'foo'"),
+	)
+})
+
+var _ = Describe("TriggerConfigs", func() {
+	It("returns the trigger which fires first", func() {
+		triggers := TriggerConfigs{
+			{Cron: "0 12 * * *"},
+			{Cron: "0 2 * * *"},
+			{Cron: "not a cron"},
+		}
+
+		after := time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)
+
+		trigger, next, found := triggers.Next(after)
+		Expect(found).To(BeTrue())
+		Expect(trigger).To(Equal(TriggerConfig{Cron: "0 2 * * *"}))
+		Expect(next).To(Equal(time.Date(2018, 7, 1, 2, 0, 0, 0, time.UTC)))
+	})
+
+	It("returns nothing when there are no triggers", func() {
+		_, _, found := TriggerConfigs{}.Next(time.Now())
+		Expect(found).To(BeFalse())
+	})
+})
@@ -6,6 +6,26 @@ type VersionsDB struct {
 	BuildInputs      []BuildInput
 	JobIDs           map[string]int
 	ResourceIDs      map[string]int
+
+	// PinnedVersionIDs maps the IDs of resources that have been pinned to a
+	// version to the pinned version's ID.
+	PinnedVersionIDs map[int]int
+
+	// DisabledVersions are never candidates, and are only used to explain why
+	// an input could not be resolved.
+	DisabledVersions []ResourceVersion
+
+	// VersionFields maps the IDs of versions to their fields, so that inputs
+	// can filter versions by their contents.
+	VersionFields map[int]map[string]string
+}
+
+// A VersionFilter restricts an input's candidates to the versions whose
+// fields it allows. A nil VersionFilter allows every version.
+type VersionFilter func(fields map[string]string) bool
+
+func (db VersionsDB) allows(filter VersionFilter, versionID int) bool {
+	return filter == nil || filter(db.VersionFields[versionID])
 }
 
 type ResourceVersion struct {
@@ -38,10 +58,30 @@ func (db VersionsDB) IsVersionFirstOccurrence(versionID int, jobID int, inputNam
 	return true
 }
 
-func (db VersionsDB) AllVersionsOfResource(resourceID int) VersionCandidates {
+func (db VersionsDB) IsVersionDisabled(versionID int) bool {
+	for _, v := range db.DisabledVersions {
+		if v.VersionID == versionID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (db VersionsDB) HasDisabledVersionsOfResource(resourceID int) bool {
+	for _, v := range db.DisabledVersions {
+		if v.ResourceID == resourceID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (db VersionsDB) AllVersionsOfResource(resourceID int, filter VersionFilter) VersionCandidates {
 	candidates := VersionCandidates{}
 	for _, output := range db.ResourceVersions {
-		if output.ResourceID == resourceID {
+		if output.ResourceID == resourceID && db.allows(filter, output.VersionID) {
 			candidates.Add(VersionCandidate{
 				VersionID:  output.VersionID,
 				CheckOrder: output.CheckOrder,
@@ -52,12 +92,12 @@ func (db VersionsDB) AllVersionsOfResource(resourceID int) VersionCandidates {
 	return candidates
 }
 
-func (db VersionsDB) LatestVersionOfResource(resourceID int) (VersionCandidate, bool) {
+func (db VersionsDB) LatestVersionOfResource(resourceID int, filter VersionFilter) (VersionCandidate, bool) {
 	var candidate VersionCandidate
 	var found bool
 
 	for _, v := range db.ResourceVersions {
-		if v.ResourceID == resourceID && v.CheckOrder > candidate.CheckOrder {
+		if v.ResourceID == resourceID && v.CheckOrder > candidate.CheckOrder && db.allows(filter, v.VersionID) {
 			candidate = VersionCandidate{
 				VersionID:  v.VersionID,
 				CheckOrder: v.CheckOrder,
@@ -88,7 +128,7 @@ func (db VersionsDB) FindVersionOfResource(resourceID int, versionID int) (Versi
 	return candidate, found
 }
 
-func (db VersionsDB) VersionsOfResourcePassedJobs(resourceID int, passed JobSet) VersionCandidates {
+func (db VersionsDB) VersionsOfResourcePassedJobs(resourceID int, passed JobSet, filter VersionFilter) VersionCandidates {
 	candidates := VersionCandidates{}
 
 	firstTick := true
@@ -96,7 +136,7 @@ func (db VersionsDB) VersionsOfResourcePassedJobs(resourceID int, passed JobSet)
 		versions := VersionCandidates{}
 
 		for _, output := range db.BuildOutputs {
-			if output.ResourceID == resourceID && output.JobID == jobID {
+			if output.ResourceID == resourceID && output.JobID == jobID && db.allows(filter, output.VersionID) {
 				versions.Add(VersionCandidate{
 					VersionID:  output.VersionID,
 					CheckOrder: output.CheckOrder,
@@ -1,6 +1,7 @@
 package algorithm_test
 
 import (
+	"context"
 	"github.com/concourse/atc/db/algorithm"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -38,7 +39,7 @@ var _ = Describe("Resolve", func() {
 
 	JustBeforeEach(func() {
 		var ok bool
-		inputMapping, ok = inputConfigs.Resolve(versionsDB)
+		inputMapping, _, ok = inputConfigs.Resolve(context.Background(), versionsDB)
 		Expect(ok).To(BeTrue())
 	})
 
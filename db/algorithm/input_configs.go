@@ -1,35 +1,70 @@
 package algorithm
 
+import (
+	"context"
+	"strconv"
+
+	"github.com/concourse/atc/tracing"
+)
+
 type InputConfigs []InputConfig
 
 type InputConfig struct {
 	Name            string
 	JobName         string
 	Passed          JobSet
+	UnknownPassed   []string
 	UseEveryVersion bool
 	PinnedVersionID int
+	VersionFilter   VersionFilter
 	ResourceID      int
 	JobID           int
 }
 
-func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
+// Resolve finds the versions to use for the inputs. If there are none, it
+// returns why for each input that could not be resolved.
+func (configs InputConfigs) Resolve(ctx context.Context, db *VersionsDB) (InputMapping, ResolutionFailures, bool) {
+	_, span := tracing.StartSpan(ctx, "algorithm.Resolve", tracing.Attrs{
+		"inputs": strconv.Itoa(len(configs)),
+	})
+	defer span.End()
+
 	jobs := JobSet{}
 	inputCandidates := InputCandidates{}
+	failures := ResolutionFailures{}
 
 	for _, inputConfig := range configs {
+		if len(inputConfig.UnknownPassed) > 0 {
+			failures[inputConfig.Name] = unknownJobsPassed(inputConfig.UnknownPassed)
+			continue
+		}
+
 		versionCandidates := VersionCandidates{}
 
+		// a version pinned on the resource overrides the input's own version
+		// config
+		pinnedVersionID := inputConfig.PinnedVersionID
+		if resourcePinnedVersionID, pinned := db.PinnedVersionIDs[inputConfig.ResourceID]; pinned {
+			pinnedVersionID = resourcePinnedVersionID
+		}
+
+		// a pinned version is exact, so the input's filter does not apply to it
+		filter := inputConfig.VersionFilter
+		if pinnedVersionID != 0 {
+			filter = nil
+		}
+
 		if len(inputConfig.Passed) == 0 {
-			if inputConfig.UseEveryVersion {
-				versionCandidates = db.AllVersionsOfResource(inputConfig.ResourceID)
+			if inputConfig.UseEveryVersion && pinnedVersionID == 0 {
+				versionCandidates = db.AllVersionsOfResource(inputConfig.ResourceID, filter)
 			} else {
 				var versionCandidate VersionCandidate
 				var found bool
 
-				if inputConfig.PinnedVersionID != 0 {
-					versionCandidate, found = db.FindVersionOfResource(inputConfig.ResourceID, inputConfig.PinnedVersionID)
+				if pinnedVersionID != 0 {
+					versionCandidate, found = db.FindVersionOfResource(inputConfig.ResourceID, pinnedVersionID)
 				} else {
-					versionCandidate, found = db.LatestVersionOfResource(inputConfig.ResourceID)
+					versionCandidate, found = db.LatestVersionOfResource(inputConfig.ResourceID, filter)
 				}
 
 				if found {
@@ -38,7 +73,8 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 			}
 
 			if versionCandidates.IsEmpty() {
-				return nil, false
+				failures[inputConfig.Name] = db.resourceFailure(inputConfig.ResourceID, pinnedVersionID, filter)
+				continue
 			}
 		} else {
 			jobs = jobs.Union(inputConfig.Passed)
@@ -46,10 +82,16 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 			versionCandidates = db.VersionsOfResourcePassedJobs(
 				inputConfig.ResourceID,
 				inputConfig.Passed,
+				filter,
 			)
 
+			if pinnedVersionID != 0 {
+				versionCandidates = versionCandidates.ForVersion(pinnedVersionID)
+			}
+
 			if versionCandidates.IsEmpty() {
-				return nil, false
+				failures[inputConfig.Name] = db.passedFailure(inputConfig.ResourceID, inputConfig.Passed, pinnedVersionID, filter)
+				continue
 			}
 		}
 
@@ -62,16 +104,28 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 		inputCandidates = append(inputCandidates, InputVersionCandidates{
 			Input:                 inputConfig.Name,
 			Passed:                inputConfig.Passed,
-			UseEveryVersion:       inputConfig.UseEveryVersion,
-			PinnedVersionID:       inputConfig.PinnedVersionID,
+			UseEveryVersion:       inputConfig.UseEveryVersion && pinnedVersionID == 0,
+			PinnedVersionID:       pinnedVersionID,
 			VersionCandidates:     versionCandidates,
 			ExistingBuildResolver: existingBuildResolver,
 		})
 	}
 
+	if len(failures) > 0 {
+		return nil, failures, false
+	}
+
 	basicMapping, ok := inputCandidates.Reduce(0, jobs)
 	if !ok {
-		return nil, false
+		// each input has candidates on its own, so it's the inputs with passed
+		// constraints that have no versions in common
+		for _, inputConfig := range configs {
+			if len(inputConfig.Passed) > 0 || len(jobs) == 0 {
+				failures[inputConfig.Name] = NoCompatibleVersions
+			}
+		}
+
+		return nil, failures, false
 	}
 
 	mapping := InputMapping{}
@@ -85,5 +139,5 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 		}
 	}
 
-	return mapping, true
+	return mapping, nil, true
 }
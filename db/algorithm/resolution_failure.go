@@ -0,0 +1,105 @@
+package algorithm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ResolutionFailure is why an input could not be resolved to a version.
+type ResolutionFailure string
+
+const (
+	NoVersions               ResolutionFailure = "no versions available"
+	AllVersionsDisabled      ResolutionFailure = "all versions are disabled"
+	PinnedVersionUnavailable ResolutionFailure = "pinned version is not available"
+	PinnedVersionDisabled    ResolutionFailure = "pinned version is disabled"
+	NoCompatibleVersions     ResolutionFailure = "no versions satisfy passed constraints together with the other inputs"
+	NoVersionsMatchFilter    ResolutionFailure = "no versions match the version filter"
+)
+
+// ResolutionFailures maps the names of inputs which could not be resolved to
+// why they could not be.
+type ResolutionFailures map[string]ResolutionFailure
+
+func unknownJobsPassed(jobNames []string) ResolutionFailure {
+	return ResolutionFailure(fmt.Sprintf("passed jobs do not exist: %s", strings.Join(jobNames, ", ")))
+}
+
+func noVersionsPassed(jobNames []string) ResolutionFailure {
+	return ResolutionFailure(fmt.Sprintf("no versions have passed %s", strings.Join(jobNames, ", ")))
+}
+
+func noVersionPassedAll(jobNames []string) ResolutionFailure {
+	return ResolutionFailure(fmt.Sprintf("no version has passed all of %s", strings.Join(jobNames, ", ")))
+}
+
+func pinnedVersionNotPassed(jobNames []string) ResolutionFailure {
+	return ResolutionFailure(fmt.Sprintf("pinned version has not passed all of %s", strings.Join(jobNames, ", ")))
+}
+
+// resourceFailure is why there is no candidate for an input without passed
+// constraints.
+func (db VersionsDB) resourceFailure(resourceID int, pinnedVersionID int, filter VersionFilter) ResolutionFailure {
+	if pinnedVersionID != 0 {
+		if db.IsVersionDisabled(pinnedVersionID) {
+			return PinnedVersionDisabled
+		}
+
+		return PinnedVersionUnavailable
+	}
+
+	if filter != nil && !db.AllVersionsOfResource(resourceID, nil).IsEmpty() {
+		return NoVersionsMatchFilter
+	}
+
+	if db.HasDisabledVersionsOfResource(resourceID) {
+		return AllVersionsDisabled
+	}
+
+	return NoVersions
+}
+
+// passedFailure is why there is no candidate for an input with passed
+// constraints.
+func (db VersionsDB) passedFailure(resourceID int, passed JobSet, pinnedVersionID int, filter VersionFilter) ResolutionFailure {
+	if pinnedVersionID != 0 {
+		if db.IsVersionDisabled(pinnedVersionID) {
+			return PinnedVersionDisabled
+		}
+
+		if !db.VersionsOfResourcePassedJobs(resourceID, passed, nil).IsEmpty() {
+			return pinnedVersionNotPassed(db.jobNames(passed))
+		}
+	}
+
+	if filter != nil && !db.VersionsOfResourcePassedJobs(resourceID, passed, nil).IsEmpty() {
+		return NoVersionsMatchFilter
+	}
+
+	notPassed := JobSet{}
+	for jobID := range passed {
+		if db.VersionsOfResourcePassedJobs(resourceID, JobSet{jobID: struct{}{}}, nil).IsEmpty() {
+			notPassed[jobID] = struct{}{}
+		}
+	}
+
+	if len(notPassed) > 0 {
+		return noVersionsPassed(db.jobNames(notPassed))
+	}
+
+	return noVersionPassedAll(db.jobNames(passed))
+}
+
+func (db VersionsDB) jobNames(jobs JobSet) []string {
+	names := []string{}
+	for name, id := range db.JobIDs {
+		if jobs.Contains(id) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
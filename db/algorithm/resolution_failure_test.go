@@ -0,0 +1,241 @@
+package algorithm_test
+
+import (
+	"context"
+	"github.com/concourse/atc/db/algorithm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resolution failures", func() {
+	var (
+		versionsDB   *algorithm.VersionsDB
+		inputConfigs algorithm.InputConfigs
+
+		failures algorithm.ResolutionFailures
+		ok       bool
+	)
+
+	BeforeEach(func() {
+		versionsDB = &algorithm.VersionsDB{
+			ResourceVersions: []algorithm.ResourceVersion{
+				{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+				{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+				{VersionID: 3, ResourceID: 22, CheckOrder: 1},
+			},
+			BuildOutputs:     []algorithm.BuildOutput{},
+			BuildInputs:      []algorithm.BuildInput{},
+			JobIDs:           map[string]int{"j1": 11, "j2": 12, "j3": 13},
+			ResourceIDs:      map[string]int{"r1": 21, "r2": 22, "r3": 23},
+			PinnedVersionIDs: map[int]int{},
+		}
+	})
+
+	JustBeforeEach(func() {
+		_, failures, ok = inputConfigs.Resolve(context.Background(), versionsDB)
+	})
+
+	output := func(versionID int, resourceID int, buildID int, jobID int) algorithm.BuildOutput {
+		return algorithm.BuildOutput{
+			ResourceVersion: algorithm.ResourceVersion{VersionID: versionID, ResourceID: resourceID, CheckOrder: versionID},
+			BuildID:         buildID,
+			JobID:           jobID,
+		}
+	}
+
+	Context("when every input resolves", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{Name: "some-input", ResourceID: 21, JobID: 11},
+			}
+		})
+
+		It("returns no failures", func() {
+			Expect(ok).To(BeTrue())
+			Expect(failures).To(BeEmpty())
+		})
+	})
+
+	Context("when a resource has no versions", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{Name: "some-input", ResourceID: 21, JobID: 11},
+				{Name: "empty-input", ResourceID: 23, JobID: 11},
+			}
+		})
+
+		It("records it for the input", func() {
+			Expect(ok).To(BeFalse())
+			Expect(failures).To(Equal(algorithm.ResolutionFailures{
+				"empty-input": algorithm.NoVersions,
+			}))
+		})
+
+		Context("because they are all disabled", func() {
+			BeforeEach(func() {
+				versionsDB.DisabledVersions = []algorithm.ResourceVersion{
+					{VersionID: 4, ResourceID: 23, CheckOrder: 1},
+				}
+			})
+
+			It("says so", func() {
+				Expect(failures).To(Equal(algorithm.ResolutionFailures{
+					"empty-input": algorithm.AllVersionsDisabled,
+				}))
+			})
+		})
+	})
+
+	Context("when a pinned version is missing", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{Name: "some-input", ResourceID: 21, JobID: 11, PinnedVersionID: 5},
+			}
+		})
+
+		It("records it for the input", func() {
+			Expect(ok).To(BeFalse())
+			Expect(failures).To(Equal(algorithm.ResolutionFailures{
+				"some-input": algorithm.PinnedVersionUnavailable,
+			}))
+		})
+
+		Context("because it is disabled", func() {
+			BeforeEach(func() {
+				versionsDB.DisabledVersions = []algorithm.ResourceVersion{
+					{VersionID: 5, ResourceID: 21, CheckOrder: 3},
+				}
+			})
+
+			It("says so", func() {
+				Expect(failures).To(Equal(algorithm.ResolutionFailures{
+					"some-input": algorithm.PinnedVersionDisabled,
+				}))
+			})
+		})
+	})
+
+	Context("when no version has passed a job", func() {
+		BeforeEach(func() {
+			versionsDB.BuildOutputs = []algorithm.BuildOutput{
+				output(1, 21, 31, 12),
+			}
+
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "some-input",
+					ResourceID: 21,
+					JobID:      11,
+					Passed:     algorithm.JobSet{12: struct{}{}, 13: struct{}{}},
+				},
+			}
+		})
+
+		It("names the job", func() {
+			Expect(ok).To(BeFalse())
+			Expect(failures).To(Equal(algorithm.ResolutionFailures{
+				"some-input": "no versions have passed j3",
+			}))
+		})
+	})
+
+	Context("when a passed constraint names an unknown job", func() {
+		BeforeEach(func() {
+			versionsDB.BuildOutputs = []algorithm.BuildOutput{
+				output(1, 21, 31, 12),
+			}
+
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:          "some-input",
+					ResourceID:    21,
+					JobID:         11,
+					Passed:        algorithm.JobSet{12: struct{}{}},
+					UnknownPassed: []string{"other-pipeline/some-job"},
+				},
+			}
+		})
+
+		It("names the unknown job", func() {
+			Expect(ok).To(BeFalse())
+			Expect(failures).To(Equal(algorithm.ResolutionFailures{
+				"some-input": "passed jobs do not exist: other-pipeline/some-job",
+			}))
+		})
+	})
+
+	Context("when no single version has passed every job", func() {
+		BeforeEach(func() {
+			versionsDB.BuildOutputs = []algorithm.BuildOutput{
+				output(1, 21, 31, 12),
+				output(2, 21, 32, 13),
+			}
+
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "some-input",
+					ResourceID: 21,
+					JobID:      11,
+					Passed:     algorithm.JobSet{12: struct{}{}, 13: struct{}{}},
+				},
+			}
+		})
+
+		It("names the jobs", func() {
+			Expect(ok).To(BeFalse())
+			Expect(failures).To(Equal(algorithm.ResolutionFailures{
+				"some-input": "no version has passed all of j2, j3",
+			}))
+		})
+
+		Context("when the input is pinned to a version which has passed some", func() {
+			BeforeEach(func() {
+				versionsDB.BuildOutputs = append(versionsDB.BuildOutputs, output(2, 21, 33, 12))
+				inputConfigs[0].PinnedVersionID = 1
+			})
+
+			It("says the pinned version has not passed them", func() {
+				Expect(failures).To(Equal(algorithm.ResolutionFailures{
+					"some-input": "pinned version has not passed all of j2, j3",
+				}))
+			})
+		})
+	})
+
+	Context("when inputs resolve on their own but not together", func() {
+		BeforeEach(func() {
+			versionsDB.BuildOutputs = []algorithm.BuildOutput{
+				output(1, 21, 31, 12),
+				output(3, 22, 32, 12),
+			}
+
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "some-input",
+					ResourceID: 21,
+					JobID:      11,
+					Passed:     algorithm.JobSet{12: struct{}{}},
+				},
+				{
+					Name:       "other-input",
+					ResourceID: 22,
+					JobID:      11,
+					Passed:     algorithm.JobSet{12: struct{}{}},
+				},
+				{
+					Name:       "unconstrained-input",
+					ResourceID: 21,
+					JobID:      11,
+				},
+			}
+		})
+
+		It("records it for the inputs with passed constraints", func() {
+			Expect(ok).To(BeFalse())
+			Expect(failures).To(Equal(algorithm.ResolutionFailures{
+				"some-input":  algorithm.NoCompatibleVersions,
+				"other-input": algorithm.NoCompatibleVersions,
+			}))
+		})
+	})
+})
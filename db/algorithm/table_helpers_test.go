@@ -2,6 +2,7 @@ package algorithm_test
 
 import (
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -14,6 +15,9 @@ type DB struct {
 	BuildInputs  []DBRow
 	BuildOutputs []DBRow
 	Resources    []DBRow
+
+	// resource name to the version pinned on the resource
+	PinnedVersions map[string]string
 }
 
 type DBRow struct {
@@ -140,6 +144,14 @@ func (example Example) Run() {
 		}
 	}
 
+	for resource, version := range example.DB.PinnedVersions {
+		if db.PinnedVersionIDs == nil {
+			db.PinnedVersionIDs = map[int]int{}
+		}
+
+		db.PinnedVersionIDs[resourceIDs.ID(resource)] = versionIDs.ID(version)
+	}
+
 	inputConfigs := make(algorithm.InputConfigs, len(example.Inputs))
 	for i, input := range example.Inputs {
 		passed := algorithm.JobSet{}
@@ -162,7 +174,7 @@ func (example Example) Run() {
 		}
 	}
 
-	resolved, ok := inputConfigs.Resolve(db)
+	resolved, _, ok := inputConfigs.Resolve(context.Background(), db)
 
 	prettyValues := map[string]string{}
 	for name, inputVersion := range resolved {
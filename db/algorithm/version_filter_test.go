@@ -0,0 +1,151 @@
+package algorithm_test
+
+import (
+	"context"
+	"strings"
+
+	"github.com/concourse/atc/db/algorithm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Version filters", func() {
+	var (
+		versionsDB   *algorithm.VersionsDB
+		inputConfigs algorithm.InputConfigs
+
+		mapping  algorithm.InputMapping
+		failures algorithm.ResolutionFailures
+		ok       bool
+	)
+
+	releasesOnly := func(fields map[string]string) bool {
+		return strings.HasPrefix(fields["tag"], "v")
+	}
+
+	BeforeEach(func() {
+		versionsDB = &algorithm.VersionsDB{
+			ResourceVersions: []algorithm.ResourceVersion{
+				{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+				{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+				{VersionID: 3, ResourceID: 21, CheckOrder: 3},
+				{VersionID: 4, ResourceID: 21, CheckOrder: 4},
+			},
+			BuildOutputs: []algorithm.BuildOutput{
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+					BuildID:         31,
+					JobID:           12,
+				},
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 4, ResourceID: 21, CheckOrder: 4},
+					BuildID:         32,
+					JobID:           12,
+				},
+			},
+			BuildInputs:      []algorithm.BuildInput{},
+			JobIDs:           map[string]int{"j1": 11, "j2": 12},
+			ResourceIDs:      map[string]int{"r1": 21},
+			PinnedVersionIDs: map[int]int{},
+			VersionFields: map[int]map[string]string{
+				1: {"tag": "v1.0.0"},
+				2: {"tag": "v1.1.0"},
+				3: {"tag": "nightly-3"},
+				4: {"tag": "nightly-4"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		mapping, failures, ok = inputConfigs.Resolve(context.Background(), versionsDB)
+	})
+
+	Context("when the input uses the latest version", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{Name: "some-input", ResourceID: 21, JobID: 11, VersionFilter: releasesOnly},
+			}
+		})
+
+		It("uses the latest version which the filter allows", func() {
+			Expect(ok).To(BeTrue())
+			Expect(mapping["some-input"].VersionID).To(Equal(2))
+		})
+	})
+
+	Context("when the input uses every version", func() {
+		BeforeEach(func() {
+			versionsDB.BuildInputs = []algorithm.BuildInput{
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+					BuildID:         33,
+					JobID:           11,
+					InputName:       "some-input",
+				},
+			}
+
+			inputConfigs = algorithm.InputConfigs{
+				{Name: "some-input", ResourceID: 21, JobID: 11, UseEveryVersion: true, VersionFilter: releasesOnly},
+			}
+		})
+
+		It("only uses versions which the filter allows", func() {
+			Expect(ok).To(BeTrue())
+			Expect(mapping["some-input"].VersionID).To(Equal(2))
+		})
+	})
+
+	Context("when the input has passed constraints", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{Name: "some-input", ResourceID: 21, JobID: 11, Passed: algorithm.JobSet{12: struct{}{}}, VersionFilter: releasesOnly},
+			}
+		})
+
+		It("uses the latest passed version which the filter allows", func() {
+			Expect(ok).To(BeTrue())
+			Expect(mapping["some-input"].VersionID).To(Equal(2))
+		})
+
+		Context("when no passed version is allowed", func() {
+			BeforeEach(func() {
+				versionsDB.VersionFields[2] = map[string]string{"tag": "nightly-2"}
+			})
+
+			It("says so", func() {
+				Expect(ok).To(BeFalse())
+				Expect(failures).To(Equal(algorithm.ResolutionFailures{
+					"some-input": algorithm.NoVersionsMatchFilter,
+				}))
+			})
+		})
+	})
+
+	Context("when the input is pinned", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{Name: "some-input", ResourceID: 21, JobID: 11, PinnedVersionID: 3, VersionFilter: releasesOnly},
+			}
+		})
+
+		It("uses the pinned version regardless of the filter", func() {
+			Expect(ok).To(BeTrue())
+			Expect(mapping["some-input"].VersionID).To(Equal(3))
+		})
+	})
+
+	Context("when no version is allowed", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{Name: "some-input", ResourceID: 21, JobID: 11, VersionFilter: func(map[string]string) bool { return false }},
+			}
+		})
+
+		It("says so", func() {
+			Expect(ok).To(BeFalse())
+			Expect(failures).To(Equal(algorithm.ResolutionFailures{
+				"some-input": algorithm.NoVersionsMatchFilter,
+			}))
+		})
+	})
+})
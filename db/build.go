@@ -28,7 +28,7 @@ const (
 	BuildStatusErrored   BuildStatus = "errored"
 )
 
-var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.public_plan, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.tracked_by").
+var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.public_plan, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.tracked_by, b.trigger_cause").
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
 	JoinClause("LEFT OUTER JOIN pipelines p ON b.pipeline_id = p.id").
@@ -54,6 +54,7 @@ type Build interface {
 	ReapTime() time.Time
 	Tracker() string
 	IsManuallyTriggered() bool
+	TriggerCause() *atc.TriggerCause
 	IsScheduled() bool
 	IsRunning() bool
 
@@ -105,6 +106,7 @@ type build struct {
 	jobName      string
 
 	isManuallyTriggered bool
+	triggerCause        *atc.TriggerCause
 
 	engine         string
 	engineMetadata string
@@ -141,6 +143,8 @@ func (b *build) Status() BuildStatus          { return b.status }
 func (b *build) Tracker() string              { return b.trackedBy }
 func (b *build) IsScheduled() bool            { return b.scheduled }
 
+func (b *build) TriggerCause() *atc.TriggerCause { return b.triggerCause }
+
 func (b *build) IsRunning() bool {
 	switch b.status {
 	case BuildStatusPending, BuildStatusStarted:
@@ -260,6 +264,17 @@ func (b *build) Start(engine, metadata string, plan atc.Plan) (bool, error) {
 		}
 	}
 
+	err = saveTeamEvent(tx, b.teamID, event.BuildStarted{
+		BuildID:      b.id,
+		BuildName:    b.name,
+		PipelineName: b.pipelineName,
+		JobName:      b.jobName,
+		Time:         startTime.Unix(),
+	})
+	if err != nil {
+		return false, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return false, err
@@ -342,6 +357,11 @@ func (b *build) Finish(status BuildStatus) error {
 			return err
 		}
 
+		err = queueBuildNotification(tx, b.jobID, b.id)
+		if err != nil {
+			return err
+		}
+
 		err = updateTransitionBuildForJob(tx, b.jobID, b.id, status)
 		if err != nil {
 			return err
@@ -358,6 +378,18 @@ func (b *build) Finish(status BuildStatus) error {
 		}
 	}
 
+	err = saveTeamEvent(tx, b.teamID, event.BuildFinished{
+		BuildID:      b.id,
+		BuildName:    b.name,
+		PipelineName: b.pipelineName,
+		JobName:      b.jobName,
+		Status:       atc.BuildStatus(status),
+		Time:         endTime.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return err
@@ -605,84 +637,19 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		return BuildPreparation{}, false, nil
 	}
 
-	configInputs := job.Config().Inputs()
-
-	nextBuildInputs, found, err := job.GetNextBuildInputs()
+	inputResolution, err := job.InputResolution()
 	if err != nil {
 		return BuildPreparation{}, false, err
 	}
 
-	inputsSatisfiedStatus := BuildPreparationStatusBlocking
-	inputs := map[string]BuildPreparationStatus{}
-	missingInputReasons := MissingInputReasons{}
-
-	if found {
-		inputsSatisfiedStatus = BuildPreparationStatusNotBlocking
-		for _, buildInput := range nextBuildInputs {
-			inputs[buildInput.Name] = BuildPreparationStatusNotBlocking
-		}
-	} else {
-		buildInputs, err := job.GetIndependentBuildInputs()
-		if err != nil {
-			return BuildPreparation{}, false, err
-		}
-
-		for _, configInput := range configInputs {
-			found := false
-			for _, buildInput := range buildInputs {
-				if buildInput.Name == configInput.Name {
-					found = true
-					break
-				}
-			}
-			if found {
-				inputs[configInput.Name] = BuildPreparationStatusNotBlocking
-			} else {
-				inputs[configInput.Name] = BuildPreparationStatusBlocking
-				if len(configInput.Passed) > 0 {
-					if configInput.Version != nil && configInput.Version.Pinned != nil {
-						_, found, err := pipeline.GetVersionedResourceByVersion(configInput.Version.Pinned, configInput.Resource)
-						if err != nil {
-							return BuildPreparation{}, false, err
-						}
-
-						if found {
-							missingInputReasons.RegisterPassedConstraint(configInput.Name)
-						} else {
-							versionJSON, err := json.Marshal(configInput.Version.Pinned)
-							if err != nil {
-								return BuildPreparation{}, false, err
-							}
-
-							missingInputReasons.RegisterPinnedVersionUnavailable(configInput.Name, string(versionJSON))
-						}
-					} else {
-						missingInputReasons.RegisterPassedConstraint(configInput.Name)
-					}
-				} else {
-					if configInput.Version != nil && configInput.Version.Pinned != nil {
-						versionJSON, err := json.Marshal(configInput.Version.Pinned)
-						if err != nil {
-							return BuildPreparation{}, false, err
-						}
-
-						missingInputReasons.RegisterPinnedVersionUnavailable(configInput.Name, string(versionJSON))
-					} else {
-						missingInputReasons.RegisterNoVersions(configInput.Name)
-					}
-				}
-			}
-		}
-	}
-
 	buildPreparation := BuildPreparation{
 		BuildID:             b.id,
 		PausedPipeline:      pausedPipelineStatus,
 		PausedJob:           pausedJobStatus,
 		MaxRunningBuilds:    maxInFlightReachedStatus,
-		Inputs:              inputs,
-		InputsSatisfied:     inputsSatisfiedStatus,
-		MissingInputReasons: missingInputReasons,
+		Inputs:              inputResolution.Inputs,
+		InputsSatisfied:     inputResolution.InputsSatisfied,
+		MissingInputReasons: inputResolution.MissingInputReasons,
 	}
 
 	return buildPreparation, true, nil
@@ -1003,12 +970,12 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		jobID, pipelineID                                                    sql.NullInt64
 		engine, engineMetadata, jobName, pipelineName, publicPlan, trackedBy sql.NullString
 		startTime, endTime, reapTime                                         pq.NullTime
-		nonce                                                                sql.NullString
+		nonce, triggerCause                                                  sql.NullString
 
 		status string
 	)
 
-	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &engine, &engineMetadata, &publicPlan, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &trackedBy)
+	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &engine, &engineMetadata, &publicPlan, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &trackedBy, &triggerCause)
 	if err != nil {
 		return err
 	}
@@ -1046,6 +1013,14 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		}
 	}
 
+	b.triggerCause = nil
+	if triggerCause.Valid {
+		err = json.Unmarshal([]byte(triggerCause.String), &b.triggerCause)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1,6 +1,10 @@
 package db
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/concourse/atc/db/algorithm"
+)
 
 type BuildPreparationStatus string
 
@@ -30,6 +34,20 @@ func (mir MissingInputReasons) RegisterPinnedVersionUnavailable(inputName string
 	mir[inputName] = fmt.Sprintf(PinnedVersionUnavailable, version)
 }
 
+func (mir MissingInputReasons) RegisterResolutionFailures(failures algorithm.ResolutionFailures) {
+	for inputName, failure := range failures {
+		mir[inputName] = string(failure)
+	}
+}
+
+// InputResolution is whether each of a job's inputs is blocking its next
+// build, and why.
+type InputResolution struct {
+	Inputs              map[string]BuildPreparationStatus
+	InputsSatisfied     BuildPreparationStatus
+	MissingInputReasons MissingInputReasons
+}
+
 type BuildPreparation struct {
 	BuildID             int
 	PausedPipeline      BuildPreparationStatus
@@ -0,0 +1,164 @@
+package db
+
+import (
+	"sort"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/algorithm"
+)
+
+// crossPipelinePassed returns the jobs in other pipelines of the team which
+// the pipeline's inputs have passed constraints on, as `pipeline/job`. Names
+// of the pipeline's own jobs are never taken to be cross-pipeline.
+func (p *pipeline) crossPipelinePassed(jobs Jobs, jobIDs map[string]int) []string {
+	seen := map[string]bool{}
+	refs := []string{}
+
+	for _, job := range jobs {
+		for _, input := range job.Config().Inputs() {
+			for _, passed := range input.Passed {
+				if _, local := jobIDs[passed]; local || seen[passed] {
+					continue
+				}
+
+				if _, _, ok := atc.ParseCrossPipelinePassed(passed); ok {
+					seen[passed] = true
+					refs = append(refs, passed)
+				}
+			}
+		}
+	}
+
+	sort.Strings(refs)
+
+	return refs
+}
+
+// loadCrossPipelineOutputs adds the jobs to the versions DB under their
+// `pipeline/job` names, along with their outputs of resources which share a
+// resource config with resources of the pipeline. Each output is recorded as
+// the pipeline's own version of the resource, so that the algorithm can treat
+// the jobs like any other.
+func (p *pipeline) loadCrossPipelineOutputs(versionsDB *algorithm.VersionsDB, refs []string) error {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	refConditions := sq.Or{}
+	for _, ref := range refs {
+		pipelineName, jobName, _ := atc.ParseCrossPipelinePassed(ref)
+		refConditions = append(refConditions, sq.Eq{
+			"p.name": pipelineName,
+			"j.name": jobName,
+		})
+	}
+
+	rows, err := psql.Select("p.name, j.name, j.id").
+		From("jobs j").
+		Join("pipelines p ON p.id = j.pipeline_id").
+		Where(sq.Eq{"p.team_id": p.teamID}).
+		Where(refConditions).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	jobIDs := []int{}
+	for rows.Next() {
+		var pipelineName, jobName string
+		var id int
+		err = rows.Scan(&pipelineName, &jobName, &id)
+		if err != nil {
+			return err
+		}
+
+		versionsDB.JobIDs[pipelineName+"/"+jobName] = id
+		jobIDs = append(jobIDs, id)
+	}
+
+	if len(jobIDs) == 0 {
+		return nil
+	}
+
+	// the inputs of succeeded builds are implicit outputs, as they are within
+	// the pipeline
+	for _, table := range []string{"build_outputs", "build_inputs"} {
+		err = p.loadCrossPipelineBuildOutputs(versionsDB, table, jobIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadCrossPipelineBuildOutputs adds the versions recorded in the table for
+// succeeded builds of the jobs as outputs of the pipeline's own resources.
+func (p *pipeline) loadCrossPipelineBuildOutputs(versionsDB *algorithm.VersionsDB, table string, jobIDs []int) error {
+	rows, err := psql.Select("v.id, v.check_order, r.id, o.build_id, b.job_id").
+		From(table + " o").
+		Join("builds b ON b.id = o.build_id").
+		Join("versioned_resources ov ON ov.id = o.versioned_resource_id").
+		Join("resources orr ON orr.id = ov.resource_id").
+		Join("resources r ON r.resource_config_id = orr.resource_config_id").
+		Join("versioned_resources v ON v.resource_id = r.id AND v.type = ov.type AND md5(v.version) = md5(ov.version)").
+		Where(sq.Eq{
+			"b.job_id":      jobIDs,
+			"b.status":      BuildStatusSucceeded,
+			"r.pipeline_id": p.id,
+			"v.enabled":     true,
+		}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var output algorithm.BuildOutput
+		err = rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID, &output.JobID)
+		if err != nil {
+			return err
+		}
+
+		versionsDB.BuildOutputs = append(versionsDB.BuildOutputs, output)
+	}
+
+	return nil
+}
+
+// crossPipelineCacheIndex sums the cache indexes of the pipelines named by
+// the refs, so that the versions DB is reloaded when any of them changes.
+func (p *pipeline) crossPipelineCacheIndex(refs []string) (int, error) {
+	if len(refs) == 0 {
+		return 0, nil
+	}
+
+	pipelineNames := []string{}
+	for _, ref := range refs {
+		pipelineName, _, _ := atc.ParseCrossPipelinePassed(ref)
+		pipelineNames = append(pipelineNames, pipelineName)
+	}
+
+	var cacheIndex int
+	err := psql.Select("COALESCE(SUM(cache_index), 0)").
+		From("pipelines").
+		Where(sq.Eq{
+			"team_id": p.teamID,
+			"name":    pipelineNames,
+		}).
+		RunWith(p.conn).
+		QueryRow().
+		Scan(&cacheIndex)
+	if err != nil {
+		return 0, err
+	}
+
+	return cacheIndex, nil
+}
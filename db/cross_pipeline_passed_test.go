@@ -0,0 +1,167 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/algorithm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cross-pipeline passed constraints", func() {
+	var (
+		upstreamPipeline   db.Pipeline
+		downstreamPipeline db.Pipeline
+
+		upstreamJob   db.Job
+		downstreamJob db.Job
+
+		upstreamVersion   db.SavedVersionedResource
+		downstreamVersion db.SavedVersionedResource
+		downstreamRepo    db.Resource
+	)
+
+	repoConfig := atc.ResourceConfig{
+		Name:   "repo",
+		Type:   "some-base-resource-type",
+		Source: atc.Source{"uri": "some-repo"},
+	}
+
+	savePipeline := func(name string, job atc.JobConfig) (db.Pipeline, db.Job, db.Resource) {
+		pipeline, _, err := defaultTeam.SavePipeline(name, atc.Config{
+			Jobs:      atc.JobConfigs{job},
+			Resources: atc.ResourceConfigs{repoConfig},
+		}, db.ConfigVersion(0), db.PipelineUnpaused)
+		Expect(err).ToNot(HaveOccurred())
+
+		dbJob, found, err := pipeline.Job(job.Name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		resource, found, err := pipeline.Resource(repoConfig.Name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		return pipeline, dbJob, resource
+	}
+
+	saveVersion := func(pipeline db.Pipeline) db.SavedVersionedResource {
+		err := pipeline.SaveResourceVersions(repoConfig, []atc.Version{{"ref": "v1"}})
+		Expect(err).ToNot(HaveOccurred())
+
+		savedVersion, found, err := pipeline.GetLatestVersionedResource(repoConfig.Name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		return savedVersion
+	}
+
+	BeforeEach(func() {
+		var upstreamRepo db.Resource
+		upstreamPipeline, upstreamJob, upstreamRepo = savePipeline("upstream", atc.JobConfig{
+			Name: "build",
+			Plan: atc.PlanSequence{{Get: "repo"}},
+		})
+
+		downstreamPipeline, downstreamJob, downstreamRepo = savePipeline("downstream", atc.JobConfig{
+			Name: "deploy",
+			Plan: atc.PlanSequence{{Get: "repo", Passed: []string{"upstream/build"}}},
+		})
+
+		resourceConfigCheckSession, err := resourceConfigCheckSessionFactory.FindOrCreateResourceConfigCheckSession(
+			logger,
+			repoConfig.Type,
+			repoConfig.Source,
+			creds.VersionedResourceTypes{},
+			db.ContainerOwnerExpiries{
+				GraceTime: 1 * time.Minute,
+				Min:       5 * time.Minute,
+				Max:       5 * time.Minute,
+			},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(upstreamRepo.SetResourceConfig(resourceConfigCheckSession.ResourceConfig().ID)).To(Succeed())
+		Expect(downstreamRepo.SetResourceConfig(resourceConfigCheckSession.ResourceConfig().ID)).To(Succeed())
+
+		upstreamVersion = saveVersion(upstreamPipeline)
+		downstreamVersion = saveVersion(downstreamPipeline)
+	})
+
+	It("loads the other pipeline's job and its outputs as the pipeline's own versions", func() {
+		versions, err := downstreamPipeline.LoadVersionsDB()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(versions.JobIDs).To(Equal(map[string]int{
+			"deploy":         downstreamJob.ID(),
+			"upstream/build": upstreamJob.ID(),
+		}))
+
+		Expect(versions.BuildOutputs).To(BeEmpty())
+
+		By("reloading once the other pipeline's job has succeeded")
+		build, err := upstreamJob.CreateBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = build.SaveOutput(upstreamVersion.VersionedResource)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = build.Finish(db.BuildStatusSucceeded)
+		Expect(err).ToNot(HaveOccurred())
+
+		versions, err = downstreamPipeline.LoadVersionsDB()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(versions.BuildOutputs).To(ConsistOf(algorithm.BuildOutput{
+			ResourceVersion: algorithm.ResourceVersion{
+				VersionID:  downstreamVersion.ID,
+				ResourceID: downstreamRepo.ID(),
+				CheckOrder: downstreamVersion.CheckOrder,
+			},
+			JobID:   upstreamJob.ID(),
+			BuildID: build.ID(),
+		}))
+	})
+
+	It("does not load outputs of failed builds", func() {
+		build, err := upstreamJob.CreateBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = build.SaveOutput(upstreamVersion.VersionedResource)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = build.Finish(db.BuildStatusFailed)
+		Expect(err).ToNot(HaveOccurred())
+
+		versions, err := downstreamPipeline.LoadVersionsDB()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(versions.BuildOutputs).To(BeEmpty())
+	})
+
+	Context("when the resources do not share a resource config", func() {
+		BeforeEach(func() {
+			_, err := dbConn.Exec(`UPDATE resources SET resource_config_id = NULL WHERE id = $1`, downstreamRepo.ID())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("does not load the other pipeline's outputs", func() {
+			build, err := upstreamJob.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.SaveOutput(upstreamVersion.VersionedResource)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			versions, err := downstreamPipeline.LoadVersionsDB()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(versions.BuildOutputs).To(BeEmpty())
+		})
+	})
+})
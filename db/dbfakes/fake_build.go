@@ -441,6 +441,15 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	TriggerCauseStub        func() *atc.TriggerCause
+	triggerCauseMutex       sync.RWMutex
+	triggerCauseArgsForCall []struct{}
+	triggerCauseReturns     struct {
+		result1 *atc.TriggerCause
+	}
+	triggerCauseReturnsOnCall map[int]struct {
+		result1 *atc.TriggerCause
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -2237,6 +2246,46 @@ func (fake *FakeBuild) ScheduleReturnsOnCall(i int, result1 bool, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) TriggerCause() *atc.TriggerCause {
+	fake.triggerCauseMutex.Lock()
+	ret, specificReturn := fake.triggerCauseReturnsOnCall[len(fake.triggerCauseArgsForCall)]
+	fake.triggerCauseArgsForCall = append(fake.triggerCauseArgsForCall, struct{}{})
+	fake.recordInvocation("TriggerCause", []interface{}{})
+	fake.triggerCauseMutex.Unlock()
+	if fake.TriggerCauseStub != nil {
+		return fake.TriggerCauseStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.triggerCauseReturns.result1
+}
+
+func (fake *FakeBuild) TriggerCauseCallCount() int {
+	fake.triggerCauseMutex.RLock()
+	defer fake.triggerCauseMutex.RUnlock()
+	return len(fake.triggerCauseArgsForCall)
+}
+
+func (fake *FakeBuild) TriggerCauseReturns(result1 *atc.TriggerCause) {
+	fake.TriggerCauseStub = nil
+	fake.triggerCauseReturns = struct {
+		result1 *atc.TriggerCause
+	}{result1}
+}
+
+func (fake *FakeBuild) TriggerCauseReturnsOnCall(i int, result1 *atc.TriggerCause) {
+	fake.TriggerCauseStub = nil
+	if fake.triggerCauseReturnsOnCall == nil {
+		fake.triggerCauseReturnsOnCall = make(map[int]struct {
+			result1 *atc.TriggerCause
+		})
+	}
+	fake.triggerCauseReturnsOnCall[i] = struct {
+		result1 *atc.TriggerCause
+	}{result1}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2322,6 +2371,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.abortNotifierMutex.RUnlock()
 	fake.scheduleMutex.RLock()
 	defer fake.scheduleMutex.RUnlock()
+	fake.triggerCauseMutex.RLock()
+	defer fake.triggerCauseMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
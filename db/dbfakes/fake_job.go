@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -308,6 +309,51 @@ type FakeJob struct {
 		result2 bool
 		result3 error
 	}
+	SaveMissingInputReasonsStub        func(db.MissingInputReasons) error
+	saveMissingInputReasonsMutex       sync.RWMutex
+	saveMissingInputReasonsArgsForCall []struct {
+		arg1 db.MissingInputReasons
+	}
+	saveMissingInputReasonsReturns struct {
+		result1 error
+	}
+	saveMissingInputReasonsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	InputResolutionStub        func() (db.InputResolution, error)
+	inputResolutionMutex       sync.RWMutex
+	inputResolutionArgsForCall []struct{}
+	inputResolutionReturns     struct {
+		result1 db.InputResolution
+		result2 error
+	}
+	inputResolutionReturnsOnCall map[int]struct {
+		result1 db.InputResolution
+		result2 error
+	}
+	TriggersEvaluatedAtStub        func() time.Time
+	triggersEvaluatedAtMutex       sync.RWMutex
+	triggersEvaluatedAtArgsForCall []struct{}
+	triggersEvaluatedAtReturns     struct {
+		result1 time.Time
+	}
+	triggersEvaluatedAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	SaveTriggersEvaluatedStub        func(evaluatedAt time.Time, cause *atc.TriggerCause) (bool, error)
+	saveTriggersEvaluatedMutex       sync.RWMutex
+	saveTriggersEvaluatedArgsForCall []struct {
+		evaluatedAt time.Time
+		cause       *atc.TriggerCause
+	}
+	saveTriggersEvaluatedReturns struct {
+		result1 bool
+		result2 error
+	}
+	saveTriggersEvaluatedReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1551,6 +1597,189 @@ func (fake *FakeJob) GetNextPendingBuildBySerialGroupReturnsOnCall(i int, result
 	}{result1, result2, result3}
 }
 
+func (fake *FakeJob) SaveMissingInputReasons(arg1 db.MissingInputReasons) error {
+	fake.saveMissingInputReasonsMutex.Lock()
+	ret, specificReturn := fake.saveMissingInputReasonsReturnsOnCall[len(fake.saveMissingInputReasonsArgsForCall)]
+	fake.saveMissingInputReasonsArgsForCall = append(fake.saveMissingInputReasonsArgsForCall, struct {
+		arg1 db.MissingInputReasons
+	}{arg1})
+	fake.recordInvocation("SaveMissingInputReasons", []interface{}{arg1})
+	fake.saveMissingInputReasonsMutex.Unlock()
+	if fake.SaveMissingInputReasonsStub != nil {
+		return fake.SaveMissingInputReasonsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveMissingInputReasonsReturns.result1
+}
+
+func (fake *FakeJob) SaveMissingInputReasonsCallCount() int {
+	fake.saveMissingInputReasonsMutex.RLock()
+	defer fake.saveMissingInputReasonsMutex.RUnlock()
+	return len(fake.saveMissingInputReasonsArgsForCall)
+}
+
+func (fake *FakeJob) SaveMissingInputReasonsArgsForCall(i int) db.MissingInputReasons {
+	fake.saveMissingInputReasonsMutex.RLock()
+	defer fake.saveMissingInputReasonsMutex.RUnlock()
+	return fake.saveMissingInputReasonsArgsForCall[i].arg1
+}
+
+func (fake *FakeJob) SaveMissingInputReasonsReturns(result1 error) {
+	fake.SaveMissingInputReasonsStub = nil
+	fake.saveMissingInputReasonsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) SaveMissingInputReasonsReturnsOnCall(i int, result1 error) {
+	fake.SaveMissingInputReasonsStub = nil
+	if fake.saveMissingInputReasonsReturnsOnCall == nil {
+		fake.saveMissingInputReasonsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveMissingInputReasonsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeJob) InputResolution() (db.InputResolution, error) {
+	fake.inputResolutionMutex.Lock()
+	ret, specificReturn := fake.inputResolutionReturnsOnCall[len(fake.inputResolutionArgsForCall)]
+	fake.inputResolutionArgsForCall = append(fake.inputResolutionArgsForCall, struct{}{})
+	fake.recordInvocation("InputResolution", []interface{}{})
+	fake.inputResolutionMutex.Unlock()
+	if fake.InputResolutionStub != nil {
+		return fake.InputResolutionStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.inputResolutionReturns.result1, fake.inputResolutionReturns.result2
+}
+
+func (fake *FakeJob) InputResolutionCallCount() int {
+	fake.inputResolutionMutex.RLock()
+	defer fake.inputResolutionMutex.RUnlock()
+	return len(fake.inputResolutionArgsForCall)
+}
+
+func (fake *FakeJob) InputResolutionReturns(result1 db.InputResolution, result2 error) {
+	fake.InputResolutionStub = nil
+	fake.inputResolutionReturns = struct {
+		result1 db.InputResolution
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) InputResolutionReturnsOnCall(i int, result1 db.InputResolution, result2 error) {
+	fake.InputResolutionStub = nil
+	if fake.inputResolutionReturnsOnCall == nil {
+		fake.inputResolutionReturnsOnCall = make(map[int]struct {
+			result1 db.InputResolution
+			result2 error
+		})
+	}
+	fake.inputResolutionReturnsOnCall[i] = struct {
+		result1 db.InputResolution
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) TriggersEvaluatedAt() time.Time {
+	fake.triggersEvaluatedAtMutex.Lock()
+	ret, specificReturn := fake.triggersEvaluatedAtReturnsOnCall[len(fake.triggersEvaluatedAtArgsForCall)]
+	fake.triggersEvaluatedAtArgsForCall = append(fake.triggersEvaluatedAtArgsForCall, struct{}{})
+	fake.recordInvocation("TriggersEvaluatedAt", []interface{}{})
+	fake.triggersEvaluatedAtMutex.Unlock()
+	if fake.TriggersEvaluatedAtStub != nil {
+		return fake.TriggersEvaluatedAtStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.triggersEvaluatedAtReturns.result1
+}
+
+func (fake *FakeJob) TriggersEvaluatedAtCallCount() int {
+	fake.triggersEvaluatedAtMutex.RLock()
+	defer fake.triggersEvaluatedAtMutex.RUnlock()
+	return len(fake.triggersEvaluatedAtArgsForCall)
+}
+
+func (fake *FakeJob) TriggersEvaluatedAtReturns(result1 time.Time) {
+	fake.TriggersEvaluatedAtStub = nil
+	fake.triggersEvaluatedAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeJob) TriggersEvaluatedAtReturnsOnCall(i int, result1 time.Time) {
+	fake.TriggersEvaluatedAtStub = nil
+	if fake.triggersEvaluatedAtReturnsOnCall == nil {
+		fake.triggersEvaluatedAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.triggersEvaluatedAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeJob) SaveTriggersEvaluated(evaluatedAt time.Time, cause *atc.TriggerCause) (bool, error) {
+	fake.saveTriggersEvaluatedMutex.Lock()
+	ret, specificReturn := fake.saveTriggersEvaluatedReturnsOnCall[len(fake.saveTriggersEvaluatedArgsForCall)]
+	fake.saveTriggersEvaluatedArgsForCall = append(fake.saveTriggersEvaluatedArgsForCall, struct {
+		evaluatedAt time.Time
+		cause       *atc.TriggerCause
+	}{evaluatedAt, cause})
+	fake.recordInvocation("SaveTriggersEvaluated", []interface{}{evaluatedAt, cause})
+	fake.saveTriggersEvaluatedMutex.Unlock()
+	if fake.SaveTriggersEvaluatedStub != nil {
+		return fake.SaveTriggersEvaluatedStub(evaluatedAt, cause)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.saveTriggersEvaluatedReturns.result1, fake.saveTriggersEvaluatedReturns.result2
+}
+
+func (fake *FakeJob) SaveTriggersEvaluatedCallCount() int {
+	fake.saveTriggersEvaluatedMutex.RLock()
+	defer fake.saveTriggersEvaluatedMutex.RUnlock()
+	return len(fake.saveTriggersEvaluatedArgsForCall)
+}
+
+func (fake *FakeJob) SaveTriggersEvaluatedArgsForCall(i int) (time.Time, *atc.TriggerCause) {
+	fake.saveTriggersEvaluatedMutex.RLock()
+	defer fake.saveTriggersEvaluatedMutex.RUnlock()
+	return fake.saveTriggersEvaluatedArgsForCall[i].evaluatedAt, fake.saveTriggersEvaluatedArgsForCall[i].cause
+}
+
+func (fake *FakeJob) SaveTriggersEvaluatedReturns(result1 bool, result2 error) {
+	fake.SaveTriggersEvaluatedStub = nil
+	fake.saveTriggersEvaluatedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) SaveTriggersEvaluatedReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.SaveTriggersEvaluatedStub = nil
+	if fake.saveTriggersEvaluatedReturnsOnCall == nil {
+		fake.saveTriggersEvaluatedReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.saveTriggersEvaluatedReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1610,6 +1839,14 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
 	fake.getNextPendingBuildBySerialGroupMutex.RLock()
 	defer fake.getNextPendingBuildBySerialGroupMutex.RUnlock()
+	fake.saveMissingInputReasonsMutex.RLock()
+	defer fake.saveMissingInputReasonsMutex.RUnlock()
+	fake.inputResolutionMutex.RLock()
+	defer fake.inputResolutionMutex.RUnlock()
+	fake.triggersEvaluatedAtMutex.RLock()
+	defer fake.triggersEvaluatedAtMutex.RUnlock()
+	fake.saveTriggersEvaluatedMutex.RLock()
+	defer fake.saveTriggersEvaluatedMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -67,6 +67,21 @@ type FakePipeline struct {
 	configVersionReturnsOnCall map[int]struct {
 		result1 db.ConfigVersion
 	}
+	ConfigAtVersionStub        func(db.ConfigVersion) (db.PipelineConfigVersion, bool, error)
+	configAtVersionMutex       sync.RWMutex
+	configAtVersionArgsForCall []struct {
+		arg1 db.ConfigVersion
+	}
+	configAtVersionReturns struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}
+	configAtVersionReturnsOnCall map[int]struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}
 	PublicStub        func() bool
 	publicMutex       sync.RWMutex
 	publicArgsForCall []struct{}
@@ -525,6 +540,34 @@ type FakePipeline struct {
 		result1 db.Build
 		result2 error
 	}
+	ConfigStub        func() (atc.Config, bool, error)
+	configMutex       sync.RWMutex
+	configArgsForCall []struct{}
+	configReturns     struct {
+		result1 atc.Config
+		result2 bool
+		result3 error
+	}
+	configReturnsOnCall map[int]struct {
+		result1 atc.Config
+		result2 bool
+		result3 error
+	}
+	ConfigHistoryStub        func(db.Page) ([]db.PipelineConfigVersion, db.Pagination, error)
+	configHistoryMutex       sync.RWMutex
+	configHistoryArgsForCall []struct {
+		arg1 db.Page
+	}
+	configHistoryReturns struct {
+		result1 []db.PipelineConfigVersion
+		result2 db.Pagination
+		result3 error
+	}
+	configHistoryReturnsOnCall map[int]struct {
+		result1 []db.PipelineConfigVersion
+		result2 db.Pagination
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -769,6 +812,60 @@ func (fake *FakePipeline) ConfigVersionReturnsOnCall(i int, result1 db.ConfigVer
 	}{result1}
 }
 
+func (fake *FakePipeline) ConfigAtVersion(arg1 db.ConfigVersion) (db.PipelineConfigVersion, bool, error) {
+	fake.configAtVersionMutex.Lock()
+	ret, specificReturn := fake.configAtVersionReturnsOnCall[len(fake.configAtVersionArgsForCall)]
+	fake.configAtVersionArgsForCall = append(fake.configAtVersionArgsForCall, struct {
+		arg1 db.ConfigVersion
+	}{arg1})
+	fake.recordInvocation("ConfigAtVersion", []interface{}{arg1})
+	fake.configAtVersionMutex.Unlock()
+	if fake.ConfigAtVersionStub != nil {
+		return fake.ConfigAtVersionStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.configAtVersionReturns.result1, fake.configAtVersionReturns.result2, fake.configAtVersionReturns.result3
+}
+
+func (fake *FakePipeline) ConfigAtVersionCallCount() int {
+	fake.configAtVersionMutex.RLock()
+	defer fake.configAtVersionMutex.RUnlock()
+	return len(fake.configAtVersionArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigAtVersionArgsForCall(i int) db.ConfigVersion {
+	fake.configAtVersionMutex.RLock()
+	defer fake.configAtVersionMutex.RUnlock()
+	return fake.configAtVersionArgsForCall[i].arg1
+}
+
+func (fake *FakePipeline) ConfigAtVersionReturns(result1 db.PipelineConfigVersion, result2 bool, result3 error) {
+	fake.ConfigAtVersionStub = nil
+	fake.configAtVersionReturns = struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) ConfigAtVersionReturnsOnCall(i int, result1 db.PipelineConfigVersion, result2 bool, result3 error) {
+	fake.ConfigAtVersionStub = nil
+	if fake.configAtVersionReturnsOnCall == nil {
+		fake.configAtVersionReturnsOnCall = make(map[int]struct {
+			result1 db.PipelineConfigVersion
+			result2 bool
+			result3 error
+		})
+	}
+	fake.configAtVersionReturnsOnCall[i] = struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakePipeline) Public() bool {
 	fake.publicMutex.Lock()
 	ret, specificReturn := fake.publicReturnsOnCall[len(fake.publicArgsForCall)]
@@ -2545,6 +2642,106 @@ func (fake *FakePipeline) CreateOneOffBuildReturnsOnCall(i int, result1 db.Build
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) Config() (atc.Config, bool, error) {
+	fake.configMutex.Lock()
+	ret, specificReturn := fake.configReturnsOnCall[len(fake.configArgsForCall)]
+	fake.configArgsForCall = append(fake.configArgsForCall, struct{}{})
+	fake.recordInvocation("Config", []interface{}{})
+	fake.configMutex.Unlock()
+	if fake.ConfigStub != nil {
+		return fake.ConfigStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.configReturns.result1, fake.configReturns.result2, fake.configReturns.result3
+}
+
+func (fake *FakePipeline) ConfigCallCount() int {
+	fake.configMutex.RLock()
+	defer fake.configMutex.RUnlock()
+	return len(fake.configArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigReturns(result1 atc.Config, result2 bool, result3 error) {
+	fake.ConfigStub = nil
+	fake.configReturns = struct {
+		result1 atc.Config
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) ConfigReturnsOnCall(i int, result1 atc.Config, result2 bool, result3 error) {
+	fake.ConfigStub = nil
+	if fake.configReturnsOnCall == nil {
+		fake.configReturnsOnCall = make(map[int]struct {
+			result1 atc.Config
+			result2 bool
+			result3 error
+		})
+	}
+	fake.configReturnsOnCall[i] = struct {
+		result1 atc.Config
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) ConfigHistory(arg1 db.Page) ([]db.PipelineConfigVersion, db.Pagination, error) {
+	fake.configHistoryMutex.Lock()
+	ret, specificReturn := fake.configHistoryReturnsOnCall[len(fake.configHistoryArgsForCall)]
+	fake.configHistoryArgsForCall = append(fake.configHistoryArgsForCall, struct {
+		arg1 db.Page
+	}{arg1})
+	fake.recordInvocation("ConfigHistory", []interface{}{arg1})
+	fake.configHistoryMutex.Unlock()
+	if fake.ConfigHistoryStub != nil {
+		return fake.ConfigHistoryStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.configHistoryReturns.result1, fake.configHistoryReturns.result2, fake.configHistoryReturns.result3
+}
+
+func (fake *FakePipeline) ConfigHistoryCallCount() int {
+	fake.configHistoryMutex.RLock()
+	defer fake.configHistoryMutex.RUnlock()
+	return len(fake.configHistoryArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigHistoryArgsForCall(i int) db.Page {
+	fake.configHistoryMutex.RLock()
+	defer fake.configHistoryMutex.RUnlock()
+	return fake.configHistoryArgsForCall[i].arg1
+}
+
+func (fake *FakePipeline) ConfigHistoryReturns(result1 []db.PipelineConfigVersion, result2 db.Pagination, result3 error) {
+	fake.ConfigHistoryStub = nil
+	fake.configHistoryReturns = struct {
+		result1 []db.PipelineConfigVersion
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) ConfigHistoryReturnsOnCall(i int, result1 []db.PipelineConfigVersion, result2 db.Pagination, result3 error) {
+	fake.ConfigHistoryStub = nil
+	if fake.configHistoryReturnsOnCall == nil {
+		fake.configHistoryReturnsOnCall = make(map[int]struct {
+			result1 []db.PipelineConfigVersion
+			result2 db.Pagination
+			result3 error
+		})
+	}
+	fake.configHistoryReturnsOnCall[i] = struct {
+		result1 []db.PipelineConfigVersion
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2560,6 +2757,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.groupsMutex.RUnlock()
 	fake.configVersionMutex.RLock()
 	defer fake.configVersionMutex.RUnlock()
+	fake.configAtVersionMutex.RLock()
+	defer fake.configAtVersionMutex.RUnlock()
 	fake.publicMutex.RLock()
 	defer fake.publicMutex.RUnlock()
 	fake.pausedMutex.RLock()
@@ -2634,6 +2833,10 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.renameMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
 	defer fake.createOneOffBuildMutex.RUnlock()
+	fake.configMutex.RLock()
+	defer fake.configMutex.RUnlock()
+	fake.configHistoryMutex.RLock()
+	defer fake.configHistoryMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
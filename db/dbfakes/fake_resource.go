@@ -127,6 +127,33 @@ type FakeResource struct {
 	failingToCheckReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	PinnedVersionIDStub        func() int
+	pinnedVersionIDMutex       sync.RWMutex
+	pinnedVersionIDArgsForCall []struct{}
+	pinnedVersionIDReturns     struct {
+		result1 int
+	}
+	pinnedVersionIDReturnsOnCall map[int]struct {
+		result1 int
+	}
+	PinnedVersionStub        func() atc.Version
+	pinnedVersionMutex       sync.RWMutex
+	pinnedVersionArgsForCall []struct{}
+	pinnedVersionReturns     struct {
+		result1 atc.Version
+	}
+	pinnedVersionReturnsOnCall map[int]struct {
+		result1 atc.Version
+	}
+	PinCommentStub        func() string
+	pinCommentMutex       sync.RWMutex
+	pinCommentArgsForCall []struct{}
+	pinCommentReturns     struct {
+		result1 string
+	}
+	pinCommentReturnsOnCall map[int]struct {
+		result1 string
+	}
 	SetResourceConfigStub        func(int) error
 	setResourceConfigMutex       sync.RWMutex
 	setResourceConfigArgsForCall []struct {
@@ -156,6 +183,29 @@ type FakeResource struct {
 	unpauseReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PinVersionStub        func(versionedResourceID int, comment string) (bool, error)
+	pinVersionMutex       sync.RWMutex
+	pinVersionArgsForCall []struct {
+		versionedResourceID int
+		comment             string
+	}
+	pinVersionReturns struct {
+		result1 bool
+		result2 error
+	}
+	pinVersionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	UnpinVersionStub        func() error
+	unpinVersionMutex       sync.RWMutex
+	unpinVersionArgsForCall []struct{}
+	unpinVersionReturns     struct {
+		result1 error
+	}
+	unpinVersionReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct{}
@@ -691,6 +741,126 @@ func (fake *FakeResource) FailingToCheckReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeResource) PinnedVersionID() int {
+	fake.pinnedVersionIDMutex.Lock()
+	ret, specificReturn := fake.pinnedVersionIDReturnsOnCall[len(fake.pinnedVersionIDArgsForCall)]
+	fake.pinnedVersionIDArgsForCall = append(fake.pinnedVersionIDArgsForCall, struct{}{})
+	fake.recordInvocation("PinnedVersionID", []interface{}{})
+	fake.pinnedVersionIDMutex.Unlock()
+	if fake.PinnedVersionIDStub != nil {
+		return fake.PinnedVersionIDStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.pinnedVersionIDReturns.result1
+}
+
+func (fake *FakeResource) PinnedVersionIDCallCount() int {
+	fake.pinnedVersionIDMutex.RLock()
+	defer fake.pinnedVersionIDMutex.RUnlock()
+	return len(fake.pinnedVersionIDArgsForCall)
+}
+
+func (fake *FakeResource) PinnedVersionIDReturns(result1 int) {
+	fake.PinnedVersionIDStub = nil
+	fake.pinnedVersionIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeResource) PinnedVersionIDReturnsOnCall(i int, result1 int) {
+	fake.PinnedVersionIDStub = nil
+	if fake.pinnedVersionIDReturnsOnCall == nil {
+		fake.pinnedVersionIDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.pinnedVersionIDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeResource) PinnedVersion() atc.Version {
+	fake.pinnedVersionMutex.Lock()
+	ret, specificReturn := fake.pinnedVersionReturnsOnCall[len(fake.pinnedVersionArgsForCall)]
+	fake.pinnedVersionArgsForCall = append(fake.pinnedVersionArgsForCall, struct{}{})
+	fake.recordInvocation("PinnedVersion", []interface{}{})
+	fake.pinnedVersionMutex.Unlock()
+	if fake.PinnedVersionStub != nil {
+		return fake.PinnedVersionStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.pinnedVersionReturns.result1
+}
+
+func (fake *FakeResource) PinnedVersionCallCount() int {
+	fake.pinnedVersionMutex.RLock()
+	defer fake.pinnedVersionMutex.RUnlock()
+	return len(fake.pinnedVersionArgsForCall)
+}
+
+func (fake *FakeResource) PinnedVersionReturns(result1 atc.Version) {
+	fake.PinnedVersionStub = nil
+	fake.pinnedVersionReturns = struct {
+		result1 atc.Version
+	}{result1}
+}
+
+func (fake *FakeResource) PinnedVersionReturnsOnCall(i int, result1 atc.Version) {
+	fake.PinnedVersionStub = nil
+	if fake.pinnedVersionReturnsOnCall == nil {
+		fake.pinnedVersionReturnsOnCall = make(map[int]struct {
+			result1 atc.Version
+		})
+	}
+	fake.pinnedVersionReturnsOnCall[i] = struct {
+		result1 atc.Version
+	}{result1}
+}
+
+func (fake *FakeResource) PinComment() string {
+	fake.pinCommentMutex.Lock()
+	ret, specificReturn := fake.pinCommentReturnsOnCall[len(fake.pinCommentArgsForCall)]
+	fake.pinCommentArgsForCall = append(fake.pinCommentArgsForCall, struct{}{})
+	fake.recordInvocation("PinComment", []interface{}{})
+	fake.pinCommentMutex.Unlock()
+	if fake.PinCommentStub != nil {
+		return fake.PinCommentStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.pinCommentReturns.result1
+}
+
+func (fake *FakeResource) PinCommentCallCount() int {
+	fake.pinCommentMutex.RLock()
+	defer fake.pinCommentMutex.RUnlock()
+	return len(fake.pinCommentArgsForCall)
+}
+
+func (fake *FakeResource) PinCommentReturns(result1 string) {
+	fake.PinCommentStub = nil
+	fake.pinCommentReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResource) PinCommentReturnsOnCall(i int, result1 string) {
+	fake.PinCommentStub = nil
+	if fake.pinCommentReturnsOnCall == nil {
+		fake.pinCommentReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.pinCommentReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeResource) SetResourceConfig(arg1 int) error {
 	fake.setResourceConfigMutex.Lock()
 	ret, specificReturn := fake.setResourceConfigReturnsOnCall[len(fake.setResourceConfigArgsForCall)]
@@ -819,6 +989,98 @@ func (fake *FakeResource) UnpauseReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeResource) PinVersion(versionedResourceID int, comment string) (bool, error) {
+	fake.pinVersionMutex.Lock()
+	ret, specificReturn := fake.pinVersionReturnsOnCall[len(fake.pinVersionArgsForCall)]
+	fake.pinVersionArgsForCall = append(fake.pinVersionArgsForCall, struct {
+		versionedResourceID int
+		comment             string
+	}{versionedResourceID, comment})
+	fake.recordInvocation("PinVersion", []interface{}{versionedResourceID, comment})
+	fake.pinVersionMutex.Unlock()
+	if fake.PinVersionStub != nil {
+		return fake.PinVersionStub(versionedResourceID, comment)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.pinVersionReturns.result1, fake.pinVersionReturns.result2
+}
+
+func (fake *FakeResource) PinVersionCallCount() int {
+	fake.pinVersionMutex.RLock()
+	defer fake.pinVersionMutex.RUnlock()
+	return len(fake.pinVersionArgsForCall)
+}
+
+func (fake *FakeResource) PinVersionArgsForCall(i int) (int, string) {
+	fake.pinVersionMutex.RLock()
+	defer fake.pinVersionMutex.RUnlock()
+	return fake.pinVersionArgsForCall[i].versionedResourceID, fake.pinVersionArgsForCall[i].comment
+}
+
+func (fake *FakeResource) PinVersionReturns(result1 bool, result2 error) {
+	fake.PinVersionStub = nil
+	fake.pinVersionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) PinVersionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.PinVersionStub = nil
+	if fake.pinVersionReturnsOnCall == nil {
+		fake.pinVersionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.pinVersionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) UnpinVersion() error {
+	fake.unpinVersionMutex.Lock()
+	ret, specificReturn := fake.unpinVersionReturnsOnCall[len(fake.unpinVersionArgsForCall)]
+	fake.unpinVersionArgsForCall = append(fake.unpinVersionArgsForCall, struct{}{})
+	fake.recordInvocation("UnpinVersion", []interface{}{})
+	fake.unpinVersionMutex.Unlock()
+	if fake.UnpinVersionStub != nil {
+		return fake.UnpinVersionStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.unpinVersionReturns.result1
+}
+
+func (fake *FakeResource) UnpinVersionCallCount() int {
+	fake.unpinVersionMutex.RLock()
+	defer fake.unpinVersionMutex.RUnlock()
+	return len(fake.unpinVersionArgsForCall)
+}
+
+func (fake *FakeResource) UnpinVersionReturns(result1 error) {
+	fake.UnpinVersionStub = nil
+	fake.unpinVersionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) UnpinVersionReturnsOnCall(i int, result1 error) {
+	fake.UnpinVersionStub = nil
+	if fake.unpinVersionReturnsOnCall == nil {
+		fake.unpinVersionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unpinVersionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResource) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -891,12 +1153,22 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.webhookTokenMutex.RUnlock()
 	fake.failingToCheckMutex.RLock()
 	defer fake.failingToCheckMutex.RUnlock()
+	fake.pinnedVersionIDMutex.RLock()
+	defer fake.pinnedVersionIDMutex.RUnlock()
+	fake.pinnedVersionMutex.RLock()
+	defer fake.pinnedVersionMutex.RUnlock()
+	fake.pinCommentMutex.RLock()
+	defer fake.pinCommentMutex.RUnlock()
 	fake.setResourceConfigMutex.RLock()
 	defer fake.setResourceConfigMutex.RUnlock()
 	fake.pauseMutex.RLock()
 	defer fake.pauseMutex.RUnlock()
 	fake.unpauseMutex.RLock()
 	defer fake.unpauseMutex.RUnlock()
+	fake.pinVersionMutex.RLock()
+	defer fake.pinVersionMutex.RUnlock()
+	fake.unpinVersionMutex.RLock()
+	defer fake.unpinVersionMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -39,14 +39,23 @@ type FakeTeam struct {
 	adminReturnsOnCall map[int]struct {
 		result1 bool
 	}
-	AuthStub        func() map[string][]string
+	AuthStub        func() atc.TeamAuth
 	authMutex       sync.RWMutex
 	authArgsForCall []struct{}
 	authReturns     struct {
-		result1 map[string][]string
+		result1 atc.TeamAuth
 	}
 	authReturnsOnCall map[int]struct {
-		result1 map[string][]string
+		result1 atc.TeamAuth
+	}
+	RedactSecretsStub        func() bool
+	redactSecretsMutex       sync.RWMutex
+	redactSecretsArgsForCall []struct{}
+	redactSecretsReturns     struct {
+		result1 bool
+	}
+	redactSecretsReturnsOnCall map[int]struct {
+		result1 bool
 	}
 	DeleteStub        func() error
 	deleteMutex       sync.RWMutex
@@ -86,6 +95,25 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	SavePipelineWithAuthorStub        func(author string, pipelineName string, config atc.Config, from db.ConfigVersion, pausedState db.PipelinePausedState) (db.Pipeline, bool, error)
+	savePipelineWithAuthorMutex       sync.RWMutex
+	savePipelineWithAuthorArgsForCall []struct {
+		author       string
+		pipelineName string
+		config       atc.Config
+		from         db.ConfigVersion
+		pausedState  db.PipelinePausedState
+	}
+	savePipelineWithAuthorReturns struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}
+	savePipelineWithAuthorReturnsOnCall map[int]struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}
 	PipelineStub        func(pipelineName string) (db.Pipeline, bool, error)
 	pipelineMutex       sync.RWMutex
 	pipelineArgsForCall []struct {
@@ -331,10 +359,10 @@ type FakeTeam struct {
 		result1 db.CreatingContainer
 		result2 error
 	}
-	UpdateProviderAuthStub        func(auth map[string][]string) error
+	UpdateProviderAuthStub        func(auth atc.TeamAuth) error
 	updateProviderAuthMutex       sync.RWMutex
 	updateProviderAuthArgsForCall []struct {
-		auth map[string][]string
+		auth atc.TeamAuth
 	}
 	updateProviderAuthReturns struct {
 		result1 error
@@ -342,6 +370,55 @@ type FakeTeam struct {
 	updateProviderAuthReturnsOnCall map[int]struct {
 		result1 error
 	}
+	NotificationDeliveriesStub        func(limit int) ([]db.NotificationDelivery, error)
+	notificationDeliveriesMutex       sync.RWMutex
+	notificationDeliveriesArgsForCall []struct {
+		limit int
+	}
+	notificationDeliveriesReturns struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}
+	notificationDeliveriesReturnsOnCall map[int]struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}
+	EventsStub        func(cursor int) (db.TeamEventSource, error)
+	eventsMutex       sync.RWMutex
+	eventsArgsForCall []struct {
+		cursor int
+	}
+	eventsReturns struct {
+		result1 db.TeamEventSource
+		result2 error
+	}
+	eventsReturnsOnCall map[int]struct {
+		result1 db.TeamEventSource
+		result2 error
+	}
+	LatestEventIDStub        func() (int, error)
+	latestEventIDMutex       sync.RWMutex
+	latestEventIDArgsForCall []struct{}
+	latestEventIDReturns     struct {
+		result1 int
+		result2 error
+	}
+	latestEventIDReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	UpdateSettingsStub        func(auth atc.TeamAuth, redactSecrets *bool) error
+	updateSettingsMutex       sync.RWMutex
+	updateSettingsArgsForCall []struct {
+		auth          atc.TeamAuth
+		redactSecrets *bool
+	}
+	updateSettingsReturns struct {
+		result1 error
+	}
+	updateSettingsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -466,7 +543,7 @@ func (fake *FakeTeam) AdminReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
-func (fake *FakeTeam) Auth() map[string][]string {
+func (fake *FakeTeam) Auth() atc.TeamAuth {
 	fake.authMutex.Lock()
 	ret, specificReturn := fake.authReturnsOnCall[len(fake.authArgsForCall)]
 	fake.authArgsForCall = append(fake.authArgsForCall, struct{}{})
@@ -487,22 +564,62 @@ func (fake *FakeTeam) AuthCallCount() int {
 	return len(fake.authArgsForCall)
 }
 
-func (fake *FakeTeam) AuthReturns(result1 map[string][]string) {
+func (fake *FakeTeam) AuthReturns(result1 atc.TeamAuth) {
 	fake.AuthStub = nil
 	fake.authReturns = struct {
-		result1 map[string][]string
+		result1 atc.TeamAuth
 	}{result1}
 }
 
-func (fake *FakeTeam) AuthReturnsOnCall(i int, result1 map[string][]string) {
+func (fake *FakeTeam) AuthReturnsOnCall(i int, result1 atc.TeamAuth) {
 	fake.AuthStub = nil
 	if fake.authReturnsOnCall == nil {
 		fake.authReturnsOnCall = make(map[int]struct {
-			result1 map[string][]string
+			result1 atc.TeamAuth
 		})
 	}
 	fake.authReturnsOnCall[i] = struct {
-		result1 map[string][]string
+		result1 atc.TeamAuth
+	}{result1}
+}
+
+func (fake *FakeTeam) RedactSecrets() bool {
+	fake.redactSecretsMutex.Lock()
+	ret, specificReturn := fake.redactSecretsReturnsOnCall[len(fake.redactSecretsArgsForCall)]
+	fake.redactSecretsArgsForCall = append(fake.redactSecretsArgsForCall, struct{}{})
+	fake.recordInvocation("RedactSecrets", []interface{}{})
+	fake.redactSecretsMutex.Unlock()
+	if fake.RedactSecretsStub != nil {
+		return fake.RedactSecretsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.redactSecretsReturns.result1
+}
+
+func (fake *FakeTeam) RedactSecretsCallCount() int {
+	fake.redactSecretsMutex.RLock()
+	defer fake.redactSecretsMutex.RUnlock()
+	return len(fake.redactSecretsArgsForCall)
+}
+
+func (fake *FakeTeam) RedactSecretsReturns(result1 bool) {
+	fake.RedactSecretsStub = nil
+	fake.redactSecretsReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeTeam) RedactSecretsReturnsOnCall(i int, result1 bool) {
+	fake.RedactSecretsStub = nil
+	if fake.redactSecretsReturnsOnCall == nil {
+		fake.redactSecretsReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.redactSecretsReturnsOnCall[i] = struct {
+		result1 bool
 	}{result1}
 }
 
@@ -651,6 +768,64 @@ func (fake *FakeTeam) SavePipelineReturnsOnCall(i int, result1 db.Pipeline, resu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) SavePipelineWithAuthor(author string, pipelineName string, config atc.Config, from db.ConfigVersion, pausedState db.PipelinePausedState) (db.Pipeline, bool, error) {
+	fake.savePipelineWithAuthorMutex.Lock()
+	ret, specificReturn := fake.savePipelineWithAuthorReturnsOnCall[len(fake.savePipelineWithAuthorArgsForCall)]
+	fake.savePipelineWithAuthorArgsForCall = append(fake.savePipelineWithAuthorArgsForCall, struct {
+		author       string
+		pipelineName string
+		config       atc.Config
+		from         db.ConfigVersion
+		pausedState  db.PipelinePausedState
+	}{author, pipelineName, config, from, pausedState})
+	fake.recordInvocation("SavePipelineWithAuthor", []interface{}{author, pipelineName, config, from, pausedState})
+	fake.savePipelineWithAuthorMutex.Unlock()
+	if fake.SavePipelineWithAuthorStub != nil {
+		return fake.SavePipelineWithAuthorStub(author, pipelineName, config, from, pausedState)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.savePipelineWithAuthorReturns.result1, fake.savePipelineWithAuthorReturns.result2, fake.savePipelineWithAuthorReturns.result3
+}
+
+func (fake *FakeTeam) SavePipelineWithAuthorCallCount() int {
+	fake.savePipelineWithAuthorMutex.RLock()
+	defer fake.savePipelineWithAuthorMutex.RUnlock()
+	return len(fake.savePipelineWithAuthorArgsForCall)
+}
+
+func (fake *FakeTeam) SavePipelineWithAuthorArgsForCall(i int) (string, string, atc.Config, db.ConfigVersion, db.PipelinePausedState) {
+	fake.savePipelineWithAuthorMutex.RLock()
+	defer fake.savePipelineWithAuthorMutex.RUnlock()
+	return fake.savePipelineWithAuthorArgsForCall[i].author, fake.savePipelineWithAuthorArgsForCall[i].pipelineName, fake.savePipelineWithAuthorArgsForCall[i].config, fake.savePipelineWithAuthorArgsForCall[i].from, fake.savePipelineWithAuthorArgsForCall[i].pausedState
+}
+
+func (fake *FakeTeam) SavePipelineWithAuthorReturns(result1 db.Pipeline, result2 bool, result3 error) {
+	fake.SavePipelineWithAuthorStub = nil
+	fake.savePipelineWithAuthorReturns = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SavePipelineWithAuthorReturnsOnCall(i int, result1 db.Pipeline, result2 bool, result3 error) {
+	fake.SavePipelineWithAuthorStub = nil
+	if fake.savePipelineWithAuthorReturnsOnCall == nil {
+		fake.savePipelineWithAuthorReturnsOnCall = make(map[int]struct {
+			result1 db.Pipeline
+			result2 bool
+			result3 error
+		})
+	}
+	fake.savePipelineWithAuthorReturnsOnCall[i] = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) Pipeline(pipelineName string) (db.Pipeline, bool, error) {
 	fake.pipelineMutex.Lock()
 	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
@@ -1562,11 +1737,11 @@ func (fake *FakeTeam) CreateContainerReturnsOnCall(i int, result1 db.CreatingCon
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) UpdateProviderAuth(auth map[string][]string) error {
+func (fake *FakeTeam) UpdateProviderAuth(auth atc.TeamAuth) error {
 	fake.updateProviderAuthMutex.Lock()
 	ret, specificReturn := fake.updateProviderAuthReturnsOnCall[len(fake.updateProviderAuthArgsForCall)]
 	fake.updateProviderAuthArgsForCall = append(fake.updateProviderAuthArgsForCall, struct {
-		auth map[string][]string
+		auth atc.TeamAuth
 	}{auth})
 	fake.recordInvocation("UpdateProviderAuth", []interface{}{auth})
 	fake.updateProviderAuthMutex.Unlock()
@@ -1585,7 +1760,7 @@ func (fake *FakeTeam) UpdateProviderAuthCallCount() int {
 	return len(fake.updateProviderAuthArgsForCall)
 }
 
-func (fake *FakeTeam) UpdateProviderAuthArgsForCall(i int) map[string][]string {
+func (fake *FakeTeam) UpdateProviderAuthArgsForCall(i int) atc.TeamAuth {
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
 	return fake.updateProviderAuthArgsForCall[i].auth
@@ -1610,6 +1785,200 @@ func (fake *FakeTeam) UpdateProviderAuthReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) NotificationDeliveries(limit int) ([]db.NotificationDelivery, error) {
+	fake.notificationDeliveriesMutex.Lock()
+	ret, specificReturn := fake.notificationDeliveriesReturnsOnCall[len(fake.notificationDeliveriesArgsForCall)]
+	fake.notificationDeliveriesArgsForCall = append(fake.notificationDeliveriesArgsForCall, struct {
+		limit int
+	}{limit})
+	fake.recordInvocation("NotificationDeliveries", []interface{}{limit})
+	fake.notificationDeliveriesMutex.Unlock()
+	if fake.NotificationDeliveriesStub != nil {
+		return fake.NotificationDeliveriesStub(limit)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.notificationDeliveriesReturns.result1, fake.notificationDeliveriesReturns.result2
+}
+
+func (fake *FakeTeam) NotificationDeliveriesCallCount() int {
+	fake.notificationDeliveriesMutex.RLock()
+	defer fake.notificationDeliveriesMutex.RUnlock()
+	return len(fake.notificationDeliveriesArgsForCall)
+}
+
+func (fake *FakeTeam) NotificationDeliveriesArgsForCall(i int) int {
+	fake.notificationDeliveriesMutex.RLock()
+	defer fake.notificationDeliveriesMutex.RUnlock()
+	return fake.notificationDeliveriesArgsForCall[i].limit
+}
+
+func (fake *FakeTeam) NotificationDeliveriesReturns(result1 []db.NotificationDelivery, result2 error) {
+	fake.NotificationDeliveriesStub = nil
+	fake.notificationDeliveriesReturns = struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) NotificationDeliveriesReturnsOnCall(i int, result1 []db.NotificationDelivery, result2 error) {
+	fake.NotificationDeliveriesStub = nil
+	if fake.notificationDeliveriesReturnsOnCall == nil {
+		fake.notificationDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []db.NotificationDelivery
+			result2 error
+		})
+	}
+	fake.notificationDeliveriesReturnsOnCall[i] = struct {
+		result1 []db.NotificationDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) Events(cursor int) (db.TeamEventSource, error) {
+	fake.eventsMutex.Lock()
+	ret, specificReturn := fake.eventsReturnsOnCall[len(fake.eventsArgsForCall)]
+	fake.eventsArgsForCall = append(fake.eventsArgsForCall, struct {
+		cursor int
+	}{cursor})
+	fake.recordInvocation("Events", []interface{}{cursor})
+	fake.eventsMutex.Unlock()
+	if fake.EventsStub != nil {
+		return fake.EventsStub(cursor)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.eventsReturns.result1, fake.eventsReturns.result2
+}
+
+func (fake *FakeTeam) EventsCallCount() int {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return len(fake.eventsArgsForCall)
+}
+
+func (fake *FakeTeam) EventsArgsForCall(i int) int {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return fake.eventsArgsForCall[i].cursor
+}
+
+func (fake *FakeTeam) EventsReturns(result1 db.TeamEventSource, result2 error) {
+	fake.EventsStub = nil
+	fake.eventsReturns = struct {
+		result1 db.TeamEventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) EventsReturnsOnCall(i int, result1 db.TeamEventSource, result2 error) {
+	fake.EventsStub = nil
+	if fake.eventsReturnsOnCall == nil {
+		fake.eventsReturnsOnCall = make(map[int]struct {
+			result1 db.TeamEventSource
+			result2 error
+		})
+	}
+	fake.eventsReturnsOnCall[i] = struct {
+		result1 db.TeamEventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) LatestEventID() (int, error) {
+	fake.latestEventIDMutex.Lock()
+	ret, specificReturn := fake.latestEventIDReturnsOnCall[len(fake.latestEventIDArgsForCall)]
+	fake.latestEventIDArgsForCall = append(fake.latestEventIDArgsForCall, struct{}{})
+	fake.recordInvocation("LatestEventID", []interface{}{})
+	fake.latestEventIDMutex.Unlock()
+	if fake.LatestEventIDStub != nil {
+		return fake.LatestEventIDStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.latestEventIDReturns.result1, fake.latestEventIDReturns.result2
+}
+
+func (fake *FakeTeam) LatestEventIDCallCount() int {
+	fake.latestEventIDMutex.RLock()
+	defer fake.latestEventIDMutex.RUnlock()
+	return len(fake.latestEventIDArgsForCall)
+}
+
+func (fake *FakeTeam) LatestEventIDReturns(result1 int, result2 error) {
+	fake.LatestEventIDStub = nil
+	fake.latestEventIDReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) LatestEventIDReturnsOnCall(i int, result1 int, result2 error) {
+	fake.LatestEventIDStub = nil
+	if fake.latestEventIDReturnsOnCall == nil {
+		fake.latestEventIDReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.latestEventIDReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UpdateSettings(auth atc.TeamAuth, redactSecrets *bool) error {
+	fake.updateSettingsMutex.Lock()
+	ret, specificReturn := fake.updateSettingsReturnsOnCall[len(fake.updateSettingsArgsForCall)]
+	fake.updateSettingsArgsForCall = append(fake.updateSettingsArgsForCall, struct {
+		auth          atc.TeamAuth
+		redactSecrets *bool
+	}{auth, redactSecrets})
+	fake.recordInvocation("UpdateSettings", []interface{}{auth, redactSecrets})
+	fake.updateSettingsMutex.Unlock()
+	if fake.UpdateSettingsStub != nil {
+		return fake.UpdateSettingsStub(auth, redactSecrets)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateSettingsReturns.result1
+}
+
+func (fake *FakeTeam) UpdateSettingsCallCount() int {
+	fake.updateSettingsMutex.RLock()
+	defer fake.updateSettingsMutex.RUnlock()
+	return len(fake.updateSettingsArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateSettingsArgsForCall(i int) (atc.TeamAuth, *bool) {
+	fake.updateSettingsMutex.RLock()
+	defer fake.updateSettingsMutex.RUnlock()
+	return fake.updateSettingsArgsForCall[i].auth, fake.updateSettingsArgsForCall[i].redactSecrets
+}
+
+func (fake *FakeTeam) UpdateSettingsReturns(result1 error) {
+	fake.UpdateSettingsStub = nil
+	fake.updateSettingsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateSettingsReturnsOnCall(i int, result1 error) {
+	fake.UpdateSettingsStub = nil
+	if fake.updateSettingsReturnsOnCall == nil {
+		fake.updateSettingsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateSettingsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1621,12 +1990,16 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.adminMutex.RUnlock()
 	fake.authMutex.RLock()
 	defer fake.authMutex.RUnlock()
+	fake.redactSecretsMutex.RLock()
+	defer fake.redactSecretsMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.renameMutex.RLock()
 	defer fake.renameMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
+	fake.savePipelineWithAuthorMutex.RLock()
+	defer fake.savePipelineWithAuthorMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelinesMutex.RLock()
@@ -1665,6 +2038,14 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createContainerMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
+	fake.notificationDeliveriesMutex.RLock()
+	defer fake.notificationDeliveriesMutex.RUnlock()
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	fake.latestEventIDMutex.RLock()
+	defer fake.latestEventIDMutex.RUnlock()
+	fake.updateSettingsMutex.RLock()
+	defer fake.updateSettingsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -30,24 +30,34 @@ func (e Key) Encrypt(plaintext []byte) (string, *string, error) {
 	return hex.EncodeToString(ciphertext), &noncense, nil
 }
 
+// checkValue identifies the key by what it encrypts a block of zeroes to, as
+// with a key check value. The all-zero nonce this uses is never chosen in
+// practice when encrypting data, as nonces are random.
+func (e Key) checkValue() string {
+	nonce := make([]byte, e.aesgcm.NonceSize())
+	return hex.EncodeToString(e.aesgcm.Seal(nil, nonce, make([]byte, 16), nil))
+}
+
 func (e Key) Decrypt(text string, n *string) ([]byte, error) {
 	if n == nil {
 		return nil, ErrDataIsNotEncrypted
 	}
 
+	// data which is not in the form this key encrypts to, or fails to
+	// authenticate, must be encrypted with another key
 	ciphertext, err := hex.DecodeString(text)
 	if err != nil {
-		return nil, err
+		return nil, ErrDataIsEncryptedWithAnotherKey
 	}
 
 	nonce, err := hex.DecodeString(*n)
-	if err != nil {
-		return nil, err
+	if err != nil || len(nonce) != e.aesgcm.NonceSize() {
+		return nil, ErrDataIsEncryptedWithAnotherKey
 	}
 
 	plaintext, err := e.aesgcm.Open(nil, nonce, ciphertext, nil)
 	if err != nil {
-		return nil, err
+		return nil, ErrDataIsEncryptedWithAnotherKey
 	}
 
 	return plaintext, nil
@@ -78,7 +78,7 @@ var _ = Describe("Encryption Key", func() {
 				wrongKey := encryption.NewKey(aesgcm)
 
 				_, err = wrongKey.Decrypt(encryptedText, nonce)
-				Expect(err).To(HaveOccurred())
+				Expect(err).To(Equal(encryption.ErrDataIsEncryptedWithAnotherKey))
 			})
 		})
 	})
@@ -0,0 +1,127 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+)
+
+var ErrDataIsNotEnveloped = errors.New("failed to decrypt data that is not envelope encrypted")
+
+// wrapped keys and hex-encoded ciphertext never contain the separator
+const envelopeSeparator = "."
+
+// Envelope is a Strategy which encrypts data with a data key generated when
+// it is constructed. The data key is stored alongside the data it encrypts,
+// wrapped by a KeyManager, so that the raw key is never persisted or
+// configured.
+type Envelope struct {
+	manager KeyManager
+
+	wrappedKey string
+	key        *Key
+
+	unwrappedKeys map[string]*Key
+	unwrapLock    *sync.Mutex
+}
+
+func NewEnvelope(manager KeyManager) (*Envelope, error) {
+	dataKey := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+
+	key, err := newAESKey(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedKey, err := manager.WrapKey(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Envelope{
+		manager: manager,
+
+		wrappedKey: wrappedKey,
+		key:        key,
+
+		unwrappedKeys: map[string]*Key{wrappedKey: key},
+		unwrapLock:    &sync.Mutex{},
+	}, nil
+}
+
+func (e *Envelope) Encrypt(plaintext []byte) (string, *string, error) {
+	ciphertext, nonce, err := e.key.Encrypt(plaintext)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return e.wrappedKey + envelopeSeparator + ciphertext, nonce, nil
+}
+
+func (e *Envelope) Decrypt(text string, nonce *string) ([]byte, error) {
+	if nonce == nil {
+		return nil, ErrDataIsNotEncrypted
+	}
+
+	i := strings.LastIndex(text, envelopeSeparator)
+	if i == -1 {
+		return nil, ErrDataIsNotEnveloped
+	}
+
+	key, err := e.unwrap(text[:i])
+	if err != nil {
+		return nil, err
+	}
+
+	return key.Decrypt(text[i+len(envelopeSeparator):], nonce)
+}
+
+// unwrap returns the data key for a wrapped key, only asking the key manager
+// the first time it is seen. The lock is not held while asking, as the key
+// manager may be remote; a key unwrapped by two callers at once is harmless.
+func (e *Envelope) unwrap(wrappedKey string) (*Key, error) {
+	e.unwrapLock.Lock()
+	key, found := e.unwrappedKeys[wrappedKey]
+	e.unwrapLock.Unlock()
+
+	if found {
+		return key, nil
+	}
+
+	dataKey, err := e.manager.UnwrapKey(wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err = newAESKey(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	e.unwrapLock.Lock()
+	e.unwrappedKeys[wrappedKey] = key
+	e.unwrapLock.Unlock()
+
+	return key, nil
+}
+
+func newAESKey(key []byte) (*Key, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKey(aesgcm), nil
+}
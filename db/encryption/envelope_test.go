@@ -0,0 +1,175 @@
+package encryption_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/concourse/atc/db/encryption"
+	"github.com/concourse/atc/db/encryption/encryptionfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Envelope", func() {
+	var (
+		keyFile    string
+		keyManager *encryption.FileKeyManager
+
+		envelope *encryption.Envelope
+	)
+
+	BeforeEach(func() {
+		file, err := ioutil.TempFile("", "master-key")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = file.WriteString("AES256Key-32Characters1234567890\n")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(file.Close()).To(Succeed())
+
+		keyFile = file.Name()
+
+		keyManager, err = encryption.NewFileKeyManager(keyFile)
+		Expect(err).ToNot(HaveOccurred())
+
+		envelope, err = encryption.NewEnvelope(keyManager)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(keyFile)).To(Succeed())
+	})
+
+	It("encrypts and decrypts plaintext", func() {
+		encrypted, nonce, err := envelope.Encrypt([]byte("exampleplaintext"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(encrypted).ToNot(ContainSubstring("exampleplaintext"))
+
+		decrypted, err := envelope.Decrypt(encrypted, nonce)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decrypted).To(Equal([]byte("exampleplaintext")))
+	})
+
+	It("decrypts data encrypted with another data key wrapped by the same master key", func() {
+		other, err := encryption.NewEnvelope(keyManager)
+		Expect(err).ToNot(HaveOccurred())
+
+		encrypted, nonce, err := other.Encrypt([]byte("exampleplaintext"))
+		Expect(err).ToNot(HaveOccurred())
+
+		decrypted, err := envelope.Decrypt(encrypted, nonce)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decrypted).To(Equal([]byte("exampleplaintext")))
+	})
+
+	It("fails to decrypt data which is not encrypted", func() {
+		_, err := envelope.Decrypt("plaintext", nil)
+		Expect(err).To(Equal(encryption.ErrDataIsNotEncrypted))
+	})
+
+	It("fails to decrypt data which is encrypted with a static key", func() {
+		encrypted, nonce, err := newTestKey("AES256Key-32Characters0987654321").Encrypt([]byte("exampleplaintext"))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = envelope.Decrypt(encrypted, nonce)
+		Expect(err).To(Equal(encryption.ErrDataIsNotEnveloped))
+	})
+
+	Context("with a key manager", func() {
+		var fakeKeyManager *encryptionfakes.FakeKeyManager
+
+		BeforeEach(func() {
+			dataKeys := map[string][]byte{}
+
+			fakeKeyManager = new(encryptionfakes.FakeKeyManager)
+			fakeKeyManager.WrapKeyStub = func(dataKey []byte) (string, error) {
+				wrappedKey := fmt.Sprintf("wrapped-key-%d", len(dataKeys))
+				dataKeys[wrappedKey] = dataKey
+				return wrappedKey, nil
+			}
+			fakeKeyManager.UnwrapKeyStub = func(wrappedKey string) ([]byte, error) {
+				return dataKeys[wrappedKey], nil
+			}
+
+			var err error
+			envelope, err = encryption.NewEnvelope(fakeKeyManager)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("stores only the wrapped data key with the data", func() {
+			encrypted, _, err := envelope.Encrypt([]byte("exampleplaintext"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(encrypted).To(HavePrefix("wrapped-key-0."))
+		})
+
+		It("does not need to unwrap its own data key", func() {
+			encrypted, nonce, err := envelope.Encrypt([]byte("exampleplaintext"))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = envelope.Decrypt(encrypted, nonce)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeKeyManager.UnwrapKeyCallCount()).To(BeZero())
+		})
+
+		It("unwraps other data keys only once", func() {
+			encrypted, nonce, err := envelope.Encrypt([]byte("exampleplaintext"))
+			Expect(err).ToNot(HaveOccurred())
+
+			other, err := encryption.NewEnvelope(fakeKeyManager)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = other.Decrypt(encrypted, nonce)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = other.Decrypt(encrypted, nonce)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeKeyManager.UnwrapKeyCallCount()).To(Equal(1))
+		})
+
+		It("decrypts with known data keys while another is being unwrapped", func() {
+			encrypted, nonce, err := envelope.Encrypt([]byte("exampleplaintext"))
+			Expect(err).ToNot(HaveOccurred())
+
+			other, err := encryption.NewEnvelope(fakeKeyManager)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherEncrypted, otherNonce, err := other.Encrypt([]byte("otherplaintext"))
+			Expect(err).ToNot(HaveOccurred())
+
+			unwrapping := make(chan struct{})
+			unblock := make(chan struct{})
+			defer close(unblock)
+
+			unwrap := fakeKeyManager.UnwrapKeyStub
+			fakeKeyManager.UnwrapKeyStub = func(wrappedKey string) ([]byte, error) {
+				close(unwrapping)
+				<-unblock
+				return unwrap(wrappedKey)
+			}
+
+			go func() {
+				defer GinkgoRecover()
+
+				_, _ = other.Decrypt(encrypted, nonce)
+			}()
+
+			Eventually(unwrapping).Should(BeClosed())
+
+			decrypted, err := other.Decrypt(otherEncrypted, otherNonce)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decrypted).To(Equal([]byte("otherplaintext")))
+		})
+
+		It("fails if the data key cannot be wrapped", func() {
+			fakeKeyManager.WrapKeyStub = nil
+			fakeKeyManager.WrapKeyReturns("", errors.New("nope"))
+
+			_, err := encryption.NewEnvelope(fakeKeyManager)
+			Expect(err).To(MatchError("nope"))
+		})
+	})
+})
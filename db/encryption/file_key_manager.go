@@ -0,0 +1,51 @@
+package encryption
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+)
+
+var ErrMalformedWrappedKey = errors.New("malformed wrapped key")
+
+// FileKeyManager is a KeyManager whose master key is read from a local file.
+// It stands in for a KMS, e.g. when testing.
+type FileKeyManager struct {
+	key *Key
+}
+
+// NewFileKeyManager reads a 16 or 32 character master key from the given
+// file.
+func NewFileKeyManager(path string) (*FileKeyManager, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := newAESKey([]byte(strings.TrimSpace(string(contents))))
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileKeyManager{
+		key: key,
+	}, nil
+}
+
+func (manager *FileKeyManager) WrapKey(dataKey []byte) (string, error) {
+	ciphertext, nonce, err := manager.key.Encrypt(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	return *nonce + ":" + ciphertext, nil
+}
+
+func (manager *FileKeyManager) UnwrapKey(wrappedKey string) ([]byte, error) {
+	parts := strings.SplitN(wrappedKey, ":", 2)
+	if len(parts) != 2 {
+		return nil, ErrMalformedWrappedKey
+	}
+
+	return manager.key.Decrypt(parts[1], &parts[0])
+}
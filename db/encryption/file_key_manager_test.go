@@ -0,0 +1,80 @@
+package encryption_test
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/concourse/atc/db/encryption"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileKeyManager", func() {
+	var keyFile string
+
+	writeKey := func(key string) {
+		Expect(ioutil.WriteFile(keyFile, []byte(key), 0600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		file, err := ioutil.TempFile("", "master-key")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+
+		keyFile = file.Name()
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(keyFile)).To(Succeed())
+	})
+
+	It("wraps and unwraps data keys", func() {
+		writeKey("AES256Key-32Characters1234567890")
+
+		manager, err := encryption.NewFileKeyManager(keyFile)
+		Expect(err).ToNot(HaveOccurred())
+
+		wrapped, err := manager.WrapKey([]byte("some-data-key"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(wrapped).ToNot(ContainSubstring("some-data-key"))
+
+		unwrapped, err := manager.UnwrapKey(wrapped)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(unwrapped).To(Equal([]byte("some-data-key")))
+	})
+
+	It("fails to unwrap keys wrapped by another master key", func() {
+		writeKey("AES256Key-32Characters1234567890")
+
+		manager, err := encryption.NewFileKeyManager(keyFile)
+		Expect(err).ToNot(HaveOccurred())
+
+		wrapped, err := manager.WrapKey([]byte("some-data-key"))
+		Expect(err).ToNot(HaveOccurred())
+
+		writeKey("AES256Key-32Characters0987654321")
+
+		other, err := encryption.NewFileKeyManager(keyFile)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = other.UnwrapKey(wrapped)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails to unwrap malformed keys", func() {
+		writeKey("AES256Key-32Characters1234567890")
+
+		manager, err := encryption.NewFileKeyManager(keyFile)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = manager.UnwrapKey("bogus")
+		Expect(err).To(Equal(encryption.ErrMalformedWrappedKey))
+	})
+
+	It("fails if the key is not a valid length", func() {
+		writeKey("too-short")
+
+		_, err := encryption.NewFileKeyManager(keyFile)
+		Expect(err).To(HaveOccurred())
+	})
+})
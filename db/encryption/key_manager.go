@@ -0,0 +1,10 @@
+package encryption
+
+//go:generate counterfeiter . KeyManager
+
+// KeyManager wraps and unwraps data keys with a master key which is held
+// outside of the ATC, e.g. by a KMS.
+type KeyManager interface {
+	WrapKey(dataKey []byte) (string, error)
+	UnwrapKey(wrappedKey string) ([]byte, error)
+}
@@ -0,0 +1,161 @@
+package encryption
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var ErrDataIsEncryptedWithUnknownKey = errors.New("failed to decrypt data that is encrypted with an unknown key")
+
+// Keyring is a Strategy which encrypts with its current key but is able to
+// decrypt data encrypted with any of its old keys, or not encrypted at all.
+// This allows the data to be read while it is being rotated to the current
+// key. A nil current key means data is rotated to plaintext.
+type Keyring struct {
+	current Strategy
+	old     []Strategy
+}
+
+func NewKeyring(current Strategy, old ...Strategy) *Keyring {
+	return &Keyring{
+		current: current,
+		old:     old,
+	}
+}
+
+// Rotating returns true if there are old keys which data may still be
+// encrypted with.
+func (k *Keyring) Rotating() bool {
+	return len(k.old) > 0
+}
+
+// Fingerprint identifies the keys in the keyring without revealing them, so
+// that work done with them, e.g. rotating data to the current key, needn't be
+// repeated while they stay the same. Envelope encryption is identified as
+// such rather than by its master key, as data can't be rotated between master
+// keys.
+func (k *Keyring) Fingerprint() string {
+	old := []string{}
+	for _, key := range k.old {
+		old = append(old, fingerprint(key))
+	}
+
+	sort.Strings(old)
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "current:%s\n", fingerprint(k.current))
+	for _, key := range old {
+		fmt.Fprintf(hash, "old:%s\n", key)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+func fingerprint(strategy Strategy) string {
+	switch s := strategy.(type) {
+	case nil:
+		return "none"
+	case *Key:
+		return s.checkValue()
+	case *Envelope:
+		return "envelope"
+	default:
+		return fmt.Sprintf("%T", strategy)
+	}
+}
+
+func (k *Keyring) Encrypt(plaintext []byte) (string, *string, error) {
+	if k.current == nil {
+		return string(plaintext), nil, nil
+	}
+
+	return k.current.Encrypt(plaintext)
+}
+
+func (k *Keyring) Decrypt(text string, nonce *string) ([]byte, error) {
+	if nonce == nil {
+		return []byte(text), nil
+	}
+
+	plaintext, _, err := k.decrypt(text, nonce)
+	return plaintext, err
+}
+
+// Reencrypt decrypts the data with whichever key it was encrypted with and
+// encrypts it with the current key. If the data is already encrypted with the
+// current key it is returned unchanged and rotated is false.
+func (k *Keyring) Reencrypt(text string, nonce *string) (string, *string, bool, error) {
+	if nonce == nil {
+		if k.current == nil {
+			return text, nil, false, nil
+		}
+
+		encrypted, newNonce, err := k.current.Encrypt([]byte(text))
+		if err != nil {
+			return "", nil, false, err
+		}
+
+		return encrypted, newNonce, true, nil
+	}
+
+	plaintext, current, err := k.decrypt(text, nonce)
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	if current {
+		return text, nonce, false, nil
+	}
+
+	encrypted, newNonce, err := k.Encrypt(plaintext)
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	return encrypted, newNonce, true, nil
+}
+
+// decrypt tries each key in turn, moving on only when the data is encrypted
+// with another one. Any other failure, e.g. a key manager being unreachable,
+// says nothing of which key the data is encrypted with, so it is returned.
+func (k *Keyring) decrypt(text string, nonce *string) ([]byte, bool, error) {
+	if k.current != nil {
+		plaintext, err := k.current.Decrypt(text, nonce)
+		if err == nil {
+			return plaintext, true, nil
+		}
+
+		if !isEncryptedWithAnotherKey(err) {
+			return nil, false, err
+		}
+	}
+
+	for _, key := range k.old {
+		plaintext, err := key.Decrypt(text, nonce)
+		if err == nil {
+			return plaintext, false, nil
+		}
+
+		if !isEncryptedWithAnotherKey(err) {
+			return nil, false, err
+		}
+	}
+
+	if k.current == nil && len(k.old) == 0 {
+		return nil, false, ErrDataIsEncrypted
+	}
+
+	return nil, false, ErrDataIsEncryptedWithUnknownKey
+}
+
+func isEncryptedWithAnotherKey(err error) bool {
+	switch err {
+	case ErrDataIsEncryptedWithAnotherKey, ErrDataIsEncrypted, ErrDataIsNotEnveloped, ErrMalformedWrappedKey:
+		return true
+	default:
+		return false
+	}
+}
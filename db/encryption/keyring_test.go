@@ -0,0 +1,240 @@
+package encryption_test
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+
+	"github.com/concourse/atc/db/encryption"
+	"github.com/concourse/atc/db/encryption/encryptionfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Keyring", func() {
+	var (
+		currentKey *encryption.Key
+		oldKey     *encryption.Key
+		otherKey   *encryption.Key
+
+		keyring *encryption.Keyring
+	)
+
+	BeforeEach(func() {
+		currentKey = newTestKey("AES256Key-32Characters1234567890")
+		oldKey = newTestKey("AES256Key-32Characters0987654321")
+		otherKey = newTestKey("AES256Key-32Characters9564567123")
+	})
+
+	Context("with a current key and old keys", func() {
+		BeforeEach(func() {
+			keyring = encryption.NewKeyring(currentKey, oldKey)
+		})
+
+		It("is rotating", func() {
+			Expect(keyring.Rotating()).To(BeTrue())
+		})
+
+		It("encrypts with the current key", func() {
+			encrypted, nonce, err := keyring.Encrypt([]byte("exampleplaintext"))
+			Expect(err).ToNot(HaveOccurred())
+
+			decrypted, err := currentKey.Decrypt(encrypted, nonce)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decrypted).To(Equal([]byte("exampleplaintext")))
+		})
+
+		It("decrypts data encrypted with either key", func() {
+			encrypted, nonce, err := oldKey.Encrypt([]byte("old"))
+			Expect(err).ToNot(HaveOccurred())
+
+			decrypted, err := keyring.Decrypt(encrypted, nonce)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decrypted).To(Equal([]byte("old")))
+
+			encrypted, nonce, err = currentKey.Encrypt([]byte("current"))
+			Expect(err).ToNot(HaveOccurred())
+
+			decrypted, err = keyring.Decrypt(encrypted, nonce)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decrypted).To(Equal([]byte("current")))
+		})
+
+		It("decrypts data which is not yet encrypted", func() {
+			decrypted, err := keyring.Decrypt("plaintext", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decrypted).To(Equal([]byte("plaintext")))
+		})
+
+		It("fails to decrypt data encrypted with an unknown key", func() {
+			encrypted, nonce, err := otherKey.Encrypt([]byte("other"))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = keyring.Decrypt(encrypted, nonce)
+			Expect(err).To(Equal(encryption.ErrDataIsEncryptedWithUnknownKey))
+		})
+
+		Describe("Reencrypt", func() {
+			It("re-encrypts data encrypted with an old key", func() {
+				encrypted, nonce, err := oldKey.Encrypt([]byte("old"))
+				Expect(err).ToNot(HaveOccurred())
+
+				reencrypted, newNonce, rotated, err := keyring.Reencrypt(encrypted, nonce)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rotated).To(BeTrue())
+
+				decrypted, err := currentKey.Decrypt(reencrypted, newNonce)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(decrypted).To(Equal([]byte("old")))
+			})
+
+			It("encrypts plaintext data", func() {
+				encrypted, nonce, rotated, err := keyring.Reencrypt("plaintext", nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rotated).To(BeTrue())
+
+				decrypted, err := currentKey.Decrypt(encrypted, nonce)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(decrypted).To(Equal([]byte("plaintext")))
+			})
+
+			It("leaves data encrypted with the current key alone", func() {
+				encrypted, nonce, err := currentKey.Encrypt([]byte("current"))
+				Expect(err).ToNot(HaveOccurred())
+
+				reencrypted, newNonce, rotated, err := keyring.Reencrypt(encrypted, nonce)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rotated).To(BeFalse())
+				Expect(reencrypted).To(Equal(encrypted))
+				Expect(newNonce).To(Equal(nonce))
+			})
+
+			It("fails to re-encrypt data encrypted with an unknown key", func() {
+				encrypted, nonce, err := otherKey.Encrypt([]byte("other"))
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, _, err = keyring.Reencrypt(encrypted, nonce)
+				Expect(err).To(Equal(encryption.ErrDataIsEncryptedWithUnknownKey))
+			})
+		})
+	})
+
+	Context("when the current key fails to decrypt for some other reason", func() {
+		var (
+			fakeCurrent *encryptionfakes.FakeStrategy
+			disaster    error
+
+			encrypted string
+			nonce     *string
+		)
+
+		BeforeEach(func() {
+			disaster = errors.New("key manager unreachable")
+
+			fakeCurrent = new(encryptionfakes.FakeStrategy)
+			fakeCurrent.DecryptReturns(nil, disaster)
+
+			keyring = encryption.NewKeyring(fakeCurrent, oldKey)
+
+			var err error
+			encrypted, nonce, err = oldKey.Encrypt([]byte("old"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the error rather than trying the old keys", func() {
+			_, err := keyring.Decrypt(encrypted, nonce)
+			Expect(err).To(Equal(disaster))
+		})
+
+		It("does not re-encrypt the data", func() {
+			_, _, rotated, err := keyring.Reencrypt(encrypted, nonce)
+			Expect(err).To(Equal(disaster))
+			Expect(rotated).To(BeFalse())
+			Expect(fakeCurrent.EncryptCallCount()).To(BeZero())
+		})
+
+		Context("when the data turns out to be encrypted with another key", func() {
+			BeforeEach(func() {
+				fakeCurrent.DecryptReturns(nil, encryption.ErrDataIsEncryptedWithAnotherKey)
+			})
+
+			It("decrypts it with an old key", func() {
+				decrypted, err := keyring.Decrypt(encrypted, nonce)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(decrypted).To(Equal([]byte("old")))
+			})
+		})
+	})
+
+	Context("with only old keys", func() {
+		BeforeEach(func() {
+			keyring = encryption.NewKeyring(nil, oldKey)
+		})
+
+		It("does not encrypt", func() {
+			encrypted, nonce, err := keyring.Encrypt([]byte("plaintext"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(encrypted).To(Equal("plaintext"))
+			Expect(nonce).To(BeNil())
+		})
+
+		It("decrypts data encrypted with an old key to plaintext when re-encrypting", func() {
+			encrypted, nonce, err := oldKey.Encrypt([]byte("old"))
+			Expect(err).ToNot(HaveOccurred())
+
+			decrypted, newNonce, rotated, err := keyring.Reencrypt(encrypted, nonce)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rotated).To(BeTrue())
+			Expect(decrypted).To(Equal("old"))
+			Expect(newNonce).To(BeNil())
+		})
+	})
+
+	Context("with only a current key", func() {
+		BeforeEach(func() {
+			keyring = encryption.NewKeyring(currentKey)
+		})
+
+		It("is not rotating", func() {
+			Expect(keyring.Rotating()).To(BeFalse())
+		})
+	})
+
+	Describe("Fingerprint", func() {
+		It("is the same for the same keys", func() {
+			Expect(encryption.NewKeyring(currentKey, oldKey, otherKey).Fingerprint()).To(Equal(
+				encryption.NewKeyring(newTestKey("AES256Key-32Characters1234567890"), otherKey, oldKey).Fingerprint(),
+			))
+		})
+
+		It("differs when the current key differs", func() {
+			Expect(encryption.NewKeyring(currentKey, oldKey).Fingerprint()).ToNot(Equal(
+				encryption.NewKeyring(otherKey, oldKey).Fingerprint(),
+			))
+
+			Expect(encryption.NewKeyring(currentKey, oldKey).Fingerprint()).ToNot(Equal(
+				encryption.NewKeyring(nil, oldKey).Fingerprint(),
+			))
+		})
+
+		It("differs when the old keys differ", func() {
+			Expect(encryption.NewKeyring(currentKey, oldKey).Fingerprint()).ToNot(Equal(
+				encryption.NewKeyring(currentKey, otherKey).Fingerprint(),
+			))
+
+			Expect(encryption.NewKeyring(currentKey, oldKey).Fingerprint()).ToNot(Equal(
+				encryption.NewKeyring(currentKey).Fingerprint(),
+			))
+		})
+	})
+})
+
+func newTestKey(k string) *encryption.Key {
+	block, err := aes.NewCipher([]byte(k))
+	Expect(err).ToNot(HaveOccurred())
+
+	aesgcm, err := cipher.NewGCM(block)
+	Expect(err).ToNot(HaveOccurred())
+
+	return encryption.NewKey(aesgcm)
+}
@@ -0,0 +1,75 @@
+package vaulttransit
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/creds/vault"
+	"github.com/concourse/atc/db/encryption"
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+type Config struct {
+	URL string `long:"url" description:"Vault server address used to wrap the data keys used for encryption."`
+
+	MountPath string `long:"mount-path" default:"transit"   description:"Path at which the transit secrets engine is mounted."`
+	KeyName   string `long:"key-name"   default:"concourse" description:"Name of the transit key used to wrap data keys."`
+
+	LoginTimeout time.Duration `long:"login-timeout" default:"1m" description:"How long to wait to log in to Vault before failing to wrap or unwrap a data key."`
+
+	TLS struct {
+		CACert     string `long:"ca-cert"              description:"Path to a PEM-encoded CA cert file to use to verify the vault server SSL cert."`
+		CAPath     string `long:"ca-path"              description:"Path to a directory of PEM-encoded CA cert files to verify the vault server SSL cert."`
+		ClientCert string `long:"client-cert"          description:"Path to the client certificate for Vault authorization."`
+		ClientKey  string `long:"client-key"           description:"Path to the client private key for Vault authorization."`
+		ServerName string `long:"server-name"          description:"If set, is used to set the SNI host when connecting via TLS."`
+		Insecure   bool   `long:"insecure-skip-verify" description:"Enable insecure SSL verification."`
+	}
+
+	Auth vault.AuthConfig
+}
+
+func (config Config) IsConfigured() bool {
+	return config.URL != ""
+}
+
+func (config Config) Validate() error {
+	_, err := url.Parse(config.URL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %s", err)
+	}
+
+	if config.Auth.ClientToken != "" {
+		return nil
+	}
+
+	if config.Auth.Backend != "" {
+		return nil
+	}
+
+	return errors.New("must configure client token or auth backend")
+}
+
+func (config Config) NewKeyManager(logger lager.Logger) (encryption.KeyManager, error) {
+	tlsConfig := &vaultapi.TLSConfig{
+		CACert:        config.TLS.CACert,
+		CAPath:        config.TLS.CAPath,
+		TLSServerName: config.TLS.ServerName,
+		Insecure:      config.TLS.Insecure,
+
+		ClientCert: config.TLS.ClientCert,
+		ClientKey:  config.TLS.ClientKey,
+	}
+
+	c, err := vault.NewAPIClient(logger, config.URL, tlsConfig, config.Auth)
+	if err != nil {
+		return nil, err
+	}
+
+	ra := vault.NewReAuther(c, config.Auth.BackendMaxTTL, config.Auth.RetryInitial, config.Auth.RetryMax)
+
+	return NewKeyManager(c, ra.LoggedIn(), config.LoginTimeout, config.MountPath, config.KeyName), nil
+}
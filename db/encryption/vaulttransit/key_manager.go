@@ -0,0 +1,119 @@
+package vaulttransit
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+// ErrNotLoggedIn is returned when logging in to vault takes longer than the
+// login timeout given to the KeyManager.
+var ErrNotLoggedIn = errors.New("timed out waiting to log in to vault")
+
+//go:generate counterfeiter . SecretWriter
+
+// A SecretWriter writes data to the given vault path. It should be thread
+// safe!
+type SecretWriter interface {
+	Write(path string, data map[string]interface{}) (*vaultapi.Secret, error)
+}
+
+// KeyManager wraps data keys using a named key in Vault's transit secrets
+// engine, so that the key used to wrap them never leaves Vault.
+type KeyManager struct {
+	writer       SecretWriter
+	loggedIn     <-chan struct{}
+	loginTimeout time.Duration
+	mountPath    string
+	keyName      string
+
+	loginLock   *sync.Mutex
+	hasLoggedIn bool
+}
+
+func NewKeyManager(writer SecretWriter, loggedIn <-chan struct{}, loginTimeout time.Duration, mountPath string, keyName string) *KeyManager {
+	return &KeyManager{
+		writer:       writer,
+		loggedIn:     loggedIn,
+		loginTimeout: loginTimeout,
+		mountPath:    mountPath,
+		keyName:      keyName,
+
+		loginLock: &sync.Mutex{},
+	}
+}
+
+func (manager *KeyManager) WrapKey(dataKey []byte) (string, error) {
+	secret, err := manager.write("encrypt", map[string]interface{}{
+		"plaintext": base64.StdEncoding.EncodeToString(dataKey),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, ok := secret.Data["ciphertext"].(string)
+	if !ok {
+		return "", fmt.Errorf("no ciphertext in response from vault")
+	}
+
+	return ciphertext, nil
+}
+
+func (manager *KeyManager) UnwrapKey(wrappedKey string) ([]byte, error) {
+	secret, err := manager.write("decrypt", map[string]interface{}{
+		"ciphertext": wrappedKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, ok := secret.Data["plaintext"].(string)
+	if !ok {
+		return nil, fmt.Errorf("no plaintext in response from vault")
+	}
+
+	return base64.StdEncoding.DecodeString(plaintext)
+}
+
+// write blocks until the loggedIn channel passed to the constructor signals
+// a successful login for the first time, failing with ErrNotLoggedIn if that
+// takes longer than the login timeout.
+func (manager *KeyManager) write(operation string, data map[string]interface{}) (*vaultapi.Secret, error) {
+	err := manager.waitForLogin()
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := manager.writer.Write(path.Join(manager.mountPath, operation, manager.keyName), data)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret == nil {
+		return nil, fmt.Errorf("no response from vault")
+	}
+
+	return secret, nil
+}
+
+func (manager *KeyManager) waitForLogin() error {
+	manager.loginLock.Lock()
+	defer manager.loginLock.Unlock()
+
+	if manager.hasLoggedIn {
+		return nil
+	}
+
+	select {
+	case <-manager.loggedIn:
+		manager.hasLoggedIn = true
+		return nil
+	case <-time.After(manager.loginTimeout):
+		return ErrNotLoggedIn
+	}
+}
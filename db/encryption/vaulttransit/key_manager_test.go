@@ -0,0 +1,122 @@
+package vaulttransit_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/concourse/atc/db/encryption/vaulttransit"
+	"github.com/concourse/atc/db/encryption/vaulttransit/vaulttransitfakes"
+	vaultapi "github.com/hashicorp/vault/api"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyManager", func() {
+	var (
+		fakeWriter *vaulttransitfakes.FakeSecretWriter
+		loggedIn   chan struct{}
+
+		manager *vaulttransit.KeyManager
+	)
+
+	BeforeEach(func() {
+		fakeWriter = new(vaulttransitfakes.FakeSecretWriter)
+
+		loggedIn = make(chan struct{}, 1)
+		loggedIn <- struct{}{}
+
+		manager = vaulttransit.NewKeyManager(fakeWriter, loggedIn, time.Second, "transit", "some-key")
+	})
+
+	Describe("WrapKey", func() {
+		It("encrypts the data key with the transit key", func() {
+			fakeWriter.WriteReturns(&vaultapi.Secret{
+				Data: map[string]interface{}{
+					"ciphertext": "vault:v1:some-ciphertext",
+				},
+			}, nil)
+
+			wrapped, err := manager.WrapKey([]byte("some-data-key"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(wrapped).To(Equal("vault:v1:some-ciphertext"))
+
+			Expect(fakeWriter.WriteCallCount()).To(Equal(1))
+			path, data := fakeWriter.WriteArgsForCall(0)
+			Expect(path).To(Equal("transit/encrypt/some-key"))
+			Expect(data).To(Equal(map[string]interface{}{
+				"plaintext": "c29tZS1kYXRhLWtleQ==",
+			}))
+		})
+
+		It("fails if vault fails", func() {
+			fakeWriter.WriteReturns(nil, errors.New("nope"))
+
+			_, err := manager.WrapKey([]byte("some-data-key"))
+			Expect(err).To(MatchError("nope"))
+		})
+
+		It("fails if vault does not return ciphertext", func() {
+			fakeWriter.WriteReturns(&vaultapi.Secret{}, nil)
+
+			_, err := manager.WrapKey([]byte("some-data-key"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("UnwrapKey", func() {
+		It("decrypts the wrapped key with the transit key", func() {
+			fakeWriter.WriteReturns(&vaultapi.Secret{
+				Data: map[string]interface{}{
+					"plaintext": "c29tZS1kYXRhLWtleQ==",
+				},
+			}, nil)
+
+			unwrapped, err := manager.UnwrapKey("vault:v1:some-ciphertext")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(unwrapped).To(Equal([]byte("some-data-key")))
+
+			Expect(fakeWriter.WriteCallCount()).To(Equal(1))
+			path, data := fakeWriter.WriteArgsForCall(0)
+			Expect(path).To(Equal("transit/decrypt/some-key"))
+			Expect(data).To(Equal(map[string]interface{}{
+				"ciphertext": "vault:v1:some-ciphertext",
+			}))
+		})
+
+		It("fails if vault does not respond", func() {
+			fakeWriter.WriteReturns(nil, nil)
+
+			_, err := manager.UnwrapKey("vault:v1:some-ciphertext")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("only waits for the first login", func() {
+		fakeWriter.WriteReturns(&vaultapi.Secret{
+			Data: map[string]interface{}{
+				"ciphertext": "vault:v1:some-ciphertext",
+			},
+		}, nil)
+
+		_, err := manager.WrapKey([]byte("some-data-key"))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = manager.WrapKey([]byte("some-data-key"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeWriter.WriteCallCount()).To(Equal(2))
+	})
+
+	Context("when logging in takes too long", func() {
+		BeforeEach(func() {
+			manager = vaulttransit.NewKeyManager(fakeWriter, make(chan struct{}), 10*time.Millisecond, "transit", "some-key")
+		})
+
+		It("fails without writing to vault", func() {
+			_, err := manager.WrapKey([]byte("some-data-key"))
+			Expect(err).To(Equal(vaulttransit.ErrNotLoggedIn))
+
+			Expect(fakeWriter.WriteCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package vaulttransit_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestVaultTransit(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Vault Transit Suite")
+}
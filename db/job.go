@@ -4,11 +4,14 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/event"
+	"github.com/lib/pq"
 )
 
 //go:generate counterfeiter . Job
@@ -24,6 +27,7 @@ type Job interface {
 	TeamName() string
 	Config() atc.JobConfig
 	Tags() []string
+	TriggersEvaluatedAt() time.Time
 
 	Reload() (bool, error)
 
@@ -44,12 +48,16 @@ type Job interface {
 	SaveIndependentInputMapping(inputMapping algorithm.InputMapping) error
 	DeleteNextInputMapping() error
 
+	SaveMissingInputReasons(MissingInputReasons) error
+	SaveTriggersEvaluated(evaluatedAt time.Time, cause *atc.TriggerCause) (bool, error)
+	InputResolution() (InputResolution, error)
+
 	SetMaxInFlightReached(bool) error
 	GetRunningBuildsBySerialGroup(serialGroups []string) ([]Build, error)
 	GetNextPendingBuildBySerialGroup(serialGroups []string) (Build, bool, error)
 }
 
-var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.team_id", "t.name", "j.nonce", "array_to_json(j.tags)").
+var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.team_id", "t.name", "j.nonce", "array_to_json(j.tags)", "j.triggers_evaluated_at").
 	From("jobs j, pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id").
 	Where(sq.Expr("j.pipeline_id = p.id"))
@@ -76,6 +84,8 @@ type job struct {
 	config             atc.JobConfig
 	tags               []string
 
+	triggersEvaluatedAt time.Time
+
 	conn        Conn
 	lockFactory lock.LockFactory
 }
@@ -103,6 +113,8 @@ func (j *job) TeamName() string        { return j.teamName }
 func (j *job) Config() atc.JobConfig   { return j.config }
 func (j *job) Tags() []string          { return j.tags }
 
+func (j *job) TriggersEvaluatedAt() time.Time { return j.triggersEvaluatedAt }
+
 func (j *job) Reload() (bool, error) {
 	row := jobsQuery.Where(sq.Eq{"j.id": j.id}).
 		RunWith(j.conn).
@@ -451,6 +463,235 @@ func (j *job) DeleteNextInputMapping() error {
 	return tx.Commit()
 }
 
+// SaveMissingInputReasons records why the job's inputs could not be resolved
+// when it was last scheduled. It is left as-is if nothing has changed.
+func (j *job) SaveMissingInputReasons(reasons MissingInputReasons) error {
+	payload, err := json.Marshal(reasons)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("jobs").
+		Set("missing_input_reasons", string(payload)).
+		Where(sq.Eq{"id": j.id}).
+		Where(sq.Expr("missing_input_reasons IS DISTINCT FROM ?", string(payload))).
+		RunWith(j.conn).
+		Exec()
+	return err
+}
+
+// SaveTriggersEvaluated records when the job's triggers were last evaluated,
+// and when one of them has fired ensures a pending build exists for it. The
+// zero time clears the record. It returns false without saving anything if
+// the triggers have been evaluated elsewhere since the job was loaded.
+func (j *job) SaveTriggersEvaluated(evaluatedAt time.Time, cause *atc.TriggerCause) (bool, error) {
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	var previous, next interface{}
+	if !j.triggersEvaluatedAt.IsZero() {
+		previous = j.triggersEvaluatedAt
+	}
+
+	// cron schedules are to the minute, and seconds survive the round trip
+	// through the database unchanged
+	evaluatedAt = evaluatedAt.Truncate(time.Second)
+	if !evaluatedAt.IsZero() {
+		next = evaluatedAt
+	}
+
+	result, err := psql.Update("jobs").
+		Set("triggers_evaluated_at", next).
+		Where(sq.Eq{"id": j.id}).
+		Where(sq.Expr("triggers_evaluated_at IS NOT DISTINCT FROM ?::timestamptz", previous)).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	if cause != nil {
+		payload, err := json.Marshal(cause)
+		if err != nil {
+			return false, err
+		}
+
+		var pending bool
+		err = tx.QueryRow(`
+			SELECT EXISTS (SELECT 1 FROM builds WHERE job_id = $1 AND status = 'pending')
+		`, j.id).Scan(&pending)
+		if err != nil {
+			return false, err
+		}
+
+		if !pending {
+			_, err = j.createPendingBuild(tx, map[string]interface{}{
+				"trigger_cause": string(payload),
+			})
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	j.triggersEvaluatedAt = evaluatedAt
+
+	return true, nil
+}
+
+func (j *job) InputResolution() (InputResolution, error) {
+	resolution := InputResolution{
+		Inputs:              map[string]BuildPreparationStatus{},
+		InputsSatisfied:     BuildPreparationStatusBlocking,
+		MissingInputReasons: MissingInputReasons{},
+	}
+
+	nextBuildInputs, found, err := j.GetNextBuildInputs()
+	if err != nil {
+		return InputResolution{}, err
+	}
+
+	if found {
+		resolution.InputsSatisfied = BuildPreparationStatusNotBlocking
+		for _, buildInput := range nextBuildInputs {
+			resolution.Inputs[buildInput.Name] = BuildPreparationStatusNotBlocking
+		}
+
+		return resolution, nil
+	}
+
+	buildInputs, err := j.GetIndependentBuildInputs()
+	if err != nil {
+		return InputResolution{}, err
+	}
+
+	savedReasons, err := j.savedMissingInputReasons()
+	if err != nil {
+		return InputResolution{}, err
+	}
+
+	for _, configInput := range j.config.Inputs() {
+		if reason, found := savedReasons[configInput.Name]; found {
+			resolution.Inputs[configInput.Name] = BuildPreparationStatusBlocking
+			resolution.MissingInputReasons[configInput.Name] = reason
+			continue
+		}
+
+		found := false
+		for _, buildInput := range buildInputs {
+			if buildInput.Name == configInput.Name {
+				found = true
+				break
+			}
+		}
+
+		if found {
+			resolution.Inputs[configInput.Name] = BuildPreparationStatusNotBlocking
+			continue
+		}
+
+		resolution.Inputs[configInput.Name] = BuildPreparationStatusBlocking
+
+		// the job has not been scheduled since reasons were recorded, so make
+		// a guess
+		err := j.guessMissingInputReason(resolution.MissingInputReasons, configInput)
+		if err != nil {
+			return InputResolution{}, err
+		}
+	}
+
+	return resolution, nil
+}
+
+func (j *job) savedMissingInputReasons() (MissingInputReasons, error) {
+	var payload sql.NullString
+	err := psql.Select("missing_input_reasons").
+		From("jobs").
+		Where(sq.Eq{"id": j.id}).
+		RunWith(j.conn).
+		QueryRow().
+		Scan(&payload)
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := MissingInputReasons{}
+	if !payload.Valid {
+		return reasons, nil
+	}
+
+	err = json.Unmarshal([]byte(payload.String), &reasons)
+	if err != nil {
+		return nil, err
+	}
+
+	return reasons, nil
+}
+
+func (j *job) guessMissingInputReason(reasons MissingInputReasons, configInput atc.JobInput) error {
+	pinned := configInput.Version != nil && configInput.Version.Pinned != nil
+	if !pinned {
+		if len(configInput.Passed) > 0 {
+			reasons.RegisterPassedConstraint(configInput.Name)
+		} else {
+			reasons.RegisterNoVersions(configInput.Name)
+		}
+
+		return nil
+	}
+
+	versionJSON, err := json.Marshal(configInput.Version.Pinned)
+	if err != nil {
+		return err
+	}
+
+	if len(configInput.Passed) > 0 {
+		var available int
+		err := psql.Select("COUNT(*)").
+			From("versioned_resources v").
+			Join("resources r ON r.id = v.resource_id").
+			Where(sq.Eq{
+				"v.version":     string(versionJSON),
+				"v.enabled":     true,
+				"r.name":        configInput.Resource,
+				"r.pipeline_id": j.pipelineID,
+			}).
+			RunWith(j.conn).
+			QueryRow().
+			Scan(&available)
+		if err != nil {
+			return err
+		}
+
+		if available > 0 {
+			reasons.RegisterPassedConstraint(configInput.Name)
+			return nil
+		}
+	}
+
+	reasons.RegisterPinnedVersionUnavailable(configInput.Name, string(versionJSON))
+
+	return nil
+}
+
 func (j *job) EnsurePendingBuildExists() error {
 	tx, err := j.conn.Begin()
 	if err != nil {
@@ -550,30 +791,42 @@ func (j *job) CreateBuild() (Build, error) {
 
 	defer Rollback(tx)
 
-	buildName, err := j.getNewBuildName(tx)
+	build, err := j.createPendingBuild(tx, map[string]interface{}{
+		"manually_triggered": true,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	build := &build{conn: j.conn, lockFactory: j.lockFactory}
-	err = createBuild(tx, build, map[string]interface{}{
-		"name":               buildName,
-		"job_id":             j.id,
-		"pipeline_id":        j.pipelineID,
-		"team_id":            j.teamID,
-		"status":             BuildStatusPending,
-		"manually_triggered": true,
-	})
+	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
 
-	err = updateNextBuildForJob(tx, j.id)
+	return build, nil
+}
+
+// createPendingBuild creates the job's next build with the given columns set
+// in addition to those every build of the job has.
+func (j *job) createPendingBuild(tx Tx, vals map[string]interface{}) (*build, error) {
+	buildName, err := j.getNewBuildName(tx)
 	if err != nil {
 		return nil, err
 	}
 
-	err = tx.Commit()
+	vals["name"] = buildName
+	vals["job_id"] = j.id
+	vals["pipeline_id"] = j.pipelineID
+	vals["team_id"] = j.teamID
+	vals["status"] = BuildStatusPending
+
+	build := &build{conn: j.conn, lockFactory: j.lockFactory}
+	err = createBuild(tx, build, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	err = updateNextBuildForJob(tx, j.id)
 	if err != nil {
 		return nil, err
 	}
@@ -613,10 +866,17 @@ func (j *job) updateSerialGroups(serialGroups []string) error {
 }
 
 func (j *job) updatePausedJob(pause bool) error {
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
 	result, err := psql.Update("jobs").
 		Set("paused", pause).
 		Where(sq.Eq{"id": j.id}).
-		RunWith(j.conn).
+		RunWith(tx).
 		Exec()
 	if err != nil {
 		return err
@@ -631,7 +891,17 @@ func (j *job) updatePausedJob(pause bool) error {
 		return nonOneRowAffectedError{rowsAffected}
 	}
 
-	return nil
+	var ev atc.Event = event.JobUnpaused{PipelineName: j.pipelineName, JobName: j.name}
+	if pause {
+		ev = event.JobPaused{PipelineName: j.pipelineName, JobName: j.name}
+	}
+
+	err = saveTeamEvent(tx, j.teamID, ev)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (j *job) getBuildInputs(table string) ([]BuildInput, error) {
@@ -842,13 +1112,17 @@ func scanJob(j *job, row scannable) error {
 		nonce      sql.NullString
 		tagsBlob   []byte
 		tags       []string
+
+		triggersEvaluatedAt pq.NullTime
 	)
 
-	err := row.Scan(&j.id, &j.name, &configBlob, &j.paused, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &j.teamID, &j.teamName, &nonce, &tagsBlob)
+	err := row.Scan(&j.id, &j.name, &configBlob, &j.paused, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &j.teamID, &j.teamName, &nonce, &tagsBlob, &triggersEvaluatedAt)
 	if err != nil {
 		return err
 	}
 
+	j.triggersEvaluatedAt = triggersEvaluatedAt.Time
+
 	es := j.conn.EncryptionStrategy()
 
 	var noncense *string
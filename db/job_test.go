@@ -841,6 +841,90 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("InputResolution", func() {
+		BeforeEach(func() {
+			var found bool
+			var err error
+			job, found, err = pipeline.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		Context("when the job has not been scheduled", func() {
+			It("guesses why the inputs are blocking", func() {
+				resolution, err := job.InputResolution()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resolution).To(Equal(db.InputResolution{
+					Inputs: map[string]db.BuildPreparationStatus{
+						"some-input": db.BuildPreparationStatusBlocking,
+					},
+					InputsSatisfied: db.BuildPreparationStatusBlocking,
+					MissingInputReasons: db.MissingInputReasons{
+						"some-input": db.NoVerionsSatisfiedPassedConstraints,
+					},
+				}))
+			})
+		})
+
+		Context("when missing input reasons have been saved", func() {
+			BeforeEach(func() {
+				err := job.SaveMissingInputReasons(db.MissingInputReasons{
+					"some-input":    "no versions have passed job-2",
+					"removed-input": "no versions available",
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns them for the job's inputs", func() {
+				resolution, err := job.InputResolution()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resolution).To(Equal(db.InputResolution{
+					Inputs: map[string]db.BuildPreparationStatus{
+						"some-input": db.BuildPreparationStatusBlocking,
+					},
+					InputsSatisfied: db.BuildPreparationStatusBlocking,
+					MissingInputReasons: db.MissingInputReasons{
+						"some-input": "no versions have passed job-2",
+					},
+				}))
+			})
+
+			Context("when the inputs are then resolved", func() {
+				BeforeEach(func() {
+					err := pipeline.SaveResourceVersions(atc.ResourceConfig{
+						Name: "some-resource",
+						Type: "some-type",
+					}, []atc.Version{{"version": "v1"}})
+					Expect(err).ToNot(HaveOccurred())
+
+					savedVR, found, err := pipeline.GetLatestVersionedResource("some-resource")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					err = job.SaveMissingInputReasons(db.MissingInputReasons{})
+					Expect(err).ToNot(HaveOccurred())
+
+					err = job.SaveNextInputMapping(algorithm.InputMapping{
+						"some-input": algorithm.InputVersion{VersionID: savedVR.ID, FirstOccurrence: true},
+					})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("returns that nothing is blocking", func() {
+					resolution, err := job.InputResolution()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(resolution).To(Equal(db.InputResolution{
+						Inputs: map[string]db.BuildPreparationStatus{
+							"some-input": db.BuildPreparationStatusNotBlocking,
+						},
+						InputsSatisfied:     db.BuildPreparationStatusNotBlocking,
+						MissingInputReasons: db.MissingInputReasons{},
+					}))
+				})
+			})
+		})
+	})
+
 	Describe("saving build inputs", func() {
 		var (
 			buildMetadata []db.ResourceMetadataField
@@ -1187,4 +1271,115 @@ var _ = Describe("Job", func() {
 			})
 		})
 	})
+
+	Describe("SaveTriggersEvaluated", func() {
+		var (
+			evaluatedAt time.Time
+			cause       *atc.TriggerCause
+		)
+
+		BeforeEach(func() {
+			evaluatedAt = time.Date(2018, 7, 1, 2, 0, 30, 0, time.UTC)
+			cause = &atc.TriggerCause{
+				Cron:          "0 2 * * *",
+				Timezone:      "Europe/London",
+				ScheduledTime: time.Date(2018, 7, 1, 2, 0, 0, 0, time.UTC).Unix(),
+			}
+		})
+
+		It("records when the triggers were evaluated", func() {
+			Expect(job.TriggersEvaluatedAt().IsZero()).To(BeTrue())
+
+			saved, err := job.SaveTriggersEvaluated(evaluatedAt, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(saved).To(BeTrue())
+
+			found, err := job.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(job.TriggersEvaluatedAt().Equal(evaluatedAt)).To(BeTrue())
+
+			pendingBuilds, err := job.GetPendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingBuilds).To(BeEmpty())
+		})
+
+		It("clears the record given the zero time", func() {
+			_, err := job.SaveTriggersEvaluated(evaluatedAt, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			saved, err := job.SaveTriggersEvaluated(time.Time{}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(saved).To(BeTrue())
+
+			found, err := job.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(job.TriggersEvaluatedAt().IsZero()).To(BeTrue())
+		})
+
+		It("creates a pending build caused by the trigger", func() {
+			saved, err := job.SaveTriggersEvaluated(evaluatedAt, cause)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(saved).To(BeTrue())
+
+			pendingBuilds, err := job.GetPendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingBuilds).To(HaveLen(1))
+			Expect(pendingBuilds[0].TriggerCause()).To(Equal(cause))
+			Expect(pendingBuilds[0].IsManuallyTriggered()).To(BeFalse())
+		})
+
+		Context("when a pending build already exists", func() {
+			var pendingBuild db.Build
+
+			BeforeEach(func() {
+				var err error
+				pendingBuild, err = job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not create another one", func() {
+				saved, err := job.SaveTriggersEvaluated(evaluatedAt, cause)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(saved).To(BeTrue())
+
+				pendingBuilds, err := job.GetPendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pendingBuilds).To(HaveLen(1))
+				Expect(pendingBuilds[0].ID()).To(Equal(pendingBuild.ID()))
+				Expect(pendingBuilds[0].TriggerCause()).To(BeNil())
+			})
+		})
+
+		Context("when the triggers have been evaluated elsewhere since the job was loaded", func() {
+			BeforeEach(func() {
+				otherJob, found, err := pipeline.Job(job.Name())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				saved, err := otherJob.SaveTriggersEvaluated(evaluatedAt, cause)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(saved).To(BeTrue())
+			})
+
+			It("does not save anything", func() {
+				saved, err := job.SaveTriggersEvaluated(evaluatedAt.Add(time.Minute), cause)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(saved).To(BeFalse())
+
+				found, err := job.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(job.TriggersEvaluatedAt().Equal(evaluatedAt)).To(BeTrue())
+
+				pendingBuilds, err := job.GetPendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pendingBuilds).To(HaveLen(1))
+			})
+		})
+	})
 })
@@ -0,0 +1,441 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc/db/encryption"
+	"github.com/lib/pq"
+)
+
+var encryptedColumns = map[string]string{
+	"teams":                   "legacy_auth",
+	"resources":               "config",
+	"jobs":                    "config",
+	"resource_types":          "config",
+	"builds":                  "engine_metadata",
+	"pipeline_configs":        "config",
+	"notification_deliveries": "request",
+}
+
+// KeyRotation is a pass over the encrypted data in the database, rotating it
+// to the current encryption key.
+type KeyRotation struct {
+	ID         int
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Progress   map[string]KeyRotationProgress
+	Error      string
+}
+
+func (rotation KeyRotation) Finished() bool {
+	return !rotation.FinishedAt.IsZero()
+}
+
+// settled returns true if running the rotation again with the same keys would
+// not get any further, because it either rotated everything or found data
+// that none of the keys can decrypt.
+func (rotation KeyRotation) settled() bool {
+	if !rotation.Finished() {
+		return false
+	}
+
+	switch rotation.Error {
+	case "", encryption.ErrDataIsEncryptedWithUnknownKey.Error(), encryption.ErrDataIsEncrypted.Error():
+		return true
+	default:
+		return false
+	}
+}
+
+// KeyRotationProgress is how far a key rotation has got through a table.
+// Cursor is the ID of the last row scanned, from which an interrupted
+// rotation carries on.
+type KeyRotationProgress struct {
+	Total   int `json:"total"`
+	Scanned int `json:"scanned"`
+	Rotated int `json:"rotated"`
+	Cursor  int `json:"cursor"`
+}
+
+//go:generate counterfeiter . KeyRotationFactory
+
+type KeyRotationFactory interface {
+	LatestKeyRotation() (KeyRotation, bool, error)
+}
+
+type keyRotationFactory struct {
+	conn Conn
+}
+
+func NewKeyRotationFactory(conn Conn) KeyRotationFactory {
+	return &keyRotationFactory{
+		conn: conn,
+	}
+}
+
+func (factory *keyRotationFactory) LatestKeyRotation() (KeyRotation, bool, error) {
+	return latestKeyRotation(factory.conn, sq.Eq{})
+}
+
+func latestKeyRotation(conn Conn, where sq.Eq) (KeyRotation, bool, error) {
+	var (
+		rotation   KeyRotation
+		finishedAt pq.NullTime
+		progress   []byte
+		errMessage sql.NullString
+	)
+
+	err := psql.Select("id, started_at, finished_at, progress, error").
+		From("encryption_key_rotations").
+		Where(where).
+		OrderBy("id DESC").
+		Limit(1).
+		RunWith(conn).
+		QueryRow().
+		Scan(&rotation.ID, &rotation.StartedAt, &finishedAt, &progress, &errMessage)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return KeyRotation{}, false, nil
+		}
+
+		return KeyRotation{}, false, err
+	}
+
+	err = json.Unmarshal(progress, &rotation.Progress)
+	if err != nil {
+		return KeyRotation{}, false, err
+	}
+
+	if finishedAt.Valid {
+		rotation.FinishedAt = finishedAt.Time
+	}
+
+	rotation.Error = errMessage.String
+
+	return rotation, true, nil
+}
+
+// KeyRotator rotates the encrypted data in the database to the keyring's
+// current key in batches, recording its progress as it goes. It is meant to
+// be run periodically under a lock.
+//
+// Rotations are recorded along with the keyring's fingerprint. Once one has
+// finished, or has found data that none of the keys can decrypt, runs with
+// the same keys do nothing, even after a restart, as everything written since
+// is encrypted with the current key. One which was interrupted carries on
+// from where it got to. No rotation is recorded when there is no data to
+// rotate.
+type KeyRotator struct {
+	conn      Conn
+	keyring   *encryption.Keyring
+	batchSize int
+
+	finished bool
+}
+
+func NewKeyRotator(conn Conn, keyring *encryption.Keyring, batchSize int) *KeyRotator {
+	return &KeyRotator{
+		conn:      conn,
+		keyring:   keyring,
+		batchSize: batchSize,
+	}
+}
+
+func (rotator *KeyRotator) Run(ctx context.Context) error {
+	if rotator.finished {
+		return nil
+	}
+
+	logger := lagerctx.FromContext(ctx).Session("rotate-encryption-key")
+
+	rotation, found, err := latestKeyRotation(rotator.conn, sq.Eq{
+		"key_fingerprint": rotator.keyring.Fingerprint(),
+	})
+	if err != nil {
+		logger.Error("failed-to-find-previous-rotation", err)
+		return err
+	}
+
+	if found && rotation.settled() {
+		logger.Debug("already-rotated", lager.Data{"rotation": rotation.ID})
+		rotator.finished = true
+		return nil
+	}
+
+	if found && !rotation.Finished() {
+		logger.Info("resuming", lager.Data{"rotation": rotation.ID})
+	} else {
+		pending, err := rotator.pending()
+		if err != nil {
+			logger.Error("failed-to-count-pending-data", err)
+			return err
+		}
+
+		if !pending.any() {
+			logger.Debug("nothing-to-rotate")
+			rotator.finished = true
+			return nil
+		}
+
+		rotation, err = rotator.start(pending)
+		if err != nil {
+			logger.Error("failed-to-start", err)
+			return err
+		}
+	}
+
+	tables := []string{}
+	for table := range encryptedColumns {
+		tables = append(tables, table)
+	}
+
+	sort.Strings(tables)
+
+	for _, table := range tables {
+		err = rotator.rotateTable(logger.Session("table", lager.Data{"table": table}), &rotation, table)
+		if err != nil {
+			break
+		}
+	}
+
+	finishErr := rotator.finish(rotation, err)
+	if finishErr != nil {
+		logger.Error("failed-to-finish", finishErr)
+		return finishErr
+	}
+
+	if err != nil {
+		if err == encryption.ErrDataIsEncryptedWithUnknownKey || err == encryption.ErrDataIsEncrypted {
+			// trying again will not help until the missing key is configured,
+			// which requires a restart
+			logger.Info("giving-up", lager.Data{"error": err.Error()})
+			rotator.finished = true
+		}
+
+		return err
+	}
+
+	rotator.finished = true
+
+	logger.Info("finished", lager.Data{"progress": rotation.Progress})
+
+	return nil
+}
+
+func (rotator *KeyRotator) pendingCondition(table string) sq.Sqlizer {
+	present := sq.NotEq{encryptedColumns[table]: nil}
+
+	if !rotator.keyring.Rotating() {
+		// with no old keys, only data which is not yet encrypted needs rotating
+		return sq.And{present, sq.Eq{"nonce": nil}}
+	}
+
+	return present
+}
+
+type pendingData map[string]int
+
+func (pending pendingData) any() bool {
+	for _, total := range pending {
+		if total > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (rotator *KeyRotator) pending() (pendingData, error) {
+	pending := pendingData{}
+
+	for table := range encryptedColumns {
+		var total int
+		err := psql.Select("COUNT(*)").
+			From(table).
+			Where(rotator.pendingCondition(table)).
+			RunWith(rotator.conn).
+			QueryRow().
+			Scan(&total)
+		if err != nil {
+			return nil, err
+		}
+
+		pending[table] = total
+	}
+
+	return pending, nil
+}
+
+func (rotator *KeyRotator) start(pending pendingData) (KeyRotation, error) {
+	rotation := KeyRotation{
+		Progress: map[string]KeyRotationProgress{},
+	}
+
+	for table, total := range pending {
+		rotation.Progress[table] = KeyRotationProgress{Total: total}
+	}
+
+	progress, err := json.Marshal(rotation.Progress)
+	if err != nil {
+		return KeyRotation{}, err
+	}
+
+	err = psql.Insert("encryption_key_rotations").
+		Columns("progress", "key_fingerprint").
+		Values(string(progress), rotator.keyring.Fingerprint()).
+		Suffix("RETURNING id, started_at").
+		RunWith(rotator.conn).
+		QueryRow().
+		Scan(&rotation.ID, &rotation.StartedAt)
+	if err != nil {
+		return KeyRotation{}, err
+	}
+
+	return rotation, nil
+}
+
+type encryptedRow struct {
+	id    int
+	val   string
+	nonce *string
+}
+
+func (rotator *KeyRotator) rotateTable(logger lager.Logger, rotation *KeyRotation, table string) error {
+	col := encryptedColumns[table]
+	progress := rotation.Progress[table]
+
+	for {
+		batch, err := rotator.nextBatch(table, progress.Cursor)
+		if err != nil {
+			logger.Error("failed-to-fetch-batch", err)
+			return err
+		}
+
+		if len(batch) == 0 {
+			return nil
+		}
+
+		for _, row := range batch {
+			progress.Cursor = row.id
+
+			encrypted, nonce, rotated, err := rotator.keyring.Reencrypt(row.val, row.nonce)
+			if err != nil {
+				logger.Error("failed-to-re-encrypt", err, lager.Data{"id": row.id})
+				return err
+			}
+
+			if !rotated {
+				continue
+			}
+
+			// the row may have been rewritten with the current key since it was
+			// read, in which case it is left alone
+			result, err := psql.Update(table).
+				Set(col, encrypted).
+				Set("nonce", nonce).
+				Where(sq.Eq{"id": row.id}).
+				Where(sq.Expr("nonce IS NOT DISTINCT FROM ?", row.nonce)).
+				RunWith(rotator.conn).
+				Exec()
+			if err != nil {
+				logger.Error("failed-to-update", err, lager.Data{"id": row.id})
+				return err
+			}
+
+			affected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+
+			progress.Rotated += int(affected)
+		}
+
+		progress.Scanned += len(batch)
+		rotation.Progress[table] = progress
+
+		err = rotator.saveProgress(*rotation)
+		if err != nil {
+			logger.Error("failed-to-save-progress", err)
+			return err
+		}
+	}
+}
+
+func (rotator *KeyRotator) nextBatch(table string, cursor int) ([]encryptedRow, error) {
+	rows, err := psql.Select("id", encryptedColumns[table], "nonce").
+		From(table).
+		Where(rotator.pendingCondition(table)).
+		Where(sq.Gt{"id": cursor}).
+		OrderBy("id").
+		Limit(uint64(rotator.batchSize)).
+		RunWith(rotator.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	batch := []encryptedRow{}
+	for rows.Next() {
+		var (
+			row   encryptedRow
+			nonce sql.NullString
+		)
+
+		err := rows.Scan(&row.id, &row.val, &nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		if nonce.Valid {
+			row.nonce = &nonce.String
+		}
+
+		batch = append(batch, row)
+	}
+
+	return batch, rows.Err()
+}
+
+func (rotator *KeyRotator) saveProgress(rotation KeyRotation) error {
+	progress, err := json.Marshal(rotation.Progress)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("encryption_key_rotations").
+		Set("progress", string(progress)).
+		Where(sq.Eq{"id": rotation.ID}).
+		RunWith(rotator.conn).
+		Exec()
+	return err
+}
+
+func (rotator *KeyRotator) finish(rotation KeyRotation, rotationErr error) error {
+	progress, err := json.Marshal(rotation.Progress)
+	if err != nil {
+		return err
+	}
+
+	var errMessage interface{}
+	if rotationErr != nil {
+		errMessage = rotationErr.Error()
+	}
+
+	_, err = psql.Update("encryption_key_rotations").
+		Set("progress", string(progress)).
+		Set("finished_at", sq.Expr("now()")).
+		Set("error", errMessage).
+		Where(sq.Eq{"id": rotation.ID}).
+		RunWith(rotator.conn).
+		Exec()
+	return err
+}
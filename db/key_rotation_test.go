@@ -0,0 +1,267 @@
+package db_test
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"database/sql"
+	"fmt"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/encryption"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyRotation", func() {
+	var (
+		oldKey *encryption.Key
+		newKey *encryption.Key
+
+		ctx                context.Context
+		keyRotationFactory db.KeyRotationFactory
+	)
+
+	key := func(k string) *encryption.Key {
+		block, err := aes.NewCipher([]byte(k))
+		Expect(err).ToNot(HaveOccurred())
+
+		aesgcm, err := cipher.NewGCM(block)
+		Expect(err).ToNot(HaveOccurred())
+
+		return encryption.NewKey(aesgcm)
+	}
+
+	resourceConfig := func() (string, *string) {
+		var (
+			config string
+			nonce  sql.NullString
+		)
+
+		err := psql.Select("config", "nonce").
+			From("resources").
+			Where(map[string]interface{}{"id": defaultResource.ID()}).
+			RunWith(dbConn).
+			QueryRow().
+			Scan(&config, &nonce)
+		Expect(err).ToNot(HaveOccurred())
+
+		if !nonce.Valid {
+			return config, nil
+		}
+
+		return config, &nonce.String
+	}
+
+	BeforeEach(func() {
+		oldKey = key("AES256Key-32Characters1234567890")
+		newKey = key("AES256Key-32Characters0987654321")
+
+		ctx = lagerctx.NewContext(context.Background(), logger)
+		keyRotationFactory = db.NewKeyRotationFactory(dbConn)
+	})
+
+	It("has no rotations to begin with", func() {
+		_, found, err := keyRotationFactory.LatestKeyRotation()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	Context("when data has not been encrypted yet", func() {
+		It("encrypts it with the current key", func() {
+			plaintext, nonce := resourceConfig()
+			Expect(nonce).To(BeNil())
+
+			err := db.NewKeyRotator(dbConn, encryption.NewKeyring(newKey), 1).Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			encrypted, nonce := resourceConfig()
+			Expect(nonce).ToNot(BeNil())
+
+			decrypted, err := newKey.Decrypt(encrypted, nonce)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(decrypted)).To(Equal(plaintext))
+		})
+	})
+
+	Context("when all data is already encrypted with the current key", func() {
+		BeforeEach(func() {
+			err := db.NewKeyRotator(dbConn, encryption.NewKeyring(newKey), 1).Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("does not record another rotation", func() {
+			first, found, err := keyRotationFactory.LatestKeyRotation()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = db.NewKeyRotator(dbConn, encryption.NewKeyring(newKey), 1).Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			latest, _, err := keyRotationFactory.LatestKeyRotation()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(latest.ID).To(Equal(first.ID))
+		})
+	})
+
+	Context("when data is encrypted with an old key", func() {
+		var plaintext string
+
+		BeforeEach(func() {
+			err := db.NewKeyRotator(dbConn, encryption.NewKeyring(oldKey), 1).Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			encrypted, nonce := resourceConfig()
+
+			decrypted, err := oldKey.Decrypt(encrypted, nonce)
+			Expect(err).ToNot(HaveOccurred())
+
+			plaintext = string(decrypted)
+		})
+
+		It("re-encrypts it with the current key in batches", func() {
+			rotator := db.NewKeyRotator(dbConn, encryption.NewKeyring(newKey, oldKey), 1)
+
+			err := rotator.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			encrypted, nonce := resourceConfig()
+
+			decrypted, err := newKey.Decrypt(encrypted, nonce)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(decrypted)).To(Equal(plaintext))
+
+			rotation, found, err := keyRotationFactory.LatestKeyRotation()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(rotation.Finished()).To(BeTrue())
+			Expect(rotation.Error).To(BeEmpty())
+			Expect(rotation.Progress["resources"]).To(Equal(db.KeyRotationProgress{
+				Total:   1,
+				Scanned: 1,
+				Rotated: 1,
+				Cursor:  defaultResource.ID(),
+			}))
+		})
+
+		It("does nothing when run again with the same keys, e.g. after a restart", func() {
+			err := db.NewKeyRotator(dbConn, encryption.NewKeyring(newKey, oldKey), 1).Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			first, _, err := keyRotationFactory.LatestKeyRotation()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = db.NewKeyRotator(dbConn, encryption.NewKeyring(newKey, oldKey), 1).Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			latest, _, err := keyRotationFactory.LatestKeyRotation()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(latest.ID).To(Equal(first.ID))
+		})
+
+		It("rotates again once the keys change", func() {
+			err := db.NewKeyRotator(dbConn, encryption.NewKeyring(newKey, oldKey), 1).Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			first, _, err := keyRotationFactory.LatestKeyRotation()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = db.NewKeyRotator(dbConn, encryption.NewKeyring(nil, newKey, oldKey), 1).Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			latest, _, err := keyRotationFactory.LatestKeyRotation()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(latest.ID).ToNot(Equal(first.ID))
+
+			config, nonce := resourceConfig()
+			Expect(nonce).To(BeNil())
+			Expect(config).To(Equal(plaintext))
+		})
+
+		Context("when a rotation with the same keys was interrupted", func() {
+			var interruptedID int
+
+			BeforeEach(func() {
+				keyring := encryption.NewKeyring(newKey, oldKey)
+
+				err := psql.Insert("encryption_key_rotations").
+					Columns("progress", "key_fingerprint").
+					Values(
+						fmt.Sprintf(`{"resources":{"total":1,"scanned":1,"rotated":0,"cursor":%d}}`, defaultResource.ID()),
+						keyring.Fingerprint(),
+					).
+					Suffix("RETURNING id").
+					RunWith(dbConn).
+					QueryRow().
+					Scan(&interruptedID)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("carries on from where it got to", func() {
+				err := db.NewKeyRotator(dbConn, encryption.NewKeyring(newKey, oldKey), 1).Run(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				rotation, _, err := keyRotationFactory.LatestKeyRotation()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rotation.ID).To(Equal(interruptedID))
+				Expect(rotation.Finished()).To(BeTrue())
+				Expect(rotation.Progress["resources"].Scanned).To(Equal(1))
+
+				encrypted, nonce := resourceConfig()
+
+				_, err = oldKey.Decrypt(encrypted, nonce)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		It("does nothing once the rotation has finished", func() {
+			rotator := db.NewKeyRotator(dbConn, encryption.NewKeyring(newKey, oldKey), 1)
+
+			err := rotator.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			first, _, err := keyRotationFactory.LatestKeyRotation()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = rotator.Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			latest, _, err := keyRotationFactory.LatestKeyRotation()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(latest.ID).To(Equal(first.ID))
+		})
+
+		It("decrypts it when there is no current key", func() {
+			err := db.NewKeyRotator(dbConn, encryption.NewKeyring(nil, oldKey), 1).Run(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			config, nonce := resourceConfig()
+			Expect(nonce).To(BeNil())
+			Expect(config).To(Equal(plaintext))
+		})
+
+		Context("when none of the keys decrypt it", func() {
+			It("records the failure and does not try again", func() {
+				otherKey := key("AES256Key-32Characters9564567123")
+				rotator := db.NewKeyRotator(dbConn, encryption.NewKeyring(newKey, otherKey), 1)
+
+				err := rotator.Run(ctx)
+				Expect(err).To(Equal(encryption.ErrDataIsEncryptedWithUnknownKey))
+
+				first, found, err := keyRotationFactory.LatestKeyRotation()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(first.Finished()).To(BeTrue())
+				Expect(first.Error).To(Equal(encryption.ErrDataIsEncryptedWithUnknownKey.Error()))
+
+				err = rotator.Run(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				latest, _, err := keyRotationFactory.LatestKeyRotation()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(latest.ID).To(Equal(first.ID))
+			})
+		})
+	})
+})
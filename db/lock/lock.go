@@ -20,6 +20,7 @@ const (
 	LockTypeVolumeCreating
 	LockTypeContainerCreating
 	LockTypeDatabaseMigration
+	LockTypeTeamEvents
 )
 
 var ErrLostLock = errors.New("lock was lost while held, possibly due to connection breakage")
@@ -0,0 +1,67 @@
+package migration_test
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Add roles to team auth", func() {
+	const preMigrationVersion = 1531234567
+	const postMigrationVersion = 1531300000
+
+	var (
+		db *sql.DB
+	)
+
+	Context("Up", func() {
+		It("gives the existing users and groups of each team the owner role", func() {
+			db = postgresRunner.OpenDBAtVersion(preMigrationVersion)
+
+			SetupTeam(db, "some-team", `{"users":["local:some-user"],"groups":["github:some-org"]}`)
+
+			_ = db.Close()
+
+			db = postgresRunner.OpenDBAtVersion(postMigrationVersion)
+
+			var auth string
+			err := db.QueryRow(`SELECT auth FROM teams WHERE name = 'some-team'`).Scan(&auth)
+			Expect(err).NotTo(HaveOccurred())
+
+			_ = db.Close()
+
+			var roles map[string]map[string][]string
+			err = json.Unmarshal([]byte(auth), &roles)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(roles).To(Equal(map[string]map[string][]string{
+				"owner": {
+					"users":  {"local:some-user"},
+					"groups": {"github:some-org"},
+				},
+			}))
+		})
+	})
+
+	Context("Down", func() {
+		It("keeps only the owners", func() {
+			db = postgresRunner.OpenDBAtVersion(postMigrationVersion)
+
+			SetupTeam(db, "some-team", `{"owner":{"users":["local:some-user"]},"viewer":{"users":["local:other-user"]}}`)
+
+			_ = db.Close()
+
+			db = postgresRunner.OpenDBAtVersion(preMigrationVersion)
+
+			auth := fetchTeamAuth(db, "some-team")
+
+			_ = db.Close()
+
+			Expect(auth).To(Equal(map[string]interface{}{
+				"users": []interface{}{"local:some-user"},
+			}))
+		})
+	})
+})
@@ -0,0 +1,99 @@
+package migration_test
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Backfill pipeline configs", func() {
+	const preMigrationVersion = 1532100000
+	const postMigrationVersion = 1532200000
+
+	var (
+		db *sql.DB
+	)
+
+	Context("Up", func() {
+		It("records the current config of pipelines without any versions", func() {
+			db = postgresRunner.OpenDBAtVersion(preMigrationVersion)
+
+			_, err := db.Exec(`
+				INSERT INTO teams(id, name) VALUES
+				(1, 'some-team')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO pipelines(id, team_id, name, groups, version) VALUES
+				(1, 1, 'old-pipeline', '[{"name":"some-group","jobs":["some-job"]}]', 3),
+				(2, 1, 'new-pipeline', NULL, 2)
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO jobs(pipeline_id, name, config, active) VALUES
+				(1, 'some-job', '{"name":"some-job"}', true),
+				(1, 'removed-job', '{"name":"removed-job"}', false)
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO resources(pipeline_id, name, config, active) VALUES
+				(1, 'some-resource', '{"name":"some-resource","type":"git"}', true)
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = db.Exec(`
+				INSERT INTO pipeline_configs(pipeline_id, version, config, author) VALUES
+				(2, 2, '{"jobs":[]}', 'some-user')
+			`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_ = db.Close()
+
+			db = postgresRunner.OpenDBAtVersion(postMigrationVersion)
+
+			var oldVersion int
+			var oldConfig string
+			err = db.QueryRow(`
+				SELECT version, config
+				FROM pipeline_configs
+				WHERE pipeline_id = 1
+			`).Scan(&oldVersion, &oldConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			var newVersions int
+			err = db.QueryRow(`
+				SELECT COUNT(*)
+				FROM pipeline_configs
+				WHERE pipeline_id = 2
+			`).Scan(&newVersions)
+			Expect(err).NotTo(HaveOccurred())
+
+			_ = db.Close()
+
+			Expect(oldVersion).To(Equal(3))
+			Expect(newVersions).To(Equal(1))
+
+			var config map[string]interface{}
+			err = json.Unmarshal([]byte(oldConfig), &config)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(config).To(Equal(map[string]interface{}{
+				"groups": []interface{}{
+					map[string]interface{}{"name": "some-group", "jobs": []interface{}{"some-job"}},
+				},
+				"jobs": []interface{}{
+					map[string]interface{}{"name": "some-job"},
+				},
+				"resources": []interface{}{
+					map[string]interface{}{"name": "some-resource", "type": "git"},
+				},
+				"resource_types": []interface{}{},
+			}))
+		})
+	})
+})
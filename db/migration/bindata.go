@@ -40,6 +40,30 @@
 // db/migration/migrations/1529692120_add_cache_index_to_pipelines.up.sql
 // db/migration/migrations/1530037770_replace_materialized_views_with_joins.down.sql
 // db/migration/migrations/1530037770_replace_materialized_views_with_joins.up.sql
+// db/migration/migrations/1531234567_add_active_volumes_to_workers.down.sql
+// db/migration/migrations/1531234567_add_active_volumes_to_workers.up.sql
+// db/migration/migrations/1531300000_add_roles_to_team_auth.down.sql
+// db/migration/migrations/1531300000_add_roles_to_team_auth.up.sql
+// db/migration/migrations/1531400000_create_pipeline_configs.down.sql
+// db/migration/migrations/1531400000_create_pipeline_configs.up.sql
+// db/migration/migrations/1531500000_add_pinned_version_to_resources.down.sql
+// db/migration/migrations/1531500000_add_pinned_version_to_resources.up.sql
+// db/migration/migrations/1531600000_add_redact_secrets_to_teams.down.sql
+// db/migration/migrations/1531600000_add_redact_secrets_to_teams.up.sql
+// db/migration/migrations/1531700000_create_encryption_key_rotations.down.sql
+// db/migration/migrations/1531700000_create_encryption_key_rotations.up.sql
+// db/migration/migrations/1531800000_create_notification_deliveries.down.sql
+// db/migration/migrations/1531800000_create_notification_deliveries.up.sql
+// db/migration/migrations/1531900000_create_team_events.down.sql
+// db/migration/migrations/1531900000_create_team_events.up.sql
+// db/migration/migrations/1532000000_add_missing_input_reasons_to_jobs.down.sql
+// db/migration/migrations/1532000000_add_missing_input_reasons_to_jobs.up.sql
+// db/migration/migrations/1532100000_add_job_triggers.down.sql
+// db/migration/migrations/1532100000_add_job_triggers.up.sql
+// db/migration/migrations/1532200000_backfill_pipeline_configs.down.sql
+// db/migration/migrations/1532200000_backfill_pipeline_configs.up.go
+// db/migration/migrations/1532300000_add_key_fingerprint_to_encryption_key_rotations.down.sql
+// db/migration/migrations/1532300000_add_key_fingerprint_to_encryption_key_rotations.up.sql
 // DO NOT EDIT!
 
 package migration
@@ -907,6 +931,486 @@ func _1530037770_replace_materialized_views_with_joinsUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __1531234567_add_active_volumes_to_workersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x4c\x2e\xc9\x2c\x4b\x8d\x2f\xcb\xcf\x29\xcd\x4d\x2d\xb6\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x52\x6c\xcb\x36\x41\x00\x00\x00")
+
+func _1531234567_add_active_volumes_to_workersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1531234567_add_active_volumes_to_workersDownSql,
+		"1531234567_add_active_volumes_to_workers.down.sql",
+	)
+}
+
+func _1531234567_add_active_volumes_to_workersDownSql() (*asset, error) {
+	bytes, err := _1531234567_add_active_volumes_to_workersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1531234567_add_active_volumes_to_workers.down.sql", size: 65, mode: os.FileMode(420), modTime: time.Unix(1792199044, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1531234567_add_active_volumes_to_workersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\xcf\x2f\xca\x4e\x2d\x2a\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x48\x4c\x2e\xc9\x2c\x4b\x8d\x2f\xcb\xcf\x29\xcd\x4d\x2d\x56\xc8\xcc\x2b\x49\x4d\x4f\x2d\x52\x70\x71\x75\x73\x0c\xf5\x09\x51\x30\xb0\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xfa\x3d\xa0\x38\x52\x00\x00\x00")
+
+func _1531234567_add_active_volumes_to_workersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1531234567_add_active_volumes_to_workersUpSql,
+		"1531234567_add_active_volumes_to_workers.up.sql",
+	)
+}
+
+func _1531234567_add_active_volumes_to_workersUpSql() (*asset, error) {
+	bytes, err := _1531234567_add_active_volumes_to_workersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1531234567_add_active_volumes_to_workers.up.sql", size: 82, mode: os.FileMode(420), modTime: time.Unix(1792199044, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1531300000_add_roles_to_team_authDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x08\x0d\x70\x71\x0c\x71\x55\x28\x49\x4d\xcc\x2d\x56\x08\x76\x0d\x51\x48\x2c\x2d\xc9\x50\xb0\x55\xd0\x00\xd1\x56\x56\x59\xc5\xf9\x79\xba\x76\xea\xf9\xe5\x79\xa9\x45\xea\x9a\x56\x56\x25\xa9\x15\x25\x0a\xe1\x1e\xae\x41\xae\x10\x85\x9e\xc1\x0a\x7e\xfe\x21\x0a\x7e\xa1\x3e\x3e\xd6\x5c\xce\xfe\xbe\xbe\x9e\x21\xd6\x5c\x00\xef\x74\x9a\x2e\x5d\x00\x00\x00")
+
+func _1531300000_add_roles_to_team_authDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1531300000_add_roles_to_team_authDownSql,
+		"1531300000_add_roles_to_team_auth.down.sql",
+	)
+}
+
+func _1531300000_add_roles_to_team_authDownSql() (*asset, error) {
+	bytes, err := _1531300000_add_roles_to_team_authDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1531300000_add_roles_to_team_auth.down.sql", size: 93, mode: os.FileMode(420), modTime: time.Unix(1792200216, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1531300000_add_roles_to_team_authUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x08\x0d\x70\x71\x0c\x71\x55\x28\x49\x4d\xcc\x2d\x56\x08\x76\x0d\x51\x48\x2c\x2d\xc9\x50\xb0\x55\xc8\x2a\xce\xcf\x8b\x4f\x2a\xcd\xcc\x49\x89\xcf\x4f\xca\x4a\x4d\x2e\xd1\x50\xcf\x2f\xcf\x4b\x2d\x52\xd7\x01\xab\xb0\xb2\x02\x29\xd0\xb4\xb2\x2a\x49\xad\x28\x51\x08\xf7\x70\x0d\x72\x85\xe8\xf4\x0c\x56\xf0\xf3\x0f\x51\xf0\x0b\xf5\xf1\xb1\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xcd\xac\xfe\xdc\x6e\x00\x00\x00")
+
+func _1531300000_add_roles_to_team_authUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1531300000_add_roles_to_team_authUpSql,
+		"1531300000_add_roles_to_team_auth.up.sql",
+	)
+}
+
+func _1531300000_add_roles_to_team_authUpSql() (*asset, error) {
+	bytes, err := _1531300000_add_roles_to_team_authUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1531300000_add_roles_to_team_auth.up.sql", size: 110, mode: os.FileMode(420), modTime: time.Unix(1792200216, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1531400000_create_pipeline_configsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x50\x2a\xc8\x2c\x48\xcd\xc9\xcc\x4b\x8d\x4f\xce\xcf\x4b\xcb\x4c\x2f\x56\xb2\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xde\xb1\x99\xce\x30\x00\x00\x00")
+
+func _1531400000_create_pipeline_configsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1531400000_create_pipeline_configsDownSql,
+		"1531400000_create_pipeline_configs.down.sql",
+	)
+}
+
+func _1531400000_create_pipeline_configsDownSql() (*asset, error) {
+	bytes, err := _1531400000_create_pipeline_configsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1531400000_create_pipeline_configs.down.sql", size: 48, mode: os.FileMode(420), modTime: time.Unix(1792200449, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1531400000_create_pipeline_configsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x85\x51\x4b\x6e\xc2\x30\x14\xdc\x73\x8a\x27\x6f\x08\x12\x37\x60\x65\x92\x07\xb2\x1a\x9c\xd6\x71\xa4\xb2\x8a\x22\x78\x80\x55\x70\xa2\xc4\x2d\x6d\x4f\x5f\xf3\x4b\x68\x53\xb5\xde\xd9\x33\x9e\x19\xcd\x4c\x71\x2e\xe4\x64\x00\x10\x2a\xe4\x1a\x41\xf3\x69\x8c\xc0\x2a\x53\xd1\xde\x58\xca\x57\xa5\xdd\x98\x6d\xc3\x20\xf0\x9c\xd3\x61\x66\xcd\xa0\xa1\xda\x14\xfb\xf1\xed\xa9\x65\x9f\x30\x63\x1d\x6d\xa9\x06\x99\x68\x90\x59\x1c\xb7\xac\x37\xaa\x1b\x53\xda\x3f\x18\x17\x33\x06\x8e\xde\x5d\x1f\xb5\xa5\x5d\xd1\x05\x6c\xdf\x8a\x57\xb7\x2b\xeb\x1f\x3f\x20\xc2\x19\xcf\x62\x0d\xc3\x61\x27\x5d\x53\xe1\x68\x9d\x17\xce\x93\xcd\x81\x1a\x57\x1c\x2a\x38\x1a\xb7\x3b\x5f\xe1\xb3\xb4\xd4\x17\xb0\xe5\x31\x18\xdd\x34\x1e\x95\x58\x70\xb5\x84\x07\x5c\x42\x70\xaa\xa1\x45\xc2\x44\xa6\x5a\x71\x21\x75\xbf\xb9\xfc\xae\x9c\x7c\xf3\x42\x1f\x0c\x66\x89\x42\x31\x97\x57\xa1\xfb\xf2\x46\xa0\x70\x86\x0a\x65\x88\x69\x27\xd5\xb0\x8b\x1d\x24\xd2\x27\x8b\xd1\xcf\x14\xf2\x34\xe4\x11\x7a\xff\xd1\xdd\x78\x99\x14\x4f\x19\x82\x90\x11\x3e\xff\x93\xe4\x3a\x46\x7e\x0e\xe4\x75\x7f\x5b\xfc\x5b\xb4\x71\x37\xa0\xb7\x0c\x93\xc5\x42\xe8\xc9\xe0\x0b\x8c\xed\xe9\xd7\x3f\x02\x00\x00")
+
+func _1531400000_create_pipeline_configsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1531400000_create_pipeline_configsUpSql,
+		"1531400000_create_pipeline_configs.up.sql",
+	)
+}
+
+func _1531400000_create_pipeline_configsUpSql() (*asset, error) {
+	bytes, err := _1531400000_create_pipeline_configsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1531400000_create_pipeline_configs.up.sql", size: 575, mode: os.FileMode(420), modTime: time.Unix(1792200449, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1531500000_add_pinned_version_to_resourcesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x50\x2a\x4a\x2d\xce\x2f\x2d\x4a\x4e\x2d\x56\x02\xca\xb8\x04\xf9\x07\x28\x38\xfb\xfb\x84\xfa\xfa\x29\x28\x15\x64\xe6\xe5\xa5\xa6\xc4\x97\xa5\x16\x15\x67\xe6\xe7\xc5\x67\xa6\x28\xe9\x60\x51\x12\x9f\x9c\x9f\x9b\x9b\x9a\x57\xa2\x64\xcd\xe5\xec\xef\xeb\xeb\x19\x62\xcd\x05\x00\x73\xe0\xa7\x41\x69\x00\x00\x00")
+
+func _1531500000_add_pinned_version_to_resourcesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1531500000_add_pinned_version_to_resourcesDownSql,
+		"1531500000_add_pinned_version_to_resources.down.sql",
+	)
+}
+
+func _1531500000_add_pinned_version_to_resourcesDownSql() (*asset, error) {
+	bytes, err := _1531500000_add_pinned_version_to_resourcesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1531500000_add_pinned_version_to_resources.down.sql", size: 105, mode: os.FileMode(420), modTime: time.Unix(1792200684, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1531500000_add_pinned_version_to_resourcesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6d\x8f\xcb\x0a\xc2\x30\x10\x45\xf7\xfd\x8a\x21\xab\x0a\xfe\x41\x57\x7d\x4c\x25\x98\x26\x90\xa6\x0b\x57\x59\xb4\xa3\x04\x69\x2a\x69\x14\xfd\x7b\x1f\x08\x15\xea\xfa\x1c\xee\xe1\x16\xb8\xe3\x32\x4b\x00\x72\x61\x50\x83\xc9\x0b\x81\xc0\x02\xcd\xd3\x35\xf4\x34\xb3\x37\xa9\x2a\x28\x95\xe8\x1a\x09\xec\xe2\xbc\xa7\xc1\xde\x28\xcc\x6e\xf2\xd6\x0d\x0c\x9c\x8f\x74\xa2\xb0\x5d\x9b\xb6\x9f\xc6\x91\x7c\x64\x10\xe9\x1e\x17\x41\xb6\x46\xe7\x5c\x9a\x9f\x8e\x5d\x0d\xdb\xe3\x99\x1e\x0c\x6a\xa5\x91\xef\x24\xec\xf1\x00\xe9\x9f\xfc\x06\x34\xd6\xa8\x51\x96\xd8\x02\xfb\x82\x97\xb2\x3c\x48\xd9\x47\x53\x12\x2a\x14\x68\x10\x5a\x34\x20\x3b\x21\xb2\xa4\x54\x4d\xc3\x4d\x96\x3c\x01\xe1\x0b\xa4\xf4\x05\x01\x00\x00")
+
+func _1531500000_add_pinned_version_to_resourcesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1531500000_add_pinned_version_to_resourcesUpSql,
+		"1531500000_add_pinned_version_to_resources.up.sql",
+	)
+}
+
+func _1531500000_add_pinned_version_to_resourcesUpSql() (*asset, error) {
+	bytes, err := _1531500000_add_pinned_version_to_resourcesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1531500000_add_pinned_version_to_resources.up.sql", size: 261, mode: os.FileMode(420), modTime: time.Unix(1792200684, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1531600000_add_redact_secrets_to_teamsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x50\x2a\x49\x4d\xcc\x2d\x56\x02\x8a\xba\x04\xf9\x07\x28\x38\xfb\xfb\x84\xfa\xfa\x29\x28\x15\xa5\xa6\x24\x26\x97\xc4\x17\xa7\x26\x17\xa5\x96\x14\x2b\x59\x73\x39\xfb\xfb\xfa\x7a\x86\x58\x73\x01\x00\xf0\x5b\x59\xeb\x45\x00\x00\x00")
+
+func _1531600000_add_redact_secrets_to_teamsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1531600000_add_redact_secrets_to_teamsDownSql,
+		"1531600000_add_redact_secrets_to_teams.down.sql",
+	)
+}
+
+func _1531600000_add_redact_secrets_to_teamsDownSql() (*asset, error) {
+	bytes, err := _1531600000_add_redact_secrets_to_teamsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1531600000_add_redact_secrets_to_teams.down.sql", size: 69, mode: os.FileMode(420), modTime: time.Unix(1792201678, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1531600000_add_redact_secrets_to_teamsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x15\xc8\x4d\x0a\x80\x20\x10\x06\xd0\xbd\xa7\xf8\xf0\x1a\xae\x2c\x2d\x82\x51\x21\xc6\x75\x58\x4d\xab\x7e\x20\xbd\x3f\xd1\x5b\xbe\xce\x8f\x53\x34\x0a\xb0\xc4\x7e\x06\xdb\x8e\x3c\x74\x93\x72\x55\xfd\xaf\x73\xe8\x13\xe5\x10\xa1\x5f\xd9\xcb\xd6\x96\x2a\xdb\x2b\xad\x6a\xac\xcf\x73\x4a\xb9\x11\x13\x23\x66\x22\x38\x3f\xd8\x4c\x8c\xa3\x9c\x55\x8c\xea\x53\x08\x13\x1b\xf5\x01\x53\xf4\xce\x11\x63\x00\x00\x00")
+
+func _1531600000_add_redact_secrets_to_teamsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1531600000_add_redact_secrets_to_teamsUpSql,
+		"1531600000_add_redact_secrets_to_teams.up.sql",
+	)
+}
+
+func _1531600000_add_redact_secrets_to_teamsUpSql() (*asset, error) {
+	bytes, err := _1531600000_add_redact_secrets_to_teamsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1531600000_add_redact_secrets_to_teams.up.sql", size: 99, mode: os.FileMode(420), modTime: time.Unix(1792201678, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1531700000_create_encryption_key_rotationsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x50\x4a\xcd\x4b\x2e\xaa\x2c\x28\xc9\xcc\xcf\x8b\xcf\x4e\xad\x8c\x2f\xca\x2f\x49\x04\x71\x8a\x95\xac\xb9\x9c\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\x1c\xc1\xe4\xf4\x38\x00\x00\x00")
+
+func _1531700000_create_encryption_key_rotationsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1531700000_create_encryption_key_rotationsDownSql,
+		"1531700000_create_encryption_key_rotations.down.sql",
+	)
+}
+
+func _1531700000_create_encryption_key_rotationsDownSql() (*asset, error) {
+	bytes, err := _1531700000_create_encryption_key_rotationsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1531700000_create_encryption_key_rotations.down.sql", size: 56, mode: os.FileMode(420), modTime: time.Unix(1792201978, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1531700000_create_encryption_key_rotationsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x85\x8f\xcb\x0e\x82\x30\x10\x45\xf7\x7c\xc5\xa4\x1b\x21\xf1\x0f\x58\x01\x56\x43\x04\x34\xa4\x2e\x5c\x11\x22\xa3\xd6\x47\x4b\xa6\x4d\x10\x8d\xff\x6e\x31\x81\x8d\x0b\x67\x77\xee\xdc\x9c\xcc\xc4\x7c\x95\x16\xa1\x07\x90\x94\x3c\x12\x1c\x44\x14\x67\x1c\x18\xaa\x03\xf5\xad\x95\x5a\x55\x57\xec\x2b\xd2\xb6\x1e\xc0\x30\xf0\x5d\x77\x18\x26\x1b\x06\x06\x49\xd6\xb7\xf9\x18\x19\x5b\x93\xc5\xa6\xaa\x2d\x03\x2b\xef\xe8\xf8\xde\x42\x27\xed\xf9\x8b\xf0\xd4\x0a\xa1\xd8\x08\x28\x76\x59\x06\x0b\xbe\x8c\x76\x99\x00\xa5\x3b\x3f\x98\x1c\x47\xa9\xa4\x39\xff\x91\x4c\xed\x96\xf4\x89\xd0\xb8\xbb\x2e\x46\xab\x5f\xf7\xec\xf5\x9e\x4d\x65\x24\xd2\xe4\xa4\xf8\xb0\x63\xb6\x2d\xd3\x3c\x2a\xf7\xb0\xe6\x7b\xf0\x87\x97\x02\xb7\x08\x42\x2f\xd9\xe4\x79\x2a\x42\xef\x03\x46\x57\x2c\x34\x1f\x01\x00\x00")
+
+func _1531700000_create_encryption_key_rotationsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1531700000_create_encryption_key_rotationsUpSql,
+		"1531700000_create_encryption_key_rotations.up.sql",
+	)
+}
+
+func _1531700000_create_encryption_key_rotationsUpSql() (*asset, error) {
+	bytes, err := _1531700000_create_encryption_key_rotationsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1531700000_create_encryption_key_rotations.up.sql", size: 287, mode: os.FileMode(420), modTime: time.Unix(1792201978, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1531800000_create_notification_deliveriesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x50\xca\xcb\x2f\xc9\x4c\xcb\x4c\x4e\x2c\xc9\xcc\xcf\x8b\x4f\x49\xcd\xc9\x2c\x4b\x2d\xca\x4c\x2d\x56\x42\x57\x97\x54\x9a\x99\x93\x12\x8f\xac\x1a\xa4\xc6\xd9\xdf\xd7\xd7\x33\xc4\x9a\x0b\x00\x3f\x19\xf3\x87\x5b\x00\x00\x00")
+
+func _1531800000_create_notification_deliveriesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1531800000_create_notification_deliveriesDownSql,
+		"1531800000_create_notification_deliveries.down.sql",
+	)
+}
+
+func _1531800000_create_notification_deliveriesDownSql() (*asset, error) {
+	bytes, err := _1531800000_create_notification_deliveriesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1531800000_create_notification_deliveries.down.sql", size: 91, mode: os.FileMode(420), modTime: time.Unix(1792202562, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1531800000_create_notification_deliveriesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb5\x52\x4d\x6f\x82\x40\x10\xbd\xf3\x2b\x26\x5c\x84\xc4\x43\xef\xa4\x07\xc4\xb5\x25\x45\x6c\x10\xd3\x7a\x22\x5b\x98\xda\x4d\x64\x97\xee\x2e\xda\xf4\xd7\x77\x51\x44\xeb\x47\x6d\xd2\x94\x0b\x99\x7d\x6f\xde\x9b\xd9\x7d\x03\x72\x17\xc6\x9e\x05\x10\x24\xc4\x4f\x09\xa4\xfe\x20\x22\x60\xbf\xd4\x6c\x59\x64\x5c\x68\xf6\xca\x72\xaa\x99\xe0\xca\x06\xc7\xd0\x9a\xaf\x45\x59\x61\x03\xe3\x1a\x17\x28\x21\x9e\xa4\x10\xcf\xa2\x08\x12\x32\x22\x09\x89\x03\x32\x85\x0d\x4b\x81\xc3\x0a\x17\x26\x31\x0c\x49\x44\x8c\x41\xe0\x4f\x03\x7f\x48\xfa\x3b\xad\x4a\xe2\x8a\x89\x5a\x65\x4a\x53\x5d\x1b\x97\xad\xf8\xb6\xda\xb1\x1e\x93\x70\xec\x27\x73\x78\x20\x73\x70\xf6\xf6\xae\x81\x5d\xcf\x3a\x99\xfe\x70\xee\xac\xc0\x25\x5b\xa1\x64\x78\xb8\x41\x33\xbb\x32\x87\x74\xd9\xff\x8f\xa5\x34\xd2\xf2\xaa\x54\x43\xba\xaa\xc4\x69\x89\xb6\xa1\x7e\xe8\x4e\xa3\xc3\x24\xbe\xd7\xa8\xf4\x25\x98\x0b\x9e\xb7\xbd\xdd\xd9\xee\x92\xbf\x75\x18\xef\x91\x3f\x8b\x52\xe8\x55\xc8\x0b\xc6\x17\xbd\x8e\x4f\xb5\xc6\xb2\xd2\xea\xcc\x26\xbb\xa6\x9b\x83\x79\x54\x65\x82\x82\x59\x2e\x0a\xec\x3a\x3a\x18\xa5\x14\xf2\x68\x1e\x6e\x8a\xac\x35\x31\x7f\x83\xb2\xd2\xac\x44\xcb\x0a\xd6\x4c\xbf\x6d\x4a\xf8\x14\x1c\x4f\x7d\xb9\x58\x3b\x6e\x27\x94\x4b\xa4\x1a\x8b\x3f\x69\xb4\x49\xb9\xa2\x72\x3e\x93\xe7\xd2\x18\xc6\x43\xf2\x0c\x17\xc2\x98\xb5\x19\x69\xde\xfe\x02\x05\x9c\x96\xe3\x7a\xbf\x15\x6d\x1f\xf0\x47\xd1\xa3\x3b\x77\xe1\xe9\xde\x44\x12\xb6\xd1\x80\xdb\x7d\x0a\x3c\x2b\x98\x8c\xc7\x61\xea\x59\x5f\x65\xaf\x67\x69\x26\x04\x00\x00")
+
+func _1531800000_create_notification_deliveriesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1531800000_create_notification_deliveriesUpSql,
+		"1531800000_create_notification_deliveries.up.sql",
+	)
+}
+
+func _1531800000_create_notification_deliveriesUpSql() (*asset, error) {
+	bytes, err := _1531800000_create_notification_deliveriesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1531800000_create_notification_deliveries.up.sql", size: 1062, mode: os.FileMode(420), modTime: time.Unix(1792202562, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1531900000_create_team_eventsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\x50\x2a\x49\x4d\xcc\x8d\x4f\x2d\x4b\xcd\x2b\x29\x56\xb2\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x0a\xa5\x7c\x97\x2b\x00\x00\x00")
+
+func _1531900000_create_team_eventsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1531900000_create_team_eventsDownSql,
+		"1531900000_create_team_events.down.sql",
+	)
+}
+
+func _1531900000_create_team_eventsDownSql() (*asset, error) {
+	bytes, err := _1531900000_create_team_eventsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1531900000_create_team_events.down.sql", size: 43, mode: os.FileMode(420), modTime: time.Unix(1792202926, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1531900000_create_team_eventsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7d\x90\xcd\x8a\x83\x30\x14\x85\xf7\x3e\xc5\xc1\x95\x42\xdf\xc0\x55\x1a\x6f\x07\x19\x8d\xc5\x5a\x98\xae\x24\x33\x5e\x3a\x81\xfa\x83\x86\xfe\xcc\xd3\x4f\x2c\x6d\x2d\x0c\x9d\x90\x4d\xf2\x1d\xbe\xdc\x9c\x25\xbd\x25\x2a\xf2\x00\x59\x90\x28\x09\xa5\x58\xa6\x04\xdf\xb2\x6e\x2a\x3e\x72\x6b\x47\x1f\x81\xc3\xd3\xf2\x4d\xed\xe3\xd3\xec\x47\x1e\x8c\x3e\x2c\xee\xb7\xd7\xec\x84\x4c\x6b\x79\xcf\x03\x54\x5e\x42\x6d\xd3\x14\x05\xad\xa8\x20\x25\x69\x83\x29\x34\x22\x30\x75\x88\x5c\x21\xa6\x94\xdc\x63\x52\x6c\xa4\x88\x69\x36\x5d\x7a\xf6\x5d\xf4\x6c\x1f\x8e\x07\x3b\xf2\x30\x9a\xae\x7d\x85\x7b\x7d\x39\x74\xba\x7e\x85\xbf\x06\xd6\x96\xeb\x4a\x5b\x97\x30\x0d\x8f\x56\x37\x3d\x4e\xc6\x7e\x5f\x8f\xf8\xe9\x5a\x9e\xe7\x8e\x69\x25\xb6\x69\x89\xb6\x3b\x05\xe1\xdd\xb1\x2e\x92\x4c\x14\x3b\xbc\xd3\x0e\xc1\x54\x45\xe8\x40\x18\x79\x73\x77\x89\x8a\xe9\x03\x4f\xd5\x55\xb7\x6a\xdc\x9e\x7e\xfd\x44\x10\xdc\xd0\x02\xae\x92\xe8\x3f\xc7\x3c\xfa\x1f\xc7\x8c\x9c\x42\xe6\x59\x96\x94\x91\xf7\x0b\x5b\x77\xc7\xe1\xd2\x01\x00\x00")
+
+func _1531900000_create_team_eventsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1531900000_create_team_eventsUpSql,
+		"1531900000_create_team_events.up.sql",
+	)
+}
+
+func _1531900000_create_team_eventsUpSql() (*asset, error) {
+	bytes, err := _1531900000_create_team_eventsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1531900000_create_team_events.up.sql", size: 466, mode: os.FileMode(420), modTime: time.Unix(1792202926, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1532000000_add_missing_input_reasons_to_jobsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xc8\xca\x4f\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xc8\xcd\x2c\x2e\xce\xcc\x4b\x8f\xcf\xcc\x2b\x28\x2d\x89\x2f\x4a\x4d\x2c\xce\xcf\x2b\xb6\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xf9\x62\xc7\xa6\x45\x00\x00\x00")
+
+func _1532000000_add_missing_input_reasons_to_jobsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1532000000_add_missing_input_reasons_to_jobsDownSql,
+		"1532000000_add_missing_input_reasons_to_jobs.down.sql",
+	)
+}
+
+func _1532000000_add_missing_input_reasons_to_jobsDownSql() (*asset, error) {
+	bytes, err := _1532000000_add_missing_input_reasons_to_jobsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1532000000_add_missing_input_reasons_to_jobs.down.sql", size: 69, mode: os.FileMode(420), modTime: time.Unix(1792203302, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1532000000_add_missing_input_reasons_to_jobsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xc8\xca\x4f\x2a\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\xc8\xcd\x2c\x2e\xce\xcc\x4b\x8f\xcf\xcc\x2b\x28\x2d\x89\x2f\x4a\x4d\x2c\xce\xcf\x2b\x56\x28\x49\xad\x28\xb1\xe6\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x83\xbb\xea\x2a\x49\x00\x00\x00")
+
+func _1532000000_add_missing_input_reasons_to_jobsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1532000000_add_missing_input_reasons_to_jobsUpSql,
+		"1532000000_add_missing_input_reasons_to_jobs.up.sql",
+	)
+}
+
+func _1532000000_add_missing_input_reasons_to_jobsUpSql() (*asset, error) {
+	bytes, err := _1532000000_add_missing_input_reasons_to_jobsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1532000000_add_missing_input_reasons_to_jobs.up.sql", size: 73, mode: os.FileMode(420), modTime: time.Unix(1792203302, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1532100000_add_job_triggersDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x50\xca\xca\x4f\x2a\x56\x02\x0a\xba\x04\xf9\x07\x28\x38\xfb\xfb\x84\xfa\xfa\x29\x28\x95\x14\x65\xa6\xa7\xa7\x16\x15\xc7\xa7\x96\x25\xe6\x94\x26\x96\xa4\xa6\xc4\x27\x96\x28\x59\x73\xa1\x6b\x4e\x2a\xcd\xcc\x49\xc1\xa9\x3d\x3e\x39\xb1\xb4\x38\x15\xa8\xcd\xd9\xdf\xd7\xd7\x33\xc4\x9a\x0b\x00\x97\xf6\xc9\x57\x82\x00\x00\x00")
+
+func _1532100000_add_job_triggersDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1532100000_add_job_triggersDownSql,
+		"1532100000_add_job_triggers.down.sql",
+	)
+}
+
+func _1532100000_add_job_triggersDownSql() (*asset, error) {
+	bytes, err := _1532100000_add_job_triggersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1532100000_add_job_triggers.down.sql", size: 130, mode: os.FileMode(420), modTime: time.Unix(1792204066, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1532100000_add_job_triggersUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x50\xca\xca\x4f\x2a\x56\x02\x09\xba\xb8\x28\x38\xfb\xfb\x84\xfa\xfa\x29\x28\x95\x14\x65\xa6\xa7\xa7\x16\x15\xc7\xa7\x96\x25\xe6\x94\x26\x96\xa4\xa6\xc4\x27\x96\x28\x29\x94\x64\xe6\xa6\x16\x97\x24\xe6\x16\x28\x94\x67\x96\x64\x80\xb9\x0a\x55\xf9\x79\xa9\xd6\x5c\xe8\x86\x26\x95\x66\xe6\xa4\xe0\x32\x36\x3e\x39\xb1\xb4\x38\x15\x68\x5c\x6a\x45\x89\x35\x97\xb3\xbf\xaf\xaf\x67\x88\x35\x17\x00\x83\x6b\xf8\xb2\x9e\x00\x00\x00")
+
+func _1532100000_add_job_triggersUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1532100000_add_job_triggersUpSql,
+		"1532100000_add_job_triggers.up.sql",
+	)
+}
+
+func _1532100000_add_job_triggersUpSql() (*asset, error) {
+	bytes, err := _1532100000_add_job_triggersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1532100000_add_job_triggers.up.sql", size: 158, mode: os.FileMode(420), modTime: time.Unix(1792204066, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1532200000_backfill_pipeline_configsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x3d\x8d\x31\x0e\x82\x40\x10\x45\xfb\x3d\xc5\xef\x68\xe0\x04\x74\x1a\x62\x28\xd0\xc6\x0b\x0c\xc3\xac\x6e\x58\x77\xc9\xcc\x48\xe2\xed\xa5\x30\xb6\x2f\x79\xef\x9d\x86\xcb\x78\xed\x03\xd0\x75\x98\x89\xd7\x98\x72\x96\x05\x5c\x4b\x4c\x0f\xec\xa2\x96\x6a\x31\x30\x95\xc6\x31\x0b\xbc\xe6\x05\xb4\x91\x3a\xa2\xd6\x17\x6a\x11\x83\xd1\x7e\x38\x96\x0a\xcb\x2f\xe5\x4f\xf9\x34\x2a\x58\x65\xf3\x16\x64\x7f\x60\x7e\x0c\x40\xcc\x6f\x25\x97\x70\xbe\x4d\xd3\x78\xef\xc3\x17\x0f\x58\x8f\x91\x87\x00\x00\x00")
+
+func _1532200000_backfill_pipeline_configsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1532200000_backfill_pipeline_configsDownSql,
+		"1532200000_backfill_pipeline_configs.down.sql",
+	)
+}
+
+func _1532200000_backfill_pipeline_configsDownSql() (*asset, error) {
+	bytes, err := _1532200000_backfill_pipeline_configsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1532200000_backfill_pipeline_configs.down.sql", size: 135, mode: os.FileMode(420), modTime: time.Unix(1792205495, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1532200000_backfill_pipeline_configsUpGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x95\x55\x6d\x6f\xdb\x36\x10\xfe\x6c\xfd\x8a\x9b\x11\x74\x52\x2b\x28\x4b\xba\x7d\x29\xe0\x0f\x4d\xec\x61\x01\x1a\x67\xb3\xdd\x6e\x43\x10\xd8\xb4\x44\x3b\x6a\x65\x4a\x23\xe9\x24\x46\x90\xff\xbe\xe3\xab\x5e\xec\xac\x9e\x01\x3b\x21\x79\xf7\xdc\xdd\xc3\xe3\x73\x15\x49\xbf\x91\x35\x85\x4d\xbe\xe6\x44\xe6\x25\x13\x41\x90\x6f\xaa\x92\x4b\x08\x83\x5e\x3f\x23\x92\x2c\x89\xa0\xa7\xe2\x9f\xa2\x8f\x6b\xca\xd2\x32\xcb\xd9\xfa\xf4\xab\x28\x59\x3f\x88\x82\xe0\xf4\x14\x3e\x57\xf3\xb3\x5f\xde\x9f\x9f\xff\xa4\x3e\xc0\x69\x5a\xf2\x4c\x80\xbc\xa7\x90\x6e\x39\xa7\x4c\x42\x5a\xb2\x55\xbe\x86\x72\x05\x94\xa4\xf7\x50\xe5\x15\x2d\x72\x46\xd1\x86\x48\x78\x24\x02\x0a\x22\xa4\x82\x12\xe4\x81\x66\xb0\xa4\xab\x92\x53\xe7\xf5\x40\xb9\x50\x89\xc1\x23\xe5\x34\x46\x7c\x22\x04\xdd\x2c\x11\x60\x0d\xb9\x84\x15\x2f\x37\x3a\x98\x43\xfd\x51\x28\xa4\x35\x2f\xb7\x95\x00\xc2\x32\x34\xc2\xbf\xa9\xcc\x1f\x28\x7c\x2d\x97\x42\x41\x88\x72\xcb\x53\x8a\xff\xaa\x73\xb7\x04\xb9\xab\xa8\x48\x82\xd5\x96\xa5\x10\x0a\x5a\xac\xe0\x6d\xcd\x4b\xd4\xae\x33\x8c\x80\x72\x5e\x72\x78\x0e\x7a\xf2\x29\x56\x0b\xf8\x30\x00\xe5\x95\x0c\x2f\x92\x0b\xba\xce\x59\x18\x05\xbd\x7c\xa5\x8f\x7e\x18\x00\xcb\x0b\x65\xdc\xe3\x54\x6e\x39\x53\xbb\x41\xef\x25\x08\x7a\x19\x5d\x51\x0e\x2a\x28\x62\x2a\x83\x39\x0c\x40\x3e\x25\x93\xb2\x28\x96\x78\x3d\x0a\xe5\x05\x7f\x82\x1e\x2f\x1f\x85\x8f\x84\x16\x7f\x6c\x29\xdf\x85\x0b\x74\x99\x8e\x3e\x8d\x2e\x67\x50\x25\x79\x16\xe3\xaf\xa5\x4c\xfd\x6b\x78\x40\x93\x5f\x27\x37\xd7\x9e\x23\x01\x15\x6e\xfd\xf9\xdb\x68\x32\x82\xf1\xcd\x0c\x46\x7f\x5d\x4d\x67\x53\x75\xe5\x1e\xeb\x0c\x5a\x1e\x73\x73\x1b\x02\x52\x30\x6e\x69\xe2\x8f\xf2\x0c\x33\x56\xb1\xd1\x1d\x93\x5d\x1c\x55\xb6\x22\x1b\xb6\xcc\xa6\x4a\xb3\xdf\x5d\x53\x08\xc9\xb7\xa9\xd4\x3e\x08\xac\x3f\x39\x93\xb8\xb2\xa6\x76\x65\x2f\x18\x54\x27\x26\x13\xf2\x78\x4d\x85\xc0\x4e\x36\xe0\x75\x9d\xc8\xd4\xed\xdd\x81\x30\xcf\x2f\x41\x0f\xbb\x0c\x14\xa7\xc9\x98\x3e\x49\x4b\xbe\xef\x4d\x74\x3c\xec\xa6\x32\x21\xdc\x35\x18\x3e\x8c\x64\xbc\x2d\x8a\xa9\xe4\xd8\x90\x78\xa6\xca\x1e\x18\xd8\x69\x4a\x58\xf8\xc6\x21\xea\xbb\xa9\x57\xfe\x8e\xde\x18\xa4\x48\xd5\xbb\xc7\x5a\x8b\x36\x5d\x9a\xcf\xd0\x5e\x2d\x06\xeb\x50\x10\xf6\x19\x26\xd4\xb7\x80\xc6\x2a\xf9\x42\x0a\x64\x53\x43\x7e\x1f\xc0\xfa\x98\x9a\xa2\x6e\x60\xe5\x41\xaa\x8a\xb2\x2c\xf4\x5b\xb1\x6f\x94\xc8\xdc\x80\xe2\x76\x5e\xef\x2a\x3a\x39\x61\x6b\xda\x68\x41\x95\x8c\x7d\xe3\x78\xba\x21\xd5\xad\xd0\x01\xef\x3a\xe9\xe8\xa4\xfb\x26\xa7\xfe\x07\xe8\xa4\x1f\xbb\xf4\x54\xc4\x6f\x74\x17\x03\x4a\x56\xd1\x08\xd8\x00\x36\x7f\x0c\x9e\x92\x02\x44\xab\x3f\x66\x27\xd6\x87\x5e\x21\x9c\x45\x63\xa7\x6d\x31\xd7\xa2\x81\x66\xdd\x1d\x9d\x97\x21\xdc\x3e\x9d\xb6\x48\x18\x45\x72\x7d\x75\x69\x4c\x42\x25\x25\x3a\xff\x9a\x3a\xec\x1b\x75\x05\x87\x9a\xa3\xdd\x1d\x86\x06\x1b\xed\x16\xa9\xb8\x33\x11\xed\xfd\x5e\x13\x2e\xee\x49\x11\xda\x6c\x8e\xc6\x74\xb7\x4f\x76\x45\x49\x32\x5f\xc4\x01\xcc\xa3\x5b\x18\xc7\x08\xdf\x55\x12\x9f\x95\x03\x65\x25\x4b\x69\x9b\x20\xec\x3e\x22\xe9\x7a\x97\x8c\x8c\x75\x68\x33\x38\x3a\xca\xdc\x95\x8f\x52\x39\x7a\xa2\xa9\x56\xca\xde\xd5\x78\x3a\x9a\xcc\xe0\x6a\x3c\xbb\xd9\x17\xb7\xb0\xa1\x69\x31\xf8\x27\x6a\x8e\x6d\x96\x9a\xb8\x2f\x1f\x3f\x7d\x1e\xa1\x60\x9e\x9c\xc5\x70\x72\x8e\xdf\xf7\xf8\xfd\x59\x1d\x2d\x5a\x37\xd7\x58\x78\xb4\x57\xaa\x3f\xae\x2e\x5d\x9a\xdd\xc3\xba\x2e\xcb\xcd\x26\x47\xed\x0a\x70\xfb\xb5\xb9\xf5\x5a\xa7\xc1\x5b\x25\x5e\x33\xd7\x71\x60\x5e\x47\x9d\xf1\xd5\x50\x49\x6d\x04\xe1\x6d\xf7\x45\xc6\x66\xf6\x69\xc5\xfc\xee\x50\x6a\x91\xe7\x06\xd1\xe2\x9d\x8e\xf9\x6e\xe1\xc7\x50\x7b\x9a\x9c\x9c\xe1\xc1\xc7\xf1\xd0\x26\x8f\x8b\x9b\xc9\x70\x34\x81\x8b\xbf\x41\x8d\x99\x45\x33\xcb\xff\x1a\x37\xb8\x8c\x3b\x33\xc7\xd3\x6f\x98\x68\xce\x9b\xd4\xef\x18\x2d\xd7\x29\xc3\x9e\xc6\x2b\xa8\x0e\x8a\x1d\x34\x9d\xdd\xd7\x86\x4c\xad\x7a\xfb\x0e\x87\x46\x88\xb1\x4f\x1c\x93\x6e\x7d\x44\xdb\xd4\xf5\x77\x5e\x9e\x4b\xdb\x4b\x79\xf7\xc4\x75\xbd\xd5\xf3\xb4\x59\x66\x57\xa0\x5f\xbc\xde\xd7\x95\x19\xf1\xdd\x8b\xf7\x6c\xc7\xa7\xce\x9d\x09\x8a\x4d\xe8\xd8\xc6\x2a\x9a\x85\x35\x47\x96\xb7\x1e\xb4\x8b\x4f\xfc\xd8\xd5\xd5\x65\xb4\x15\xed\x15\x3d\x19\x1a\xab\xb0\xc3\xaa\x8b\xf1\xff\x08\x4d\xbb\x3c\x7a\xb1\xef\x0e\xd5\x4e\x72\x51\xd4\x7a\xcb\xde\x0d\x03\xe0\x63\xfe\x17\x69\xc1\x7c\x40\x98\x0b\x00\x00")
+
+func _1532200000_backfill_pipeline_configsUpGoBytes() ([]byte, error) {
+	return bindataRead(
+		__1532200000_backfill_pipeline_configsUpGo,
+		"1532200000_backfill_pipeline_configs.up.go",
+	)
+}
+
+func _1532200000_backfill_pipeline_configsUpGo() (*asset, error) {
+	bytes, err := _1532200000_backfill_pipeline_configsUpGoBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1532200000_backfill_pipeline_configs.up.go", size: 2968, mode: os.FileMode(420), modTime: time.Unix(1792205492, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1532300000_add_key_fingerprint_to_encryption_key_rotationsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x50\x4a\xcd\x4b\x2e\xaa\x2c\x28\xc9\xcc\xcf\x8b\xcf\x4e\xad\x8c\x2f\xca\x2f\x49\x04\x71\x8a\x95\x80\x0a\x5d\x82\xfc\x03\x14\x9c\xfd\x7d\x42\x7d\xfd\x14\x94\x40\xb2\x69\x99\x79\xe9\xa9\x45\x05\x45\x99\x79\x25\x4a\xd6\x5c\xce\xfe\xbe\xbe\x9e\x21\xd6\x5c\x00\x6d\x6c\x10\x96\x59\x00\x00\x00")
+
+func _1532300000_add_key_fingerprint_to_encryption_key_rotationsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1532300000_add_key_fingerprint_to_encryption_key_rotationsDownSql,
+		"1532300000_add_key_fingerprint_to_encryption_key_rotations.down.sql",
+	)
+}
+
+func _1532300000_add_key_fingerprint_to_encryption_key_rotationsDownSql() (*asset, error) {
+	bytes, err := _1532300000_add_key_fingerprint_to_encryption_key_rotationsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1532300000_add_key_fingerprint_to_encryption_key_rotations.down.sql", size: 89, mode: os.FileMode(420), modTime: time.Unix(1792207934, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __1532300000_add_key_fingerprint_to_encryption_key_rotationsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x73\x72\x75\xf7\xf4\xb3\xe6\x52\x50\x70\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x50\x4a\xcd\x4b\x2e\xaa\x2c\x28\xc9\xcc\xcf\x8b\xcf\x4e\xad\x8c\x2f\xca\x2f\x49\x04\x71\x8a\x95\x40\x0a\x5d\x5c\x14\x9c\xfd\x7d\x42\x7d\xfd\x14\x94\x40\x92\x69\x99\x79\xe9\xa9\x45\x05\x45\x99\x79\x25\x4a\x0a\x25\xa9\x15\x25\xd6\x5c\xce\xfe\xbe\xbe\x9e\x21\xd6\x5c\x00\xcf\xa8\x11\x18\x5d\x00\x00\x00")
+
+func _1532300000_add_key_fingerprint_to_encryption_key_rotationsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1532300000_add_key_fingerprint_to_encryption_key_rotationsUpSql,
+		"1532300000_add_key_fingerprint_to_encryption_key_rotations.up.sql",
+	)
+}
+
+func _1532300000_add_key_fingerprint_to_encryption_key_rotationsUpSql() (*asset, error) {
+	bytes, err := _1532300000_add_key_fingerprint_to_encryption_key_rotationsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1532300000_add_key_fingerprint_to_encryption_key_rotations.up.sql", size: 93, mode: os.FileMode(420), modTime: time.Unix(1792207934, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -999,6 +1503,30 @@ var _bindata = map[string]func() (*asset, error){
 	"1529692120_add_cache_index_to_pipelines.up.sql": _1529692120_add_cache_index_to_pipelinesUpSql,
 	"1530037770_replace_materialized_views_with_joins.down.sql": _1530037770_replace_materialized_views_with_joinsDownSql,
 	"1530037770_replace_materialized_views_with_joins.up.sql": _1530037770_replace_materialized_views_with_joinsUpSql,
+	"1531234567_add_active_volumes_to_workers.down.sql": _1531234567_add_active_volumes_to_workersDownSql,
+	"1531234567_add_active_volumes_to_workers.up.sql": _1531234567_add_active_volumes_to_workersUpSql,
+	"1531300000_add_roles_to_team_auth.down.sql": _1531300000_add_roles_to_team_authDownSql,
+	"1531300000_add_roles_to_team_auth.up.sql": _1531300000_add_roles_to_team_authUpSql,
+	"1531400000_create_pipeline_configs.down.sql": _1531400000_create_pipeline_configsDownSql,
+	"1531400000_create_pipeline_configs.up.sql": _1531400000_create_pipeline_configsUpSql,
+	"1531500000_add_pinned_version_to_resources.down.sql": _1531500000_add_pinned_version_to_resourcesDownSql,
+	"1531500000_add_pinned_version_to_resources.up.sql": _1531500000_add_pinned_version_to_resourcesUpSql,
+	"1531600000_add_redact_secrets_to_teams.down.sql": _1531600000_add_redact_secrets_to_teamsDownSql,
+	"1531600000_add_redact_secrets_to_teams.up.sql": _1531600000_add_redact_secrets_to_teamsUpSql,
+	"1531700000_create_encryption_key_rotations.down.sql": _1531700000_create_encryption_key_rotationsDownSql,
+	"1531700000_create_encryption_key_rotations.up.sql": _1531700000_create_encryption_key_rotationsUpSql,
+	"1531800000_create_notification_deliveries.down.sql": _1531800000_create_notification_deliveriesDownSql,
+	"1531800000_create_notification_deliveries.up.sql": _1531800000_create_notification_deliveriesUpSql,
+	"1531900000_create_team_events.down.sql": _1531900000_create_team_eventsDownSql,
+	"1531900000_create_team_events.up.sql": _1531900000_create_team_eventsUpSql,
+	"1532000000_add_missing_input_reasons_to_jobs.down.sql": _1532000000_add_missing_input_reasons_to_jobsDownSql,
+	"1532000000_add_missing_input_reasons_to_jobs.up.sql": _1532000000_add_missing_input_reasons_to_jobsUpSql,
+	"1532100000_add_job_triggers.down.sql": _1532100000_add_job_triggersDownSql,
+	"1532100000_add_job_triggers.up.sql": _1532100000_add_job_triggersUpSql,
+	"1532200000_backfill_pipeline_configs.down.sql": _1532200000_backfill_pipeline_configsDownSql,
+	"1532200000_backfill_pipeline_configs.up.go": _1532200000_backfill_pipeline_configsUpGo,
+	"1532300000_add_key_fingerprint_to_encryption_key_rotations.down.sql": _1532300000_add_key_fingerprint_to_encryption_key_rotationsDownSql,
+	"1532300000_add_key_fingerprint_to_encryption_key_rotations.up.sql": _1532300000_add_key_fingerprint_to_encryption_key_rotationsUpSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -1081,6 +1609,30 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"1529692120_add_cache_index_to_pipelines.up.sql": &bintree{_1529692120_add_cache_index_to_pipelinesUpSql, map[string]*bintree{}},
 	"1530037770_replace_materialized_views_with_joins.down.sql": &bintree{_1530037770_replace_materialized_views_with_joinsDownSql, map[string]*bintree{}},
 	"1530037770_replace_materialized_views_with_joins.up.sql": &bintree{_1530037770_replace_materialized_views_with_joinsUpSql, map[string]*bintree{}},
+	"1531234567_add_active_volumes_to_workers.down.sql": &bintree{_1531234567_add_active_volumes_to_workersDownSql, map[string]*bintree{}},
+	"1531234567_add_active_volumes_to_workers.up.sql": &bintree{_1531234567_add_active_volumes_to_workersUpSql, map[string]*bintree{}},
+	"1531300000_add_roles_to_team_auth.down.sql": &bintree{_1531300000_add_roles_to_team_authDownSql, map[string]*bintree{}},
+	"1531300000_add_roles_to_team_auth.up.sql": &bintree{_1531300000_add_roles_to_team_authUpSql, map[string]*bintree{}},
+	"1531400000_create_pipeline_configs.down.sql": &bintree{_1531400000_create_pipeline_configsDownSql, map[string]*bintree{}},
+	"1531400000_create_pipeline_configs.up.sql": &bintree{_1531400000_create_pipeline_configsUpSql, map[string]*bintree{}},
+	"1531500000_add_pinned_version_to_resources.down.sql": &bintree{_1531500000_add_pinned_version_to_resourcesDownSql, map[string]*bintree{}},
+	"1531500000_add_pinned_version_to_resources.up.sql": &bintree{_1531500000_add_pinned_version_to_resourcesUpSql, map[string]*bintree{}},
+	"1531600000_add_redact_secrets_to_teams.down.sql": &bintree{_1531600000_add_redact_secrets_to_teamsDownSql, map[string]*bintree{}},
+	"1531600000_add_redact_secrets_to_teams.up.sql": &bintree{_1531600000_add_redact_secrets_to_teamsUpSql, map[string]*bintree{}},
+	"1531700000_create_encryption_key_rotations.down.sql": &bintree{_1531700000_create_encryption_key_rotationsDownSql, map[string]*bintree{}},
+	"1531700000_create_encryption_key_rotations.up.sql": &bintree{_1531700000_create_encryption_key_rotationsUpSql, map[string]*bintree{}},
+	"1531800000_create_notification_deliveries.down.sql": &bintree{_1531800000_create_notification_deliveriesDownSql, map[string]*bintree{}},
+	"1531800000_create_notification_deliveries.up.sql": &bintree{_1531800000_create_notification_deliveriesUpSql, map[string]*bintree{}},
+	"1531900000_create_team_events.down.sql": &bintree{_1531900000_create_team_eventsDownSql, map[string]*bintree{}},
+	"1531900000_create_team_events.up.sql": &bintree{_1531900000_create_team_eventsUpSql, map[string]*bintree{}},
+	"1532000000_add_missing_input_reasons_to_jobs.down.sql": &bintree{_1532000000_add_missing_input_reasons_to_jobsDownSql, map[string]*bintree{}},
+	"1532000000_add_missing_input_reasons_to_jobs.up.sql": &bintree{_1532000000_add_missing_input_reasons_to_jobsUpSql, map[string]*bintree{}},
+	"1532100000_add_job_triggers.down.sql": &bintree{_1532100000_add_job_triggersDownSql, map[string]*bintree{}},
+	"1532100000_add_job_triggers.up.sql": &bintree{_1532100000_add_job_triggersUpSql, map[string]*bintree{}},
+	"1532200000_backfill_pipeline_configs.down.sql": &bintree{_1532200000_backfill_pipeline_configsDownSql, map[string]*bintree{}},
+	"1532200000_backfill_pipeline_configs.up.go": &bintree{_1532200000_backfill_pipeline_configsUpGo, map[string]*bintree{}},
+	"1532300000_add_key_fingerprint_to_encryption_key_rotations.down.sql": &bintree{_1532300000_add_key_fingerprint_to_encryption_key_rotationsDownSql, map[string]*bintree{}},
+	"1532300000_add_key_fingerprint_to_encryption_key_rotations.up.sql": &bintree{_1532300000_add_key_fingerprint_to_encryption_key_rotationsUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
@@ -0,0 +1,3 @@
+BEGIN;
+  UPDATE teams SET auth = (auth::json->'owner')::text WHERE auth IS NOT NULL;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  UPDATE teams SET auth = json_build_object('owner', auth::json)::text WHERE auth IS NOT NULL;
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+  CREATE TABLE "pipeline_configs" (
+      "id" serial,
+      "pipeline_id" integer NOT NULL,
+      "version" integer NOT NULL,
+      "config" text NOT NULL,
+      "nonce" text,
+      "author" text NOT NULL DEFAULT '',
+      "created_at" timestamp with time zone NOT NULL DEFAULT now(),
+      PRIMARY KEY ("id"),
+      CONSTRAINT "pipeline_configs_pipeline_id_fkey" FOREIGN KEY ("pipeline_id") REFERENCES "pipelines"("id") ON DELETE CASCADE
+  );
+  CREATE UNIQUE INDEX "pipeline_configs_pipeline_id_version_key" ON "pipeline_configs" ("pipeline_id", "version");
+COMMIT;
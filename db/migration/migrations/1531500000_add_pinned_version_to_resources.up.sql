@@ -0,0 +1,6 @@
+BEGIN;
+  ALTER TABLE "resources"
+  ADD COLUMN "pinned_version_id" integer,
+  ADD COLUMN "pin_comment" text,
+  ADD CONSTRAINT "resources_pinned_version_id_fkey" FOREIGN KEY ("pinned_version_id") REFERENCES "versioned_resources"("id") ON DELETE SET NULL;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE "teams"
+  ADD COLUMN "redact_secrets" boolean NOT NULL DEFAULT false;
+COMMIT;
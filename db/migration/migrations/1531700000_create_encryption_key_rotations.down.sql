@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE "encryption_key_rotations";
+COMMIT;
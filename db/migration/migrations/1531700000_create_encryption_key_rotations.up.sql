@@ -0,0 +1,10 @@
+BEGIN;
+  CREATE TABLE "encryption_key_rotations" (
+      "id" serial,
+      "started_at" timestamp with time zone NOT NULL DEFAULT now(),
+      "finished_at" timestamp with time zone,
+      "progress" json NOT NULL DEFAULT '{}',
+      "error" text,
+      PRIMARY KEY ("id")
+  );
+COMMIT;
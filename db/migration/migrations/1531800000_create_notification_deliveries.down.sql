@@ -0,0 +1,4 @@
+BEGIN;
+  DROP TABLE "notification_deliveries";
+  DROP TABLE "build_notifications";
+COMMIT;
@@ -0,0 +1,27 @@
+BEGIN;
+  CREATE TABLE "build_notifications" (
+      "build_id" integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+      "previous_status" build_status,
+      PRIMARY KEY ("build_id")
+  );
+
+  CREATE TABLE "notification_deliveries" (
+      "id" serial,
+      "build_id" integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+      "team_id" integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+      "name" text NOT NULL,
+      "request" text NOT NULL,
+      "nonce" text,
+      "status" text NOT NULL DEFAULT 'pending',
+      "attempts" integer NOT NULL DEFAULT 0,
+      "response_code" integer,
+      "error" text,
+      "next_attempt_at" timestamp with time zone NOT NULL DEFAULT now(),
+      "created_at" timestamp with time zone NOT NULL DEFAULT now(),
+      "delivered_at" timestamp with time zone,
+      PRIMARY KEY ("id")
+  );
+
+  CREATE INDEX notification_deliveries_team_id ON notification_deliveries (team_id);
+  CREATE INDEX notification_deliveries_pending ON notification_deliveries (next_attempt_at) WHERE status = 'pending';
+COMMIT;
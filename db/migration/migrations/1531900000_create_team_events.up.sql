@@ -0,0 +1,14 @@
+BEGIN;
+  CREATE TABLE "team_events" (
+      "id" bigserial,
+      "team_id" integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+      "type" text NOT NULL,
+      "version" text NOT NULL,
+      "payload" text NOT NULL,
+      "created_at" timestamp with time zone NOT NULL DEFAULT now(),
+      PRIMARY KEY ("id")
+  );
+
+  CREATE INDEX team_events_team_id_id ON team_events (team_id, id);
+  CREATE INDEX team_events_created_at ON team_events (created_at);
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE jobs DROP COLUMN missing_input_reasons;
+COMMIT;
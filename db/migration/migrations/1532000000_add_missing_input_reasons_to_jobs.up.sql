@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE jobs ADD COLUMN missing_input_reasons text;
+COMMIT;
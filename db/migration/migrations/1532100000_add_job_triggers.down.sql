@@ -0,0 +1,7 @@
+BEGIN;
+  ALTER TABLE "jobs"
+  DROP COLUMN "triggers_evaluated_at";
+
+  ALTER TABLE "builds"
+  DROP COLUMN "trigger_cause";
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+  ALTER TABLE "jobs"
+  ADD COLUMN "triggers_evaluated_at" timestamp with time zone;
+
+  ALTER TABLE "builds"
+  ADD COLUMN "trigger_cause" text;
+COMMIT;
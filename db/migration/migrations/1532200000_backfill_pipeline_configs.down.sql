@@ -0,0 +1,4 @@
+BEGIN;
+  -- backfilled config versions can't be told apart from ones saved since;
+  -- they're kept, as they're still accurate
+COMMIT;
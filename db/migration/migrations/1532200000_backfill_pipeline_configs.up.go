@@ -0,0 +1,144 @@
+package migrations
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+// Up_1532200000 records the current config of each pipeline that was last
+// saved before config versions were, reassembling it from the pipeline's
+// groups and its active jobs, resources, and resource types.
+func (self *migrations) Up_1532200000() error {
+	tx, err := self.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	rows, err := tx.Query(`
+		SELECT p.id, p.version, p.groups
+		FROM pipelines p
+		WHERE NOT EXISTS (
+			SELECT 1 FROM pipeline_configs c WHERE c.pipeline_id = p.id
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	type unversionedPipeline struct {
+		id      int
+		version int
+		groups  json.RawMessage
+	}
+
+	pipelines := []unversionedPipeline{}
+	for rows.Next() {
+		pipeline := unversionedPipeline{}
+
+		var groups sql.NullString
+		err = rows.Scan(&pipeline.id, &pipeline.version, &groups)
+		if err != nil {
+			return err
+		}
+
+		pipeline.groups = json.RawMessage("null")
+		if groups.Valid {
+			pipeline.groups = json.RawMessage(groups.String)
+		}
+
+		pipelines = append(pipelines, pipeline)
+	}
+
+	for _, pipeline := range pipelines {
+		config := map[string]json.RawMessage{
+			"groups": pipeline.groups,
+		}
+
+		for key, table := range map[string]string{
+			"jobs":           "jobs",
+			"resources":      "resources",
+			"resource_types": "resource_types",
+		} {
+			configs, err := self.activePipelineConfigs(tx, table, pipeline.id)
+			if err != nil {
+				return err
+			}
+
+			config[key], err = json.Marshal(configs)
+			if err != nil {
+				return err
+			}
+		}
+
+		payload, err := json.Marshal(config)
+		if err != nil {
+			return err
+		}
+
+		encryptedPayload, nonce, err := self.Strategy.Encrypt(payload)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO pipeline_configs (pipeline_id, version, config, nonce)
+			VALUES ($1, $2, $3, $4)
+		`, pipeline.id, pipeline.version, encryptedPayload, nonce)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (self *migrations) activePipelineConfigs(tx *sql.Tx, table string, pipelineID int) ([]json.RawMessage, error) {
+	rows, err := tx.Query(`
+		SELECT config, nonce
+		FROM `+table+`
+		WHERE pipeline_id = $1
+		AND active
+		ORDER BY id
+	`, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	type encryptedConfig struct {
+		config string
+		nonce  sql.NullString
+	}
+
+	encryptedConfigs := []encryptedConfig{}
+	for rows.Next() {
+		config := encryptedConfig{}
+
+		err = rows.Scan(&config.config, &config.nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		encryptedConfigs = append(encryptedConfigs, config)
+	}
+
+	configs := []json.RawMessage{}
+	for _, config := range encryptedConfigs {
+		var noncense *string
+		if config.nonce.Valid {
+			noncense = &config.nonce.String
+		}
+
+		decryptedConfig, err := self.Strategy.Decrypt(config.config, noncense)
+		if err != nil {
+			return nil, err
+		}
+
+		configs = append(configs, json.RawMessage(decryptedConfig))
+	}
+
+	return configs, nil
+}
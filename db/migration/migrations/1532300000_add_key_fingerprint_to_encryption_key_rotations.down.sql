@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE "encryption_key_rotations"
+  DROP COLUMN "key_fingerprint";
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE "encryption_key_rotations"
+  ADD COLUMN "key_fingerprint" text;
+COMMIT;
@@ -0,0 +1,339 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliveryStatusPending   NotificationDeliveryStatus = "pending"
+	NotificationDeliveryStatusSucceeded NotificationDeliveryStatus = "succeeded"
+	NotificationDeliveryStatusFailed    NotificationDeliveryStatus = "failed"
+)
+
+// BuildNotification is a job's build which has finished and has yet to be
+// matched against its pipeline's notifications. PreviousStatus is the status
+// of the job's build before it, or empty if there was none.
+type BuildNotification struct {
+	BuildID        int
+	BuildName      string
+	Status         BuildStatus
+	PreviousStatus BuildStatus
+	TeamID         int
+	TeamName       string
+	PipelineID     int
+	PipelineName   string
+	JobName        string
+}
+
+// NotificationRequest is the HTTP request made to deliver a notification. The
+// URL and headers are stored as configured, with credentials interpolated only
+// when the request is made.
+type NotificationRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body"`
+}
+
+type NotificationDelivery struct {
+	ID           int
+	Name         string
+	BuildID      int
+	BuildName    string
+	TeamName     string
+	PipelineName string
+	JobName      string
+	Request      NotificationRequest
+	Status       NotificationDeliveryStatus
+	Attempts     int
+	ResponseCode int
+	Error        string
+	CreatedAt    time.Time
+	DeliveredAt  time.Time
+}
+
+// NotificationDeliveryAttempt is the outcome of making a delivery's request.
+// A failed attempt is retried at RetryAt, or not at all if RetryAt is zero.
+type NotificationDeliveryAttempt struct {
+	Succeeded    bool
+	ResponseCode int
+	Error        string
+	RetryAt      time.Time
+}
+
+//go:generate counterfeiter . NotificationFactory
+
+type NotificationFactory interface {
+	QueuedBuildNotifications(limit int) ([]BuildNotification, error)
+	CreateDeliveries(BuildNotification, []NotificationDelivery) error
+
+	PendingDeliveries(limit int) ([]NotificationDelivery, error)
+	SaveDeliveryAttempt(deliveryID int, attempt NotificationDeliveryAttempt) error
+}
+
+type notificationFactory struct {
+	conn Conn
+}
+
+func NewNotificationFactory(conn Conn) NotificationFactory {
+	return &notificationFactory{
+		conn: conn,
+	}
+}
+
+func (factory *notificationFactory) QueuedBuildNotifications(limit int) ([]BuildNotification, error) {
+	rows, err := psql.Select("n.build_id, b.name, b.status, n.previous_status, t.id, t.name, p.id, p.name, j.name").
+		From("build_notifications n").
+		Join("builds b ON b.id = n.build_id").
+		Join("jobs j ON j.id = b.job_id").
+		Join("pipelines p ON p.id = b.pipeline_id").
+		Join("teams t ON t.id = b.team_id").
+		OrderBy("n.build_id ASC").
+		Limit(uint64(limit)).
+		RunWith(factory.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	notifications := []BuildNotification{}
+	for rows.Next() {
+		var (
+			notification   BuildNotification
+			previousStatus sql.NullString
+		)
+
+		err = rows.Scan(
+			&notification.BuildID,
+			&notification.BuildName,
+			&notification.Status,
+			&previousStatus,
+			&notification.TeamID,
+			&notification.TeamName,
+			&notification.PipelineID,
+			&notification.PipelineName,
+			&notification.JobName,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		notification.PreviousStatus = BuildStatus(previousStatus.String)
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+func (factory *notificationFactory) CreateDeliveries(notification BuildNotification, deliveries []NotificationDelivery) error {
+	tx, err := factory.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	es := factory.conn.EncryptionStrategy()
+
+	for _, delivery := range deliveries {
+		payload, err := json.Marshal(delivery.Request)
+		if err != nil {
+			return err
+		}
+
+		encryptedPayload, nonce, err := es.Encrypt(payload)
+		if err != nil {
+			return err
+		}
+
+		_, err = psql.Insert("notification_deliveries").
+			Columns("build_id", "team_id", "name", "request", "nonce").
+			Values(notification.BuildID, notification.TeamID, delivery.Name, encryptedPayload, nonce).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = psql.Delete("build_notifications").
+		Where(sq.Eq{"build_id": notification.BuildID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (factory *notificationFactory) PendingDeliveries(limit int) ([]NotificationDelivery, error) {
+	rows, err := notificationDeliveriesQuery.
+		Where(sq.Eq{"d.status": NotificationDeliveryStatusPending}).
+		Where(sq.Expr("d.next_attempt_at <= now()")).
+		OrderBy("d.id ASC").
+		Limit(uint64(limit)).
+		RunWith(factory.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanNotificationDeliveries(factory.conn, rows)
+}
+
+func (factory *notificationFactory) SaveDeliveryAttempt(deliveryID int, attempt NotificationDeliveryAttempt) error {
+	var responseCode, errMessage interface{}
+	if attempt.ResponseCode != 0 {
+		responseCode = attempt.ResponseCode
+	}
+
+	if attempt.Error != "" {
+		errMessage = attempt.Error
+	}
+
+	update := psql.Update("notification_deliveries").
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("response_code", responseCode).
+		Set("error", errMessage).
+		Where(sq.Eq{"id": deliveryID})
+
+	if attempt.Succeeded {
+		update = update.
+			Set("status", NotificationDeliveryStatusSucceeded).
+			Set("delivered_at", sq.Expr("now()"))
+	} else if attempt.RetryAt.IsZero() {
+		update = update.
+			Set("status", NotificationDeliveryStatusFailed)
+	} else {
+		update = update.
+			Set("next_attempt_at", attempt.RetryAt)
+	}
+
+	_, err := update.RunWith(factory.conn).Exec()
+	return err
+}
+
+var notificationDeliveriesQuery = psql.Select(`
+		d.id,
+		d.name,
+		d.build_id,
+		b.name,
+		t.name,
+		p.name,
+		j.name,
+		d.request,
+		d.nonce,
+		d.status,
+		d.attempts,
+		d.response_code,
+		d.error,
+		d.created_at,
+		d.delivered_at
+	`).
+	From("notification_deliveries d").
+	Join("builds b ON b.id = d.build_id").
+	Join("jobs j ON j.id = b.job_id").
+	Join("pipelines p ON p.id = b.pipeline_id").
+	Join("teams t ON t.id = d.team_id")
+
+func scanNotificationDeliveries(conn Conn, rows *sql.Rows) ([]NotificationDelivery, error) {
+	defer Close(rows)
+
+	deliveries := []NotificationDelivery{}
+	for rows.Next() {
+		delivery, err := scanNotificationDelivery(conn, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func scanNotificationDelivery(conn Conn, row scannable) (NotificationDelivery, error) {
+	var (
+		delivery     NotificationDelivery
+		request      string
+		nonce        sql.NullString
+		responseCode sql.NullInt64
+		errMessage   sql.NullString
+		deliveredAt  pq.NullTime
+	)
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.Name,
+		&delivery.BuildID,
+		&delivery.BuildName,
+		&delivery.TeamName,
+		&delivery.PipelineName,
+		&delivery.JobName,
+		&request,
+		&nonce,
+		&delivery.Status,
+		&delivery.Attempts,
+		&responseCode,
+		&errMessage,
+		&delivery.CreatedAt,
+		&deliveredAt,
+	)
+	if err != nil {
+		return NotificationDelivery{}, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decryptedRequest, err := conn.EncryptionStrategy().Decrypt(request, noncense)
+	if err != nil {
+		return NotificationDelivery{}, err
+	}
+
+	err = json.Unmarshal(decryptedRequest, &delivery.Request)
+	if err != nil {
+		return NotificationDelivery{}, err
+	}
+
+	delivery.ResponseCode = int(responseCode.Int64)
+	delivery.Error = errMessage.String
+
+	if deliveredAt.Valid {
+		delivery.DeliveredAt = deliveredAt.Time
+	}
+
+	return delivery, nil
+}
+
+func queueBuildNotification(tx Tx, jobID int, buildID int) error {
+	_, err := tx.Exec(`
+		INSERT INTO build_notifications (build_id, previous_status)
+		SELECT $1, (
+			SELECT status
+			FROM builds
+			WHERE job_id = $2
+			AND id < $1
+			AND completed
+			ORDER BY id DESC
+			LIMIT 1
+		)
+		WHERE NOT EXISTS (
+			SELECT 1 FROM build_notifications WHERE build_id = $1
+		)
+	`, buildID, jobID)
+	return err
+}
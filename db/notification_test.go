@@ -0,0 +1,206 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NotificationFactory", func() {
+	var notificationFactory db.NotificationFactory
+
+	BeforeEach(func() {
+		notificationFactory = db.NewNotificationFactory(dbConn)
+	})
+
+	Describe("finishing builds", func() {
+		It("queues a notification for each finished job build with the status of the build before it", func() {
+			firstBuild, err := defaultJob.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			secondBuild, err := defaultJob.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(firstBuild.Finish(db.BuildStatusSucceeded)).To(Succeed())
+			Expect(secondBuild.Finish(db.BuildStatusFailed)).To(Succeed())
+
+			notifications, err := notificationFactory.QueuedBuildNotifications(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(notifications).To(Equal([]db.BuildNotification{
+				{
+					BuildID:      firstBuild.ID(),
+					BuildName:    firstBuild.Name(),
+					Status:       db.BuildStatusSucceeded,
+					TeamID:       defaultTeam.ID(),
+					TeamName:     defaultTeam.Name(),
+					PipelineID:   defaultPipeline.ID(),
+					PipelineName: defaultPipeline.Name(),
+					JobName:      defaultJob.Name(),
+				},
+				{
+					BuildID:        secondBuild.ID(),
+					BuildName:      secondBuild.Name(),
+					Status:         db.BuildStatusFailed,
+					PreviousStatus: db.BuildStatusSucceeded,
+					TeamID:         defaultTeam.ID(),
+					TeamName:       defaultTeam.Name(),
+					PipelineID:     defaultPipeline.ID(),
+					PipelineName:   defaultPipeline.Name(),
+					JobName:        defaultJob.Name(),
+				},
+			}))
+		})
+
+		It("does not queue notifications for one-off builds", func() {
+			build, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(build.Finish(db.BuildStatusSucceeded)).To(Succeed())
+
+			notifications, err := notificationFactory.QueuedBuildNotifications(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(notifications).To(BeEmpty())
+		})
+	})
+
+	Describe("deliveries", func() {
+		var (
+			build        db.Build
+			notification db.BuildNotification
+		)
+
+		BeforeEach(func() {
+			var err error
+			build, err = defaultJob.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(build.Finish(db.BuildStatusFailed)).To(Succeed())
+
+			notifications, err := notificationFactory.QueuedBuildNotifications(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(notifications).To(HaveLen(1))
+
+			notification = notifications[0]
+
+			err = notificationFactory.CreateDeliveries(notification, []db.NotificationDelivery{
+				{
+					Name: "some-notification",
+					Request: db.NotificationRequest{
+						Method:  "POST",
+						URL:     "https://example.com/((token))",
+						Headers: map[string]string{"Content-Type": "application/json"},
+						Body:    `{"status":"failed"}`,
+					},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("removes the build from the queue", func() {
+			notifications, err := notificationFactory.QueuedBuildNotifications(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(notifications).To(BeEmpty())
+		})
+
+		It("returns the pending deliveries", func() {
+			deliveries, err := notificationFactory.PendingDeliveries(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deliveries).To(HaveLen(1))
+
+			delivery := deliveries[0]
+			Expect(delivery.Name).To(Equal("some-notification"))
+			Expect(delivery.BuildID).To(Equal(build.ID()))
+			Expect(delivery.BuildName).To(Equal(build.Name()))
+			Expect(delivery.TeamName).To(Equal(defaultTeam.Name()))
+			Expect(delivery.PipelineName).To(Equal(defaultPipeline.Name()))
+			Expect(delivery.JobName).To(Equal(defaultJob.Name()))
+			Expect(delivery.Status).To(Equal(db.NotificationDeliveryStatusPending))
+			Expect(delivery.Request).To(Equal(db.NotificationRequest{
+				Method:  "POST",
+				URL:     "https://example.com/((token))",
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    `{"status":"failed"}`,
+			}))
+		})
+
+		Context("when a delivery succeeds", func() {
+			BeforeEach(func() {
+				deliveries, err := notificationFactory.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = notificationFactory.SaveDeliveryAttempt(deliveries[0].ID, db.NotificationDeliveryAttempt{
+					Succeeded:    true,
+					ResponseCode: 200,
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is no longer pending", func() {
+				deliveries, err := notificationFactory.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(BeEmpty())
+			})
+
+			It("is recorded in the team's delivery log", func() {
+				deliveries, err := defaultTeam.NotificationDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(HaveLen(1))
+				Expect(deliveries[0].Status).To(Equal(db.NotificationDeliveryStatusSucceeded))
+				Expect(deliveries[0].Attempts).To(Equal(1))
+				Expect(deliveries[0].ResponseCode).To(Equal(200))
+				Expect(deliveries[0].DeliveredAt).ToNot(BeZero())
+			})
+		})
+
+		Context("when a delivery fails and is to be retried", func() {
+			BeforeEach(func() {
+				deliveries, err := notificationFactory.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = notificationFactory.SaveDeliveryAttempt(deliveries[0].ID, db.NotificationDeliveryAttempt{
+					ResponseCode: 503,
+					Error:        "unexpected response",
+					RetryAt:      time.Now().Add(time.Hour),
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is not pending until it is to be retried", func() {
+				deliveries, err := notificationFactory.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(BeEmpty())
+
+				deliveries, err = defaultTeam.NotificationDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(HaveLen(1))
+				Expect(deliveries[0].Status).To(Equal(db.NotificationDeliveryStatusPending))
+				Expect(deliveries[0].Attempts).To(Equal(1))
+				Expect(deliveries[0].ResponseCode).To(Equal(503))
+				Expect(deliveries[0].Error).To(Equal("unexpected response"))
+			})
+		})
+
+		Context("when a delivery fails for good", func() {
+			BeforeEach(func() {
+				deliveries, err := notificationFactory.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = notificationFactory.SaveDeliveryAttempt(deliveries[0].ID, db.NotificationDeliveryAttempt{
+					Error: "connection refused",
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("is marked as failed", func() {
+				deliveries, err := defaultTeam.NotificationDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(HaveLen(1))
+				Expect(deliveries[0].Status).To(Equal(db.NotificationDeliveryStatusFailed))
+				Expect(deliveries[0].ResponseCode).To(BeZero())
+				Expect(deliveries[0].Error).To(Equal("connection refused"))
+			})
+		})
+	})
+})
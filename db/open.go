@@ -3,7 +3,6 @@ package db
 import (
 	"database/sql"
 	"database/sql/driver"
-	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -54,15 +53,8 @@ type Tx interface {
 	Stmt(stmt *sql.Stmt) *sql.Stmt
 }
 
-func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *encryption.Key, oldKey *encryption.Key, connectionName string, lockFactory lock.LockFactory) (Conn, error) {
+func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, strategy encryption.Strategy, connectionName string, lockFactory lock.LockFactory) (Conn, error) {
 	for {
-		var strategy encryption.Strategy
-		if newKey != nil {
-			strategy = newKey
-		} else {
-			strategy = encryption.NewNoEncryption()
-		}
-
 		sqlDb, err := migration.NewOpenHelper(sqlDriver, sqlDataSource, lockFactory, strategy).Open()
 		if err != nil {
 			if shouldRetry(err) {
@@ -74,23 +66,6 @@ func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *e
 			return nil, err
 		}
 
-		switch {
-		case oldKey != nil && newKey == nil:
-			err = decryptToPlaintext(logger.Session("decrypt"), sqlDb, oldKey)
-		case oldKey != nil && newKey != nil:
-			err = encryptWithNewKey(logger.Session("rotate"), sqlDb, newKey, oldKey)
-		}
-		if err != nil {
-			return nil, err
-		}
-
-		if newKey != nil {
-			err = encryptPlaintext(logger.Session("encrypt"), sqlDb, newKey)
-			if err != nil {
-				return nil, err
-			}
-		}
-
 		listener := pq.NewListener(sqlDataSource, time.Second, time.Minute, nil)
 
 		return &db{
@@ -115,218 +90,6 @@ func shouldRetry(err error) bool {
 	return false
 }
 
-var encryptedColumns = map[string]string{
-	"teams":          "legacy_auth",
-	"resources":      "config",
-	"jobs":           "config",
-	"resource_types": "config",
-	"builds":         "engine_metadata",
-}
-
-func encryptPlaintext(logger lager.Logger, sqlDB *sql.DB, key *encryption.Key) error {
-	for table, col := range encryptedColumns {
-		rows, err := sqlDB.Query(`
-			SELECT id, ` + col + `
-			FROM ` + table + `
-			WHERE nonce IS NULL
-		`)
-		if err != nil {
-			return err
-		}
-
-		tLog := logger.Session("table", lager.Data{
-			"table": table,
-		})
-
-		encryptedRows := 0
-
-		for rows.Next() {
-			var (
-				id  int
-				val sql.NullString
-			)
-
-			err := rows.Scan(&id, &val)
-			if err != nil {
-				tLog.Error("failed-to-scan", err)
-				return err
-			}
-
-			if !val.Valid {
-				continue
-			}
-
-			rLog := tLog.Session("row", lager.Data{
-				"id": id,
-			})
-
-			encrypted, nonce, err := key.Encrypt([]byte(val.String))
-			if err != nil {
-				rLog.Error("failed-to-encrypt", err)
-				return err
-			}
-
-			_, err = sqlDB.Exec(`
-				UPDATE `+table+`
-				SET `+col+` = $1, nonce = $2
-				WHERE id = $3
-			`, encrypted, nonce, id)
-			if err != nil {
-				rLog.Error("failed-to-update", err)
-				return err
-			}
-
-			encryptedRows++
-		}
-
-		if encryptedRows > 0 {
-			tLog.Info("encrypted-existing-plaintext-data", lager.Data{
-				"rows": encryptedRows,
-			})
-		}
-	}
-
-	return nil
-}
-
-func decryptToPlaintext(logger lager.Logger, sqlDB *sql.DB, oldKey *encryption.Key) error {
-	for table, col := range encryptedColumns {
-		rows, err := sqlDB.Query(`
-			SELECT id, nonce, ` + col + `
-			FROM ` + table + `
-			WHERE nonce IS NOT NULL
-		`)
-		if err != nil {
-			return err
-		}
-
-		tLog := logger.Session("table", lager.Data{
-			"table": table,
-		})
-
-		decryptedRows := 0
-
-		for rows.Next() {
-			var (
-				id         int
-				val, nonce string
-			)
-
-			err := rows.Scan(&id, &nonce, &val)
-			if err != nil {
-				tLog.Error("failed-to-scan", err)
-				return err
-			}
-
-			rLog := tLog.Session("row", lager.Data{
-				"id": id,
-			})
-
-			decrypted, err := oldKey.Decrypt(val, &nonce)
-			if err != nil {
-				rLog.Error("failed-to-decrypt", err)
-				return err
-			}
-
-			_, err = sqlDB.Exec(`
-				UPDATE `+table+`
-				SET `+col+` = $1, nonce = NULL
-				WHERE id = $2
-			`, decrypted, id)
-			if err != nil {
-				rLog.Error("failed-to-update", err)
-				return err
-			}
-
-			decryptedRows++
-		}
-
-		if decryptedRows > 0 {
-			tLog.Info("decrypted-existing-encrypted-data", lager.Data{
-				"rows": decryptedRows,
-			})
-		}
-	}
-
-	return nil
-}
-
-var ErrEncryptedWithUnknownKey = errors.New("row encrypted with neither old nor new key")
-
-func encryptWithNewKey(logger lager.Logger, sqlDB *sql.DB, newKey *encryption.Key, oldKey *encryption.Key) error {
-	for table, col := range encryptedColumns {
-		rows, err := sqlDB.Query(`
-			SELECT id, nonce, ` + col + `
-			FROM ` + table + `
-			WHERE nonce IS NOT NULL
-		`)
-		if err != nil {
-			return err
-		}
-
-		tLog := logger.Session("table", lager.Data{
-			"table": table,
-		})
-
-		encryptedRows := 0
-
-		for rows.Next() {
-			var (
-				id         int
-				val, nonce string
-			)
-
-			err := rows.Scan(&id, &nonce, &val)
-			if err != nil {
-				tLog.Error("failed-to-scan", err)
-				return err
-			}
-
-			rLog := tLog.Session("row", lager.Data{
-				"id": id,
-			})
-
-			decrypted, err := oldKey.Decrypt(val, &nonce)
-			if err != nil {
-				_, err = newKey.Decrypt(val, &nonce)
-				if err == nil {
-					rLog.Debug("already-encrypted-with-new-key")
-					continue
-				}
-
-				logger.Error("failed-to-decrypt-with-either-key", err)
-				return ErrEncryptedWithUnknownKey
-			}
-
-			encrypted, newNonce, err := newKey.Encrypt(decrypted)
-			if err != nil {
-				rLog.Error("failed-to-encrypt", err)
-				return err
-			}
-
-			_, err = sqlDB.Exec(`
-				UPDATE `+table+`
-				SET `+col+` = $1, nonce = $2
-				WHERE id = $3
-			`, encrypted, newNonce, id)
-			if err != nil {
-				rLog.Error("failed-to-update", err)
-				return err
-			}
-
-			encryptedRows++
-		}
-
-		if encryptedRows > 0 {
-			tLog.Info("re-encrypted-existing-encrypted-data", lager.Data{
-				"rows": encryptedRows,
-			})
-		}
-	}
-
-	return nil
-}
-
 type db struct {
 	*sql.DB
 
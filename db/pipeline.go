@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/event"
 )
 
 type ErrResourceNotFound struct {
@@ -38,6 +39,9 @@ type Pipeline interface {
 	TeamName() string
 	Groups() atc.GroupConfigs
 	ConfigVersion() ConfigVersion
+	ConfigHistory(page Page) ([]PipelineConfigVersion, Pagination, error)
+	ConfigAtVersion(ConfigVersion) (PipelineConfigVersion, bool, error)
+	Config() (atc.Config, bool, error)
 	Public() bool
 	Paused() bool
 	ScopedName(string) string
@@ -120,6 +124,8 @@ type pipeline struct {
 	cacheIndex int
 	versionsDB *algorithm.VersionsDB
 
+	crossPipelineRefs []string
+
 	conn        Conn
 	lockFactory lock.LockFactory
 }
@@ -313,23 +319,51 @@ func (p *pipeline) CreateJobBuild(jobName string) (Build, error) {
 }
 
 func (p *pipeline) SetResourceCheckError(resource Resource, cause error) error {
-	var err error
-
-	if cause == nil {
-		_, err = psql.Update("resources").
-			Set("check_error", nil).
-			Where(sq.Eq{"id": resource.ID()}).
-			RunWith(p.conn).
-			Exec()
-	} else {
-		_, err = psql.Update("resources").
-			Set("check_error", cause.Error()).
-			Where(sq.Eq{"id": resource.ID()}).
-			RunWith(p.conn).
-			Exec()
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return err
 	}
 
-	return err
+	defer Rollback(tx)
+
+	checked := event.ResourceChecked{
+		PipelineName: p.name,
+		ResourceName: resource.Name(),
+	}
+
+	var checkError interface{}
+	if cause != nil {
+		checked.CheckError = cause.Error()
+		checkError = cause.Error()
+	}
+
+	// resources are checked often, so only tell the team when the outcome
+	// differs from the last check
+	result, err := psql.Update("resources").
+		Set("check_error", checkError).
+		Where(sq.Eq{"id": resource.ID()}).
+		Where(sq.Expr("check_error IS DISTINCT FROM ?", checkError)).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return nil
+	}
+
+	err = saveTeamEvent(tx, p.teamID, checked)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (p *pipeline) GetAllPendingBuilds() (map[string][]Build, error) {
@@ -1013,8 +1047,10 @@ func (p *pipeline) Destroy() error {
 }
 
 func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
+	// the config version is included so that passed constraints on jobs in
+	// other pipelines are loaded as soon as they are configured
 	var cacheIndex int
-	err := psql.Select("cache_index").
+	err := psql.Select("cache_index + version").
 		From("pipelines").
 		Where(sq.Eq{"id": p.id}).
 		RunWith(p.conn).
@@ -1024,6 +1060,13 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		return nil, err
 	}
 
+	crossPipelineCacheIndex, err := p.crossPipelineCacheIndex(p.crossPipelineRefs)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheIndex += crossPipelineCacheIndex
+
 	if p.versionsDB != nil && p.cacheIndex == cacheIndex {
 		return p.versionsDB, nil
 	}
@@ -1034,6 +1077,9 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		ResourceVersions: []algorithm.ResourceVersion{},
 		JobIDs:           map[string]int{},
 		ResourceIDs:      map[string]int{},
+		PinnedVersionIDs: map[int]int{},
+		DisabledVersions: []algorithm.ResourceVersion{},
+		VersionFields:    map[int]map[string]string{},
 	}
 
 	rows, err := psql.Select("v.id, v.check_order, r.id, o.build_id, b.job_id").
@@ -1131,6 +1177,31 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.ResourceVersions = append(db.ResourceVersions, output)
 	}
 
+	rows, err = psql.Select("v.id, v.check_order, r.id").
+		From("versioned_resources v, resources r").
+		Where(sq.Expr("r.id = v.resource_id")).
+		Where(sq.Eq{
+			"v.enabled":     false,
+			"r.pipeline_id": p.id,
+		}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var disabled algorithm.ResourceVersion
+		err = rows.Scan(&disabled.VersionID, &disabled.CheckOrder, &disabled.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		db.DisabledVersions = append(db.DisabledVersions, disabled)
+	}
+
 	rows, err = psql.Select("j.name, j.id").
 		From("jobs j").
 		Where(sq.Eq{"j.pipeline_id": p.id}).
@@ -1153,7 +1224,7 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.JobIDs[name] = id
 	}
 
-	rows, err = psql.Select("r.name, r.id").
+	rows, err = psql.Select("r.name, r.id, r.pinned_version_id").
 		From("resources r").
 		Where(sq.Eq{"r.pipeline_id": p.id}).
 		RunWith(p.conn).
@@ -1167,20 +1238,102 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 	for rows.Next() {
 		var name string
 		var id int
-		err = rows.Scan(&name, &id)
+		var pinnedVersionID sql.NullInt64
+		err = rows.Scan(&name, &id, &pinnedVersionID)
 		if err != nil {
 			return nil, err
 		}
 
 		db.ResourceIDs[name] = id
+
+		if pinnedVersionID.Valid {
+			db.PinnedVersionIDs[id] = int(pinnedVersionID.Int64)
+		}
+	}
+
+	jobs, err := p.Jobs()
+	if err != nil {
+		return nil, err
 	}
 
+	err = p.loadVersionFields(db, jobs)
+	if err != nil {
+		return nil, err
+	}
+
+	crossPipelineRefs := p.crossPipelinePassed(jobs, db.JobIDs)
+
+	err = p.loadCrossPipelineOutputs(db, crossPipelineRefs)
+	if err != nil {
+		return nil, err
+	}
+
+	// if the refs have changed, the cache index will differ the next time and
+	// the DB will be loaded once more
+	p.crossPipelineRefs = crossPipelineRefs
+
 	p.versionsDB = db
 	p.cacheIndex = cacheIndex
 
 	return db, nil
 }
 
+// loadVersionFields adds the fields of the enabled versions of resources which
+// inputs filter the versions of, so that the filters can be applied to them.
+func (p *pipeline) loadVersionFields(versionsDB *algorithm.VersionsDB, jobs Jobs) error {
+	resourceIDs := []int{}
+	for _, job := range jobs {
+		for _, input := range job.Config().Inputs() {
+			if input.Version == nil || !input.Version.IsFiltered() {
+				continue
+			}
+
+			if id, found := versionsDB.ResourceIDs[input.Resource]; found {
+				resourceIDs = append(resourceIDs, id)
+			}
+		}
+	}
+
+	if len(resourceIDs) == 0 {
+		return nil
+	}
+
+	rows, err := psql.Select("v.id, v.version").
+		From("versioned_resources v").
+		Where(sq.Eq{
+			"v.resource_id": resourceIDs,
+			"v.enabled":     true,
+		}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var id int
+		var version string
+		err = rows.Scan(&id, &version)
+		if err != nil {
+			return err
+		}
+
+		// a version which is not a flat set of strings has no fields for a
+		// filter to allow, so it is left out rather than failing every job
+		var fields map[string]string
+		err = json.Unmarshal([]byte(version), &fields)
+		if err != nil {
+			continue
+		}
+
+		versionsDB.VersionFields[id] = fields
+	}
+
+	return nil
+}
+
 func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 	if len(buildIDs) == 0 {
 		return nil
@@ -0,0 +1,204 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/configdiff"
+)
+
+// PipelineConfigVersion is a config that was saved for a pipeline.
+//
+// Diff is a unified diff of the config's YAML against the version saved
+// before it, or against nothing for the first version. It is computed as the
+// version is loaded so that it's subject to the same encryption as the config
+// itself.
+//
+// Config and Diff are only loaded for a single version; ConfigHistory leaves
+// them empty so that listing versions doesn't decrypt every config.
+type PipelineConfigVersion struct {
+	Version   ConfigVersion
+	Config    atc.Config
+	Author    string
+	CreatedAt time.Time
+	Diff      string
+}
+
+var pipelineConfigsQuery = psql.Select("version, config, nonce, author, created_at").
+	From("pipeline_configs")
+
+func (p *pipeline) ConfigHistory(page Page) ([]PipelineConfigVersion, Pagination, error) {
+	query := psql.Select("version, author, created_at").
+		From("pipeline_configs").
+		Where(sq.Eq{"pipeline_id": p.id})
+
+	limit := uint64(page.Limit)
+
+	var reverse bool
+	if page.Since == 0 && page.Until == 0 {
+		query = query.OrderBy("version DESC").Limit(limit)
+	} else if page.Until != 0 {
+		query = query.Where(sq.Gt{"version": page.Until}).OrderBy("version ASC").Limit(limit)
+		reverse = true
+	} else {
+		query = query.Where(sq.Lt{"version": page.Since}).OrderBy("version DESC").Limit(limit)
+	}
+
+	rows, err := query.RunWith(p.conn).Query()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer Close(rows)
+
+	versions := []PipelineConfigVersion{}
+	for rows.Next() {
+		var version PipelineConfigVersion
+		err := rows.Scan(&version.Version, &version.Author, &version.CreatedAt)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		if reverse {
+			versions = append([]PipelineConfigVersion{version}, versions...)
+		} else {
+			versions = append(versions, version)
+		}
+	}
+
+	if len(versions) == 0 {
+		return []PipelineConfigVersion{}, Pagination{}, nil
+	}
+
+	var maxVersion, minVersion int
+	err = psql.Select("COALESCE(MAX(version), 0)", "COALESCE(MIN(version), 0)").
+		From("pipeline_configs").
+		Where(sq.Eq{"pipeline_id": p.id}).
+		RunWith(p.conn).
+		QueryRow().
+		Scan(&maxVersion, &minVersion)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	first := versions[0]
+	last := versions[len(versions)-1]
+
+	var pagination Pagination
+
+	if int(first.Version) < maxVersion {
+		pagination.Previous = &Page{
+			Until: int(first.Version),
+			Limit: page.Limit,
+		}
+	}
+
+	if int(last.Version) > minVersion {
+		pagination.Next = &Page{
+			Since: int(last.Version),
+			Limit: page.Limit,
+		}
+	}
+
+	return versions, pagination, nil
+}
+
+func (p *pipeline) ConfigAtVersion(configVersion ConfigVersion) (PipelineConfigVersion, bool, error) {
+	rows, err := pipelineConfigsQuery.
+		Where(sq.Eq{"pipeline_id": p.id}).
+		Where(sq.LtOrEq{"version": configVersion}).
+		OrderBy("version DESC").
+		Limit(2).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return PipelineConfigVersion{}, false, err
+	}
+
+	defer Close(rows)
+
+	versions := []PipelineConfigVersion{}
+	for rows.Next() {
+		version, err := p.scanConfigVersion(rows)
+		if err != nil {
+			return PipelineConfigVersion{}, false, err
+		}
+
+		versions = append(versions, version)
+	}
+
+	if len(versions) == 0 || versions[0].Version != configVersion {
+		return PipelineConfigVersion{}, false, nil
+	}
+
+	var previous *atc.Config
+	if len(versions) == 2 {
+		previous = &versions[1].Config
+	}
+
+	version := versions[0]
+
+	version.Diff, err = configdiff.Diff(previous, version.Config)
+	if err != nil {
+		return PipelineConfigVersion{}, false, err
+	}
+
+	return version, true, nil
+}
+
+// Config returns the config the pipeline was last saved with, or false if it
+// was saved before config versions were recorded.
+func (p *pipeline) Config() (atc.Config, bool, error) {
+	row := pipelineConfigsQuery.
+		Where(sq.Eq{"pipeline_id": p.id}).
+		OrderBy("version DESC").
+		Limit(1).
+		RunWith(p.conn).
+		QueryRow()
+
+	version, err := p.scanConfigVersion(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.Config{}, false, nil
+		}
+
+		return atc.Config{}, false, err
+	}
+
+	return version.Config, true, nil
+}
+
+func (p *pipeline) scanConfigVersion(row scannable) (PipelineConfigVersion, error) {
+	var (
+		version    PipelineConfigVersion
+		configBlob []byte
+		nonce      sql.NullString
+	)
+
+	err := row.Scan(&version.Version, &configBlob, &nonce, &version.Author, &version.CreatedAt)
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	es := p.conn.EncryptionStrategy()
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decryptedConfig, err := es.Decrypt(string(configBlob), noncense)
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	err = json.Unmarshal(decryptedConfig, &version.Config)
+	if err != nil {
+		return PipelineConfigVersion{}, err
+	}
+
+	return version, nil
+}
@@ -198,6 +198,118 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("Config history", func() {
+		var (
+			initialVersion db.ConfigVersion
+			updatedConfig  atc.Config
+		)
+
+		BeforeEach(func() {
+			initialVersion = pipeline.ConfigVersion()
+
+			updatedConfig = pipelineConfig
+			updatedConfig.Resources = atc.ResourceConfigs{
+				pipelineConfig.Resources[0],
+				pipelineConfig.Resources[1],
+				{
+					Name:   "some-other-resource",
+					Type:   "some-type",
+					Source: atc.Source{"some": "other-source"},
+				},
+			}
+
+			var err error
+			pipeline, _, err = team.SavePipelineWithAuthor("some-user", "fake-pipeline", updatedConfig, initialVersion, db.PipelineNoChange)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Describe("ConfigHistory", func() {
+			It("returns each saved version, newest first", func() {
+				history, pagination, err := pipeline.ConfigHistory(db.Page{Limit: 10})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(history).To(HaveLen(2))
+				Expect(pagination).To(Equal(db.Pagination{}))
+
+				Expect(history[0].Version).To(Equal(pipeline.ConfigVersion()))
+				Expect(history[0].Author).To(Equal("some-user"))
+				Expect(history[0].CreatedAt).ToNot(BeZero())
+
+				Expect(history[1].Version).To(Equal(initialVersion))
+				Expect(history[1].Author).To(BeEmpty())
+			})
+
+			It("does not load the configs or diff them", func() {
+				history, _, err := pipeline.ConfigHistory(db.Page{Limit: 10})
+				Expect(err).ToNot(HaveOccurred())
+
+				for _, version := range history {
+					Expect(version.Config).To(BeZero())
+					Expect(version.Diff).To(BeEmpty())
+				}
+			})
+
+			It("paginates the versions", func() {
+				history, pagination, err := pipeline.ConfigHistory(db.Page{Limit: 1})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(history).To(HaveLen(1))
+				Expect(history[0].Version).To(Equal(pipeline.ConfigVersion()))
+				Expect(pagination.Previous).To(BeNil())
+				Expect(pagination.Next).To(Equal(&db.Page{Since: int(pipeline.ConfigVersion()), Limit: 1}))
+
+				history, pagination, err = pipeline.ConfigHistory(*pagination.Next)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(history).To(HaveLen(1))
+				Expect(history[0].Version).To(Equal(initialVersion))
+				Expect(pagination.Previous).To(Equal(&db.Page{Until: int(initialVersion), Limit: 1}))
+				Expect(pagination.Next).To(BeNil())
+
+				history, _, err = pipeline.ConfigHistory(*pagination.Previous)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(history).To(HaveLen(1))
+				Expect(history[0].Version).To(Equal(pipeline.ConfigVersion()))
+			})
+		})
+
+		Describe("ConfigAtVersion", func() {
+			It("returns the config saved at the version", func() {
+				version, found, err := pipeline.ConfigAtVersion(initialVersion)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(version.Version).To(Equal(initialVersion))
+				Expect(version.Config).To(Equal(pipelineConfig))
+				Expect(version.Diff).To(HavePrefix("@@ -0,0 +1,"))
+			})
+
+			It("diffs the config against the one before it", func() {
+				version, found, err := pipeline.ConfigAtVersion(pipeline.ConfigVersion())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(version.Author).To(Equal("some-user"))
+				Expect(version.Diff).To(HavePrefix("@@ "))
+				Expect(version.Diff).To(MatchRegexp(`(?m)^-\s+some: source$`))
+				Expect(version.Diff).To(MatchRegexp(`(?m)^\+\s+some: other-source$`))
+				Expect(version.Diff).ToNot(ContainSubstring("job-name"))
+			})
+
+			It("does not find versions which were never saved", func() {
+				_, found, err := pipeline.ConfigAtVersion(initialVersion - 1)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Describe("Config", func() {
+			It("returns the latest saved config", func() {
+				config, found, err := pipeline.Config()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(config).To(Equal(updatedConfig))
+			})
+		})
+	})
+
 	Describe("GetLatestVersionedResource", func() {
 		var (
 			originalVersionSlice []atc.Version
@@ -1035,6 +1147,8 @@ var _ = Describe("Pipeline", func() {
 				"different-serial-group-job": differentSerialGroupJob.ID(),
 			}))
 
+			Expect(versions.VersionFields).To(BeEmpty())
+
 			By("not including saved versioned resources of other pipelines")
 			otherPipelineResource, _, err := otherDBPipeline.Resource("some-other-resource")
 			Expect(err).ToNot(HaveOccurred())
@@ -1263,6 +1377,76 @@ var _ = Describe("Pipeline", func() {
 				explicitOutput,
 				implicitOutput,
 			}))
+
+			By("including disabled versions separately")
+			err = dbPipeline.DisableVersionedResource(savedVR1.ID)
+			Expect(err).ToNot(HaveOccurred())
+
+			versions, err = dbPipeline.LoadVersionsDB()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(versions.DisabledVersions).To(Equal([]algorithm.ResourceVersion{
+				{VersionID: savedVR1.ID, ResourceID: resource.ID(), CheckOrder: savedVR1.CheckOrder},
+			}))
+
+			for _, resourceVersion := range versions.ResourceVersions {
+				Expect(resourceVersion.VersionID).ToNot(Equal(savedVR1.ID))
+			}
+		})
+
+		It("loads the fields of versions of resources which inputs filter", func() {
+			filteredPipeline, _, err := team.SavePipeline("filtered-pipeline", atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						Plan: atc.PlanSequence{
+							{
+								Get: "some-resource",
+								Version: &atc.VersionConfig{
+									Semver: map[string]string{"version": ">=1"},
+								},
+							},
+							{
+								Get: "some-other-resource",
+							},
+						},
+					},
+				},
+				Resources: atc.ResourceConfigs{
+					{Name: "some-resource", Type: "some-type"},
+					{Name: "some-other-resource", Type: "some-type"},
+				},
+			}, 0, db.PipelineUnpaused)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = filteredPipeline.SaveResourceVersions(atc.ResourceConfig{
+				Name: "some-resource",
+				Type: "some-type",
+			}, []atc.Version{{"version": "1"}, {"version": "2"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = filteredPipeline.SaveResourceVersions(atc.ResourceConfig{
+				Name: "some-other-resource",
+				Type: "some-type",
+			}, []atc.Version{{"version": "3"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			malformedVR, found, err := filteredPipeline.GetLatestVersionedResource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, err = dbConn.Exec(`UPDATE versioned_resources SET version = '{"version": 2}' WHERE id = $1`, malformedVR.ID)
+			Expect(err).ToNot(HaveOccurred())
+
+			versions, err := filteredPipeline.LoadVersionsDB()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions.ResourceVersions).To(HaveLen(3))
+
+			Expect(versions.VersionFields).To(HaveLen(1))
+			for id, fields := range versions.VersionFields {
+				Expect(id).ToNot(Equal(malformedVR.ID))
+				Expect(fields).To(Equal(map[string]string{"version": "1"}))
+			}
 		})
 
 		It("can load up the latest versioned resource, enabled or not", func() {
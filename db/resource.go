@@ -28,19 +28,26 @@ type Resource interface {
 	Paused() bool
 	WebhookToken() string
 	FailingToCheck() bool
+	PinnedVersionID() int
+	PinnedVersion() atc.Version
+	PinComment() string
 
 	SetResourceConfig(int) error
 
 	Pause() error
 	Unpause() error
 
+	PinVersion(versionedResourceID int, comment string) (bool, error)
+	UnpinVersion() error
+
 	Reload() (bool, error)
 }
 
-var resourcesQuery = psql.Select("r.id, r.name, r.config, r.check_error, r.paused, r.last_checked, r.pipeline_id, r.nonce, p.name, t.name").
+var resourcesQuery = psql.Select("r.id, r.name, r.config, r.check_error, r.paused, r.last_checked, r.pipeline_id, r.nonce, p.name, t.name, r.pinned_version_id, r.pin_comment, pv.version").
 	From("resources r").
 	Join("pipelines p ON p.id = r.pipeline_id").
 	Join("teams t ON t.id = p.team_id").
+	LeftJoin("versioned_resources pv ON pv.id = r.pinned_version_id").
 	Where(sq.Eq{"r.active": true})
 
 type resource struct {
@@ -58,6 +65,10 @@ type resource struct {
 	paused       bool
 	webhookToken string
 
+	pinnedVersionID int
+	pinnedVersion   atc.Version
+	pinComment      string
+
 	conn Conn
 }
 
@@ -98,19 +109,22 @@ func (resources Resources) Configs() atc.ResourceConfigs {
 	return configs
 }
 
-func (r *resource) ID() int                { return r.id }
-func (r *resource) Name() string           { return r.name }
-func (r *resource) PipelineID() int        { return r.pipelineID }
-func (r *resource) PipelineName() string   { return r.pipelineName }
-func (r *resource) TeamName() string       { return r.teamName }
-func (r *resource) Type() string           { return r.type_ }
-func (r *resource) Source() atc.Source     { return r.source }
-func (r *resource) CheckEvery() string     { return r.checkEvery }
-func (r *resource) LastChecked() time.Time { return r.lastChecked }
-func (r *resource) Tags() atc.Tags         { return r.tags }
-func (r *resource) CheckError() error      { return r.checkError }
-func (r *resource) Paused() bool           { return r.paused }
-func (r *resource) WebhookToken() string   { return r.webhookToken }
+func (r *resource) ID() int                    { return r.id }
+func (r *resource) Name() string               { return r.name }
+func (r *resource) PipelineID() int            { return r.pipelineID }
+func (r *resource) PipelineName() string       { return r.pipelineName }
+func (r *resource) TeamName() string           { return r.teamName }
+func (r *resource) Type() string               { return r.type_ }
+func (r *resource) Source() atc.Source         { return r.source }
+func (r *resource) CheckEvery() string         { return r.checkEvery }
+func (r *resource) LastChecked() time.Time     { return r.lastChecked }
+func (r *resource) Tags() atc.Tags             { return r.tags }
+func (r *resource) CheckError() error          { return r.checkError }
+func (r *resource) Paused() bool               { return r.paused }
+func (r *resource) WebhookToken() string       { return r.webhookToken }
+func (r *resource) PinnedVersionID() int       { return r.pinnedVersionID }
+func (r *resource) PinnedVersion() atc.Version { return r.pinnedVersion }
+func (r *resource) PinComment() string         { return r.pinComment }
 func (r *resource) FailingToCheck() bool {
 	return r.checkError != nil
 }
@@ -155,6 +169,79 @@ func (r *resource) Unpause() error {
 	return err
 }
 
+// PinVersion pins the resource to one of its versions, which every job using
+// the resource will then use as its input. The resource is not checked while
+// it is pinned.
+//
+// It returns false if the version does not belong to the resource.
+func (r *resource) PinVersion(versionedResourceID int, comment string) (bool, error) {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	result, err := tx.Exec(`
+		UPDATE resources
+		SET pinned_version_id = v.id, pin_comment = $3
+		FROM versioned_resources v
+		WHERE resources.id = $1
+		AND v.id = $2
+		AND v.resource_id = resources.id
+	`, r.id, versionedResourceID, comment)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	err = bumpCacheIndex(tx, r.pipelineID)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *resource) UnpinVersion() error {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Update("resources").
+		Set("pinned_version_id", nil).
+		Set("pin_comment", nil).
+		Where(sq.Eq{"id": r.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = bumpCacheIndex(tx, r.pipelineID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (r *resource) SetResourceConfig(resourceConfigID int) error {
 	_, err := psql.Update("resources").
 		Set("resource_config_id", resourceConfigID).
@@ -171,16 +258,28 @@ func (r *resource) SetResourceConfig(resourceConfigID int) error {
 
 func scanResource(r *resource, row scannable) error {
 	var (
-		configBlob      []byte
-		checkErr, nonce sql.NullString
-		lastChecked     pq.NullTime
+		configBlob                          []byte
+		checkErr, nonce, pinComment, pinned sql.NullString
+		lastChecked                         pq.NullTime
+		pinnedVersionID                     sql.NullInt64
 	)
 
-	err := row.Scan(&r.id, &r.name, &configBlob, &checkErr, &r.paused, &lastChecked, &r.pipelineID, &nonce, &r.pipelineName, &r.teamName)
+	err := row.Scan(&r.id, &r.name, &configBlob, &checkErr, &r.paused, &lastChecked, &r.pipelineID, &nonce, &r.pipelineName, &r.teamName, &pinnedVersionID, &pinComment, &pinned)
 	if err != nil {
 		return err
 	}
 
+	r.pinnedVersionID = int(pinnedVersionID.Int64)
+	r.pinComment = pinComment.String
+
+	r.pinnedVersion = nil
+	if pinned.Valid {
+		err = json.Unmarshal([]byte(pinned.String), &r.pinnedVersion)
+		if err != nil {
+			return err
+		}
+	}
+
 	r.lastChecked = lastChecked.Time
 
 	es := r.conn.EncryptionStrategy()
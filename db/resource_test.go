@@ -167,4 +167,99 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("PinVersion", func() {
+		var (
+			resource  db.Resource
+			versionID int
+		)
+
+		BeforeEach(func() {
+			var (
+				err   error
+				found bool
+			)
+
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = pipeline.SaveResourceVersions(atc.ResourceConfig{
+				Name:   resource.Name(),
+				Type:   "docker-image",
+				Source: atc.Source{"some": "repository"},
+			}, []atc.Version{{"ref": "v1"}, {"ref": "v2"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			savedVersion, found, err := pipeline.GetVersionedResourceByVersion(atc.Version{"ref": "v1"}, resource.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			versionID = savedVersion.ID
+		})
+
+		It("pins the resource to the version with the comment", func() {
+			found, err := resource.PinVersion(versionID, "v2 is broken")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			found, err = resource.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(resource.PinnedVersionID()).To(Equal(versionID))
+			Expect(resource.PinnedVersion()).To(Equal(atc.Version{"ref": "v1"}))
+			Expect(resource.PinComment()).To(Equal("v2 is broken"))
+		})
+
+		It("pins the version for the pipeline's jobs", func() {
+			_, err := resource.PinVersion(versionID, "")
+			Expect(err).ToNot(HaveOccurred())
+
+			versionsDB, err := pipeline.LoadVersionsDB()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versionsDB.PinnedVersionIDs).To(Equal(map[int]int{resource.ID(): versionID}))
+		})
+
+		It("does not pin versions of other resources", func() {
+			otherResource, found, err := pipeline.Resource("some-other-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			found, err = otherResource.PinVersion(versionID, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			found, err = otherResource.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(otherResource.PinnedVersionID()).To(BeZero())
+		})
+
+		Context("when the resource is unpinned", func() {
+			BeforeEach(func() {
+				_, err := resource.PinVersion(versionID, "v2 is broken")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = resource.UnpinVersion()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("removes the pin and its comment", func() {
+				found, err := resource.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(resource.PinnedVersionID()).To(BeZero())
+				Expect(resource.PinnedVersion()).To(BeNil())
+				Expect(resource.PinComment()).To(BeEmpty())
+			})
+
+			It("unpins the version for the pipeline's jobs", func() {
+				versionsDB, err := pipeline.LoadVersionsDB()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versionsDB.PinnedVersionIDs).To(BeEmpty())
+			})
+		})
+	})
 })
@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/event"
 	"github.com/lib/pq"
 	uuid "github.com/nu7hatch/gouuid"
 )
@@ -27,7 +28,8 @@ type Team interface {
 	Name() string
 	Admin() bool
 
-	Auth() map[string][]string
+	Auth() atc.TeamAuth
+	RedactSecrets() bool
 
 	Delete() error
 	Rename(string) error
@@ -39,6 +41,14 @@ type Team interface {
 		pausedState PipelinePausedState,
 	) (Pipeline, bool, error)
 
+	SavePipelineWithAuthor(
+		author string,
+		pipelineName string,
+		config atc.Config,
+		from ConfigVersion,
+		pausedState PipelinePausedState,
+	) (Pipeline, bool, error)
+
 	Pipeline(pipelineName string) (Pipeline, bool, error)
 	Pipelines() ([]Pipeline, error)
 	PublicPipelines() ([]Pipeline, error)
@@ -49,6 +59,11 @@ type Team interface {
 	PrivateAndPublicBuilds(Page) ([]Build, Pagination, error)
 	Builds(page Page) ([]Build, Pagination, error)
 
+	NotificationDeliveries(limit int) ([]NotificationDelivery, error)
+
+	Events(cursor int) (TeamEventSource, error)
+	LatestEventID() (int, error)
+
 	SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error)
 	Workers() ([]Worker, error)
 
@@ -64,7 +79,8 @@ type Team interface {
 	FindContainerOnWorker(workerName string, owner ContainerOwner) (CreatingContainer, CreatedContainer, error)
 	CreateContainer(workerName string, owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error)
 
-	UpdateProviderAuth(auth map[string][]string) error
+	UpdateProviderAuth(auth atc.TeamAuth) error
+	UpdateSettings(auth atc.TeamAuth, redactSecrets *bool) error
 }
 
 type team struct {
@@ -75,14 +91,20 @@ type team struct {
 	name  string
 	admin bool
 
-	auth map[string][]string
+	auth atc.TeamAuth
+
+	redactSecrets bool
 }
 
 func (t *team) ID() int      { return t.id }
 func (t *team) Name() string { return t.name }
 func (t *team) Admin() bool  { return t.admin }
 
-func (t *team) Auth() map[string][]string { return t.auth }
+func (t *team) Auth() atc.TeamAuth { return t.auth }
+
+// RedactSecrets returns whether credentials should be masked in the output of
+// the team's builds.
+func (t *team) RedactSecrets() bool { return t.redactSecrets }
 
 func (t *team) Delete() error {
 	pipelines, err := t.Pipelines()
@@ -410,6 +432,18 @@ func (t *team) SavePipeline(
 	config atc.Config,
 	from ConfigVersion,
 	pausedState PipelinePausedState,
+) (Pipeline, bool, error) {
+	return t.SavePipelineWithAuthor("", pipelineName, config, from, pausedState)
+}
+
+// SavePipelineWithAuthor saves the pipeline config and records it in the
+// pipeline's config history as having been saved by the given author.
+func (t *team) SavePipelineWithAuthor(
+	author string,
+	pipelineName string,
+	config atc.Config,
+	from ConfigVersion,
+	pausedState PipelinePausedState,
 ) (Pipeline, bool, error) {
 	groupsPayload, err := json.Marshal(config.Groups)
 	if err != nil {
@@ -585,6 +619,11 @@ func (t *team) SavePipeline(
 		return nil, false, err
 	}
 
+	err = t.saveConfigVersion(tx, pipelineID, config, author)
+	if err != nil {
+		return nil, false, err
+	}
+
 	pipeline := newPipeline(t.conn, t.lockFactory)
 
 	err = scanPipeline(
@@ -598,6 +637,14 @@ func (t *team) SavePipeline(
 		return nil, false, err
 	}
 
+	err = saveTeamEvent(tx, t.id, event.PipelineConfigChanged{
+		PipelineName:  pipelineName,
+		ConfigVersion: int(pipeline.ConfigVersion()),
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, false, err
@@ -772,6 +819,20 @@ func (t *team) Builds(page Page) ([]Build, Pagination, error) {
 	return getBuildsWithPagination(buildsQuery.Where(sq.Eq{"t.id": t.id}), page, t.conn, t.lockFactory)
 }
 
+func (t *team) NotificationDeliveries(limit int) ([]NotificationDelivery, error) {
+	rows, err := notificationDeliveriesQuery.
+		Where(sq.Eq{"d.team_id": t.id}).
+		OrderBy("d.id DESC").
+		Limit(uint64(limit)).
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanNotificationDeliveries(t.conn, rows)
+}
+
 func (t *team) SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -793,7 +854,13 @@ func (t *team) SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, erro
 	return savedWorker, nil
 }
 
-func (t *team) UpdateProviderAuth(auth map[string][]string) error {
+func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
+	return t.UpdateSettings(auth, nil)
+}
+
+// UpdateSettings saves the team's auth and, unless it is nil, whether
+// credentials are redacted from the output of its builds, both at once.
+func (t *team) UpdateSettings(auth atc.TeamAuth, redactSecrets *bool) error {
 	jsonEncodedProviderAuth, err := json.Marshal(auth)
 	if err != nil {
 		return err
@@ -801,11 +868,11 @@ func (t *team) UpdateProviderAuth(auth map[string][]string) error {
 
 	query := `
 		UPDATE teams
-		SET auth = $1, legacy_auth = NULL, nonce = NULL
+		SET auth = $1, legacy_auth = NULL, nonce = NULL, redact_secrets = COALESCE($3, redact_secrets)
 		WHERE id = $2
-		RETURNING id, name, admin, auth, nonce
+		RETURNING id, name, admin, auth, nonce, redact_secrets
 	`
-	params := []interface{}{jsonEncodedProviderAuth, t.id}
+	params := []interface{}{jsonEncodedProviderAuth, t.id, redactSecrets}
 	return t.queryTeam(query, params)
 }
 
@@ -842,6 +909,28 @@ func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string
 	return swallowUniqueViolation(err)
 }
 
+func (t *team) saveConfigVersion(tx Tx, pipelineID int, config atc.Config, author string) error {
+	configPayload, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	es := t.conn.EncryptionStrategy()
+	encryptedPayload, nonce, err := es.Encrypt(configPayload)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO pipeline_configs (pipeline_id, version, config, nonce, author)
+		SELECT id, version, $2, $3, $4
+		FROM pipelines
+		WHERE id = $1
+	`, pipelineID, encryptedPayload, nonce, author)
+
+	return err
+}
+
 func (t *team) registerSerialGroup(tx Tx, jobName, serialGroup string, pipelineID int) error {
 	_, err := tx.Exec(`
     INSERT INTO jobs_serial_groups (serial_group, job_id) VALUES
@@ -1033,6 +1122,7 @@ func (t *team) queryTeam(query string, params []interface{}) error {
 		&t.admin,
 		&providerAuth,
 		&nonce,
+		&t.redactSecrets,
 	)
 	if err != nil {
 		return err
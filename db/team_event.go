@@ -0,0 +1,118 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/event"
+)
+
+// TeamEvent is an event in a team's activity stream. IDs increase over the
+// stream, in the order the events were committed, so that it can be resumed
+// from the last event seen.
+type TeamEvent struct {
+	ID       int
+	Envelope event.Envelope
+}
+
+//go:generate counterfeiter . TeamEventLifecycle
+
+type TeamEventLifecycle interface {
+	RemoveEventsOlderThan(time.Duration) (int, error)
+}
+
+type teamEventLifecycle struct {
+	conn Conn
+}
+
+func NewTeamEventLifecycle(conn Conn) TeamEventLifecycle {
+	return &teamEventLifecycle{
+		conn: conn,
+	}
+}
+
+func (lifecycle *teamEventLifecycle) RemoveEventsOlderThan(age time.Duration) (int, error) {
+	result, err := psql.Delete("team_events").
+		Where(sq.Expr(fmt.Sprintf("now() - created_at > '%d seconds'::interval", int(age.Seconds())))).
+		RunWith(lifecycle.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
+
+func (t *team) Events(cursor int) (TeamEventSource, error) {
+	notifier, err := newConditionNotifier(t.conn.Bus(), teamEventsChannel(t.id), func() (bool, error) {
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newTeamEventSource(t.id, t.conn, notifier, cursor), nil
+}
+
+func (t *team) LatestEventID() (int, error) {
+	var id int
+	err := psql.Select("COALESCE(MAX(id), 0)").
+		From("team_events").
+		Where(sq.Eq{"team_id": t.id}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func teamEventsChannel(teamID int) string {
+	return fmt.Sprintf("team_events_%d", teamID)
+}
+
+// saveTeamEvent records the event as part of the transaction, notifying
+// listeners once it has committed. Nothing can fail after the commit, so a
+// committed event is never reported as failed.
+//
+// A team's events are written one transaction at a time, holding a lock until
+// commit, so that their IDs are committed in order. Otherwise a stream which
+// has seen an event could go on to miss an earlier one still being written.
+// It must be the last thing done before committing, so that the lock is held
+// briefly and is always taken after any rows the transaction locks, which
+// would otherwise risk a deadlock.
+func saveTeamEvent(tx Tx, teamID int, ev atc.Event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`SELECT pg_advisory_xact_lock($1, $2)`, lock.LockTypeTeamEvents, teamID)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("team_events").
+		Columns("team_id", "type", "version", "payload").
+		Values(teamID, string(ev.EventType()), string(ev.Version()), string(payload)).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	// delivered by postgres when the transaction commits
+	_, err = tx.Exec("NOTIFY " + teamEventsChannel(teamID))
+	return err
+}
@@ -0,0 +1,163 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/event"
+)
+
+var ErrTeamEventStreamClosed = errors.New("team event stream closed")
+
+//go:generate counterfeiter . TeamEventSource
+
+type TeamEventSource interface {
+	Next() (TeamEvent, error)
+	Close() error
+}
+
+func newTeamEventSource(
+	teamID int,
+	conn Conn,
+	notifier Notifier,
+	cursor int,
+) *teamEventSource {
+	wg := new(sync.WaitGroup)
+
+	source := &teamEventSource{
+		teamID: teamID,
+
+		conn: conn,
+
+		notifier: notifier,
+
+		events: make(chan TeamEvent, 2000),
+		stop:   make(chan struct{}),
+		wg:     wg,
+	}
+
+	wg.Add(1)
+	go source.collectEvents(cursor)
+
+	return source
+}
+
+type teamEventSource struct {
+	teamID int
+
+	conn     Conn
+	notifier Notifier
+
+	events    chan TeamEvent
+	stop      chan struct{}
+	closeOnce sync.Once
+	err       error
+	wg        *sync.WaitGroup
+}
+
+func (source *teamEventSource) Next() (TeamEvent, error) {
+	e, ok := <-source.events
+	if !ok {
+		return TeamEvent{}, source.err
+	}
+
+	return e, nil
+}
+
+// Close stops the stream, causing any pending Next to return
+// ErrTeamEventStreamClosed. It is safe to call more than once.
+func (source *teamEventSource) Close() error {
+	var err error
+	source.closeOnce.Do(func() {
+		close(source.stop)
+		source.wg.Wait()
+		err = source.notifier.Close()
+	})
+
+	return err
+}
+
+func (source *teamEventSource) collectEvents(cursor int) {
+	defer source.wg.Done()
+
+	var batchSize = cap(source.events)
+
+	for {
+		select {
+		case <-source.stop:
+			source.err = ErrTeamEventStreamClosed
+			close(source.events)
+			return
+		default:
+		}
+
+		rows, err := source.conn.Query(`
+			SELECT id, type, version, payload
+			FROM team_events
+			WHERE team_id = $1
+			AND id > $2
+			ORDER BY id ASC
+			LIMIT $3
+		`, source.teamID, cursor, batchSize)
+		if err != nil {
+			source.err = err
+			close(source.events)
+			return
+		}
+
+		rowsReturned := 0
+
+		for rows.Next() {
+			rowsReturned++
+
+			var id int
+			var t, v, p string
+			err := rows.Scan(&id, &t, &v, &p)
+			if err != nil {
+				_ = rows.Close()
+
+				source.err = err
+				close(source.events)
+				return
+			}
+
+			cursor = id
+
+			data := json.RawMessage(p)
+
+			ev := TeamEvent{
+				ID: id,
+				Envelope: event.Envelope{
+					Data:    &data,
+					Event:   atc.EventType(t),
+					Version: atc.EventVersion(v),
+				},
+			}
+
+			select {
+			case source.events <- ev:
+			case <-source.stop:
+				_ = rows.Close()
+
+				source.err = ErrTeamEventStreamClosed
+				close(source.events)
+				return
+			}
+		}
+
+		if rowsReturned == batchSize {
+			// still more events
+			continue
+		}
+
+		select {
+		case <-source.notifier.Notify():
+		case <-source.stop:
+			source.err = ErrTeamEventStreamClosed
+			close(source.events)
+			return
+		}
+	}
+}
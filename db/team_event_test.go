@@ -0,0 +1,224 @@
+package db_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/event"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Team events", func() {
+	var (
+		events db.TeamEventSource
+		cursor int
+	)
+
+	nextEvent := func() (atc.EventType, map[string]interface{}) {
+		ev, err := events.Next()
+		Expect(err).ToNot(HaveOccurred())
+
+		var data map[string]interface{}
+		err = json.Unmarshal(*ev.Envelope.Data, &data)
+		Expect(err).ToNot(HaveOccurred())
+
+		return ev.Envelope.Event, data
+	}
+
+	BeforeEach(func() {
+		var err error
+		cursor, err = defaultTeam.LatestEventID()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		events, err = defaultTeam.Events(cursor)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		db.Close(events)
+	})
+
+	It("emits an event when a build starts and finishes", func() {
+		build, err := defaultJob.CreateBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		started, err := build.Start("some-engine", `{"meta":"data"}`, atc.Plan{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(started).To(BeTrue())
+
+		err = build.Finish(db.BuildStatusSucceeded)
+		Expect(err).ToNot(HaveOccurred())
+
+		eventType, data := nextEvent()
+		Expect(eventType).To(Equal(event.EventTypeBuildStarted))
+		Expect(data).To(HaveKeyWithValue("build_id", BeNumerically("==", build.ID())))
+		Expect(data).To(HaveKeyWithValue("pipeline_name", defaultPipeline.Name()))
+		Expect(data).To(HaveKeyWithValue("job_name", defaultJob.Name()))
+
+		eventType, data = nextEvent()
+		Expect(eventType).To(Equal(event.EventTypeBuildFinished))
+		Expect(data).To(HaveKeyWithValue("build_id", BeNumerically("==", build.ID())))
+		Expect(data).To(HaveKeyWithValue("status", "succeeded"))
+	})
+
+	It("emits an event when a job is paused or unpaused", func() {
+		Expect(defaultJob.Pause()).To(Succeed())
+		Expect(defaultJob.Unpause()).To(Succeed())
+
+		eventType, data := nextEvent()
+		Expect(eventType).To(Equal(event.EventTypeJobPaused))
+		Expect(data).To(Equal(map[string]interface{}{
+			"pipeline_name": defaultPipeline.Name(),
+			"job_name":      defaultJob.Name(),
+		}))
+
+		eventType, _ = nextEvent()
+		Expect(eventType).To(Equal(event.EventTypeJobUnpaused))
+	})
+
+	It("emits an event when a resource's check result is saved", func() {
+		err := defaultPipeline.SetResourceCheckError(defaultResource, errors.New("oops"))
+		Expect(err).ToNot(HaveOccurred())
+
+		err = defaultPipeline.SetResourceCheckError(defaultResource, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		eventType, data := nextEvent()
+		Expect(eventType).To(Equal(event.EventTypeResourceChecked))
+		Expect(data).To(HaveKeyWithValue("resource_name", defaultResource.Name()))
+		Expect(data).To(HaveKeyWithValue("check_error", "oops"))
+
+		eventType, data = nextEvent()
+		Expect(eventType).To(Equal(event.EventTypeResourceChecked))
+		Expect(data).ToNot(HaveKey("check_error"))
+	})
+
+	It("does not emit an event when a resource's check result is unchanged", func() {
+		err := defaultPipeline.SetResourceCheckError(defaultResource, errors.New("oops"))
+		Expect(err).ToNot(HaveOccurred())
+
+		err = defaultPipeline.SetResourceCheckError(defaultResource, errors.New("oops"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(defaultJob.Pause()).To(Succeed())
+
+		eventType, _ := nextEvent()
+		Expect(eventType).To(Equal(event.EventTypeResourceChecked))
+
+		eventType, _ = nextEvent()
+		Expect(eventType).To(Equal(event.EventTypeJobPaused))
+	})
+
+	It("writes a team's events one transaction at a time", func() {
+		tx, err := dbConn.Begin()
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = tx.Exec(`SELECT pg_advisory_xact_lock($1, $2)`, lock.LockTypeTeamEvents, defaultTeam.ID())
+		Expect(err).ToNot(HaveOccurred())
+
+		paused := make(chan error)
+		go func() {
+			paused <- defaultJob.Pause()
+		}()
+
+		Consistently(paused).ShouldNot(Receive())
+
+		Expect(tx.Commit()).To(Succeed())
+
+		Eventually(paused).Should(Receive(BeNil()))
+	})
+
+	It("emits an event when a pipeline's config is saved", func() {
+		pipeline, _, err := defaultTeam.SavePipeline("some-other-pipeline", atc.Config{}, db.ConfigVersion(0), db.PipelineUnpaused)
+		Expect(err).ToNot(HaveOccurred())
+
+		eventType, data := nextEvent()
+		Expect(eventType).To(Equal(event.EventTypePipelineConfigChanged))
+		Expect(data).To(HaveKeyWithValue("pipeline_name", "some-other-pipeline"))
+		Expect(data).To(HaveKeyWithValue("config_version", BeNumerically("==", pipeline.ConfigVersion())))
+	})
+
+	It("waits for events which have yet to happen", func() {
+		received := make(chan atc.EventType)
+		go func() {
+			defer GinkgoRecover()
+
+			eventType, _ := nextEvent()
+			received <- eventType
+		}()
+
+		Consistently(received).ShouldNot(Receive())
+
+		Expect(defaultJob.Pause()).To(Succeed())
+
+		Eventually(received).Should(Receive(Equal(event.EventTypeJobPaused)))
+	})
+
+	It("does not emit events for other teams", func() {
+		otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, _, err = otherTeam.SavePipeline("other-pipeline", atc.Config{}, db.ConfigVersion(0), db.PipelineUnpaused)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(defaultJob.Pause()).To(Succeed())
+
+		eventType, _ := nextEvent()
+		Expect(eventType).To(Equal(event.EventTypeJobPaused))
+	})
+
+	Context("when resuming from an earlier event", func() {
+		BeforeEach(func() {
+			Expect(defaultJob.Pause()).To(Succeed())
+
+			latest, err := defaultTeam.LatestEventID()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(defaultJob.Unpause()).To(Succeed())
+
+			cursor = latest
+		})
+
+		It("streams only the events after it", func() {
+			ev, err := events.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ev.ID).To(BeNumerically(">", cursor))
+			Expect(ev.Envelope.Event).To(Equal(event.EventTypeJobUnpaused))
+		})
+	})
+
+	It("returns ErrTeamEventStreamClosed from Next after Close", func() {
+		Expect(events.Close()).To(Succeed())
+
+		_, err := events.Next()
+		Expect(err).To(Equal(db.ErrTeamEventStreamClosed))
+	})
+})
+
+var _ = Describe("TeamEventLifecycle", func() {
+	It("removes events older than the given age", func() {
+		Expect(defaultJob.Pause()).To(Succeed())
+
+		_, err := dbConn.Exec(`UPDATE team_events SET created_at = now() - '2 hours'::interval`)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(defaultJob.Unpause()).To(Succeed())
+
+		removed, err := db.NewTeamEventLifecycle(dbConn).RemoveEventsOlderThan(time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(removed).To(BeNumerically(">=", 1))
+
+		var remaining int
+		err = dbConn.QueryRow(`SELECT COUNT(*) FROM team_events`).Scan(&remaining)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(remaining).To(Equal(1))
+	})
+})
@@ -51,10 +51,15 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 		return nil, err
 	}
 
+	redactSecrets := false
+	if t.RedactSecrets != nil {
+		redactSecrets = *t.RedactSecrets
+	}
+
 	row := psql.Insert("teams").
-		Columns("name, auth, admin").
-		Values(t.Name, auth, admin).
-		Suffix("RETURNING id, name, admin, auth").
+		Columns("name, auth, admin, redact_secrets").
+		Values(t.Name, auth, admin, redactSecrets).
+		Suffix("RETURNING id, name, admin, auth, redact_secrets").
 		RunWith(tx).
 		QueryRow()
 
@@ -98,7 +103,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 		lockFactory: factory.lockFactory,
 	}
 
-	row := psql.Select("id, name, admin, auth").
+	row := psql.Select("id, name, admin, auth, redact_secrets").
 		From("teams").
 		Where(sq.Eq{"LOWER(name)": strings.ToLower(teamName)}).
 		RunWith(factory.conn).
@@ -117,7 +122,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 }
 
 func (factory *teamFactory) GetTeams() ([]Team, error) {
-	rows, err := psql.Select("id, name, admin, auth").
+	rows, err := psql.Select("id, name, admin, auth, redact_secrets").
 		From("teams").
 		OrderBy("id ASC").
 		RunWith(factory.conn).
@@ -182,6 +187,7 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		&t.name,
 		&t.admin,
 		&providerAuth,
+		&t.redactSecrets,
 	)
 
 	if providerAuth.Valid {
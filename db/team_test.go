@@ -721,12 +721,12 @@ var _ = Describe("Team", func() {
 
 	Describe("Updating Auth", func() {
 		var (
-			authProvider map[string][]string
+			authProvider atc.TeamAuth
 		)
 
 		BeforeEach(func() {
-			authProvider = map[string][]string{
-				"users": []string{"local:username"},
+			authProvider = atc.TeamAuth{
+				"owner": {"users": []string{"local:username"}},
 			}
 		})
 
@@ -755,6 +755,35 @@ var _ = Describe("Team", func() {
 				Expect(value).To(BeNil())
 			})
 		})
+
+		Describe("UpdateSettings", func() {
+			It("does not redact secrets by default", func() {
+				Expect(team.RedactSecrets()).To(BeFalse())
+			})
+
+			It("saves auth along with whether the team redacts secrets", func() {
+				redactSecrets := true
+				err := team.UpdateSettings(authProvider, &redactSecrets)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(team.Auth()).To(Equal(authProvider))
+				Expect(team.RedactSecrets()).To(BeTrue())
+
+				found, _, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found.Auth()).To(Equal(authProvider))
+				Expect(found.RedactSecrets()).To(BeTrue())
+			})
+
+			It("leaves secret redaction as it was when it is not given", func() {
+				redactSecrets := true
+				err := team.UpdateSettings(authProvider, &redactSecrets)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = team.UpdateSettings(authProvider, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(team.RedactSecrets()).To(BeTrue())
+			})
+		})
 	})
 
 	Describe("Pipelines", func() {
@@ -42,6 +42,7 @@ var workersQuery = psql.Select(`
 		w.https_proxy_url,
 		w.no_proxy,
 		w.active_containers,
+		w.active_volumes,
 		w.resource_types,
 		w.platform,
 		w.tags,
@@ -145,6 +146,7 @@ func scanWorker(worker *worker, row scannable) error {
 		&httpsProxyURL,
 		&noProxy,
 		&worker.activeContainers,
+		&worker.activeVolumes,
 		&resourceTypes,
 		&platform,
 		&tags,
@@ -265,6 +267,7 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 		Set("addr", sq.Expr("("+addrSQL+")")).
 		Set("baggageclaim_url", sq.Expr("("+bcSQL+")")).
 		Set("active_containers", atcWorker.ActiveContainers).
+		Set("active_volumes", atcWorker.ActiveVolumes).
 		Set("state", sq.Expr("("+cSQL+")")).
 		Where(sq.Eq{"name": atcWorker.Name}).
 		RunWith(tx).
@@ -359,6 +362,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 					"addr",
 					"expires",
 					"active_containers",
+					"active_volumes",
 					"resource_types",
 					"tags",
 					"platform",
@@ -377,6 +381,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 					atcWorker.GardenAddr,
 					sq.Expr(expires),
 					atcWorker.ActiveContainers,
+					atcWorker.ActiveVolumes,
 					resourceTypes,
 					tags,
 					atcWorker.Platform,
@@ -409,6 +414,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			Set("addr", atcWorker.GardenAddr).
 			Set("expires", sq.Expr(expires)).
 			Set("active_containers", atcWorker.ActiveContainers).
+			Set("active_volumes", atcWorker.ActiveVolumes).
 			Set("resource_types", resourceTypes).
 			Set("tags", tags).
 			Set("platform", atcWorker.Platform).
@@ -447,6 +453,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		httpsProxyURL:    atcWorker.HTTPSProxyURL,
 		noProxy:          atcWorker.NoProxy,
 		activeContainers: atcWorker.ActiveContainers,
+		activeVolumes:    atcWorker.ActiveVolumes,
 		resourceTypes:    atcWorker.ResourceTypes,
 		platform:         atcWorker.Platform,
 		tags:             atcWorker.Tags,
@@ -6,6 +6,8 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+
+	"github.com/mitchellh/mapstructure"
 )
 
 const VersionLatest = "latest"
@@ -29,19 +31,13 @@ var VersionConfigDecodeHook = func(
 			}, nil
 		}
 	case srcType.Kind() == reflect.Map:
-		version := Version{}
-		if versionConfig, ok := data.(map[interface{}]interface{}); ok {
-			for key, val := range versionConfig {
-				if sKey, ok := key.(string); ok {
-					if sVal, ok := val.(string); ok {
-						version[sKey] = strings.TrimSpace(sVal)
-					}
-				}
-			}
+		sanitized, err := sanitize(data)
+		if err != nil {
+			return nil, err
+		}
 
-			return VersionConfig{
-				Pinned: version,
-			}, nil
+		if versionConfig, ok := sanitized.(map[string]interface{}); ok {
+			return versionConfigFromMap(versionConfig)
 		}
 	}
 
@@ -115,3 +111,39 @@ func sanitize(root interface{}) (interface{}, error) {
 		return rootVal, nil
 	}
 }
+
+// DecodeConfig decodes a config which has been unmarshaled from YAML or JSON
+// into a generic structure. It also returns any nested keys which are not part
+// of the config, as these are most likely typos.
+func DecodeConfig(structure interface{}) (Config, []string, error) {
+	var config Config
+	var md mapstructure.Metadata
+	msConfig := &mapstructure.DecoderConfig{
+		Metadata:         &md,
+		Result:           &config,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			SanitizeDecodeHook,
+			VersionConfigDecodeHook,
+		),
+	}
+
+	decoder, err := mapstructure.NewDecoder(msConfig)
+	if err != nil {
+		return Config{}, nil, err
+	}
+
+	err = decoder.Decode(structure)
+	if err != nil {
+		return Config{}, nil, err
+	}
+
+	nestedUnused := []string{}
+	for _, unused := range md.Unused {
+		if strings.Contains(unused, ".") {
+			nestedUnused = append(nestedUnused, unused)
+		}
+	}
+
+	return config, nestedUnused, nil
+}
@@ -0,0 +1,15 @@
+package atc
+
+type EncryptionKeyRotation struct {
+	ID        int                                   `json:"id"`
+	StartTime int64                                 `json:"start_time"`
+	EndTime   int64                                 `json:"end_time,omitempty"`
+	Tables    map[string]EncryptionKeyRotationTable `json:"tables"`
+	Error     string                                `json:"error,omitempty"`
+}
+
+type EncryptionKeyRotationTable struct {
+	Total   int `json:"total"`
+	Scanned int `json:"scanned"`
+	Rotated int `json:"rotated"`
+}
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -94,13 +95,15 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 		plan.Attempts,
 	)
 
-	return build.factory.Task(
+	step := build.factory.Task(
 		logger,
 		plan,
 		build.dbBuild,
 		containerMetadata,
 		build.delegate.TaskDelegate(plan.ID),
 	)
+
+	return build.timed(step, "task", plan.Task.Name)
 }
 
 func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.Step {
@@ -114,7 +117,7 @@ func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.St
 		plan.Attempts,
 	)
 
-	return build.factory.Get(
+	step := build.factory.Get(
 		logger,
 		plan,
 		build.dbBuild,
@@ -122,6 +125,8 @@ func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.St
 		containerMetadata,
 		build.delegate.GetDelegate(plan.ID),
 	)
+
+	return build.timed(step, "get", plan.Get.Name)
 }
 
 func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.Step {
@@ -135,7 +140,7 @@ func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.St
 		plan.Attempts,
 	)
 
-	return build.factory.Put(
+	step := build.factory.Put(
 		logger,
 		plan,
 		build.dbBuild,
@@ -143,6 +148,21 @@ func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.St
 		containerMetadata,
 		build.delegate.PutDelegate(plan.ID),
 	)
+
+	return build.timed(step, "put", plan.Put.Name)
+}
+
+func (build *execBuild) buildSetPipelineStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	logger = logger.Session("set-pipeline", lager.Data{
+		"name": plan.SetPipeline.Name,
+	})
+
+	return build.factory.SetPipeline(
+		logger,
+		plan,
+		build.dbBuild,
+		build.delegate.BuildStepDelegate(plan.ID),
+	)
 }
 
 func (build *execBuild) buildRetryStep(logger lager.Logger, plan atc.Plan) exec.Step {
@@ -157,6 +177,15 @@ func (build *execBuild) buildRetryStep(logger lager.Logger, plan atc.Plan) exec.
 		steps = append(steps, step)
 	}
 
+	if plan.RetryPolicy != nil {
+		return exec.RetryWithPolicy(
+			*plan.RetryPolicy,
+			build.delegate.RetryDelegate(plan.ID),
+			clock.NewClock(),
+			steps...,
+		)
+	}
+
 	return exec.Retry(steps...)
 }
 
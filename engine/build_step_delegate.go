@@ -9,26 +9,37 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
 )
 
 type BuildStepDelegate struct {
-	build  db.Build
-	planID atc.PlanID
-	clock  clock.Clock
+	build    db.Build
+	planID   atc.PlanID
+	clock    clock.Clock
+	redactor *exec.SecretRedactor
+
+	writersL sync.Mutex
+	stdout   *dbEventWriter
+	stderr   *dbEventWriter
 }
 
+// NewBuildStepDelegate constructs a delegate which saves the step's output as
+// build events. If a redactor is given, credentials it has seen are masked in
+// the output before it is saved.
 func NewBuildStepDelegate(
 	build db.Build,
 	planID atc.PlanID,
 	clock clock.Clock,
+	redactor *exec.SecretRedactor,
 ) *BuildStepDelegate {
 	return &BuildStepDelegate{
-		build:  build,
-		planID: planID,
-		clock:  clock,
+		build:    build,
+		planID:   planID,
+		clock:    clock,
+		redactor: redactor,
 	}
 }
 
@@ -36,29 +47,81 @@ func (delegate *BuildStepDelegate) ImageVersionDetermined(resourceCache *db.Used
 	return delegate.build.SaveImageResourceVersion(resourceCache)
 }
 
+func (delegate *BuildStepDelegate) Variables(variables creds.Variables) creds.Variables {
+	if delegate.redactor == nil {
+		return variables
+	}
+
+	return delegate.redactor.Track(variables)
+}
+
 func (delegate *BuildStepDelegate) Stdout() io.Writer {
-	return newDBEventWriter(
-		delegate.build,
-		event.Origin{
-			Source: event.OriginSourceStdout,
-			ID:     event.OriginID(delegate.planID),
-		},
-		delegate.clock,
-	)
+	delegate.writersL.Lock()
+	defer delegate.writersL.Unlock()
+
+	if delegate.stdout == nil {
+		delegate.stdout = newDBEventWriter(
+			delegate.build,
+			event.Origin{
+				Source: event.OriginSourceStdout,
+				ID:     event.OriginID(delegate.planID),
+			},
+			delegate.clock,
+			delegate.redactor,
+		)
+	}
+
+	return delegate.stdout
 }
 
 func (delegate *BuildStepDelegate) Stderr() io.Writer {
-	return newDBEventWriter(
-		delegate.build,
-		event.Origin{
-			Source: event.OriginSourceStderr,
-			ID:     event.OriginID(delegate.planID),
-		},
-		delegate.clock,
-	)
+	delegate.writersL.Lock()
+	defer delegate.writersL.Unlock()
+
+	if delegate.stderr == nil {
+		delegate.stderr = newDBEventWriter(
+			delegate.build,
+			event.Origin{
+				Source: event.OriginSourceStderr,
+				ID:     event.OriginID(delegate.planID),
+			},
+			delegate.clock,
+			delegate.redactor,
+		)
+	}
+
+	return delegate.stderr
+}
+
+// Flush saves any output which was held back from the step's stdout and
+// stderr, e.g. because it ended with what may have been the start of a
+// credential. Output written after the step has finished, e.g. while an
+// aborted step's container is stopping, is then saved without holding any
+// back.
+func (delegate *BuildStepDelegate) Flush(logger lager.Logger) {
+	delegate.writersL.Lock()
+	defer delegate.writersL.Unlock()
+
+	for _, writer := range []*dbEventWriter{delegate.stdout, delegate.stderr} {
+		if writer == nil {
+			continue
+		}
+
+		err := writer.Flush()
+		if err != nil {
+			logger.Error("failed-to-flush-output", err)
+		}
+	}
 }
 
 func (delegate *BuildStepDelegate) Errored(logger lager.Logger, message string) {
+	delegate.Flush(logger)
+
+	if delegate.redactor != nil {
+		redacted, dangling := delegate.redactor.Redact(message)
+		message = redacted + dangling
+	}
+
 	err := delegate.build.SaveEvent(event.Error{
 		Message: message,
 		Origin: event.Origin{
@@ -70,25 +133,34 @@ func (delegate *BuildStepDelegate) Errored(logger lager.Logger, message string)
 	}
 }
 
-func newDBEventWriter(build db.Build, origin event.Origin, clock clock.Clock) io.Writer {
+func newDBEventWriter(build db.Build, origin event.Origin, clock clock.Clock, redactor *exec.SecretRedactor) *dbEventWriter {
 	return &dbEventWriter{
-		build:  build,
-		origin: origin,
-		clock:  clock,
+		build:    build,
+		origin:   origin,
+		clock:    clock,
+		redactor: redactor,
 	}
 }
 
 type dbEventWriter struct {
 	build db.Build
 
+	lock sync.Mutex
+
 	origin event.Origin
 
 	dangling []byte
+	flushed  bool
 
 	clock clock.Clock
+
+	redactor *exec.SecretRedactor
 }
 
 func (writer *dbEventWriter) Write(data []byte) (int, error) {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
 	text := append(writer.dangling, data...)
 
 	checkEncoding, _ := utf8.DecodeLastRune(text)
@@ -99,9 +171,27 @@ func (writer *dbEventWriter) Write(data []byte) (int, error) {
 
 	writer.dangling = nil
 
+	payload := string(text)
+	if writer.redactor != nil {
+		var dangling string
+		payload, dangling = writer.redactor.Redact(payload)
+
+		// hold back what may be the start of a credential until the rest of
+		// it is written
+		if writer.flushed {
+			payload += dangling
+		} else if dangling != "" {
+			writer.dangling = []byte(dangling)
+		}
+
+		if payload == "" {
+			return len(data), nil
+		}
+	}
+
 	err := writer.build.SaveEvent(event.Log{
 		Time:    writer.clock.Now().Unix(),
-		Payload: string(text),
+		Payload: payload,
 		Origin:  writer.origin,
 	})
 	if err != nil {
@@ -111,6 +201,33 @@ func (writer *dbEventWriter) Write(data []byte) (int, error) {
 	return len(data), nil
 }
 
+// Flush saves whatever was held back by earlier writes. Nothing more will be
+// written to complete it, so it cannot be the start of a credential.
+func (writer *dbEventWriter) Flush() error {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
+	writer.flushed = true
+
+	if len(writer.dangling) == 0 {
+		return nil
+	}
+
+	payload := string(writer.dangling)
+	writer.dangling = nil
+
+	if writer.redactor != nil {
+		redacted, dangling := writer.redactor.Redact(payload)
+		payload = redacted + dangling
+	}
+
+	return writer.build.SaveEvent(event.Log{
+		Time:    writer.clock.Now().Unix(),
+		Payload: payload,
+		Origin:  writer.origin,
+	})
+}
+
 type implicitOutput struct {
 	resourceType string
 	info         exec.VersionInfo
@@ -6,11 +6,14 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/bosh-cli/director/template"
 
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/exec"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -26,7 +29,7 @@ var _ = Describe("BuildStepDelegate", func() {
 	BeforeEach(func() {
 		fakeBuild = new(dbfakes.FakeBuild)
 		fakeClock = fakeclock.NewFakeClock(time.Unix(123456789, 0))
-		delegate = engine.NewBuildStepDelegate(fakeBuild, "some-plan-id", fakeClock)
+		delegate = engine.NewBuildStepDelegate(fakeBuild, "some-plan-id", fakeClock, nil)
 	})
 
 	Describe("ImageVersionDetermined", func() {
@@ -153,4 +156,116 @@ var _ = Describe("BuildStepDelegate", func() {
 			})
 		})
 	})
+
+	Context("with a secret redactor", func() {
+		var writer io.Writer
+
+		BeforeEach(func() {
+			delegate = engine.NewBuildStepDelegate(fakeBuild, "some-plan-id", fakeClock, exec.NewSecretRedactor())
+
+			variables := delegate.Variables(template.StaticVariables{
+				"some-secret": "super-secret",
+			})
+
+			_, found, err := variables.Get(template.VariableDefinition{Name: "some-secret"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			writer = delegate.Stdout()
+		})
+
+		It("masks the credentials resolved through its variables", func() {
+			_, err := writer.Write([]byte("the secret is super-secret!\n"))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+				Time:    123456789,
+				Payload: "the secret is ((redacted))!\n",
+				Origin: event.Origin{
+					Source: event.OriginSourceStdout,
+					ID:     "some-plan-id",
+				},
+			}))
+		})
+
+		It("masks credentials split across writes", func() {
+			_, err := writer.Write([]byte("the secret is super-"))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = writer.Write([]byte("secret!\n"))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
+			Expect(fakeBuild.SaveEventArgsForCall(0).(event.Log).Payload).To(Equal("the secret is "))
+			Expect(fakeBuild.SaveEventArgsForCall(1).(event.Log).Payload).To(Equal("((redacted))!\n"))
+		})
+
+		It("saves output ending in the start of a credential once flushed", func() {
+			_, err := writer.Write([]byte("the secret is not super-"))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0).(event.Log).Payload).To(Equal("the secret is not "))
+
+			delegate.Flush(lagertest.NewTestLogger("test"))
+
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
+			Expect(fakeBuild.SaveEventArgsForCall(1)).To(Equal(event.Log{
+				Time:    123456789,
+				Payload: "super-",
+				Origin: event.Origin{
+					Source: event.OriginSourceStdout,
+					ID:     "some-plan-id",
+				},
+			}))
+
+			delegate.Flush(lagertest.NewTestLogger("test"))
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
+		})
+
+		It("saves held back output when the step is aborted", func() {
+			_, err := writer.Write([]byte("the secret is not super-"))
+			Expect(err).ToNot(HaveOccurred())
+
+			delegate.Errored(lagertest.NewTestLogger("test"), exec.AbortedLogMessage)
+
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(3))
+			Expect(fakeBuild.SaveEventArgsForCall(1).(event.Log).Payload).To(Equal("super-"))
+			Expect(fakeBuild.SaveEventArgsForCall(2).(event.Error).Message).To(Equal("interrupted"))
+		})
+
+		It("does not hold back output written after it has been flushed", func() {
+			delegate.Flush(lagertest.NewTestLogger("test"))
+
+			_, err := writer.Write([]byte("stopping super-"))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0).(event.Log).Payload).To(Equal("stopping super-"))
+		})
+
+		It("saves held back output before an error", func() {
+			_, err := writer.Write([]byte("super-"))
+			Expect(err).ToNot(HaveOccurred())
+
+			delegate.Errored(lagertest.NewTestLogger("test"), "failed")
+
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
+			Expect(fakeBuild.SaveEventArgsForCall(0).(event.Log).Payload).To(Equal("super-"))
+			Expect(fakeBuild.SaveEventArgsForCall(1).(event.Error).Message).To(Equal("failed"))
+		})
+
+		It("masks credentials in errors", func() {
+			delegate.Errored(lagertest.NewTestLogger("test"), "failed with super-secret")
+
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Error{
+				Message: "failed with ((redacted))",
+				Origin: event.Origin{
+					ID: "some-plan-id",
+				},
+			}))
+		})
+	})
 })
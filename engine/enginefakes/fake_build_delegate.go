@@ -55,6 +55,17 @@ type FakeBuildDelegate struct {
 	buildStepDelegateReturnsOnCall map[int]struct {
 		result1 exec.BuildStepDelegate
 	}
+	RetryDelegateStub        func(atc.PlanID) exec.RetryDelegate
+	retryDelegateMutex       sync.RWMutex
+	retryDelegateArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	retryDelegateReturns struct {
+		result1 exec.RetryDelegate
+	}
+	retryDelegateReturnsOnCall map[int]struct {
+		result1 exec.RetryDelegate
+	}
 	FinishStub        func(lager.Logger, error, bool)
 	finishMutex       sync.RWMutex
 	finishArgsForCall []struct {
@@ -258,6 +269,54 @@ func (fake *FakeBuildDelegate) BuildStepDelegateReturnsOnCall(i int, result1 exe
 	}{result1}
 }
 
+func (fake *FakeBuildDelegate) RetryDelegate(arg1 atc.PlanID) exec.RetryDelegate {
+	fake.retryDelegateMutex.Lock()
+	ret, specificReturn := fake.retryDelegateReturnsOnCall[len(fake.retryDelegateArgsForCall)]
+	fake.retryDelegateArgsForCall = append(fake.retryDelegateArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("RetryDelegate", []interface{}{arg1})
+	fake.retryDelegateMutex.Unlock()
+	if fake.RetryDelegateStub != nil {
+		return fake.RetryDelegateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.retryDelegateReturns.result1
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateCallCount() int {
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	return len(fake.retryDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateArgsForCall(i int) atc.PlanID {
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	return fake.retryDelegateArgsForCall[i].arg1
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateReturns(result1 exec.RetryDelegate) {
+	fake.RetryDelegateStub = nil
+	fake.retryDelegateReturns = struct {
+		result1 exec.RetryDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateReturnsOnCall(i int, result1 exec.RetryDelegate) {
+	fake.RetryDelegateStub = nil
+	if fake.retryDelegateReturnsOnCall == nil {
+		fake.retryDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.RetryDelegate
+		})
+	}
+	fake.retryDelegateReturnsOnCall[i] = struct {
+		result1 exec.RetryDelegate
+	}{result1}
+}
+
 func (fake *FakeBuildDelegate) Finish(arg1 lager.Logger, arg2 error, arg3 bool) {
 	fake.finishMutex.Lock()
 	fake.finishArgsForCall = append(fake.finishArgsForCall, struct {
@@ -295,6 +354,8 @@ func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	defer fake.taskDelegateMutex.RUnlock()
 	fake.buildStepDelegateMutex.RLock()
 	defer fake.buildStepDelegateMutex.RUnlock()
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -13,6 +13,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/tracing"
 )
 
 type execMetadata struct {
@@ -147,7 +148,15 @@ func (build *execBuild) Abort(lager.Logger) error {
 func (build *execBuild) Resume(logger lager.Logger) {
 	step := build.buildStep(logger, build.metadata.Plan)
 
-	runCtx := lagerctx.NewContext(build.ctx, logger)
+	runCtx, span := tracing.StartSpan(build.ctx, "build", tracing.Attrs{
+		"team":     build.stepMetadata.TeamName,
+		"pipeline": build.stepMetadata.PipelineName,
+		"job":      build.stepMetadata.JobName,
+		"build":    build.stepMetadata.BuildName,
+		"build_id": strconv.Itoa(build.stepMetadata.BuildID),
+	})
+
+	runCtx = lagerctx.NewContext(runCtx, logger)
 
 	state := build.runState()
 	defer build.clearRunState()
@@ -161,9 +170,11 @@ func (build *execBuild) Resume(logger lager.Logger) {
 		select {
 		case <-build.releaseCh:
 			logger.Info("releasing")
+			span.End()
 			return
 		case err := <-done:
 			build.delegate.Finish(logger.Session("finish"), err, step.Succeeded())
+			tracing.End(span, err)
 			return
 		}
 	}
@@ -188,59 +199,63 @@ func (build *execBuild) clearRunState() {
 
 func (build *execBuild) buildStep(logger lager.Logger, plan atc.Plan) exec.Step {
 	if plan.Aggregate != nil {
-		return build.buildAggregateStep(logger, plan)
+		return build.traced(build.buildAggregateStep(logger, plan), "aggregate", plan)
 	}
 
 	if plan.Do != nil {
-		return build.buildDoStep(logger, plan)
+		return build.traced(build.buildDoStep(logger, plan), "do", plan)
 	}
 
 	if plan.Timeout != nil {
-		return build.buildTimeoutStep(logger, plan)
+		return build.traced(build.buildTimeoutStep(logger, plan), "timeout", plan)
 	}
 
 	if plan.Try != nil {
-		return build.buildTryStep(logger, plan)
+		return build.traced(build.buildTryStep(logger, plan), "try", plan)
 	}
 
 	if plan.OnAbort != nil {
-		return build.buildOnAbortStep(logger, plan)
+		return build.traced(build.buildOnAbortStep(logger, plan), "on_abort", plan)
 	}
 
 	if plan.OnSuccess != nil {
-		return build.buildOnSuccessStep(logger, plan)
+		return build.traced(build.buildOnSuccessStep(logger, plan), "on_success", plan)
 	}
 
 	if plan.OnFailure != nil {
-		return build.buildOnFailureStep(logger, plan)
+		return build.traced(build.buildOnFailureStep(logger, plan), "on_failure", plan)
 	}
 
 	if plan.Ensure != nil {
-		return build.buildEnsureStep(logger, plan)
+		return build.traced(build.buildEnsureStep(logger, plan), "ensure", plan)
 	}
 
 	if plan.Task != nil {
-		return build.buildTaskStep(logger, plan)
+		return build.traced(build.buildTaskStep(logger, plan), "task", plan)
 	}
 
 	if plan.Get != nil {
-		return build.buildGetStep(logger, plan)
+		return build.traced(build.buildGetStep(logger, plan), "get", plan)
 	}
 
 	if plan.Put != nil {
-		return build.buildPutStep(logger, plan)
+		return build.traced(build.buildPutStep(logger, plan), "put", plan)
 	}
 
 	if plan.Retry != nil {
-		return build.buildRetryStep(logger, plan)
+		return build.traced(build.buildRetryStep(logger, plan), "retry", plan)
+	}
+
+	if plan.SetPipeline != nil {
+		return build.traced(build.buildSetPipelineStep(logger, plan), "set_pipeline", plan)
 	}
 
 	if plan.UserArtifact != nil {
-		return build.buildUserArtifactStep(logger, plan)
+		return build.traced(build.buildUserArtifactStep(logger, plan), "user_artifact", plan)
 	}
 
 	if plan.ArtifactOutput != nil {
-		return build.buildArtifactOutputStep(logger, plan)
+		return build.traced(build.buildArtifactOutputStep(logger, plan), "artifact_output", plan)
 	}
 
 	return exec.IdentityStep{}
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"sync"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
@@ -18,6 +19,7 @@ type BuildDelegate interface {
 	TaskDelegate(atc.PlanID) exec.TaskDelegate
 
 	BuildStepDelegate(atc.PlanID) exec.BuildStepDelegate
+	RetryDelegate(atc.PlanID) exec.RetryDelegate
 
 	Finish(lager.Logger, error, bool)
 }
@@ -28,40 +30,71 @@ type BuildDelegateFactory interface {
 	Delegate(db.Build) BuildDelegate
 }
 
-type buildDelegateFactory struct{}
+type buildDelegateFactory struct {
+	teamFactory db.TeamFactory
+}
 
-func NewBuildDelegateFactory() BuildDelegateFactory {
-	return buildDelegateFactory{}
+func NewBuildDelegateFactory(teamFactory db.TeamFactory) BuildDelegateFactory {
+	return buildDelegateFactory{
+		teamFactory: teamFactory,
+	}
 }
 
 func (factory buildDelegateFactory) Delegate(build db.Build) BuildDelegate {
-	return newBuildDelegate(build)
+	return newBuildDelegate(build, factory.teamFactory)
 }
 
 type delegate struct {
-	build db.Build
+	build       db.Build
+	teamFactory db.TeamFactory
+
+	redactorOnce sync.Once
+	redactor     *exec.SecretRedactor
 }
 
-func newBuildDelegate(build db.Build) BuildDelegate {
+func newBuildDelegate(build db.Build, teamFactory db.TeamFactory) BuildDelegate {
 	return &delegate{
-		build: build,
+		build:       build,
+		teamFactory: teamFactory,
 	}
 }
 
 func (delegate *delegate) GetDelegate(planID atc.PlanID) exec.GetDelegate {
-	return NewGetDelegate(delegate.build, planID, clock.NewClock())
+	return NewGetDelegate(delegate.build, planID, clock.NewClock(), delegate.secretRedactor())
 }
 
 func (delegate *delegate) PutDelegate(planID atc.PlanID) exec.PutDelegate {
-	return NewPutDelegate(delegate.build, planID, clock.NewClock())
+	return NewPutDelegate(delegate.build, planID, clock.NewClock(), delegate.secretRedactor())
 }
 
 func (delegate *delegate) TaskDelegate(planID atc.PlanID) exec.TaskDelegate {
-	return NewTaskDelegate(delegate.build, planID, clock.NewClock())
+	return NewTaskDelegate(delegate.build, planID, clock.NewClock(), delegate.secretRedactor())
 }
 
 func (delegate *delegate) BuildStepDelegate(planID atc.PlanID) exec.BuildStepDelegate {
-	return NewBuildStepDelegate(delegate.build, planID, clock.NewClock())
+	return NewBuildStepDelegate(delegate.build, planID, clock.NewClock(), delegate.secretRedactor())
+}
+
+func (delegate *delegate) RetryDelegate(planID atc.PlanID) exec.RetryDelegate {
+	return NewRetryDelegate(delegate.build, planID, clock.NewClock())
+}
+
+// secretRedactor returns the redactor shared by every step of the build, or
+// nil if the build's team has not enabled redaction. The team is only looked
+// up once the build's steps are constructed.
+func (delegate *delegate) secretRedactor() *exec.SecretRedactor {
+	delegate.redactorOnce.Do(func() {
+		team, found, err := delegate.teamFactory.FindTeam(delegate.build.TeamName())
+		if err == nil && found && !team.RedactSecrets() {
+			return
+		}
+
+		// redact if the team could not be determined, rather than risk
+		// revealing credentials
+		delegate.redactor = exec.NewSecretRedactor()
+	})
+
+	return delegate.redactor
 }
 
 func (delegate *delegate) Finish(logger lager.Logger, err error, succeeded bool) {
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	. "github.com/concourse/atc/engine"
@@ -16,7 +17,9 @@ import (
 
 var _ = Describe("BuildDelegate", func() {
 	var (
-		factory BuildDelegateFactory
+		factory         BuildDelegateFactory
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
 
 		fakeBuild *dbfakes.FakeBuild
 
@@ -28,9 +31,14 @@ var _ = Describe("BuildDelegate", func() {
 	)
 
 	BeforeEach(func() {
-		factory = NewBuildDelegateFactory()
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+
+		factory = NewBuildDelegateFactory(fakeTeamFactory)
 
 		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.TeamNameReturns("some-team")
 		delegate = factory.Delegate(fakeBuild)
 
 		logger = lagertest.NewTestLogger("test")
@@ -61,4 +69,63 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 	})
+
+	Describe("secret redaction", func() {
+		var savedPayloads []string
+
+		JustBeforeEach(func() {
+			stepDelegate := delegate.TaskDelegate("some-plan-id")
+
+			variables := stepDelegate.Variables(template.StaticVariables{
+				"some-secret": "super-secret",
+			})
+
+			_, _, err := variables.Get(template.VariableDefinition{Name: "some-secret"})
+			Expect(err).ToNot(HaveOccurred())
+
+			// a secret fetched by one step is masked in the output of the others
+			_, err = delegate.BuildStepDelegate("other-plan-id").Stdout().Write([]byte("super-secret\n"))
+			Expect(err).ToNot(HaveOccurred())
+
+			savedPayloads = []string{}
+			for i := 0; i < fakeBuild.SaveEventCallCount(); i++ {
+				savedPayloads = append(savedPayloads, fakeBuild.SaveEventArgsForCall(i).(event.Log).Payload)
+			}
+		})
+
+		Context("when the team redacts secrets", func() {
+			BeforeEach(func() {
+				fakeTeam.RedactSecretsReturns(true)
+			})
+
+			It("looks up the build's team once", func() {
+				Expect(fakeTeamFactory.FindTeamCallCount()).To(Equal(1))
+				Expect(fakeTeamFactory.FindTeamArgsForCall(0)).To(Equal("some-team"))
+			})
+
+			It("masks credentials in the build's output", func() {
+				Expect(savedPayloads).To(Equal([]string{"((redacted))\n"}))
+			})
+		})
+
+		Context("when the team does not redact secrets", func() {
+			BeforeEach(func() {
+				fakeTeam.RedactSecretsReturns(false)
+			})
+
+			It("leaves the build's output alone", func() {
+				Expect(savedPayloads).To(Equal([]string{"super-secret\n"}))
+			})
+		})
+
+		Context("when the team cannot be found", func() {
+			BeforeEach(func() {
+				fakeTeamFactory.FindTeamReturns(nil, false, errors.New("nope"))
+			})
+
+			It("masks credentials in the build's output", func() {
+				Expect(savedPayloads).To(Equal([]string{"((redacted))\n"}))
+			})
+		})
+	})
 })
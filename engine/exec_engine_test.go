@@ -8,6 +8,10 @@ import (
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/engine/enginefakes"
 	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/metric/metrictest"
+	"github.com/concourse/atc/tracing"
+	"github.com/concourse/atc/tracing/tracingtest"
+	"go.opentelemetry.io/otel/trace"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -470,6 +474,31 @@ var _ = Describe("ExecEngine", func() {
 				})
 			})
 
+			Context("that contains set_pipeline steps", func() {
+				BeforeEach(func() {
+					fakeFactory.SetPipelineReturns(new(execfakes.FakeStep))
+				})
+
+				It("constructs set_pipeline steps correctly", func() {
+					expectedPlan = planFactory.NewPlan(atc.SetPipelinePlan{
+						Name: "some-pipeline",
+						File: "some-input/pipeline.yml",
+					})
+
+					var err error
+					build, err = execEngine.CreateBuild(logger, dbBuild, expectedPlan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(fakeFactory.SetPipelineCallCount()).To(Equal(1))
+
+					logger, plan, build, _ := fakeFactory.SetPipelineArgsForCall(0)
+					Expect(logger).NotTo(BeNil())
+					Expect(build).To(Equal(dbBuild))
+					Expect(plan).To(Equal(expectedPlan))
+				})
+			})
+
 			Context("that contains outputs", func() {
 				var (
 					expectedPlan     atc.Plan
@@ -553,6 +582,102 @@ var _ = Describe("ExecEngine", func() {
 				})
 			})
 		})
+
+		Context("when tracing is configured", func() {
+			var recorder *tracingtest.Recorder
+
+			BeforeEach(func() {
+				recorder = tracingtest.Install()
+
+				outputPlan = planFactory.NewPlan(atc.DoPlan{
+					planFactory.NewPlan(atc.GetPlan{
+						Name:     "some-input",
+						Resource: "some-input-resource",
+						Type:     "get",
+					}),
+					planFactory.NewPlan(atc.TaskPlan{
+						Name:       "some-task",
+						ConfigPath: "some-input/build.yml",
+					}),
+				})
+			})
+
+			AfterEach(func() {
+				tracing.ConfigureTraceProvider(trace.NewNoopTracerProvider())
+			})
+
+			It("records a span for the build and each step", func() {
+				build, err := execEngine.CreateBuild(logger, dbBuild, outputPlan)
+				Expect(err).NotTo(HaveOccurred())
+
+				build.Resume(logger)
+
+				Expect(recorder.SpanNames()).To(Equal([]string{"get", "task", "do", "build"}))
+
+				Expect(recorder.Attributes("build")).To(Equal(map[string]string{
+					"team":     "some-team",
+					"pipeline": "some-pipeline",
+					"job":      "some-job",
+					"build":    "42",
+					"build_id": "4444",
+				}))
+
+				Expect(recorder.Attributes("task")).To(HaveKeyWithValue("name", "some-task"))
+			})
+		})
+
+		Context("when the build runs steps", func() {
+			var recorder *metrictest.Recorder
+
+			BeforeEach(func() {
+				recorder = metrictest.Install()
+
+				taskStep.SucceededReturns(false)
+
+				outputPlan = planFactory.NewPlan(atc.DoPlan{
+					planFactory.NewPlan(atc.GetPlan{
+						Name:     "some-input",
+						Resource: "some-input-resource",
+						Type:     "get",
+					}),
+					planFactory.NewPlan(atc.TaskPlan{
+						Name:       "some-task",
+						ConfigPath: "some-input/build.yml",
+					}),
+				})
+			})
+
+			It("emits the duration of each step", func() {
+				build, err := execEngine.CreateBuild(logger, dbBuild, outputPlan)
+				Expect(err).NotTo(HaveOccurred())
+
+				build.Resume(logger)
+
+				Eventually(func() []map[string]string {
+					return recorder.Attributes("step duration (ms)")
+				}).Should(ContainElement(Equal(map[string]string{
+					"team_name": "some-team",
+					"pipeline":  "some-pipeline",
+					"job":       "some-job",
+					"build_id":  "4444",
+					"step_type": "get",
+					"step_name": "some-input",
+					"succeeded": "true",
+				})))
+
+				Eventually(func() []map[string]string {
+					return recorder.Attributes("step duration (ms)")
+				}).Should(ContainElement(Equal(map[string]string{
+					"team_name": "some-team",
+					"pipeline":  "some-pipeline",
+					"job":       "some-job",
+					"build_id":  "4444",
+					"step_type": "task",
+					"step_name": "some-task",
+					"succeeded": "false",
+				})))
+			})
+		})
 	})
 
 	Describe("LookupBuild", func() {
@@ -11,15 +11,15 @@ import (
 )
 
 type getDelegate struct {
-	exec.BuildStepDelegate
+	*BuildStepDelegate
 
 	build       db.Build
 	eventOrigin event.Origin
 }
 
-func NewGetDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.GetDelegate {
+func NewGetDelegate(build db.Build, planID atc.PlanID, clock clock.Clock, redactor *exec.SecretRedactor) exec.GetDelegate {
 	return &getDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock),
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock, redactor),
 
 		build: build,
 		eventOrigin: event.Origin{
@@ -29,6 +29,8 @@ func NewGetDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.G
 }
 
 func (d *getDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus, info exec.VersionInfo) {
+	d.Flush(logger)
+
 	err := d.build.SaveEvent(event.FinishGet{
 		Origin:          d.eventOrigin,
 		ExitStatus:      int(exitStatus),
@@ -11,15 +11,15 @@ import (
 )
 
 type putDelegate struct {
-	exec.BuildStepDelegate
+	*BuildStepDelegate
 
 	build       db.Build
 	eventOrigin event.Origin
 }
 
-func NewPutDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.PutDelegate {
+func NewPutDelegate(build db.Build, planID atc.PlanID, clock clock.Clock, redactor *exec.SecretRedactor) exec.PutDelegate {
 	return &putDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock),
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock, redactor),
 
 		build: build,
 		eventOrigin: event.Origin{
@@ -29,6 +29,8 @@ func NewPutDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.P
 }
 
 func (d *putDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus, info exec.VersionInfo) {
+	d.Flush(logger)
+
 	err := d.build.SaveEvent(event.FinishPut{
 		Origin:          d.eventOrigin,
 		ExitStatus:      int(exitStatus),
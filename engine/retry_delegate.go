@@ -0,0 +1,44 @@
+package engine
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/exec"
+)
+
+type retryDelegate struct {
+	build       db.Build
+	eventOrigin event.Origin
+	clock       clock.Clock
+}
+
+func NewRetryDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.RetryDelegate {
+	return &retryDelegate{
+		build: build,
+		eventOrigin: event.Origin{
+			ID: event.OriginID(planID),
+		},
+		clock: clock,
+	}
+}
+
+func (d *retryDelegate) Waiting(logger lager.Logger, attempt int, delay time.Duration) {
+	err := d.build.SaveEvent(event.WaitRetry{
+		Time:    d.clock.Now().Unix(),
+		Origin:  d.eventOrigin,
+		Attempt: attempt,
+		Delay:   delay.String(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-wait-retry-event", err)
+		return
+	}
+
+	logger.Info("waiting-to-retry", lager.Data{"attempt": attempt, "delay": delay.String()})
+}
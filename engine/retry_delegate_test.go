@@ -0,0 +1,61 @@
+package engine_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/exec"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("RetryDelegate", func() {
+	var (
+		fakeBuild *dbfakes.FakeBuild
+		fakeClock *fakeclock.FakeClock
+		logger    *lagertest.TestLogger
+
+		delegate exec.RetryDelegate
+	)
+
+	BeforeEach(func() {
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123456789, 0))
+		logger = lagertest.NewTestLogger("test")
+
+		delegate = engine.NewRetryDelegate(fakeBuild, "some-plan-id", fakeClock)
+	})
+
+	Describe("Waiting", func() {
+		JustBeforeEach(func() {
+			delegate.Waiting(logger, 2, 30*time.Second)
+		})
+
+		It("saves a wait-retry event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.WaitRetry{
+				Time:    123456789,
+				Origin:  event.Origin{ID: "some-plan-id"},
+				Attempt: 2,
+				Delay:   "30s",
+			}))
+		})
+
+		Context("when saving the event fails", func() {
+			BeforeEach(func() {
+				fakeBuild.SaveEventReturns(errors.New("nope"))
+			})
+
+			It("logs the error", func() {
+				Expect(logger).To(gbytes.Say("failed-to-save-wait-retry-event"))
+			})
+		})
+	})
+})
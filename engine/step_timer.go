@@ -0,0 +1,48 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/metric"
+)
+
+// timedStep emits how long the wrapped step took to run, so that slow gets,
+// puts and tasks can be told apart across pipelines.
+type timedStep struct {
+	exec.Step
+
+	stepType string
+	stepName string
+	metadata StepMetadata
+}
+
+func (build *execBuild) timed(step exec.Step, stepType string, stepName string) exec.Step {
+	return timedStep{
+		Step:     step,
+		stepType: stepType,
+		stepName: stepName,
+		metadata: build.stepMetadata,
+	}
+}
+
+func (step timedStep) Run(ctx context.Context, state exec.RunState) error {
+	start := time.Now()
+
+	err := step.Step.Run(ctx, state)
+
+	metric.StepDuration{
+		TeamName:     step.metadata.TeamName,
+		PipelineName: step.metadata.PipelineName,
+		JobName:      step.metadata.JobName,
+		BuildID:      step.metadata.BuildID,
+		StepType:     step.stepType,
+		StepName:     step.stepName,
+		Succeeded:    err == nil && step.Step.Succeeded(),
+		Duration:     time.Since(start),
+	}.Emit(lagerctx.FromContext(ctx))
+
+	return err
+}
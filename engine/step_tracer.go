@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/tracing"
+)
+
+// tracedStep runs the wrapped step within a span, so that nested steps show
+// up as children of the steps that run them.
+type tracedStep struct {
+	exec.Step
+
+	stepType string
+	attrs    tracing.Attrs
+}
+
+func (build *execBuild) traced(step exec.Step, stepType string, plan atc.Plan) exec.Step {
+	attrs := tracing.Attrs{
+		"plan_id": string(plan.ID),
+	}
+
+	switch {
+	case plan.Task != nil:
+		attrs["name"] = plan.Task.Name
+	case plan.Get != nil:
+		attrs["name"] = plan.Get.Name
+	case plan.Put != nil:
+		attrs["name"] = plan.Put.Name
+	case plan.SetPipeline != nil:
+		attrs["name"] = plan.SetPipeline.Name
+	}
+
+	return tracedStep{
+		Step:     step,
+		stepType: stepType,
+		attrs:    attrs,
+	}
+}
+
+func (step tracedStep) Run(ctx context.Context, state exec.RunState) error {
+	ctx, span := tracing.StartSpan(ctx, step.stepType, step.attrs)
+
+	err := step.Step.Run(ctx, state)
+
+	tracing.End(span, err)
+
+	return err
+}
@@ -13,15 +13,15 @@ import (
 )
 
 type taskDelegate struct {
-	exec.BuildStepDelegate
+	*BuildStepDelegate
 
 	build       db.Build
 	eventOrigin event.Origin
 }
 
-func NewTaskDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.TaskDelegate {
+func NewTaskDelegate(build db.Build, planID atc.PlanID, clock clock.Clock, redactor *exec.SecretRedactor) exec.TaskDelegate {
 	return &taskDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock),
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock, redactor),
 
 		build: build,
 		eventOrigin: event.Origin{
@@ -59,6 +59,8 @@ func (d *taskDelegate) Starting(logger lager.Logger, taskConfig atc.TaskConfig)
 }
 
 func (d *taskDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus) {
+	d.Flush(logger)
+
 	err := d.build.SaveEvent(event.FinishTask{
 		ExitStatus: int(exitStatus),
 		Time:       time.Now().Unix(),
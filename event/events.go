@@ -35,6 +35,12 @@ type TaskConfig struct {
 
 	Run    TaskRunConfig     `json:"run"`
 	Inputs []TaskInputConfig `json:"inputs"`
+	Limits ContainerLimits   `json:"container_limits,omitempty"`
+}
+
+type ContainerLimits struct {
+	CPU    *uint64 `json:"cpu,omitempty"`
+	Memory *uint64 `json:"memory,omitempty"`
 }
 
 type TaskRunConfig struct {
@@ -67,6 +73,10 @@ func ShadowTaskConfig(config atc.TaskConfig) TaskConfig {
 			Dir:  config.Run.Dir,
 		},
 		Inputs: inputConfigs,
+		Limits: ContainerLimits{
+			CPU:    config.Limits.CPU,
+			Memory: config.Limits.Memory,
+		},
 	}
 }
 
@@ -129,3 +139,13 @@ type FinishPut struct {
 
 func (FinishPut) EventType() atc.EventType  { return EventTypeFinishPut }
 func (FinishPut) Version() atc.EventVersion { return "5.0" }
+
+type WaitRetry struct {
+	Time    int64  `json:"time"`
+	Origin  Origin `json:"origin"`
+	Attempt int    `json:"attempt"`
+	Delay   string `json:"delay"`
+}
+
+func (WaitRetry) EventType() atc.EventType  { return EventTypeWaitRetry }
+func (WaitRetry) Version() atc.EventVersion { return "1.0" }
@@ -41,6 +41,13 @@ func init() {
 	registerEvent(Status{})
 	registerEvent(Log{})
 	registerEvent(Error{})
+	registerEvent(WaitRetry{})
+	registerEvent(BuildStarted{})
+	registerEvent(BuildFinished{})
+	registerEvent(JobPaused{})
+	registerEvent(JobUnpaused{})
+	registerEvent(ResourceChecked{})
+	registerEvent(PipelineConfigChanged{})
 
 	// deprecated:
 	registerEvent(InitializeV10{})
@@ -0,0 +1,61 @@
+package event
+
+import "github.com/concourse/atc"
+
+// events streamed for a team's activity, as opposed to a single build's
+
+type BuildStarted struct {
+	BuildID      int    `json:"build_id"`
+	BuildName    string `json:"build_name"`
+	PipelineName string `json:"pipeline_name,omitempty"`
+	JobName      string `json:"job_name,omitempty"`
+	Time         int64  `json:"time"`
+}
+
+func (BuildStarted) EventType() atc.EventType  { return EventTypeBuildStarted }
+func (BuildStarted) Version() atc.EventVersion { return "1.0" }
+
+type BuildFinished struct {
+	BuildID      int             `json:"build_id"`
+	BuildName    string          `json:"build_name"`
+	PipelineName string          `json:"pipeline_name,omitempty"`
+	JobName      string          `json:"job_name,omitempty"`
+	Status       atc.BuildStatus `json:"status"`
+	Time         int64           `json:"time"`
+}
+
+func (BuildFinished) EventType() atc.EventType  { return EventTypeBuildFinished }
+func (BuildFinished) Version() atc.EventVersion { return "1.0" }
+
+type JobPaused struct {
+	PipelineName string `json:"pipeline_name"`
+	JobName      string `json:"job_name"`
+}
+
+func (JobPaused) EventType() atc.EventType  { return EventTypeJobPaused }
+func (JobPaused) Version() atc.EventVersion { return "1.0" }
+
+type JobUnpaused struct {
+	PipelineName string `json:"pipeline_name"`
+	JobName      string `json:"job_name"`
+}
+
+func (JobUnpaused) EventType() atc.EventType  { return EventTypeJobUnpaused }
+func (JobUnpaused) Version() atc.EventVersion { return "1.0" }
+
+type ResourceChecked struct {
+	PipelineName string `json:"pipeline_name"`
+	ResourceName string `json:"resource_name"`
+	CheckError   string `json:"check_error,omitempty"`
+}
+
+func (ResourceChecked) EventType() atc.EventType  { return EventTypeResourceChecked }
+func (ResourceChecked) Version() atc.EventVersion { return "1.0" }
+
+type PipelineConfigChanged struct {
+	PipelineName  string `json:"pipeline_name"`
+	ConfigVersion int    `json:"config_version"`
+}
+
+func (PipelineConfigChanged) EventType() atc.EventType  { return EventTypePipelineConfigChanged }
+func (PipelineConfigChanged) Version() atc.EventVersion { return "1.0" }
@@ -29,4 +29,21 @@ const (
 
 	// error occurred
 	EventTypeError atc.EventType = "error"
+
+	// waiting before retrying a step
+	EventTypeWaitRetry atc.EventType = "wait-retry"
+
+	// a team's build started or finished
+	EventTypeBuildStarted  atc.EventType = "build-started"
+	EventTypeBuildFinished atc.EventType = "build-finished"
+
+	// a team's job paused or unpaused
+	EventTypeJobPaused   atc.EventType = "job-paused"
+	EventTypeJobUnpaused atc.EventType = "job-unpaused"
+
+	// a team's resource checked, successfully or not
+	EventTypeResourceChecked atc.EventType = "resource-checked"
+
+	// a team's pipeline config saved
+	EventTypePipelineConfigChanged atc.EventType = "pipeline-config-changed"
 )
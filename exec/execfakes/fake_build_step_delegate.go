@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
 )
@@ -22,6 +23,17 @@ type FakeBuildStepDelegate struct {
 	imageVersionDeterminedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	VariablesStub        func(creds.Variables) creds.Variables
+	variablesMutex       sync.RWMutex
+	variablesArgsForCall []struct {
+		arg1 creds.Variables
+	}
+	variablesReturns struct {
+		result1 creds.Variables
+	}
+	variablesReturnsOnCall map[int]struct {
+		result1 creds.Variables
+	}
 	StdoutStub        func() io.Writer
 	stdoutMutex       sync.RWMutex
 	stdoutArgsForCall []struct{}
@@ -46,6 +58,11 @@ type FakeBuildStepDelegate struct {
 		arg1 lager.Logger
 		arg2 string
 	}
+	FlushStub        func(lager.Logger)
+	flushMutex       sync.RWMutex
+	flushArgsForCall []struct {
+		arg1 lager.Logger
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -98,6 +115,54 @@ func (fake *FakeBuildStepDelegate) ImageVersionDeterminedReturnsOnCall(i int, re
 	}{result1}
 }
 
+func (fake *FakeBuildStepDelegate) Variables(arg1 creds.Variables) creds.Variables {
+	fake.variablesMutex.Lock()
+	ret, specificReturn := fake.variablesReturnsOnCall[len(fake.variablesArgsForCall)]
+	fake.variablesArgsForCall = append(fake.variablesArgsForCall, struct {
+		arg1 creds.Variables
+	}{arg1})
+	fake.recordInvocation("Variables", []interface{}{arg1})
+	fake.variablesMutex.Unlock()
+	if fake.VariablesStub != nil {
+		return fake.VariablesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.variablesReturns.result1
+}
+
+func (fake *FakeBuildStepDelegate) VariablesCallCount() int {
+	fake.variablesMutex.RLock()
+	defer fake.variablesMutex.RUnlock()
+	return len(fake.variablesArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) VariablesArgsForCall(i int) creds.Variables {
+	fake.variablesMutex.RLock()
+	defer fake.variablesMutex.RUnlock()
+	return fake.variablesArgsForCall[i].arg1
+}
+
+func (fake *FakeBuildStepDelegate) VariablesReturns(result1 creds.Variables) {
+	fake.VariablesStub = nil
+	fake.variablesReturns = struct {
+		result1 creds.Variables
+	}{result1}
+}
+
+func (fake *FakeBuildStepDelegate) VariablesReturnsOnCall(i int, result1 creds.Variables) {
+	fake.VariablesStub = nil
+	if fake.variablesReturnsOnCall == nil {
+		fake.variablesReturnsOnCall = make(map[int]struct {
+			result1 creds.Variables
+		})
+	}
+	fake.variablesReturnsOnCall[i] = struct {
+		result1 creds.Variables
+	}{result1}
+}
+
 func (fake *FakeBuildStepDelegate) Stdout() io.Writer {
 	fake.stdoutMutex.Lock()
 	ret, specificReturn := fake.stdoutReturnsOnCall[len(fake.stdoutArgsForCall)]
@@ -203,17 +268,45 @@ func (fake *FakeBuildStepDelegate) ErroredArgsForCall(i int) (lager.Logger, stri
 	return fake.erroredArgsForCall[i].arg1, fake.erroredArgsForCall[i].arg2
 }
 
+func (fake *FakeBuildStepDelegate) Flush(arg1 lager.Logger) {
+	fake.flushMutex.Lock()
+	fake.flushArgsForCall = append(fake.flushArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("Flush", []interface{}{arg1})
+	fake.flushMutex.Unlock()
+	if fake.FlushStub != nil {
+		fake.FlushStub(arg1)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) FlushCallCount() int {
+	fake.flushMutex.RLock()
+	defer fake.flushMutex.RUnlock()
+	return len(fake.flushArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) FlushArgsForCall(i int) lager.Logger {
+	fake.flushMutex.RLock()
+	defer fake.flushMutex.RUnlock()
+	return fake.flushArgsForCall[i].arg1
+}
+
 func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
+	fake.variablesMutex.RLock()
+	defer fake.variablesMutex.RUnlock()
 	fake.stdoutMutex.RLock()
 	defer fake.stdoutMutex.RUnlock()
 	fake.stderrMutex.RLock()
 	defer fake.stderrMutex.RUnlock()
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
+	fake.flushMutex.RLock()
+	defer fake.flushMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
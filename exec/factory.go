@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 )
 
@@ -40,6 +41,14 @@ type Factory interface {
 		db.ContainerMetadata,
 		TaskDelegate,
 	) Step
+
+	// SetPipeline constructs a SetPipeline step.
+	SetPipeline(
+		lager.Logger,
+		atc.Plan,
+		db.Build,
+		BuildStepDelegate,
+	) Step
 }
 
 // StepMetadata is used to inject metadata to make available to the step when
@@ -53,9 +62,19 @@ type StepMetadata interface {
 type BuildStepDelegate interface {
 	ImageVersionDetermined(*db.UsedResourceCache) error
 
+	// Variables wraps the variables used to interpolate the step's config so
+	// that any credentials they resolve can be redacted from the build's
+	// output.
+	Variables(creds.Variables) creds.Variables
+
 	Stdout() io.Writer
 	Stderr() io.Writer
 
+	// Flush saves any output held back while waiting to see whether it was
+	// the start of a credential. It is called once the step has finished,
+	// whether it succeeded, failed or was aborted.
+	Flush(lager.Logger)
+
 	Errored(lager.Logger, string)
 }
 
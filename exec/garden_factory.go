@@ -11,6 +11,7 @@ import (
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
+	"github.com/concourse/atc/taskcache"
 	"github.com/concourse/atc/worker"
 )
 
@@ -19,7 +20,10 @@ type gardenFactory struct {
 	resourceFetcher        resource.Fetcher
 	resourceFactory        resource.ResourceFactory
 	dbResourceCacheFactory db.ResourceCacheFactory
+	teamFactory            db.TeamFactory
 	variablesFactory       creds.VariablesFactory
+	defaultLimits          atc.ContainerLimits
+	taskCacheStore         taskcache.Store
 }
 
 func NewGardenFactory(
@@ -27,14 +31,20 @@ func NewGardenFactory(
 	resourceFetcher resource.Fetcher,
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory db.ResourceCacheFactory,
+	teamFactory db.TeamFactory,
 	variablesFactory creds.VariablesFactory,
+	defaultLimits atc.ContainerLimits,
+	taskCacheStore taskcache.Store,
 ) Factory {
 	return &gardenFactory{
 		workerClient:           workerClient,
 		resourceFetcher:        resourceFetcher,
 		resourceFactory:        resourceFactory,
 		dbResourceCacheFactory: dbResourceCacheFactory,
+		teamFactory:            teamFactory,
 		variablesFactory:       variablesFactory,
+		defaultLimits:          defaultLimits,
+		taskCacheStore:         taskCacheStore,
 	}
 }
 
@@ -48,7 +58,7 @@ func (factory *gardenFactory) Get(
 ) Step {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("get")
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := delegate.Variables(factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName()))
 
 	getStep := NewGetStep(
 		build,
@@ -86,7 +96,7 @@ func (factory *gardenFactory) Put(
 ) Step {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("put")
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := delegate.Variables(factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName()))
 
 	putStep := NewPutStep(
 		build,
@@ -139,7 +149,7 @@ func (factory *gardenFactory) Task(
 		Stderr:   delegate.Stderr(),
 	}
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := delegate.Variables(factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName()))
 
 	taskStep := NewTaskStep(
 		Privileged(plan.Task.Privileged),
@@ -163,11 +173,30 @@ func (factory *gardenFactory) Task(
 
 		creds.NewVersionedResourceTypes(variables, plan.Task.VersionedResourceTypes),
 		variables,
+		factory.defaultLimits,
+		factory.taskCacheStore,
 	)
 
 	return LogError(taskStep, delegate)
 }
 
+func (factory *gardenFactory) SetPipeline(
+	logger lager.Logger,
+	plan atc.Plan,
+	build db.Build,
+	delegate BuildStepDelegate,
+) Step {
+	setPipelineStep := NewSetPipelineStep(
+		plan.ID,
+		*plan.SetPipeline,
+		build,
+		factory.teamFactory,
+		delegate,
+	)
+
+	return LogError(setPipelineStep, delegate)
+}
+
 func (factory *gardenFactory) taskWorkingDirectory(sourceName worker.ArtifactName) string {
 	sum := sha1.Sum([]byte(sourceName))
 	return filepath.Join("/tmp", "build", fmt.Sprintf("%x", sum[:4]))
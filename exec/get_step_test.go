@@ -112,7 +112,7 @@ var _ = Describe("GetStep", func() {
 			VersionedResourceTypes: resourceTypes,
 		}
 
-		factory = exec.NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, fakeVariablesFactory)
+		factory = exec.NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, new(dbfakes.FakeTeamFactory), fakeVariablesFactory, atc.ContainerLimits{}, nil)
 
 		fakeDelegate = new(execfakes.FakeGetDelegate)
 	})
@@ -32,6 +32,7 @@ func (step LogErrorStep) Run(ctx context.Context, state RunState) error {
 	var message string
 	switch runErr {
 	case nil:
+		step.delegate.Flush(logger)
 		return nil
 	case context.Canceled:
 		message = AbortedLogMessage
@@ -58,6 +58,10 @@ var _ = Describe("LogErrorStep", func() {
 			It("does not log", func() {
 				Expect(fakeDelegate.ErroredCallCount()).To(Equal(0))
 			})
+
+			It("flushes the step's output", func() {
+				Expect(fakeDelegate.FlushCallCount()).To(Equal(1))
+			})
 		})
 
 		Context("when aborted", func() {
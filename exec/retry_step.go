@@ -2,13 +2,30 @@ package exec
 
 import (
 	"context"
+	"math"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc"
 )
 
+//go:generate counterfeiter . RetryDelegate
+
+type RetryDelegate interface {
+	Waiting(lager.Logger, int, time.Duration)
+}
+
 // RetryStep is a step that will run the steps in order until one of them
 // succeeds.
 type RetryStep struct {
 	Attempts    []Step
 	LastAttempt Step
+
+	policy   atc.RetryPolicy
+	delegate RetryDelegate
+	clock    clock.Clock
 }
 
 func Retry(attempts ...Step) Step {
@@ -17,12 +34,46 @@ func Retry(attempts ...Step) Step {
 	}
 }
 
+// RetryWithPolicy constructs a RetryStep which waits between attempts
+// according to the given policy, notifying the delegate before each wait.
+func RetryWithPolicy(policy atc.RetryPolicy, delegate RetryDelegate, clock clock.Clock, attempts ...Step) Step {
+	return &RetryStep{
+		Attempts: attempts,
+
+		policy:   policy,
+		delegate: delegate,
+		clock:    clock,
+	}
+}
+
 // Run iterates through each step, stopping once a step succeeds. If all steps
 // fail, the RetryStep will fail.
+//
+// If the policy only retries errored attempts, a failed attempt stops the
+// iteration as well.
 func (step *RetryStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+
 	var attemptErr error
 
-	for _, attempt := range step.Attempts {
+	for i, attempt := range step.Attempts {
+		if i > 0 {
+			delay, err := step.delayBefore(i)
+			if err != nil {
+				return err
+			}
+
+			if delay > 0 {
+				step.delegate.Waiting(logger, i+1, delay)
+
+				select {
+				case <-step.clock.After(delay):
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+
 		step.LastAttempt = attempt
 
 		attemptErr = attempt.Run(ctx, state)
@@ -37,6 +88,10 @@ func (step *RetryStep) Run(ctx context.Context, state RunState) error {
 		if attempt.Succeeded() {
 			break
 		}
+
+		if step.policy.ErroredOnly {
+			break
+		}
 	}
 
 	return attemptErr
@@ -46,3 +101,48 @@ func (step *RetryStep) Run(ctx context.Context, state RunState) error {
 func (step *RetryStep) Succeeded() bool {
 	return step.LastAttempt.Succeeded()
 }
+
+// delayBefore determines how long to wait before the given retry, where 1 is
+// the first retry (i.e. the second attempt).
+func (step *RetryStep) delayBefore(retry int) (time.Duration, error) {
+	if step.policy.Delay == "" {
+		return 0, nil
+	}
+
+	delay, err := time.ParseDuration(step.policy.Delay)
+	if err != nil {
+		return 0, err
+	}
+
+	if step.policy.Backoff != atc.BackoffExponential {
+		return delay, nil
+	}
+
+	var maxDelay time.Duration
+	if step.policy.MaxDelay != "" {
+		maxDelay, err = time.ParseDuration(step.policy.MaxDelay)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	for i := 1; i < retry; i++ {
+		// without a max delay, stop doubling before the delay overflows
+		if delay > math.MaxInt64/2 {
+			delay = math.MaxInt64
+			break
+		}
+
+		delay *= 2
+
+		if maxDelay > 0 && delay >= maxDelay {
+			return maxDelay, nil
+		}
+	}
+
+	if maxDelay > 0 && delay > maxDelay {
+		return maxDelay, nil
+	}
+
+	return delay, nil
+}
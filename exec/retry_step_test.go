@@ -3,6 +3,11 @@ package exec_test
 import (
 	"context"
 	"errors"
+	"math"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/atc"
 
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/worker"
@@ -340,4 +345,156 @@ var _ = Describe("Retry Step", func() {
 			})
 		})
 	})
+
+	Context("with a retry policy", func() {
+		var (
+			policy       atc.RetryPolicy
+			fakeClock    *fakeclock.FakeClock
+			fakeDelegate *execfakes.FakeRetryDelegate
+
+			stepErr chan error
+		)
+
+		BeforeEach(func() {
+			policy = atc.RetryPolicy{}
+			fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+			fakeDelegate = new(execfakes.FakeRetryDelegate)
+		})
+
+		JustBeforeEach(func() {
+			step = RetryWithPolicy(policy, fakeDelegate, fakeClock, attempt1, attempt2, attempt3)
+
+			stepErr = make(chan error, 1)
+			go func() {
+				stepErr <- step.Run(ctx, state)
+			}()
+		})
+
+		Context("with a fixed delay", func() {
+			BeforeEach(func() {
+				policy.Delay = "10s"
+
+				attempt1.SucceededReturns(false)
+				attempt2.SucceededReturns(true)
+			})
+
+			It("waits between attempts", func() {
+				Eventually(fakeDelegate.WaitingCallCount).Should(Equal(1))
+				_, attempt, delay := fakeDelegate.WaitingArgsForCall(0)
+				Expect(attempt).To(Equal(2))
+				Expect(delay).To(Equal(10 * time.Second))
+
+				fakeClock.WaitForWatcherAndIncrement(9 * time.Second)
+				Consistently(attempt2.RunCallCount).Should(BeZero())
+
+				fakeClock.Increment(time.Second)
+				Eventually(stepErr).Should(Receive(BeNil()))
+
+				Expect(attempt2.RunCallCount()).To(Equal(1))
+				Expect(attempt3.RunCallCount()).To(BeZero())
+				Expect(step.Succeeded()).To(BeTrue())
+			})
+
+			Context("when aborted while waiting", func() {
+				It("returns the context's error without running the next attempt", func() {
+					Eventually(fakeDelegate.WaitingCallCount).Should(Equal(1))
+
+					cancel()
+
+					Eventually(stepErr).Should(Receive(Equal(context.Canceled)))
+					Expect(attempt2.RunCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("with an exponential backoff", func() {
+			BeforeEach(func() {
+				policy.Backoff = atc.BackoffExponential
+				policy.Delay = "10s"
+				policy.MaxDelay = "15s"
+
+				attempt1.SucceededReturns(false)
+				attempt2.SucceededReturns(false)
+				attempt3.SucceededReturns(false)
+			})
+
+			It("doubles the delay up to the max delay", func() {
+				fakeClock.WaitForWatcherAndIncrement(10 * time.Second)
+				Eventually(fakeDelegate.WaitingCallCount).Should(Equal(2))
+
+				_, attempt, delay := fakeDelegate.WaitingArgsForCall(0)
+				Expect(attempt).To(Equal(2))
+				Expect(delay).To(Equal(10 * time.Second))
+
+				_, attempt, delay = fakeDelegate.WaitingArgsForCall(1)
+				Expect(attempt).To(Equal(3))
+				Expect(delay).To(Equal(15 * time.Second))
+
+				fakeClock.WaitForWatcherAndIncrement(15 * time.Second)
+				Eventually(stepErr).Should(Receive(BeNil()))
+
+				Expect(attempt3.RunCallCount()).To(Equal(1))
+				Expect(step.Succeeded()).To(BeFalse())
+			})
+		})
+
+		Context("with an exponential backoff and no max delay", func() {
+			BeforeEach(func() {
+				policy.Backoff = atc.BackoffExponential
+				policy.Delay = "2000000h"
+
+				attempt1.SucceededReturns(false)
+				attempt2.SucceededReturns(false)
+				attempt3.SucceededReturns(false)
+			})
+
+			It("stops doubling the delay before it overflows", func() {
+				Eventually(fakeDelegate.WaitingCallCount).Should(Equal(1))
+				_, _, delay := fakeDelegate.WaitingArgsForCall(0)
+				Expect(delay).To(Equal(2000000 * time.Hour))
+
+				fakeClock.WaitForWatcherAndIncrement(delay)
+				Eventually(fakeDelegate.WaitingCallCount).Should(Equal(2))
+
+				_, _, delay = fakeDelegate.WaitingArgsForCall(1)
+				Expect(delay).To(Equal(time.Duration(math.MaxInt64)))
+
+				cancel()
+				Eventually(stepErr).Should(Receive(Equal(context.Canceled)))
+			})
+		})
+
+		Context("when only retrying errored attempts", func() {
+			BeforeEach(func() {
+				policy.ErroredOnly = true
+			})
+
+			Context("when attempt 1 fails", func() {
+				BeforeEach(func() {
+					attempt1.SucceededReturns(false)
+				})
+
+				It("does not retry", func() {
+					Eventually(stepErr).Should(Receive(BeNil()))
+
+					Expect(attempt2.RunCallCount()).To(BeZero())
+					Expect(step.Succeeded()).To(BeFalse())
+				})
+			})
+
+			Context("when attempt 1 errors, and attempt 2 succeeds", func() {
+				BeforeEach(func() {
+					attempt1.RunReturns(errors.New("nope"))
+					attempt2.SucceededReturns(true)
+				})
+
+				It("retries", func() {
+					Eventually(stepErr).Should(Receive(BeNil()))
+
+					Expect(attempt2.RunCallCount()).To(Equal(1))
+					Expect(step.Succeeded()).To(BeTrue())
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,125 @@
+package exec
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds"
+)
+
+const redactedSecret = "((redacted))"
+
+// minimumSecretLength is the length below which credentials are not redacted.
+// Shorter values, e.g. "true" or "1", appear so often in output that masking
+// them would obscure it without hiding anything of worth.
+const minimumSecretLength = 5
+
+// SecretRedactor remembers every credential interpolated for a build so that
+// they can be masked in the build's output.
+type SecretRedactor struct {
+	lock    sync.RWMutex
+	secrets []string
+}
+
+func NewSecretRedactor() *SecretRedactor {
+	return &SecretRedactor{}
+}
+
+// Track returns variables which remember the value of every credential they
+// resolve.
+func (redactor *SecretRedactor) Track(variables creds.Variables) creds.Variables {
+	return trackedVariables{
+		Variables: variables,
+		redactor:  redactor,
+	}
+}
+
+// Redact masks every remembered credential in the text. Any trailing text
+// which could be the start of a credential is returned separately as
+// dangling, so that it can be redacted along with whatever is written next.
+func (redactor *SecretRedactor) Redact(text string) (string, string) {
+	redactor.lock.RLock()
+	defer redactor.lock.RUnlock()
+
+	for _, secret := range redactor.secrets {
+		text = strings.Replace(text, secret, redactedSecret, -1)
+	}
+
+	dangling := 0
+	for _, secret := range redactor.secrets {
+		for i := len(secret) - 1; i > dangling; i-- {
+			if strings.HasSuffix(text, secret[:i]) {
+				dangling = i
+				break
+			}
+		}
+	}
+
+	return text[:len(text)-dangling], text[len(text)-dangling:]
+}
+
+func (redactor *SecretRedactor) remember(value interface{}) {
+	switch v := value.(type) {
+	case string:
+		redactor.add(v)
+
+		// e.g. a key read from a file with a trailing newline
+		redactor.add(strings.TrimSpace(v))
+	case map[interface{}]interface{}:
+		for _, val := range v {
+			redactor.remember(val)
+		}
+	case map[string]interface{}:
+		for _, val := range v {
+			redactor.remember(val)
+		}
+	case []interface{}:
+		for _, val := range v {
+			redactor.remember(val)
+		}
+	}
+}
+
+func (redactor *SecretRedactor) add(secret string) {
+	if len(secret) < minimumSecretLength {
+		return
+	}
+
+	redactor.lock.Lock()
+	defer redactor.lock.Unlock()
+
+	for _, existing := range redactor.secrets {
+		if existing == secret {
+			return
+		}
+	}
+
+	redactor.secrets = append(redactor.secrets, secret)
+
+	// mask longer credentials first so that one containing another is masked
+	// entirely
+	sort.Sort(byLength(redactor.secrets))
+}
+
+type byLength []string
+
+func (secrets byLength) Len() int           { return len(secrets) }
+func (secrets byLength) Swap(i, j int)      { secrets[i], secrets[j] = secrets[j], secrets[i] }
+func (secrets byLength) Less(i, j int) bool { return len(secrets[i]) > len(secrets[j]) }
+
+type trackedVariables struct {
+	creds.Variables
+
+	redactor *SecretRedactor
+}
+
+func (variables trackedVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	value, found, err := variables.Variables.Get(varDef)
+	if err == nil && found {
+		variables.redactor.remember(value)
+	}
+
+	return value, found, err
+}
@@ -0,0 +1,93 @@
+package exec_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc/creds/credsfakes"
+	"github.com/concourse/atc/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretRedactor", func() {
+	var (
+		redactor  *exec.SecretRedactor
+		variables *credsfakes.FakeVariables
+	)
+
+	BeforeEach(func() {
+		redactor = exec.NewSecretRedactor()
+		variables = new(credsfakes.FakeVariables)
+	})
+
+	resolve := func(value interface{}) {
+		variables.GetReturns(value, true, nil)
+
+		_, _, err := redactor.Track(variables).Get(template.VariableDefinition{Name: "some-var"})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("masks credentials resolved through tracked variables", func() {
+		resolve("super-secret")
+
+		redacted, dangling := redactor.Redact("echo super-secret; echo super-secret\n")
+		Expect(redacted).To(Equal("echo ((redacted)); echo ((redacted))\n"))
+		Expect(dangling).To(BeEmpty())
+	})
+
+	It("masks every value of a credential with several fields", func() {
+		resolve(map[interface{}]interface{}{
+			"username": "some-user",
+			"password": "some-password",
+		})
+
+		redacted, _ := redactor.Redact("some-user:some-password\n")
+		Expect(redacted).To(Equal("((redacted)):((redacted))\n"))
+	})
+
+	It("masks credentials with surrounding whitespace trimmed", func() {
+		resolve("some-key\n")
+
+		redacted, _ := redactor.Redact("key: some-key.\n")
+		Expect(redacted).To(Equal("key: ((redacted)).\n"))
+	})
+
+	It("masks a credential containing another entirely", func() {
+		resolve("secret")
+		resolve("secret-with-suffix")
+
+		redacted, _ := redactor.Redact("secret-with-suffix\n")
+		Expect(redacted).To(Equal("((redacted))\n"))
+	})
+
+	It("returns trailing text which may be the start of a credential separately", func() {
+		resolve("super-secret")
+
+		redacted, dangling := redactor.Redact("the secret is super-se")
+		Expect(redacted).To(Equal("the secret is "))
+		Expect(dangling).To(Equal("super-se"))
+	})
+
+	It("does not mask credentials too short to be worth masking", func() {
+		resolve("true")
+		resolve(map[interface{}]interface{}{"port": "1"})
+
+		redacted, dangling := redactor.Redact("enabled: true, port: 1")
+		Expect(redacted).To(Equal("enabled: true, port: 1"))
+		Expect(dangling).To(BeEmpty())
+	})
+
+	It("does not mask anything which was not resolved", func() {
+		variables.GetReturns(nil, false, nil)
+		redactor.Track(variables).Get(template.VariableDefinition{Name: "some-var"})
+
+		variables.GetReturns("ignored", true, errors.New("nope"))
+		redactor.Track(variables).Get(template.VariableDefinition{Name: "some-var"})
+
+		redacted, dangling := redactor.Redact("nothing ignored here\n")
+		Expect(redacted).To(Equal("nothing ignored here\n"))
+		Expect(dangling).To(BeEmpty())
+	})
+})
@@ -0,0 +1,208 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/baggageclaim"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// SetPipelineStep reads a pipeline config file out of the
+// worker.ArtifactRepository and saves it as a pipeline in the build's team.
+type SetPipelineStep struct {
+	planID      atc.PlanID
+	plan        atc.SetPipelinePlan
+	build       db.Build
+	teamFactory db.TeamFactory
+	delegate    BuildStepDelegate
+
+	succeeded bool
+}
+
+func NewSetPipelineStep(
+	planID atc.PlanID,
+	plan atc.SetPipelinePlan,
+	build db.Build,
+	teamFactory db.TeamFactory,
+	delegate BuildStepDelegate,
+) Step {
+	return &SetPipelineStep{
+		planID:      planID,
+		plan:        plan,
+		build:       build,
+		teamFactory: teamFactory,
+		delegate:    delegate,
+	}
+}
+
+// Run reads the pipeline config and any var files from their artifacts,
+// interpolates the vars, and saves the resulting config to the build's team.
+//
+// Vars given in the plan take precedence over those loaded from var files,
+// and later var files take precedence over earlier ones. Any vars which are
+// not provided are left in place to be resolved by the credential manager
+// when the pipeline runs.
+//
+// If the config is invalid, the errors are printed to stderr and the step
+// fails without saving.
+func (step *SetPipelineStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx).WithData(lager.Data{
+		"plan-id":  step.planID,
+		"pipeline": step.plan.Name,
+		"build-id": step.build.ID(),
+	})
+
+	configBytes, err := readArtifactFile(state.Artifacts(), step.plan.File)
+	if err != nil {
+		return err
+	}
+
+	vars := template.StaticVariables{}
+
+	for _, path := range step.plan.VarFiles {
+		varsBytes, err := readArtifactFile(state.Artifacts(), path)
+		if err != nil {
+			return err
+		}
+
+		var fileVars template.StaticVariables
+		err = yaml.Unmarshal(varsBytes, &fileVars)
+		if err != nil {
+			return fmt.Errorf("failed to load vars from %s: %s", path, err)
+		}
+
+		for name, val := range fileVars {
+			vars[name] = val
+		}
+	}
+
+	for name, val := range step.plan.Vars {
+		vars[name] = val
+	}
+
+	evaluated, err := template.NewTemplate(configBytes).Evaluate(vars, nil, template.EvaluateOpts{})
+	if err != nil {
+		return fmt.Errorf("failed to interpolate %s: %s", step.plan.File, err)
+	}
+
+	var configStructure interface{}
+	err = yaml.Unmarshal(evaluated, &configStructure)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %s", step.plan.File, err)
+	}
+
+	config, extraKeys, err := atc.DecodeConfig(configStructure)
+	if err != nil {
+		return fmt.Errorf("failed to decode %s: %s", step.plan.File, err)
+	}
+
+	warnings, errorMessages := config.Validate()
+	for _, key := range extraKeys {
+		errorMessages = append(errorMessages, fmt.Sprintf("unknown/extra key: %s", key))
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(step.delegate.Stderr(), "WARNING: %s\n", warning.Message)
+	}
+
+	if len(errorMessages) > 0 {
+		logger.Info("invalid-config")
+
+		fmt.Fprintf(step.delegate.Stderr(), "invalid pipeline config:\n")
+		for _, message := range errorMessages {
+			fmt.Fprintf(step.delegate.Stderr(), "%s\n", message)
+		}
+
+		step.succeeded = false
+		return nil
+	}
+
+	team := step.teamFactory.GetByID(step.build.TeamID())
+
+	var fromVersion db.ConfigVersion
+	pipeline, found, err := team.Pipeline(step.plan.Name)
+	if err != nil {
+		logger.Error("failed-to-find-pipeline", err)
+		return err
+	}
+
+	if found {
+		fromVersion = pipeline.ConfigVersion()
+	}
+
+	fmt.Fprintf(step.delegate.Stdout(), "setting pipeline: %s\n", step.plan.Name)
+
+	_, created, err := team.SavePipelineWithAuthor(step.author(), step.plan.Name, config, fromVersion, db.PipelineNoChange)
+	if err != nil {
+		logger.Error("failed-to-save-pipeline", err)
+		return err
+	}
+
+	logger.Info("saved", lager.Data{"created": created})
+
+	if created {
+		fmt.Fprintf(step.delegate.Stdout(), "pipeline created by build %d\n", step.build.ID())
+	} else {
+		fmt.Fprintf(step.delegate.Stdout(), "pipeline updated by build %d\n", step.build.ID())
+	}
+
+	step.succeeded = true
+
+	return nil
+}
+
+// author identifies the build in the pipeline's config history, e.g.
+// `some-team/some-pipeline/some-job/42`.
+func (step *SetPipelineStep) author() string {
+	if step.build.JobName() == "" {
+		return fmt.Sprintf("%s/build/%d", step.build.TeamName(), step.build.ID())
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/%s/%s",
+		step.build.TeamName(),
+		step.build.PipelineName(),
+		step.build.JobName(),
+		step.build.Name(),
+	)
+}
+
+// Succeeded returns true if the pipeline config was valid and saved.
+func (step *SetPipelineStep) Succeeded() bool {
+	return step.succeeded
+}
+
+func readArtifactFile(repo *worker.ArtifactRepository, path string) ([]byte, error) {
+	segs := strings.SplitN(path, "/", 2)
+	if len(segs) != 2 {
+		return nil, UnspecifiedArtifactSourceError{path}
+	}
+
+	sourceName := worker.ArtifactName(segs[0])
+	filePath := segs[1]
+
+	source, found := repo.SourceFor(sourceName)
+	if !found {
+		return nil, UnknownArtifactSourceError{sourceName}
+	}
+
+	stream, err := source.StreamFile(filePath)
+	if err != nil {
+		if err == baggageclaim.ErrFileNotFound {
+			return nil, fmt.Errorf("file '%s/%s' not found", sourceName, filePath)
+		}
+		return nil, err
+	}
+
+	defer stream.Close()
+
+	return ioutil.ReadAll(stream)
+}
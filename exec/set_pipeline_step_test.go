@@ -0,0 +1,338 @@
+package exec_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/worker/workerfakes"
+	"github.com/concourse/baggageclaim"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("SetPipelineStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeBuild       *dbfakes.FakeBuild
+		fakeTeamFactory *dbfakes.FakeTeamFactory
+		fakeTeam        *dbfakes.FakeTeam
+		fakeSource      *workerfakes.FakeArtifactSource
+
+		files map[string]string
+
+		plan     atc.SetPipelinePlan
+		state    exec.RunState
+		delegate *execfakes.FakeBuildStepDelegate
+		stdout   *gbytes.Buffer
+		stderr   *gbytes.Buffer
+
+		step    exec.Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.TeamIDReturns(123)
+		fakeBuild.NameReturns("7")
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.JobNameReturns("some-job")
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.GetByIDReturns(fakeTeam)
+
+		files = map[string]string{
+			"pipeline.yml": `
+resources:
+- name: some-resource
+  type: git
+  source:
+    uri: ((uri))
+    private_key: ((private_key))
+
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`,
+			"vars.yml": `
+uri: some-file-uri
+private_key: some-file-key
+`,
+		}
+
+		fakeSource = new(workerfakes.FakeArtifactSource)
+		fakeSource.StreamFileStub = func(path string) (io.ReadCloser, error) {
+			content, found := files[path]
+			if !found {
+				return nil, baggageclaim.ErrFileNotFound
+			}
+
+			return ioutil.NopCloser(bytes.NewBufferString(content)), nil
+		}
+
+		state = exec.NewRunState()
+		state.Artifacts().RegisterSource("some-artifact", fakeSource)
+
+		stdout = gbytes.NewBuffer()
+		stderr = gbytes.NewBuffer()
+
+		delegate = new(execfakes.FakeBuildStepDelegate)
+		delegate.StdoutReturns(stdout)
+		delegate.StderrReturns(stderr)
+
+		plan = atc.SetPipelinePlan{
+			Name: "some-pipeline",
+			File: "some-artifact/pipeline.yml",
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = exec.NewSetPipelineStep(
+			"some-plan-id",
+			plan,
+			fakeBuild,
+			fakeTeamFactory,
+			delegate,
+		)
+
+		stepErr = step.Run(ctx, state)
+	})
+
+	Context("when the pipeline does not exist yet", func() {
+		BeforeEach(func() {
+			fakeTeam.SavePipelineWithAuthorReturns(new(dbfakes.FakePipeline), true, nil)
+		})
+
+		It("saves the pipeline to the build's team", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(fakeTeamFactory.GetByIDArgsForCall(0)).To(Equal(123))
+			Expect(fakeTeam.SavePipelineWithAuthorCallCount()).To(Equal(1))
+
+			author, name, config, from, pausedState := fakeTeam.SavePipelineWithAuthorArgsForCall(0)
+			Expect(author).To(Equal("some-team/some-pipeline/some-job/7"))
+			Expect(name).To(Equal("some-pipeline"))
+			Expect(config.Jobs).To(HaveLen(1))
+			Expect(config.Jobs[0].Name).To(Equal("some-job"))
+			Expect(from).To(Equal(db.ConfigVersion(0)))
+			Expect(pausedState).To(Equal(db.PipelineNoChange))
+		})
+
+		It("leaves vars which are not provided to be resolved later", func() {
+			_, _, config, _, _ := fakeTeam.SavePipelineWithAuthorArgsForCall(0)
+			Expect(config.Resources[0].Source).To(Equal(atc.Source{
+				"uri":         "((uri))",
+				"private_key": "((private_key))",
+			}))
+		})
+
+		It("says the build created it", func() {
+			Expect(stdout).To(gbytes.Say("pipeline created by build 42"))
+		})
+
+		It("succeeds", func() {
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+	})
+
+	Context("when the pipeline already exists", func() {
+		BeforeEach(func() {
+			fakePipeline := new(dbfakes.FakePipeline)
+			fakePipeline.ConfigVersionReturns(db.ConfigVersion(7))
+
+			fakeTeam.PipelineReturns(fakePipeline, true, nil)
+			fakeTeam.SavePipelineWithAuthorReturns(fakePipeline, false, nil)
+		})
+
+		It("saves the config over the current version", func() {
+			Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal("some-pipeline"))
+
+			_, _, _, from, _ := fakeTeam.SavePipelineWithAuthorArgsForCall(0)
+			Expect(from).To(Equal(db.ConfigVersion(7)))
+		})
+
+		It("says the build updated it", func() {
+			Expect(stdout).To(gbytes.Say("pipeline updated by build 42"))
+		})
+	})
+
+	Context("when vars and var files are given", func() {
+		BeforeEach(func() {
+			plan.VarFiles = []string{"some-artifact/vars.yml"}
+			plan.Vars = map[string]interface{}{
+				"uri": "some-plan-uri",
+			}
+		})
+
+		It("interpolates them, preferring vars from the plan", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+
+			_, _, config, _, _ := fakeTeam.SavePipelineWithAuthorArgsForCall(0)
+			Expect(config.Resources[0].Source).To(Equal(atc.Source{
+				"uri":         "some-plan-uri",
+				"private_key": "some-file-key",
+			}))
+		})
+	})
+
+	Context("when a resource has a nested source", func() {
+		BeforeEach(func() {
+			files["pipeline.yml"] = `
+resources:
+- name: some-resource
+  type: git
+  source:
+    uri: some-uri
+    credentials:
+      username: some-user
+      keys: [a, b]
+
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+    params:
+      nested: {depth: 1}
+`
+		})
+
+		It("saves it in a form which can be encoded as JSON", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+
+			_, _, config, _, _ := fakeTeam.SavePipelineWithAuthorArgsForCall(0)
+			Expect(config.Resources[0].Source).To(Equal(atc.Source{
+				"uri": "some-uri",
+				"credentials": map[string]interface{}{
+					"username": "some-user",
+					"keys":     []interface{}{"a", "b"},
+				},
+			}))
+
+			_, err := json.Marshal(config)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("when the config has unknown keys", func() {
+		BeforeEach(func() {
+			files["pipeline.yml"] = `
+resources:
+- name: some-resource
+  type: git
+  sorce: {uri: some-uri}
+
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`
+		})
+
+		It("prints them", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stderr).To(gbytes.Say("invalid pipeline config"))
+			Expect(stderr).To(gbytes.Say("unknown/extra key: resources\\[0\\].sorce"))
+		})
+
+		It("does not save anything", func() {
+			Expect(fakeTeam.SavePipelineWithAuthorCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the build is a one-off", func() {
+		BeforeEach(func() {
+			fakeBuild.PipelineNameReturns("")
+			fakeBuild.JobNameReturns("")
+		})
+
+		It("records the build's ID as the author", func() {
+			author, _, _, _, _ := fakeTeam.SavePipelineWithAuthorArgsForCall(0)
+			Expect(author).To(Equal("some-team/build/42"))
+		})
+	})
+
+	Context("when the config file does not exist", func() {
+		BeforeEach(func() {
+			plan.File = "some-artifact/bogus.yml"
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(MatchError("file 'some-artifact/bogus.yml' not found"))
+		})
+
+		It("does not save anything", func() {
+			Expect(fakeTeam.SavePipelineWithAuthorCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the config file's artifact is unknown", func() {
+		BeforeEach(func() {
+			plan.File = "bogus-artifact/pipeline.yml"
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(Equal(exec.UnknownArtifactSourceError{"bogus-artifact"}))
+		})
+	})
+
+	Context("when the config is invalid", func() {
+		BeforeEach(func() {
+			files["pipeline.yml"] = `
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`
+		})
+
+		It("prints the errors", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stderr).To(gbytes.Say("invalid pipeline config"))
+			Expect(stderr).To(gbytes.Say("some-resource"))
+		})
+
+		It("does not save anything", func() {
+			Expect(fakeTeam.SavePipelineWithAuthorCallCount()).To(BeZero())
+		})
+
+		It("fails", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Context("when saving the pipeline fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeTeam.SavePipelineWithAuthorReturns(nil, false, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+		})
+
+		It("fails", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+})
@@ -18,6 +18,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/taskcache"
 	"github.com/concourse/atc/worker"
 )
 
@@ -90,6 +91,10 @@ type TaskStep struct {
 
 	variables creds.Variables
 
+	defaultLimits atc.ContainerLimits
+
+	taskCacheStore taskcache.Store
+
 	succeeded bool
 }
 
@@ -111,6 +116,8 @@ func NewTaskStep(
 	containerMetadata db.ContainerMetadata,
 	resourceTypes creds.VersionedResourceTypes,
 	variables creds.Variables,
+	defaultLimits atc.ContainerLimits,
+	taskCacheStore taskcache.Store,
 ) Step {
 	return &TaskStep{
 		privileged:        privileged,
@@ -130,6 +137,8 @@ func NewTaskStep(
 		containerMetadata: containerMetadata,
 		resourceTypes:     resourceTypes,
 		variables:         variables,
+		defaultLimits:     defaultLimits,
+		taskCacheStore:    taskCacheStore,
 	}
 }
 
@@ -147,7 +156,8 @@ func NewTaskStep(
 // If the script exits successfully, the outputs specified in the TaskConfig
 // are registered with the worker.ArtifactRepository. If no outputs are specified, the
 // task's entire working directory is registered as an ArtifactSource under the
-// name of the task.
+// name of the task. If a task cache store is configured, the task's caches are
+// also snapshotted to it so that they can be restored on other workers.
 func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 	logger := lagerctx.FromContext(ctx)
 
@@ -158,6 +168,8 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
+	config.Limits = config.Limits.Merge(action.defaultLimits)
+
 	action.delegate.Initializing(logger, config)
 
 	containerSpec, err := action.containerSpec(logger, repository, config)
@@ -281,6 +293,10 @@ func (action *TaskStep) Run(ctx context.Context, state RunState) error {
 
 		action.succeeded = processStatus == 0
 
+		if action.succeeded {
+			go action.snapshotCaches(logger, config, container)
+		}
+
 		return nil
 	}
 }
@@ -327,6 +343,10 @@ func (action *TaskStep) containerSpec(logger lager.Logger, repository *worker.Ar
 		User:      config.Run.User,
 		Dir:       action.artifactsRoot,
 		Env:       action.envForParams(params),
+		Limits: worker.ContainerLimits{
+			CPU:    config.Limits.CPU,
+			Memory: config.Limits.Memory,
+		},
 
 		Inputs:  []worker.InputSource{},
 		Outputs: worker.OutputPaths{},
@@ -359,7 +379,7 @@ func (action *TaskStep) containerSpec(logger lager.Logger, repository *worker.Ar
 	}
 
 	for _, cacheConfig := range config.Caches {
-		source := newTaskCacheSource(logger, action.teamID, action.jobID, action.stepName, cacheConfig.Path)
+		source := newTaskCacheSource(logger, action.taskCacheStore, action.teamID, action.jobID, action.stepName, cacheConfig.Path)
 		containerSpec.Inputs = append(containerSpec.Inputs, &taskCacheInputSource{
 			source:        source,
 			artifactsRoot: action.artifactsRoot,
@@ -419,6 +439,49 @@ func (action *TaskStep) registerOutputs(logger lager.Logger, repository *worker.
 	return nil
 }
 
+// snapshotCaches stores the contents of the task's caches so that they can be
+// restored on workers which do not have them yet. Failing to do so only
+// means the cache starts cold elsewhere, so errors are logged rather than
+// failing the build.
+//
+// It runs in the background once the step has finished so that it doesn't
+// hold up the build, and so isn't bound to the build's context; the store
+// gives up after --task-cache-snapshot-timeout instead.
+func (action *TaskStep) snapshotCaches(logger lager.Logger, config atc.TaskConfig, container worker.Container) {
+	// Do not snapshot caches for one-off builds
+	if action.taskCacheStore == nil || action.jobID == 0 {
+		return
+	}
+
+	volumeMounts := container.VolumeMounts()
+
+	for _, cacheConfig := range config.Caches {
+		for _, volumeMount := range volumeMounts {
+			if volumeMount.MountPath == filepath.Join(action.artifactsRoot, cacheConfig.Path) {
+				logger.Debug("snapshotting-cache", lager.Data{"path": volumeMount.MountPath})
+
+				err := action.snapshotCache(context.Background(), volumeMount.Volume, cacheConfig.Path)
+				if err != nil {
+					logger.Error("failed-to-snapshot-cache", err, lager.Data{"path": volumeMount.MountPath})
+				}
+			}
+		}
+	}
+}
+
+func (action *TaskStep) snapshotCache(ctx context.Context, volume worker.Volume, cachePath string) error {
+	out, err := volume.StreamOut(".")
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	key := taskcache.Key(action.teamID, action.jobID, action.stepName, cachePath)
+
+	return action.taskCacheStore.Put(ctx, key, out)
+}
+
 func (TaskStep) envForParams(params map[string]string) []string {
 	env := make([]string, 0, len(params))
 
@@ -523,6 +586,7 @@ func (s *taskCacheInputSource) DestinationPath() string {
 
 type taskCacheSource struct {
 	logger   lager.Logger
+	store    taskcache.Store
 	teamID   int
 	jobID    int
 	stepName string
@@ -531,6 +595,7 @@ type taskCacheSource struct {
 
 func newTaskCacheSource(
 	logger lager.Logger,
+	store taskcache.Store,
 	teamID int,
 	jobID int,
 	stepName string,
@@ -538,6 +603,7 @@ func newTaskCacheSource(
 ) *taskCacheSource {
 	return &taskCacheSource{
 		logger:   logger,
+		store:    store,
 		teamID:   teamID,
 		jobID:    jobID,
 		stepName: stepName,
@@ -545,8 +611,35 @@ func newTaskCacheSource(
 	}
 }
 
+// StreamTo is only called when the worker does not have the cache yet. The
+// cache is restored from its latest snapshot if there is one, and is
+// otherwise left empty.
 func (src *taskCacheSource) StreamTo(destination worker.ArtifactDestination) error {
-	// cache will be initialized every time on a new worker
+	if src.store == nil || src.jobID == 0 {
+		return nil
+	}
+
+	logger := src.logger.Session("restore-cache", lager.Data{"path": src.path})
+
+	snapshot, found, err := src.store.Get(taskcache.Key(src.teamID, src.jobID, src.stepName, src.path))
+	if err != nil {
+		logger.Error("failed-to-get-snapshot", err)
+		return nil
+	}
+
+	if !found {
+		logger.Debug("no-snapshot")
+		return nil
+	}
+
+	defer snapshot.Close()
+
+	err = destination.StreamIn(".", snapshot)
+	if err != nil {
+		logger.Error("failed-to-stream-in-snapshot", err)
+		return nil
+	}
+
 	return nil
 }
 
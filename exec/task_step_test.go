@@ -19,6 +19,8 @@ import (
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/taskcache"
+	"github.com/concourse/atc/taskcache/taskcachefakes"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/workerfakes"
 	. "github.com/onsi/ginkgo"
@@ -53,6 +55,9 @@ var _ = Describe("TaskStep", func() {
 		inputMapping  map[string]string
 		outputMapping map[string]string
 		variables     creds.Variables
+		defaultLimits atc.ContainerLimits
+
+		taskCacheStore taskcache.Store
 
 		repo  *worker.ArtifactRepository
 		state *execfakes.FakeRunState
@@ -102,6 +107,8 @@ var _ = Describe("TaskStep", func() {
 		inputMapping = nil
 		outputMapping = nil
 		imageArtifactName = ""
+		defaultLimits = atc.ContainerLimits{}
+		taskCacheStore = nil
 
 		variables = template.StaticVariables{
 			"source-param": "super-secret-source",
@@ -135,6 +142,8 @@ var _ = Describe("TaskStep", func() {
 			containerMetadata,
 			resourceTypes,
 			variables,
+			defaultLimits,
+			taskCacheStore,
 		)
 
 		stepErr = taskStep.Run(ctx, state)
@@ -221,6 +230,55 @@ var _ = Describe("TaskStep", func() {
 				Expect(actualResourceTypes).To(Equal(resourceTypes))
 			})
 
+			Context("when container limits are configured", func() {
+				var cpu, memory, defaultCPU, defaultMemory uint64
+
+				BeforeEach(func() {
+					memory = 1024 * 1024 * 1024
+					defaultCPU = 512
+					defaultMemory = 512 * 1024 * 1024
+
+					fetchedConfig.Limits = atc.ContainerLimits{Memory: &memory}
+					configSource.FetchConfigReturns(fetchedConfig, nil)
+
+					defaultLimits = atc.ContainerLimits{
+						CPU:    &defaultCPU,
+						Memory: &defaultMemory,
+					}
+				})
+
+				It("creates the container with the task's limits, falling back to the defaults", func() {
+					Expect(fakeWorkerClient.FindOrCreateContainerCallCount()).To(Equal(1))
+					_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+					Expect(spec.Limits).To(Equal(worker.ContainerLimits{
+						CPU:    &defaultCPU,
+						Memory: &memory,
+					}))
+				})
+
+				It("initializes with the effective limits", func() {
+					Expect(fakeDelegate.InitializingCallCount()).To(Equal(1))
+					_, config := fakeDelegate.InitializingArgsForCall(0)
+					Expect(config.Limits).To(Equal(atc.ContainerLimits{
+						CPU:    &defaultCPU,
+						Memory: &memory,
+					}))
+				})
+
+				Context("when the task explicitly disables a limit", func() {
+					BeforeEach(func() {
+						cpu = 0
+						fetchedConfig.Limits.CPU = &cpu
+						configSource.FetchConfigReturns(fetchedConfig, nil)
+					})
+
+					It("does not apply the default", func() {
+						_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+						Expect(*spec.Limits.CPU).To(BeZero())
+					})
+				})
+			})
+
 			Context("when rootfs uri is set instead of image resource", func() {
 				BeforeEach(func() {
 					fetchedConfig = atc.TaskConfig{
@@ -726,6 +784,169 @@ var _ = Describe("TaskStep", func() {
 							Expect(fakeVolume2.InitializeTaskCacheCallCount()).To(Equal(0))
 						})
 					})
+
+					It("does not snapshot the caches", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(fakeVolume1.StreamOutCallCount()).To(Equal(0))
+						Expect(fakeVolume2.StreamOutCallCount()).To(Equal(0))
+					})
+
+					It("leaves caches empty on workers which do not have them", func() {
+						_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+
+						fakeDestination := new(workerfakes.FakeArtifactDestination)
+						Expect(spec.Inputs[0].Source().StreamTo(fakeDestination)).To(Succeed())
+						Expect(fakeDestination.StreamInCallCount()).To(Equal(0))
+					})
+
+					Context("when a task cache store is configured", func() {
+						var fakeTaskCacheStore *taskcachefakes.FakeStore
+
+						BeforeEach(func() {
+							fakeTaskCacheStore = new(taskcachefakes.FakeStore)
+							taskCacheStore = fakeTaskCacheStore
+
+							fakeVolume1.StreamOutReturns(ioutil.NopCloser(strings.NewReader("some-snapshot")), nil)
+							fakeVolume2.StreamOutReturns(ioutil.NopCloser(strings.NewReader("other-snapshot")), nil)
+						})
+
+						It("snapshots each cache to the store", func() {
+							Expect(stepErr).ToNot(HaveOccurred())
+
+							Eventually(fakeVolume2.StreamOutCallCount).Should(Equal(1))
+							Expect(fakeVolume2.StreamOutArgsForCall(0)).To(Equal("."))
+							Expect(fakeVolume1.StreamOutCallCount()).To(Equal(1))
+							Expect(fakeVolume1.StreamOutArgsForCall(0)).To(Equal("."))
+
+							Eventually(fakeTaskCacheStore.PutCallCount).Should(Equal(2))
+
+							_, key, blob := fakeTaskCacheStore.PutArgsForCall(0)
+							Expect(key).To(Equal(taskcache.Key(teamID, jobID, "some-task", "some-path-1")))
+							Expect(ioutil.ReadAll(blob)).To(Equal([]byte("some-snapshot")))
+
+							_, key, blob = fakeTaskCacheStore.PutArgsForCall(1)
+							Expect(key).To(Equal(taskcache.Key(teamID, jobID, "some-task", "some-path-2")))
+							Expect(ioutil.ReadAll(blob)).To(Equal([]byte("other-snapshot")))
+						})
+
+						Context("when snapshotting a cache is slow", func() {
+							var unblock chan struct{}
+
+							BeforeEach(func() {
+								unblock = make(chan struct{})
+								fakeTaskCacheStore.PutStub = func(context.Context, string, io.Reader) error {
+									<-unblock
+									return nil
+								}
+							})
+
+							AfterEach(func() {
+								close(unblock)
+							})
+
+							It("does not hold up the step", func() {
+								Expect(stepErr).ToNot(HaveOccurred())
+								Expect(taskStep.Succeeded()).To(BeTrue())
+								Eventually(fakeTaskCacheStore.PutCallCount).Should(Equal(1))
+							})
+						})
+
+						Context("when snapshotting a cache fails", func() {
+							BeforeEach(func() {
+								fakeTaskCacheStore.PutReturns(taskcache.ErrBlobTooLarge)
+							})
+
+							It("does not fail the step", func() {
+								Expect(stepErr).ToNot(HaveOccurred())
+								Expect(taskStep.Succeeded()).To(BeTrue())
+							})
+						})
+
+						Context("when the process exits nonzero", func() {
+							BeforeEach(func() {
+								fakeProcess.WaitReturns(1, nil)
+							})
+
+							It("does not snapshot the caches", func() {
+								Expect(stepErr).ToNot(HaveOccurred())
+								Consistently(fakeTaskCacheStore.PutCallCount).Should(Equal(0))
+							})
+						})
+
+						Context("when task does not belong to job (one-off build)", func() {
+							BeforeEach(func() {
+								jobID = 0
+							})
+
+							It("does not snapshot the caches", func() {
+								Expect(stepErr).ToNot(HaveOccurred())
+								Consistently(fakeTaskCacheStore.PutCallCount).Should(Equal(0))
+							})
+						})
+
+						Describe("restoring a cache on a worker which does not have it", func() {
+							var fakeDestination *workerfakes.FakeArtifactDestination
+
+							BeforeEach(func() {
+								fakeDestination = new(workerfakes.FakeArtifactDestination)
+							})
+
+							restoreCache := func() error {
+								_, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateContainerArgsForCall(0)
+								return spec.Inputs[0].Source().StreamTo(fakeDestination)
+							}
+
+							Context("when the store has a snapshot", func() {
+								BeforeEach(func() {
+									fakeTaskCacheStore.GetReturns(ioutil.NopCloser(strings.NewReader("some-snapshot")), true, nil)
+								})
+
+								It("streams the snapshot in", func() {
+									Expect(restoreCache()).To(Succeed())
+
+									Expect(fakeTaskCacheStore.GetCallCount()).To(Equal(1))
+									Expect(fakeTaskCacheStore.GetArgsForCall(0)).To(Equal(taskcache.Key(teamID, jobID, "some-task", "some-path-1")))
+
+									Expect(fakeDestination.StreamInCallCount()).To(Equal(1))
+									dest, stream := fakeDestination.StreamInArgsForCall(0)
+									Expect(dest).To(Equal("."))
+									Expect(ioutil.ReadAll(stream)).To(Equal([]byte("some-snapshot")))
+								})
+
+								Context("when streaming in fails", func() {
+									BeforeEach(func() {
+										fakeDestination.StreamInReturns(errors.New("nope"))
+									})
+
+									It("leaves the cache as it is", func() {
+										Expect(restoreCache()).To(Succeed())
+									})
+								})
+							})
+
+							Context("when the store does not have a snapshot", func() {
+								BeforeEach(func() {
+									fakeTaskCacheStore.GetReturns(nil, false, nil)
+								})
+
+								It("leaves the cache empty", func() {
+									Expect(restoreCache()).To(Succeed())
+									Expect(fakeDestination.StreamInCallCount()).To(Equal(0))
+								})
+							})
+
+							Context("when getting the snapshot fails", func() {
+								BeforeEach(func() {
+									fakeTaskCacheStore.GetReturns(nil, false, errors.New("nope"))
+								})
+
+								It("leaves the cache empty", func() {
+									Expect(restoreCache()).To(Succeed())
+									Expect(fakeDestination.StreamInCallCount()).To(Equal(0))
+								})
+							})
+						})
+					})
 				})
 
 				Context("when the configuration specifies paths for outputs", func() {
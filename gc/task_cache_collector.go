@@ -0,0 +1,94 @@
+package gc
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc/taskcache"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+type taskCacheCollector struct {
+	store        taskcache.Store
+	maxAge       time.Duration
+	maxTotalSize int64
+}
+
+// NewTaskCacheCollector removes task cache snapshots which have not been
+// updated within maxAge, and then the least recently updated snapshots until
+// the store is within maxTotalSize. A zero value disables either limit.
+func NewTaskCacheCollector(
+	store taskcache.Store,
+	maxAge time.Duration,
+	maxTotalSize int64,
+) Collector {
+	return &taskCacheCollector{
+		store:        store,
+		maxAge:       maxAge,
+		maxTotalSize: maxTotalSize,
+	}
+}
+
+func (tc *taskCacheCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("task-cache-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	blobs, err := tc.store.List()
+	if err != nil {
+		logger.Error("failed-to-list-snapshots", err)
+		return err
+	}
+
+	// newest first, so that everything past the size limit is the oldest
+	sort.Slice(blobs, func(i, j int) bool {
+		return blobs[i].ModTime.After(blobs[j].ModTime)
+	})
+
+	var errs error
+
+	var totalSize int64
+	for _, blob := range blobs {
+		expired := tc.maxAge != 0 && time.Since(blob.ModTime) > tc.maxAge
+		if !expired {
+			totalSize += blob.Size
+		}
+
+		overLimit := tc.maxTotalSize != 0 && totalSize > tc.maxTotalSize
+		if !expired && !overLimit {
+			continue
+		}
+
+		if overLimit {
+			// no longer counts towards the limit once it's removed
+			totalSize -= blob.Size
+		}
+
+		err := tc.deleteSnapshot(logger, blob, expired)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+func (tc *taskCacheCollector) deleteSnapshot(logger lager.Logger, blob taskcache.Blob, expired bool) error {
+	logger.Debug("deleting-snapshot", lager.Data{
+		"key":     blob.Key,
+		"size":    blob.Size,
+		"expired": expired,
+	})
+
+	err := tc.store.Delete(blob.Key)
+	if err != nil {
+		logger.Error("failed-to-delete-snapshot", err, lager.Data{"key": blob.Key})
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/atc/gc"
+	"github.com/concourse/atc/taskcache"
+	"github.com/concourse/atc/taskcache/taskcachefakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskCacheCollector", func() {
+	var (
+		collector gc.Collector
+		fakeStore *taskcachefakes.FakeStore
+
+		maxAge       time.Duration
+		maxTotalSize int64
+
+		runErr error
+	)
+
+	deletedKeys := func() []string {
+		keys := []string{}
+		for i := 0; i < fakeStore.DeleteCallCount(); i++ {
+			keys = append(keys, fakeStore.DeleteArgsForCall(i))
+		}
+
+		return keys
+	}
+
+	BeforeEach(func() {
+		fakeStore = new(taskcachefakes.FakeStore)
+		fakeStore.ListReturns([]taskcache.Blob{
+			{Key: "old", Size: 10, ModTime: time.Now().Add(-3 * time.Hour)},
+			{Key: "newest", Size: 10, ModTime: time.Now().Add(-time.Minute)},
+			{Key: "older", Size: 10, ModTime: time.Now().Add(-2 * time.Hour)},
+			{Key: "new", Size: 10, ModTime: time.Now().Add(-time.Hour)},
+		}, nil)
+
+		maxAge = 0
+		maxTotalSize = 0
+	})
+
+	JustBeforeEach(func() {
+		collector = gc.NewTaskCacheCollector(fakeStore, maxAge, maxTotalSize)
+		runErr = collector.Run(context.TODO())
+	})
+
+	Context("when no limits are configured", func() {
+		It("deletes nothing", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeStore.DeleteCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when a maximum age is configured", func() {
+		BeforeEach(func() {
+			maxAge = 90 * time.Minute
+		})
+
+		It("deletes snapshots which have not been updated within it", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(deletedKeys()).To(ConsistOf("older", "old"))
+		})
+	})
+
+	Context("when a maximum total size is configured", func() {
+		BeforeEach(func() {
+			maxTotalSize = 25
+		})
+
+		It("deletes the least recently updated snapshots until within it", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(deletedKeys()).To(ConsistOf("older", "old"))
+		})
+
+		Context("when expired snapshots are deleted too", func() {
+			BeforeEach(func() {
+				maxAge = 150 * time.Minute
+				maxTotalSize = 15
+			})
+
+			It("does not count them towards the size", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(deletedKeys()).To(ConsistOf("new", "older", "old"))
+			})
+		})
+	})
+
+	Context("when listing snapshots fails", func() {
+		var disaster error
+
+		BeforeEach(func() {
+			disaster = errors.New("nope")
+			fakeStore.ListReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when deleting a snapshot fails", func() {
+		BeforeEach(func() {
+			maxAge = 90 * time.Minute
+			fakeStore.DeleteReturns(errors.New("nope"))
+		})
+
+		It("still deletes the rest and returns an error", func() {
+			Expect(runErr).To(HaveOccurred())
+			Expect(fakeStore.DeleteCallCount()).To(Equal(2))
+		})
+	})
+})
@@ -0,0 +1,44 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc/db"
+)
+
+type teamEventCollector struct {
+	teamEventLifecycle db.TeamEventLifecycle
+	retention          time.Duration
+}
+
+// NewTeamEventCollector removes team events older than the retention period.
+// Clients resuming a team's event stream from a removed event will only see
+// the events which remain.
+func NewTeamEventCollector(teamEventLifecycle db.TeamEventLifecycle, retention time.Duration) Collector {
+	return &teamEventCollector{
+		teamEventLifecycle: teamEventLifecycle,
+		retention:          retention,
+	}
+}
+
+func (tc *teamEventCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("team-event-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	removed, err := tc.teamEventLifecycle.RemoveEventsOlderThan(tc.retention)
+	if err != nil {
+		logger.Error("failed-to-remove-team-events", err)
+		return err
+	}
+
+	if removed > 0 {
+		logger.Debug("removed", lager.Data{"count": removed})
+	}
+
+	return nil
+}
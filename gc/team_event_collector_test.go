@@ -0,0 +1,49 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamEventCollector", func() {
+	var (
+		collector         gc.Collector
+		fakeTeamLifecycle *dbfakes.FakeTeamEventLifecycle
+	)
+
+	BeforeEach(func() {
+		fakeTeamLifecycle = new(dbfakes.FakeTeamEventLifecycle)
+		collector = gc.NewTeamEventCollector(fakeTeamLifecycle, time.Hour)
+	})
+
+	It("removes events older than the retention period", func() {
+		fakeTeamLifecycle.RemoveEventsOlderThanReturns(3, nil)
+
+		err := collector.Run(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeTeamLifecycle.RemoveEventsOlderThanCallCount()).To(Equal(1))
+		Expect(fakeTeamLifecycle.RemoveEventsOlderThanArgsForCall(0)).To(Equal(time.Hour))
+	})
+
+	Context("when removing events fails", func() {
+		var disaster error
+
+		BeforeEach(func() {
+			disaster = errors.New("nope")
+			fakeTeamLifecycle.RemoveEventsOlderThanReturns(0, disaster)
+		})
+
+		It("returns the error", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
@@ -12,6 +12,7 @@ type Job struct {
 	NextBuild            *Build `json:"next_build"`
 	FinishedBuild        *Build `json:"finished_build"`
 	TransitionBuild      *Build `json:"transition_build,omitempty"`
+	NextScheduledTime    int64  `json:"next_scheduled_time,omitempty"`
 
 	Inputs  []JobInput  `json:"inputs"`
 	Outputs []JobOutput `json:"outputs"`
@@ -35,11 +36,12 @@ type JobOutput struct {
 }
 
 type BuildInput struct {
-	Name     string   `json:"name"`
-	Resource string   `json:"resource"`
-	Type     string   `json:"type"`
-	Source   Source   `json:"source"`
-	Params   Params   `json:"params,omitempty"`
-	Version  Version  `json:"version"`
-	Tags     []string `json:"tags,omitempty"`
+	Name          string         `json:"name"`
+	Resource      string         `json:"resource"`
+	Type          string         `json:"type"`
+	Source        Source         `json:"source"`
+	Params        Params         `json:"params,omitempty"`
+	Version       Version        `json:"version"`
+	VersionFilter *VersionConfig `json:"version_filter,omitempty"`
+	Tags          []string       `json:"tags,omitempty"`
 }
@@ -2,7 +2,6 @@ package metric
 
 import (
 	"fmt"
-	"strings"
 	"time"
 
 	flags "github.com/jessevdk/go-flags"
@@ -52,6 +51,10 @@ func WireEmitters(group *flags.Group) {
 	}
 }
 
+// EmitterQueueSize is how many events may be waiting to be emitted by each
+// configured emitter before further events are dropped for it.
+const EmitterQueueSize = 1000
+
 var emitter Emitter
 var eventHost string
 var eventAttributes map[string]string
@@ -61,39 +64,29 @@ type eventEmission struct {
 	logger lager.Logger
 }
 
-var emissions = make(chan eventEmission, 1000)
-
 func Initialize(logger lager.Logger, host string, attributes map[string]string) error {
-	var emitterDescriptions []string
+	emitters := map[string]Emitter{}
 	for _, factory := range emitterFactories {
-		if factory.IsConfigured() {
-			emitterDescriptions = append(emitterDescriptions, factory.Description())
+		if !factory.IsConfigured() {
+			continue
 		}
-	}
-	if len(emitterDescriptions) > 1 {
-		return fmt.Errorf("Multiple emitters configured: %s", strings.Join(emitterDescriptions, ", "))
-	}
 
-	var err error
-	for _, factory := range emitterFactories {
-		if factory.IsConfigured() {
-			emitter, err = factory.NewEmitter()
-			if err != nil {
-				return err
-			}
+		configured, err := factory.NewEmitter()
+		if err != nil {
+			return err
 		}
+
+		emitters[factory.Description()] = configured
 	}
 
-	if emitter == nil {
+	if len(emitters) == 0 {
 		return nil
 	}
 
-	emitter = emitter
+	emitter = NewFanoutEmitter(emitters, EmitterQueueSize)
 	eventHost = host
 	eventAttributes = attributes
 
-	go emitLoop()
-
 	return nil
 }
 
@@ -118,15 +111,5 @@ func emit(logger lager.Logger, event Event) {
 
 	event.Attributes = mergedAttributes
 
-	select {
-	case emissions <- eventEmission{logger: logger, event: event}:
-	default:
-		logger.Error("queue-full", nil)
-	}
-}
-
-func emitLoop() {
-	for emission := range emissions {
-		emitter.Emit(emission.logger.Session("emit"), emission.event)
-	}
+	emitter.Emit(logger, event)
 }
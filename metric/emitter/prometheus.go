@@ -37,7 +37,17 @@ type PrometheusEmitter struct {
 	dbQueriesTotal prometheus.Counter
 	dbConnections  *prometheus.GaugeVec
 
-	resourceChecksVec *prometheus.CounterVec
+	vaultCacheHits      prometheus.Counter
+	vaultCacheMisses    prometheus.Counter
+	vaultCacheEvictions prometheus.Counter
+
+	droppedEvents prometheus.Counter
+
+	resourceChecksVec          *prometheus.CounterVec
+	resourceCheckDurationVec   *prometheus.HistogramVec
+	resourceCheckLockFailedVec *prometheus.CounterVec
+
+	stepDurationVec *prometheus.HistogramVec
 
 	workerLastSeen map[string]time.Time
 	mu             sync.Mutex
@@ -220,6 +230,40 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(dbConnections)
 
+	// vault cache metrics
+	vaultCacheHits := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "concourse",
+		Subsystem: "vault",
+		Name:      "cache_hits_total",
+		Help:      "Total number of Vault secret reads served from the cache, including cached misses.",
+	})
+	prometheus.MustRegister(vaultCacheHits)
+
+	vaultCacheMisses := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "concourse",
+		Subsystem: "vault",
+		Name:      "cache_misses_total",
+		Help:      "Total number of Vault secret reads which had to go to Vault.",
+	})
+	prometheus.MustRegister(vaultCacheMisses)
+
+	vaultCacheEvictions := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "concourse",
+		Subsystem: "vault",
+		Name:      "cache_evictions_total",
+		Help:      "Total number of Vault secrets evicted from a full cache.",
+	})
+	prometheus.MustRegister(vaultCacheEvictions)
+
+	// metrics pipeline metrics
+	droppedEvents := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "concourse",
+		Subsystem: "metrics",
+		Name:      "dropped_events_total",
+		Help:      "Total number of metric events dropped because an emitter could not keep up.",
+	})
+	prometheus.MustRegister(droppedEvents)
+
 	resourceChecksVec := prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: "concourse",
@@ -231,6 +275,42 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(resourceChecksVec)
 
+	resourceCheckDurationVec := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "resource",
+			Name:      "check_duration_seconds",
+			Help:      "Time taken to run a resource check, by resource type",
+			Buckets:   []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60, 120, 300, 600},
+		},
+		[]string{"team", "pipeline", "resource_type", "success"},
+	)
+	prometheus.MustRegister(resourceCheckDurationVec)
+
+	resourceCheckLockFailedVec := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "resource",
+			Name:      "check_lock_failures_total",
+			Help:      "Counts the number of times a resource check could not acquire its lock",
+		},
+		[]string{"team", "pipeline", "kind"},
+	)
+	prometheus.MustRegister(resourceCheckLockFailedVec)
+
+	// step metrics
+	stepDurationVec := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "steps",
+			Name:      "duration_seconds",
+			Help:      "Time taken to run a get, put or task step",
+			Buckets:   []float64{1, 5, 10, 30, 60, 180, 300, 600, 1200, 1800, 3600, 7200},
+		},
+		[]string{"team", "pipeline", "step_type", "succeeded"},
+	)
+	prometheus.MustRegister(stepDurationVec)
+
 	listener, err := net.Listen("tcp", config.bind())
 	if err != nil {
 		return nil, err
@@ -260,7 +340,17 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		dbQueriesTotal: dbQueriesTotal,
 		dbConnections:  dbConnections,
 
-		resourceChecksVec: resourceChecksVec,
+		vaultCacheHits:      vaultCacheHits,
+		vaultCacheMisses:    vaultCacheMisses,
+		vaultCacheEvictions: vaultCacheEvictions,
+
+		droppedEvents: droppedEvents,
+
+		resourceChecksVec:          resourceChecksVec,
+		resourceCheckDurationVec:   resourceCheckDurationVec,
+		resourceCheckLockFailedVec: resourceCheckLockFailedVec,
+
+		stepDurationVec: stepDurationVec,
 
 		workerLastSeen: map[string]time.Time{},
 	}
@@ -299,8 +389,22 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.databaseMetrics(logger, event)
 	case "database connections":
 		emitter.databaseMetrics(logger, event)
+	case "vault cache hits":
+		emitter.vaultCacheMetrics(logger, event)
+	case "vault cache misses":
+		emitter.vaultCacheMetrics(logger, event)
+	case "vault cache evictions":
+		emitter.vaultCacheMetrics(logger, event)
+	case "dropped events":
+		emitter.droppedEventsMetric(logger, event)
 	case "resource checked":
 		emitter.resourceMetric(logger, event)
+	case "resource check duration (ms)":
+		emitter.resourceCheckDurationMetric(logger, event)
+	case "resource check lock failed":
+		emitter.resourceCheckLockFailedMetric(logger, event)
+	case "step duration (ms)":
+		emitter.stepDurationMetric(logger, event)
 	default:
 		// unless we have a specific metric, we do nothing
 	}
@@ -468,6 +572,33 @@ func (emitter *PrometheusEmitter) databaseMetrics(logger lager.Logger, event met
 
 }
 
+func (emitter *PrometheusEmitter) vaultCacheMetrics(logger lager.Logger, event metric.Event) {
+	value, ok := event.Value.(int)
+	if !ok {
+		logger.Error("vault-cache-value-type-mismatch", fmt.Errorf("expected event.Value to be a int"))
+		return
+	}
+	switch event.Name {
+	case "vault cache hits":
+		emitter.vaultCacheHits.Add(float64(value))
+	case "vault cache misses":
+		emitter.vaultCacheMisses.Add(float64(value))
+	case "vault cache evictions":
+		emitter.vaultCacheEvictions.Add(float64(value))
+	default:
+	}
+}
+
+func (emitter *PrometheusEmitter) droppedEventsMetric(logger lager.Logger, event metric.Event) {
+	value, ok := event.Value.(int)
+	if !ok {
+		logger.Error("dropped-events-value-type-mismatch", fmt.Errorf("expected event.Value to be a int"))
+		return
+	}
+
+	emitter.droppedEvents.Add(float64(value))
+}
+
 func (emitter *PrometheusEmitter) resourceMetric(logger lager.Logger, event metric.Event) {
 	pipeline, exists := event.Attributes["pipeline"]
 	if !exists {
@@ -483,6 +614,91 @@ func (emitter *PrometheusEmitter) resourceMetric(logger lager.Logger, event metr
 	emitter.resourceChecksVec.WithLabelValues(pipeline, team).Inc()
 }
 
+func (emitter *PrometheusEmitter) resourceCheckDurationMetric(logger lager.Logger, event metric.Event) {
+	team, exists := event.Attributes["team"]
+	if !exists {
+		logger.Error("failed-to-find-team-in-event", fmt.Errorf("expected team to exist in event.Attributes"))
+		return
+	}
+	pipeline, exists := event.Attributes["pipeline"]
+	if !exists {
+		logger.Error("failed-to-find-pipeline-in-event", fmt.Errorf("expected pipeline to exist in event.Attributes"))
+		return
+	}
+	resourceType, exists := event.Attributes["resource_type"]
+	if !exists {
+		logger.Error("failed-to-find-resource-type-in-event", fmt.Errorf("expected resource_type to exist in event.Attributes"))
+		return
+	}
+	success, exists := event.Attributes["success"]
+	if !exists {
+		logger.Error("failed-to-find-success-in-event", fmt.Errorf("expected success to exist in event.Attributes"))
+		return
+	}
+
+	duration, ok := event.Value.(float64)
+	if !ok {
+		logger.Error("resource-check-duration-event-value-type-mismatch", fmt.Errorf("expected event.Value to be a float64"))
+		return
+	}
+
+	// concourse_resource_check_duration_seconds
+	emitter.resourceCheckDurationVec.WithLabelValues(team, pipeline, resourceType, success).Observe(duration / 1000)
+}
+
+func (emitter *PrometheusEmitter) resourceCheckLockFailedMetric(logger lager.Logger, event metric.Event) {
+	team, exists := event.Attributes["team"]
+	if !exists {
+		logger.Error("failed-to-find-team-in-event", fmt.Errorf("expected team to exist in event.Attributes"))
+		return
+	}
+	pipeline, exists := event.Attributes["pipeline"]
+	if !exists {
+		logger.Error("failed-to-find-pipeline-in-event", fmt.Errorf("expected pipeline to exist in event.Attributes"))
+		return
+	}
+	kind, exists := event.Attributes["kind"]
+	if !exists {
+		logger.Error("failed-to-find-kind-in-event", fmt.Errorf("expected kind to exist in event.Attributes"))
+		return
+	}
+
+	// concourse_resource_check_lock_failures_total
+	emitter.resourceCheckLockFailedVec.WithLabelValues(team, pipeline, kind).Inc()
+}
+
+func (emitter *PrometheusEmitter) stepDurationMetric(logger lager.Logger, event metric.Event) {
+	team, exists := event.Attributes["team_name"]
+	if !exists {
+		logger.Error("failed-to-find-team-name-in-event", fmt.Errorf("expected team_name to exist in event.Attributes"))
+		return
+	}
+	pipeline, exists := event.Attributes["pipeline"]
+	if !exists {
+		logger.Error("failed-to-find-pipeline-in-event", fmt.Errorf("expected pipeline to exist in event.Attributes"))
+		return
+	}
+	stepType, exists := event.Attributes["step_type"]
+	if !exists {
+		logger.Error("failed-to-find-step-type-in-event", fmt.Errorf("expected step_type to exist in event.Attributes"))
+		return
+	}
+	succeeded, exists := event.Attributes["succeeded"]
+	if !exists {
+		logger.Error("failed-to-find-succeeded-in-event", fmt.Errorf("expected succeeded to exist in event.Attributes"))
+		return
+	}
+
+	duration, ok := event.Value.(float64)
+	if !ok {
+		logger.Error("step-duration-event-value-type-mismatch", fmt.Errorf("expected event.Value to be a float64"))
+		return
+	}
+
+	// concourse_steps_duration_seconds
+	emitter.stepDurationVec.WithLabelValues(team, pipeline, stepType, succeeded).Observe(duration / 1000)
+}
+
 // updateLastSeen tracks for each worker when it last received a metric event.
 func (emitter *PrometheusEmitter) updateLastSeen(event metric.Event) {
 	emitter.mu.Lock()
@@ -0,0 +1,165 @@
+package emitter
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/metric"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrometheusEmitter", func() {
+	var (
+		logger *lagertest.TestLogger
+
+		emitter *PrometheusEmitter
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		emitter = &PrometheusEmitter{
+			droppedEvents: prometheus.NewCounter(prometheus.CounterOpts{
+				Name: "dropped_total",
+			}),
+			resourceCheckDurationVec: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Name: "resource_check_duration_seconds",
+			}, []string{"team", "pipeline", "resource_type", "success"}),
+			resourceCheckLockFailedVec: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "resource_check_lock_failures_total",
+			}, []string{"team", "pipeline", "kind"}),
+			stepDurationVec: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Name: "steps_duration_seconds",
+			}, []string{"team", "pipeline", "step_type", "succeeded"}),
+
+			workerLastSeen: map[string]time.Time{},
+		}
+	})
+
+	Describe("dropped events", func() {
+		It("adds the number of events dropped", func() {
+			emitter.Emit(logger, metric.Event{Name: "dropped events", Value: 3})
+			emitter.Emit(logger, metric.Event{Name: "dropped events", Value: 2})
+
+			Expect(counterValue(emitter.droppedEvents)).To(Equal(5.0))
+		})
+
+		It("ignores events with a value of the wrong type", func() {
+			emitter.Emit(logger, metric.Event{Name: "dropped events", Value: "3"})
+
+			Expect(counterValue(emitter.droppedEvents)).To(BeZero())
+		})
+	})
+
+	Describe("resource check duration", func() {
+		It("observes the duration in seconds", func() {
+			emitter.Emit(logger, metric.Event{
+				Name:  "resource check duration (ms)",
+				Value: 1500.0,
+				Attributes: map[string]string{
+					"team":          "some-team",
+					"pipeline":      "some-pipeline",
+					"resource_type": "git",
+					"success":       "true",
+				},
+			})
+
+			histogram := histogramValue(emitter.resourceCheckDurationVec, "some-team", "some-pipeline", "git", "true")
+			Expect(histogram.GetSampleCount()).To(BeEquivalentTo(1))
+			Expect(histogram.GetSampleSum()).To(Equal(1.5))
+		})
+
+		It("ignores events missing an attribute", func() {
+			emitter.Emit(logger, metric.Event{
+				Name:  "resource check duration (ms)",
+				Value: 1500.0,
+				Attributes: map[string]string{
+					"team":     "some-team",
+					"pipeline": "some-pipeline",
+				},
+			})
+
+			Expect(collected(emitter.resourceCheckDurationVec)).To(BeZero())
+		})
+	})
+
+	Describe("resource check lock failed", func() {
+		It("counts failures by kind", func() {
+			for _, kind := range []string{"resource", "resource", "resource_type"} {
+				emitter.Emit(logger, metric.Event{
+					Name:  "resource check lock failed",
+					Value: 1,
+					Attributes: map[string]string{
+						"team":     "some-team",
+						"pipeline": "some-pipeline",
+						"resource": "some-resource",
+						"kind":     kind,
+					},
+				})
+			}
+
+			Expect(counterValue(emitter.resourceCheckLockFailedVec.WithLabelValues("some-team", "some-pipeline", "resource"))).To(Equal(2.0))
+			Expect(counterValue(emitter.resourceCheckLockFailedVec.WithLabelValues("some-team", "some-pipeline", "resource_type"))).To(Equal(1.0))
+		})
+	})
+
+	Describe("step duration", func() {
+		It("observes the duration in seconds", func() {
+			emitter.Emit(logger, metric.Event{
+				Name:  "step duration (ms)",
+				Value: 250.0,
+				Attributes: map[string]string{
+					"team_name": "some-team",
+					"pipeline":  "some-pipeline",
+					"job":       "some-job",
+					"build_id":  "42",
+					"step_type": "task",
+					"step_name": "some-task",
+					"succeeded": "false",
+				},
+			})
+
+			histogram := histogramValue(emitter.stepDurationVec, "some-team", "some-pipeline", "task", "false")
+			Expect(histogram.GetSampleCount()).To(BeEquivalentTo(1))
+			Expect(histogram.GetSampleSum()).To(Equal(0.25))
+		})
+
+		It("ignores events with a value of the wrong type", func() {
+			emitter.Emit(logger, metric.Event{
+				Name:  "step duration (ms)",
+				Value: 250,
+				Attributes: map[string]string{
+					"team_name": "some-team",
+					"pipeline":  "some-pipeline",
+					"step_type": "task",
+					"succeeded": "false",
+				},
+			})
+
+			Expect(collected(emitter.stepDurationVec)).To(BeZero())
+		})
+	})
+})
+
+func counterValue(counter prometheus.Counter) float64 {
+	var m dto.Metric
+	Expect(counter.Write(&m)).To(Succeed())
+	return m.GetCounter().GetValue()
+}
+
+func histogramValue(vec *prometheus.HistogramVec, labels ...string) *dto.Histogram {
+	var m dto.Metric
+	Expect(vec.WithLabelValues(labels...).(prometheus.Metric).Write(&m)).To(Succeed())
+	return m.GetHistogram()
+}
+
+func collected(collector prometheus.Collector) int {
+	metrics := make(chan prometheus.Metric, 10)
+	collector.Collect(metrics)
+	close(metrics)
+	return len(metrics)
+}
@@ -0,0 +1,63 @@
+package metric
+
+import (
+	"sort"
+
+	"code.cloudfoundry.org/lager"
+)
+
+type fanoutEmitter struct {
+	queues []emitterQueue
+}
+
+type emitterQueue struct {
+	name      string
+	emitter   Emitter
+	emissions chan eventEmission
+}
+
+// NewFanoutEmitter returns an Emitter which emits each event to every one of
+// the given emitters, keyed by name. Each emitter has its own queue of up to
+// queueSize events so that a slow emitter does not hold up the others; events
+// which arrive while an emitter's queue is full are dropped for that emitter
+// and counted in DroppedEvents.
+func NewFanoutEmitter(emitters map[string]Emitter, queueSize int) Emitter {
+	names := []string{}
+	for name := range emitters {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fanout := &fanoutEmitter{}
+	for _, name := range names {
+		queue := emitterQueue{
+			name:      name,
+			emitter:   emitters[name],
+			emissions: make(chan eventEmission, queueSize),
+		}
+
+		go queue.emitLoop()
+
+		fanout.queues = append(fanout.queues, queue)
+	}
+
+	return fanout
+}
+
+func (fanout *fanoutEmitter) Emit(logger lager.Logger, event Event) {
+	for _, queue := range fanout.queues {
+		select {
+		case queue.emissions <- eventEmission{logger: logger, event: event}:
+		default:
+			DroppedEvents.Inc()
+			logger.Error("queue-full", nil, lager.Data{"emitter": queue.name})
+		}
+	}
+}
+
+func (queue emitterQueue) emitLoop() {
+	for emission := range queue.emissions {
+		queue.emitter.Emit(emission.logger.Session("emit"), emission.event)
+	}
+}
@@ -0,0 +1,78 @@
+package metric_test
+
+import (
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/atc/metric"
+	"github.com/concourse/atc/metric/metricfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FanoutEmitter", func() {
+	var (
+		logger *lagertest.TestLogger
+
+		fastEmitter *metricfakes.FakeEmitter
+		slowEmitter *metricfakes.FakeEmitter
+		unblock     chan struct{}
+
+		fanout Emitter
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		fastEmitter = new(metricfakes.FakeEmitter)
+
+		unblock = make(chan struct{})
+		slowEmitter = new(metricfakes.FakeEmitter)
+		slowEmitter.EmitStub = func(lager.Logger, Event) {
+			<-unblock
+		}
+
+		DroppedEvents.Delta()
+
+		fanout = NewFanoutEmitter(map[string]Emitter{
+			"fast": fastEmitter,
+			"slow": slowEmitter,
+		}, 2)
+	})
+
+	AfterEach(func() {
+		close(unblock)
+	})
+
+	It("emits each event to every emitter", func() {
+		fanout.Emit(logger, Event{Name: "some-event"})
+
+		Eventually(fastEmitter.EmitCallCount).Should(Equal(1))
+		Eventually(slowEmitter.EmitCallCount).Should(Equal(1))
+
+		_, event := fastEmitter.EmitArgsForCall(0)
+		Expect(event.Name).To(Equal("some-event"))
+	})
+
+	Context("when an emitter's queue is full", func() {
+		BeforeEach(func() {
+			fanout.Emit(logger, Event{Name: "some-event"})
+			Eventually(slowEmitter.EmitCallCount).Should(Equal(1))
+
+			// queued behind the event being emitted
+			fanout.Emit(logger, Event{Name: "some-event"})
+			fanout.Emit(logger, Event{Name: "some-event"})
+
+			Eventually(fastEmitter.EmitCallCount).Should(Equal(3))
+		})
+
+		It("drops the event for that emitter only", func() {
+			fanout.Emit(logger, Event{Name: "dropped-event"})
+
+			Eventually(fastEmitter.EmitCallCount).Should(Equal(4))
+			Expect(DroppedEvents.Delta()).To(Equal(1))
+
+			Expect(logger.LogMessages()).To(ContainElement("test.queue-full"))
+		})
+	})
+})
@@ -20,6 +20,12 @@ var FailedVolumes = Meter(0)
 var ContainersDeleted = Meter(0)
 var VolumesDeleted = Meter(0)
 
+var VaultCacheHits = Meter(0)
+var VaultCacheMisses = Meter(0)
+var VaultCacheEvictions = Meter(0)
+
+var DroppedEvents = Meter(0)
+
 type SchedulingFullDuration struct {
 	PipelineName string
 	Duration     time.Duration
@@ -415,3 +421,95 @@ func (event ResourceCheck) Emit(logger lager.Logger) {
 		},
 	)
 }
+
+type ResourceCheckDuration struct {
+	PipelineName string
+	ResourceName string
+	ResourceType string
+	TeamName     string
+	Success      bool
+	Duration     time.Duration
+}
+
+func (event ResourceCheckDuration) Emit(logger lager.Logger) {
+	state := EventStateOK
+	if !event.Success {
+		state = EventStateWarning
+	}
+
+	emit(
+		logger.Session("resource-check-duration"),
+		Event{
+			Name:  "resource check duration (ms)",
+			Value: ms(event.Duration),
+			State: state,
+			Attributes: map[string]string{
+				"pipeline":      event.PipelineName,
+				"resource":      event.ResourceName,
+				"resource_type": event.ResourceType,
+				"team":          event.TeamName,
+				"success":       strconv.FormatBool(event.Success),
+			},
+		},
+	)
+}
+
+type ResourceCheckLockFailed struct {
+	PipelineName string
+	TeamName     string
+	ResourceName string
+	Kind         string
+}
+
+const (
+	CheckLockKindResource     = "resource"
+	CheckLockKindResourceType = "resource_type"
+)
+
+func (event ResourceCheckLockFailed) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("resource-check-lock-failed"),
+		Event{
+			Name:  "resource check lock failed",
+			Value: 1,
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"pipeline": event.PipelineName,
+				"resource": event.ResourceName,
+				"team":     event.TeamName,
+				"kind":     event.Kind,
+			},
+		},
+	)
+}
+
+type StepDuration struct {
+	TeamName     string
+	PipelineName string
+	JobName      string
+	BuildID      int
+	StepType     string
+	StepName     string
+	Succeeded    bool
+	Duration     time.Duration
+}
+
+func (event StepDuration) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("step-duration"),
+		Event{
+			Name:  "step duration (ms)",
+			Value: ms(event.Duration),
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"team_name": event.TeamName,
+				"pipeline":  event.PipelineName,
+				"job":       event.JobName,
+				"build_id":  strconv.Itoa(event.BuildID),
+				"step_type": event.StepType,
+				"step_name": event.StepName,
+				"succeeded": strconv.FormatBool(event.Succeeded),
+			},
+		},
+	)
+}
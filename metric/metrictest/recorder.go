@@ -0,0 +1,72 @@
+package metrictest
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/atc/metric"
+)
+
+// Recorder collects emitted events in-process so that tests can assert on
+// what was emitted without configuring a real emitter.
+type Recorder struct {
+	mu     sync.Mutex
+	events []metric.Event
+}
+
+var (
+	recorder    = &Recorder{}
+	installOnce sync.Once
+)
+
+// Install configures metrics to be emitted to the returned Recorder, which is
+// shared by every caller and emptied on each call. Events are emitted
+// asynchronously, so assertions on them should be made with Eventually.
+func Install() *Recorder {
+	installOnce.Do(func() {
+		metric.RegisterEmitter(recorderFactory{})
+
+		err := metric.Initialize(lager.NewLogger("metrictest"), "", nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	recorder.mu.Lock()
+	recorder.events = nil
+	recorder.mu.Unlock()
+
+	return recorder
+}
+
+func (recorder *Recorder) Emit(logger lager.Logger, event metric.Event) {
+	recorder.mu.Lock()
+	recorder.events = append(recorder.events, event)
+	recorder.mu.Unlock()
+}
+
+// Attributes returns the attributes of every recorded event with the given
+// name, in the order they were emitted.
+func (recorder *Recorder) Attributes(name string) []map[string]string {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	attributes := []map[string]string{}
+	for _, event := range recorder.events {
+		if event.Name == name {
+			attributes = append(attributes, event.Attributes)
+		}
+	}
+
+	return attributes
+}
+
+type recorderFactory struct{}
+
+func (recorderFactory) Description() string { return "Test" }
+func (recorderFactory) IsConfigured() bool  { return true }
+
+func (recorderFactory) NewEmitter() (metric.Emitter, error) {
+	return recorder, nil
+}
@@ -93,6 +93,42 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 			},
 		)
 
+		emit(
+			tLog.Session("vault-cache-hits"),
+			Event{
+				Name:  "vault cache hits",
+				Value: VaultCacheHits.Delta(),
+				State: EventStateOK,
+			},
+		)
+
+		emit(
+			tLog.Session("vault-cache-misses"),
+			Event{
+				Name:  "vault cache misses",
+				Value: VaultCacheMisses.Delta(),
+				State: EventStateOK,
+			},
+		)
+
+		emit(
+			tLog.Session("vault-cache-evictions"),
+			Event{
+				Name:  "vault cache evictions",
+				Value: VaultCacheEvictions.Delta(),
+				State: EventStateOK,
+			},
+		)
+
+		emit(
+			tLog.Session("dropped-events"),
+			Event{
+				Name:  "dropped events",
+				Value: DroppedEvents.Delta(),
+				State: EventStateOK,
+			},
+		)
+
 		var memStats runtime.MemStats
 		runtime.ReadMemStats(&memStats)
 
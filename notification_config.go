@@ -0,0 +1,140 @@
+package atc
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"text/template"
+)
+
+// NotificationConfig is an HTTP callback made when a job's build finishes.
+//
+// Jobs, On and From filter which builds the callback is made for; each one
+// matches anything when it's empty. For example, `on: [failed]` with
+// `from: [succeeded]` only notifies when a job starts failing.
+//
+// Body is a text/template rendered with a NotificationBuild. When it's empty
+// the NotificationBuild is sent as JSON.
+type NotificationConfig struct {
+	Name    string            `yaml:"name" json:"name" mapstructure:"name"`
+	URL     string            `yaml:"url" json:"url" mapstructure:"url"`
+	Method  string            `yaml:"method,omitempty" json:"method,omitempty" mapstructure:"method"`
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty" mapstructure:"headers"`
+	Body    string            `yaml:"body,omitempty" json:"body,omitempty" mapstructure:"body"`
+
+	Jobs []string      `yaml:"jobs,omitempty" json:"jobs,omitempty" mapstructure:"jobs"`
+	On   []BuildStatus `yaml:"on,omitempty" json:"on,omitempty" mapstructure:"on"`
+	From []BuildStatus `yaml:"from,omitempty" json:"from,omitempty" mapstructure:"from"`
+}
+
+type NotificationConfigs []NotificationConfig
+
+func (notifications NotificationConfigs) Lookup(name string) (NotificationConfig, bool) {
+	for _, notification := range notifications {
+		if notification.Name == name {
+			return notification, true
+		}
+	}
+
+	return NotificationConfig{}, false
+}
+
+// NotificationBuild is the finished build a notification is made for.
+// PreviousStatus is the status of the job's build before it, if any.
+type NotificationBuild struct {
+	TeamName       string      `json:"team_name"`
+	PipelineName   string      `json:"pipeline_name"`
+	JobName        string      `json:"job_name"`
+	BuildID        int         `json:"build_id"`
+	BuildName      string      `json:"build_name"`
+	Status         BuildStatus `json:"status"`
+	PreviousStatus BuildStatus `json:"previous_status,omitempty"`
+	URL            string      `json:"url"`
+}
+
+func (config NotificationConfig) HTTPMethod() string {
+	if config.Method == "" {
+		return http.MethodPost
+	}
+
+	return config.Method
+}
+
+// Matches returns true if a build of the job finishing with the status,
+// following a build which finished with the previous status, should be
+// notified. The previous status is empty for a job's first build.
+func (config NotificationConfig) Matches(jobName string, status BuildStatus, previousStatus BuildStatus) bool {
+	if len(config.Jobs) > 0 && !containsString(config.Jobs, jobName) {
+		return false
+	}
+
+	if len(config.On) > 0 && !containsStatus(config.On, status) {
+		return false
+	}
+
+	if len(config.From) > 0 && !containsStatus(config.From, previousStatus) {
+		return false
+	}
+
+	return true
+}
+
+func (config NotificationConfig) RenderBody(build NotificationBuild) (string, error) {
+	if config.Body == "" {
+		payload, err := json.Marshal(build)
+		if err != nil {
+			return "", err
+		}
+
+		return string(payload), nil
+	}
+
+	tmpl, err := config.bodyTemplate()
+	if err != nil {
+		return "", err
+	}
+
+	buf := new(bytes.Buffer)
+	err = tmpl.Execute(buf, build)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func (config NotificationConfig) bodyTemplate() (*template.Template, error) {
+	return template.New(config.Name).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{"json": notificationJSON}).
+		Parse(config.Body)
+}
+
+func notificationJSON(value interface{}) (string, error) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(payload), nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsStatus(statuses []BuildStatus, status BuildStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,100 @@
+package atc_test
+
+import (
+	. "github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NotificationConfig", func() {
+	var config NotificationConfig
+
+	BeforeEach(func() {
+		config = NotificationConfig{
+			Name: "some-notification",
+			URL:  "https://example.com/hook",
+		}
+	})
+
+	Describe("Matches", func() {
+		It("matches every build when there are no filters", func() {
+			Expect(config.Matches("some-job", StatusSucceeded, "")).To(BeTrue())
+			Expect(config.Matches("other-job", StatusFailed, StatusSucceeded)).To(BeTrue())
+		})
+
+		It("matches only the configured jobs", func() {
+			config.Jobs = []string{"some-job"}
+
+			Expect(config.Matches("some-job", StatusFailed, "")).To(BeTrue())
+			Expect(config.Matches("other-job", StatusFailed, "")).To(BeFalse())
+		})
+
+		It("matches only transitions between the configured statuses", func() {
+			config.On = []BuildStatus{StatusFailed, StatusErrored}
+			config.From = []BuildStatus{StatusSucceeded}
+
+			Expect(config.Matches("some-job", StatusFailed, StatusSucceeded)).To(BeTrue())
+			Expect(config.Matches("some-job", StatusErrored, StatusSucceeded)).To(BeTrue())
+			Expect(config.Matches("some-job", StatusFailed, StatusFailed)).To(BeFalse())
+			Expect(config.Matches("some-job", StatusSucceeded, StatusSucceeded)).To(BeFalse())
+			Expect(config.Matches("some-job", StatusFailed, "")).To(BeFalse())
+		})
+	})
+
+	Describe("HTTPMethod", func() {
+		It("defaults to POST", func() {
+			Expect(config.HTTPMethod()).To(Equal("POST"))
+
+			config.Method = "PUT"
+			Expect(config.HTTPMethod()).To(Equal("PUT"))
+		})
+	})
+
+	Describe("RenderBody", func() {
+		var build NotificationBuild
+
+		BeforeEach(func() {
+			build = NotificationBuild{
+				TeamName:       "some-team",
+				PipelineName:   "some-pipeline",
+				JobName:        "some-job",
+				BuildID:        42,
+				BuildName:      "7",
+				Status:         StatusFailed,
+				PreviousStatus: StatusSucceeded,
+				URL:            "https://example.com/builds/42",
+			}
+		})
+
+		It("sends the build as JSON by default", func() {
+			body, err := config.RenderBody(build)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{
+				"team_name": "some-team",
+				"pipeline_name": "some-pipeline",
+				"job_name": "some-job",
+				"build_id": 42,
+				"build_name": "7",
+				"status": "failed",
+				"previous_status": "succeeded",
+				"url": "https://example.com/builds/42"
+			}`))
+		})
+
+		It("renders the body template with the build", func() {
+			config.Body = `{"text": {{json (printf "%s/%s #%s %s" .PipelineName .JobName .BuildName .Status)}}}`
+
+			body, err := config.RenderBody(build)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"text": "some-pipeline/some-job #7 failed"}`))
+		})
+
+		It("fails if the template refers to unknown fields", func() {
+			config.Body = `{{.Bogus}}`
+
+			_, err := config.RenderBody(build)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,17 @@
+package atc
+
+type NotificationDelivery struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	TeamName     string `json:"team_name"`
+	PipelineName string `json:"pipeline_name"`
+	JobName      string `json:"job_name"`
+	BuildID      int    `json:"build_id"`
+	BuildName    string `json:"build_name"`
+	Status       string `json:"status"`
+	Attempts     int    `json:"attempts"`
+	ResponseCode int    `json:"response_code,omitempty"`
+	Error        string `json:"error,omitempty"`
+	CreatedAt    int64  `json:"created_at"`
+	DeliveredAt  int64  `json:"delivered_at,omitempty"`
+}
@@ -0,0 +1,76 @@
+package notifications
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+type Config struct {
+	AllowedNetworks []string `long:"allowed-network" description:"CIDR range to which notifications may be delivered. If specified, deliveries to any other address fail. Can be specified multiple times."`
+	DeniedNetworks  []string `long:"denied-network"  description:"CIDR range to which notifications may not be delivered. Link-local addresses are always denied, as are loopback and private addresses unless they are within an allowed network. Can be specified multiple times."`
+
+	Timeout     time.Duration `long:"timeout"       default:"10s" description:"How long to wait for a delivery's request to complete."`
+	MaxInFlight int           `long:"max-in-flight" default:"10"  description:"Maximum number of deliveries to make at once."`
+}
+
+func (config Config) Validate() error {
+	_, err := parseNetworks(config.AllowedNetworks)
+	if err != nil {
+		return fmt.Errorf("invalid --notifications-allowed-network: %s", err)
+	}
+
+	_, err = parseNetworks(config.DeniedNetworks)
+	if err != nil {
+		return fmt.Errorf("invalid --notifications-denied-network: %s", err)
+	}
+
+	if config.MaxInFlight < 1 {
+		return errors.New("--notifications-max-in-flight must be at least 1")
+	}
+
+	return nil
+}
+
+// HTTPClient returns a client which only connects to addresses permitted by
+// the configured networks.
+func (config Config) HTTPClient() (*http.Client, error) {
+	allowed, err := parseNetworks(config.AllowedNetworks)
+	if err != nil {
+		return nil, err
+	}
+
+	denied, err := parseNetworks(config.DeniedNetworks)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := NewDestinationFilter(allowed, denied)
+
+	return &http.Client{
+		Timeout: config.Timeout,
+		Transport: &http.Transport{
+			// no proxy, as it would be connected to instead of the destination
+			DialContext:         filter.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}, nil
+}
+
+func parseNetworks(cidrs []string) ([]*net.IPNet, error) {
+	networks := []*net.IPNet{}
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
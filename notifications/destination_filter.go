@@ -0,0 +1,129 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+)
+
+// ErrDestinationNotPermitted is returned when a delivery's host resolves to
+// an address which notifications may not be delivered to.
+var ErrDestinationNotPermitted = errors.New("destination address not permitted")
+
+// DestinationFilter decides which addresses notifications may be delivered
+// to, so that pipeline configs cannot be used to reach services which are
+// only exposed to the ATC, such as cloud metadata endpoints.
+type DestinationFilter struct {
+	allowed []*net.IPNet
+	denied  []*net.IPNet
+
+	dialer *net.Dialer
+}
+
+// internalNetworks are private (RFC 1918 and RFC 4193) networks, which are
+// denied unless they are within an allowed network.
+var internalNetworks = []*net.IPNet{
+	mustParseCIDR("10.0.0.0/8"),
+	mustParseCIDR("172.16.0.0/12"),
+	mustParseCIDR("192.168.0.0/16"),
+	mustParseCIDR("fc00::/7"),
+}
+
+// NewDestinationFilter constructs a filter which denies link-local addresses
+// and any address in the denied networks. Loopback, unspecified and private
+// addresses are denied too, unless they are within an allowed network. If any
+// allowed networks are given, only addresses within them are permitted.
+func NewDestinationFilter(allowed []*net.IPNet, denied []*net.IPNet) *DestinationFilter {
+	return &DestinationFilter{
+		allowed: allowed,
+		denied:  denied,
+
+		dialer: &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		},
+	}
+}
+
+func (filter *DestinationFilter) Permits(ip net.IP) bool {
+	if ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
+		return false
+	}
+
+	for _, network := range filter.denied {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	for _, network := range filter.allowed {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	if isInternal(ip) {
+		return false
+	}
+
+	return len(filter.allowed) == 0
+}
+
+func isInternal(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsUnspecified() {
+		return true
+	}
+
+	for _, network := range internalNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+
+	return network
+}
+
+// DialContext resolves the address's host and connects to it only if every
+// address it resolves to is permitted. The resolved address is dialed
+// directly so that the host cannot be re-resolved to a different address.
+func (filter *DestinationFilter) DialContext(ctx context.Context, network string, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addrs) == 0 {
+		return nil, &net.DNSError{Err: "no such host", Name: host}
+	}
+
+	for _, addr := range addrs {
+		if !filter.Permits(addr.IP) {
+			return nil, ErrDestinationNotPermitted
+		}
+	}
+
+	var conn net.Conn
+	for _, addr := range addrs {
+		conn, err = filter.dialer.DialContext(ctx, network, net.JoinHostPort(addr.IP.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+	}
+
+	return nil, err
+}
@@ -0,0 +1,98 @@
+package notifications_test
+
+import (
+	"context"
+	"net"
+
+	"github.com/concourse/atc/notifications"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DestinationFilter", func() {
+	network := func(cidr string) *net.IPNet {
+		_, network, err := net.ParseCIDR(cidr)
+		Expect(err).ToNot(HaveOccurred())
+		return network
+	}
+
+	It("denies link-local addresses", func() {
+		filter := notifications.NewDestinationFilter(nil, nil)
+
+		Expect(filter.Permits(net.ParseIP("169.254.169.254"))).To(BeFalse())
+		Expect(filter.Permits(net.ParseIP("fe80::1"))).To(BeFalse())
+		Expect(filter.Permits(net.ParseIP("93.184.216.34"))).To(BeTrue())
+	})
+
+	It("denies loopback addresses", func() {
+		filter := notifications.NewDestinationFilter(nil, nil)
+
+		Expect(filter.Permits(net.ParseIP("127.0.0.1"))).To(BeFalse())
+		Expect(filter.Permits(net.ParseIP("127.1.2.3"))).To(BeFalse())
+		Expect(filter.Permits(net.ParseIP("::1"))).To(BeFalse())
+		Expect(filter.Permits(net.ParseIP("::ffff:127.0.0.1"))).To(BeFalse())
+	})
+
+	It("denies unspecified addresses", func() {
+		filter := notifications.NewDestinationFilter(nil, nil)
+
+		Expect(filter.Permits(net.ParseIP("0.0.0.0"))).To(BeFalse())
+		Expect(filter.Permits(net.ParseIP("::"))).To(BeFalse())
+	})
+
+	It("denies private addresses", func() {
+		filter := notifications.NewDestinationFilter(nil, nil)
+
+		Expect(filter.Permits(net.ParseIP("10.1.2.3"))).To(BeFalse())
+		Expect(filter.Permits(net.ParseIP("172.16.0.1"))).To(BeFalse())
+		Expect(filter.Permits(net.ParseIP("172.31.255.254"))).To(BeFalse())
+		Expect(filter.Permits(net.ParseIP("192.168.1.1"))).To(BeFalse())
+		Expect(filter.Permits(net.ParseIP("fd00::1"))).To(BeFalse())
+
+		Expect(filter.Permits(net.ParseIP("172.32.0.1"))).To(BeTrue())
+	})
+
+	It("permits loopback, unspecified and private addresses which are allowed explicitly", func() {
+		filter := notifications.NewDestinationFilter(
+			[]*net.IPNet{
+				network("127.0.0.0/8"),
+				network("0.0.0.0/32"),
+				network("::/128"),
+				network("192.168.0.0/16"),
+			},
+			nil,
+		)
+
+		Expect(filter.Permits(net.ParseIP("127.0.0.1"))).To(BeTrue())
+		Expect(filter.Permits(net.ParseIP("0.0.0.0"))).To(BeTrue())
+		Expect(filter.Permits(net.ParseIP("::"))).To(BeTrue())
+		Expect(filter.Permits(net.ParseIP("192.168.1.1"))).To(BeTrue())
+
+		Expect(filter.Permits(net.ParseIP("10.1.2.3"))).To(BeFalse())
+	})
+
+	It("denies addresses in the denied networks", func() {
+		filter := notifications.NewDestinationFilter(nil, []*net.IPNet{network("93.184.0.0/16")})
+
+		Expect(filter.Permits(net.ParseIP("93.184.216.34"))).To(BeFalse())
+		Expect(filter.Permits(net.ParseIP("8.8.8.8"))).To(BeTrue())
+	})
+
+	It("permits only addresses in the allowed networks, if any", func() {
+		filter := notifications.NewDestinationFilter(
+			[]*net.IPNet{network("10.0.0.0/8")},
+			[]*net.IPNet{network("10.0.1.0/24")},
+		)
+
+		Expect(filter.Permits(net.ParseIP("10.1.2.3"))).To(BeTrue())
+		Expect(filter.Permits(net.ParseIP("10.0.1.2"))).To(BeFalse())
+		Expect(filter.Permits(net.ParseIP("192.168.1.1"))).To(BeFalse())
+	})
+
+	It("refuses to dial addresses which are not permitted", func() {
+		filter := notifications.NewDestinationFilter(nil, nil)
+
+		_, err := filter.DialContext(context.Background(), "tcp", "169.254.169.254:80")
+		Expect(err).To(Equal(notifications.ErrDestinationNotPermitted))
+	})
+})
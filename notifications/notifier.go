@@ -0,0 +1,330 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+)
+
+// MaxDeliveryAttempts is how many times a delivery's request is made before
+// it is given up on.
+const MaxDeliveryAttempts = 5
+
+// RetryInterval is how long to wait before retrying a delivery after its
+// first failed attempt. It doubles with each subsequent attempt.
+const RetryInterval = 30 * time.Second
+
+// Notifier is run periodically under a lock. It matches builds which have
+// finished against their pipeline's notifications, queueing a delivery for
+// each match, and then makes any deliveries which are due, up to maxInFlight
+// at once.
+type Notifier struct {
+	notificationFactory db.NotificationFactory
+	pipelineFactory     db.PipelineFactory
+	variablesFactory    creds.VariablesFactory
+	httpClient          *http.Client
+	clock               clock.Clock
+	externalURL         string
+	batchSize           int
+	maxInFlight         int
+}
+
+func NewNotifier(
+	notificationFactory db.NotificationFactory,
+	pipelineFactory db.PipelineFactory,
+	variablesFactory creds.VariablesFactory,
+	httpClient *http.Client,
+	clock clock.Clock,
+	externalURL string,
+	batchSize int,
+	maxInFlight int,
+) *Notifier {
+	return &Notifier{
+		notificationFactory: notificationFactory,
+		pipelineFactory:     pipelineFactory,
+		variablesFactory:    variablesFactory,
+		httpClient:          httpClient,
+		clock:               clock,
+		externalURL:         externalURL,
+		batchSize:           batchSize,
+		maxInFlight:         maxInFlight,
+	}
+}
+
+func (notifier *Notifier) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("notifier")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	err := notifier.queueDeliveries(logger)
+	if err != nil {
+		return err
+	}
+
+	return notifier.deliver(ctx, logger)
+}
+
+func (notifier *Notifier) queueDeliveries(logger lager.Logger) error {
+	buildNotifications, err := notifier.notificationFactory.QueuedBuildNotifications(notifier.batchSize)
+	if err != nil {
+		logger.Error("failed-to-get-queued-build-notifications", err)
+		return err
+	}
+
+	if len(buildNotifications) == 0 {
+		return nil
+	}
+
+	pipelines, err := notifier.pipelineFactory.AllPipelines()
+	if err != nil {
+		logger.Error("failed-to-get-pipelines", err)
+		return err
+	}
+
+	pipelinesByID := map[int]db.Pipeline{}
+	for _, pipeline := range pipelines {
+		pipelinesByID[pipeline.ID()] = pipeline
+	}
+
+	pipelineNotifications := map[int]atc.NotificationConfigs{}
+
+	for _, buildNotification := range buildNotifications {
+		configs, loaded := pipelineNotifications[buildNotification.PipelineID]
+		if !loaded {
+			// the pipeline may have been destroyed since the build finished, in
+			// which case the build is dequeued without notifying
+			pipeline, found := pipelinesByID[buildNotification.PipelineID]
+			if found {
+				config, found, err := pipeline.Config()
+				if err != nil {
+					logger.Error("failed-to-get-pipeline-config", err)
+					return err
+				}
+
+				if found {
+					configs = config.Notifications
+				}
+			}
+
+			pipelineNotifications[buildNotification.PipelineID] = configs
+		}
+
+		deliveries := notifier.matchingDeliveries(logger, buildNotification, configs)
+
+		err = notifier.notificationFactory.CreateDeliveries(buildNotification, deliveries)
+		if err != nil {
+			logger.Error("failed-to-create-deliveries", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (notifier *Notifier) matchingDeliveries(
+	logger lager.Logger,
+	buildNotification db.BuildNotification,
+	configs atc.NotificationConfigs,
+) []db.NotificationDelivery {
+	build := atc.NotificationBuild{
+		TeamName:       buildNotification.TeamName,
+		PipelineName:   buildNotification.PipelineName,
+		JobName:        buildNotification.JobName,
+		BuildID:        buildNotification.BuildID,
+		BuildName:      buildNotification.BuildName,
+		Status:         atc.BuildStatus(buildNotification.Status),
+		PreviousStatus: atc.BuildStatus(buildNotification.PreviousStatus),
+		URL:            notifier.buildURL(buildNotification),
+	}
+
+	deliveries := []db.NotificationDelivery{}
+	for _, config := range configs {
+		if !config.Matches(build.JobName, build.Status, build.PreviousStatus) {
+			continue
+		}
+
+		body, err := config.RenderBody(build)
+		if err != nil {
+			logger.Error("failed-to-render-body", err, lager.Data{
+				"notification": config.Name,
+				"build":        build.BuildID,
+			})
+			continue
+		}
+
+		deliveries = append(deliveries, db.NotificationDelivery{
+			Name: config.Name,
+			Request: db.NotificationRequest{
+				Method:  config.HTTPMethod(),
+				URL:     config.URL,
+				Headers: config.Headers,
+				Body:    body,
+			},
+		})
+	}
+
+	return deliveries
+}
+
+func (notifier *Notifier) buildURL(buildNotification db.BuildNotification) string {
+	return fmt.Sprintf(
+		"%s/teams/%s/pipelines/%s/jobs/%s/builds/%s",
+		notifier.externalURL,
+		url.PathEscape(buildNotification.TeamName),
+		url.PathEscape(buildNotification.PipelineName),
+		url.PathEscape(buildNotification.JobName),
+		url.PathEscape(buildNotification.BuildName),
+	)
+}
+
+func (notifier *Notifier) deliver(ctx context.Context, logger lager.Logger) error {
+	deliveries, err := notifier.notificationFactory.PendingDeliveries(notifier.batchSize)
+	if err != nil {
+		logger.Error("failed-to-get-pending-deliveries", err)
+		return err
+	}
+
+	var (
+		wg       sync.WaitGroup
+		errL     sync.Mutex
+		firstErr error
+	)
+
+	// a slow destination only holds up the deliveries sharing its slot
+	inFlight := make(chan struct{}, notifier.maxInFlight)
+
+	for _, delivery := range deliveries {
+		inFlight <- struct{}{}
+
+		wg.Add(1)
+		go func(delivery db.NotificationDelivery) {
+			defer func() {
+				<-inFlight
+				wg.Done()
+			}()
+
+			err := notifier.deliverOne(ctx, logger, delivery)
+			if err != nil {
+				errL.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				errL.Unlock()
+			}
+		}(delivery)
+	}
+
+	wg.Wait()
+
+	return firstErr
+}
+
+func (notifier *Notifier) deliverOne(ctx context.Context, logger lager.Logger, delivery db.NotificationDelivery) error {
+	deliveryLogger := logger.Session("deliver", lager.Data{
+		"delivery":     delivery.ID,
+		"notification": delivery.Name,
+		"build":        delivery.BuildID,
+	})
+
+	attempt := notifier.attempt(ctx, delivery)
+	if !attempt.Succeeded {
+		deliveryLogger.Info("failed", lager.Data{
+			"attempt": delivery.Attempts + 1,
+			"error":   attempt.Error,
+		})
+
+		if delivery.Attempts+1 < MaxDeliveryAttempts && retryable(attempt) {
+			attempt.RetryAt = notifier.clock.Now().Add(RetryInterval << uint(delivery.Attempts))
+		}
+	}
+
+	err := notifier.notificationFactory.SaveDeliveryAttempt(delivery.ID, attempt)
+	if err != nil {
+		deliveryLogger.Error("failed-to-save-attempt", err)
+		return err
+	}
+
+	return nil
+}
+
+// retryable returns false for attempts which the destination rejected as a
+// client error, as the same request would only be rejected again. Timeouts
+// and rate limiting are the exception.
+func retryable(attempt db.NotificationDeliveryAttempt) bool {
+	switch {
+	case attempt.ResponseCode == http.StatusRequestTimeout, attempt.ResponseCode == http.StatusTooManyRequests:
+		return true
+	case attempt.ResponseCode >= 400 && attempt.ResponseCode < 500:
+		return false
+	default:
+		return true
+	}
+}
+
+func (notifier *Notifier) attempt(ctx context.Context, delivery db.NotificationDelivery) db.NotificationDeliveryAttempt {
+	variables := notifier.variablesFactory.NewVariables(delivery.TeamName, delivery.PipelineName)
+
+	requestURL, err := creds.NewString(variables, delivery.Request.URL).Evaluate()
+	if err != nil {
+		return db.NotificationDeliveryAttempt{Error: err.Error()}
+	}
+
+	request, err := http.NewRequest(delivery.Request.Method, requestURL, strings.NewReader(delivery.Request.Body))
+	if err != nil {
+		return db.NotificationDeliveryAttempt{Error: "invalid request"}
+	}
+
+	for name, rawValue := range delivery.Request.Headers {
+		value, err := creds.NewString(variables, rawValue).Evaluate()
+		if err != nil {
+			return db.NotificationDeliveryAttempt{Error: err.Error()}
+		}
+
+		request.Header.Set(name, value)
+	}
+
+	if request.Header.Get("Content-Type") == "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := notifier.httpClient.Do(request.WithContext(ctx))
+	if err != nil {
+		// the URL may contain interpolated credentials, so leave it out of the
+		// error which is shown in the delivery log
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
+
+		return db.NotificationDeliveryAttempt{Error: err.Error()}
+	}
+
+	defer response.Body.Close()
+
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return db.NotificationDeliveryAttempt{
+			ResponseCode: response.StatusCode,
+			Error:        fmt.Sprintf("unexpected response: %s", response.Status),
+		}
+	}
+
+	return db.NotificationDeliveryAttempt{
+		Succeeded:    true,
+		ResponseCode: response.StatusCode,
+	}
+}
@@ -0,0 +1,406 @@
+package notifications_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds/credsfakes"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/notifications"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Notifier", func() {
+	var (
+		fakeNotificationFactory *dbfakes.FakeNotificationFactory
+		fakePipelineFactory     *dbfakes.FakePipelineFactory
+		fakePipeline            *dbfakes.FakePipeline
+		fakeVariablesFactory    *credsfakes.FakeVariablesFactory
+		fakeVariables           *credsfakes.FakeVariables
+		fakeClock               *fakeclock.FakeClock
+
+		notifier *notifications.Notifier
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeNotificationFactory = new(dbfakes.FakeNotificationFactory)
+		fakePipelineFactory = new(dbfakes.FakePipelineFactory)
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.IDReturns(42)
+		fakePipelineFactory.AllPipelinesReturns([]db.Pipeline{fakePipeline}, nil)
+
+		fakeVariables = new(credsfakes.FakeVariables)
+		fakeVariablesFactory = new(credsfakes.FakeVariablesFactory)
+		fakeVariablesFactory.NewVariablesReturns(fakeVariables)
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 0))
+
+		notifier = notifications.NewNotifier(
+			fakeNotificationFactory,
+			fakePipelineFactory,
+			fakeVariablesFactory,
+			http.DefaultClient,
+			fakeClock,
+			"https://concourse.example.com",
+			100,
+			10,
+		)
+	})
+
+	JustBeforeEach(func() {
+		runErr = notifier.Run(context.TODO())
+	})
+
+	Describe("queueing deliveries", func() {
+		var buildNotification db.BuildNotification
+
+		BeforeEach(func() {
+			buildNotification = db.BuildNotification{
+				BuildID:        1,
+				BuildName:      "7",
+				Status:         db.BuildStatusFailed,
+				PreviousStatus: db.BuildStatusSucceeded,
+				TeamID:         2,
+				TeamName:       "some-team",
+				PipelineID:     42,
+				PipelineName:   "some-pipeline",
+				JobName:        "some-job",
+			}
+
+			fakeNotificationFactory.QueuedBuildNotificationsReturns([]db.BuildNotification{buildNotification}, nil)
+
+			fakePipeline.ConfigReturns(atc.Config{
+				Notifications: atc.NotificationConfigs{
+					{
+						Name:    "on-failure",
+						URL:     "https://example.com/((token))",
+						Headers: map[string]string{"Authorization": "((auth))"},
+						On:      []atc.BuildStatus{atc.StatusFailed},
+						From:    []atc.BuildStatus{atc.StatusSucceeded},
+						Body:    `{{.JobName}} #{{.BuildName}} {{.Status}} {{.URL}}`,
+					},
+					{
+						Name: "on-success",
+						URL:  "https://example.com/success",
+						On:   []atc.BuildStatus{atc.StatusSucceeded},
+					},
+					{
+						Name:   "other-job",
+						URL:    "https://example.com/other",
+						Method: "PUT",
+						Jobs:   []string{"other-job"},
+					},
+				},
+			}, true, nil)
+		})
+
+		It("creates a delivery for each matching notification", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+
+			Expect(fakeNotificationFactory.QueuedBuildNotificationsArgsForCall(0)).To(Equal(100))
+
+			Expect(fakeNotificationFactory.CreateDeliveriesCallCount()).To(Equal(1))
+			notification, deliveries := fakeNotificationFactory.CreateDeliveriesArgsForCall(0)
+			Expect(notification).To(Equal(buildNotification))
+			Expect(deliveries).To(Equal([]db.NotificationDelivery{
+				{
+					Name: "on-failure",
+					Request: db.NotificationRequest{
+						Method:  "POST",
+						URL:     "https://example.com/((token))",
+						Headers: map[string]string{"Authorization": "((auth))"},
+						Body:    "some-job #7 failed https://concourse.example.com/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/7",
+					},
+				},
+			}))
+		})
+
+		Context("when the pipeline no longer exists", func() {
+			BeforeEach(func() {
+				fakePipelineFactory.AllPipelinesReturns([]db.Pipeline{}, nil)
+			})
+
+			It("dequeues the build without any deliveries", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+
+				Expect(fakeNotificationFactory.CreateDeliveriesCallCount()).To(Equal(1))
+				_, deliveries := fakeNotificationFactory.CreateDeliveriesArgsForCall(0)
+				Expect(deliveries).To(BeEmpty())
+			})
+		})
+
+		Context("when the pipeline's config cannot be loaded", func() {
+			BeforeEach(func() {
+				fakePipeline.ConfigReturns(atc.Config{}, false, errors.New("nope"))
+			})
+
+			It("leaves the build queued", func() {
+				Expect(runErr).To(MatchError("nope"))
+				Expect(fakeNotificationFactory.CreateDeliveriesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when there are no queued builds", func() {
+			BeforeEach(func() {
+				fakeNotificationFactory.QueuedBuildNotificationsReturns([]db.BuildNotification{}, nil)
+			})
+
+			It("does not load any pipelines", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(fakePipelineFactory.AllPipelinesCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("delivering", func() {
+		var (
+			server   *ghttp.Server
+			delivery db.NotificationDelivery
+		)
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+
+			fakeVariables.GetStub = func(def template.VariableDefinition) (interface{}, bool, error) {
+				switch def.Name {
+				case "path":
+					return "some-path", true, nil
+				case "auth":
+					return "Bearer some-token", true, nil
+				default:
+					return nil, false, nil
+				}
+			}
+
+			delivery = db.NotificationDelivery{
+				ID:           3,
+				Name:         "on-failure",
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				Request: db.NotificationRequest{
+					Method:  "POST",
+					URL:     server.URL() + "/((path))",
+					Headers: map[string]string{"Authorization": "((auth))"},
+					Body:    `{"status":"failed"}`,
+				},
+				Attempts: 1,
+			}
+
+			fakeNotificationFactory.PendingDeliveriesReturns([]db.NotificationDelivery{delivery}, nil)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/some-path"),
+					ghttp.VerifyHeaderKV("Authorization", "Bearer some-token"),
+					ghttp.VerifyHeaderKV("Content-Type", "application/json"),
+					ghttp.VerifyBody([]byte(`{"status":"failed"}`)),
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				))
+			})
+
+			It("makes the request with credentials interpolated", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+
+				name, pipeline := fakeVariablesFactory.NewVariablesArgsForCall(0)
+				Expect(name).To(Equal("some-team"))
+				Expect(pipeline).To(Equal("some-pipeline"))
+			})
+
+			It("records the delivery as succeeded", func() {
+				Expect(fakeNotificationFactory.SaveDeliveryAttemptCallCount()).To(Equal(1))
+				id, attempt := fakeNotificationFactory.SaveDeliveryAttemptArgsForCall(0)
+				Expect(id).To(Equal(3))
+				Expect(attempt).To(Equal(db.NotificationDeliveryAttempt{
+					Succeeded:    true,
+					ResponseCode: http.StatusNoContent,
+				}))
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusServiceUnavailable, nil))
+			})
+
+			It("retries with backoff", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+
+				_, attempt := fakeNotificationFactory.SaveDeliveryAttemptArgsForCall(0)
+				Expect(attempt.Succeeded).To(BeFalse())
+				Expect(attempt.ResponseCode).To(Equal(http.StatusServiceUnavailable))
+				Expect(attempt.Error).To(ContainSubstring("503"))
+				Expect(attempt.RetryAt).To(Equal(fakeClock.Now().Add(2 * notifications.RetryInterval)))
+			})
+
+			Context("on the last attempt", func() {
+				BeforeEach(func() {
+					delivery.Attempts = notifications.MaxDeliveryAttempts - 1
+					fakeNotificationFactory.PendingDeliveriesReturns([]db.NotificationDelivery{delivery}, nil)
+				})
+
+				It("gives up", func() {
+					_, attempt := fakeNotificationFactory.SaveDeliveryAttemptArgsForCall(0)
+					Expect(attempt.Succeeded).To(BeFalse())
+					Expect(attempt.RetryAt).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the request is rejected as a client error", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusBadRequest, nil))
+			})
+
+			It("gives up without retrying", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+
+				_, attempt := fakeNotificationFactory.SaveDeliveryAttemptArgsForCall(0)
+				Expect(attempt.Succeeded).To(BeFalse())
+				Expect(attempt.ResponseCode).To(Equal(http.StatusBadRequest))
+				Expect(attempt.RetryAt).To(BeZero())
+			})
+		})
+
+		Context("when the request is rate limited", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusTooManyRequests, nil))
+			})
+
+			It("retries with backoff", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+
+				_, attempt := fakeNotificationFactory.SaveDeliveryAttemptArgsForCall(0)
+				Expect(attempt.Succeeded).To(BeFalse())
+				Expect(attempt.RetryAt).To(Equal(fakeClock.Now().Add(2 * notifications.RetryInterval)))
+			})
+		})
+
+		Context("when a credential cannot be found", func() {
+			BeforeEach(func() {
+				delivery.Request.URL = server.URL() + "/((missing))"
+				fakeNotificationFactory.PendingDeliveriesReturns([]db.NotificationDelivery{delivery}, nil)
+			})
+
+			It("fails the attempt without making the request", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+
+				_, attempt := fakeNotificationFactory.SaveDeliveryAttemptArgsForCall(0)
+				Expect(attempt.Succeeded).To(BeFalse())
+				Expect(attempt.Error).ToNot(BeEmpty())
+			})
+		})
+
+		Context("when saving the attempt fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusOK, nil))
+				fakeNotificationFactory.SaveDeliveryAttemptReturns(errors.New("nope"))
+			})
+
+			It("returns the error", func() {
+				Expect(runErr).To(MatchError("nope"))
+			})
+		})
+
+		Context("when the destination is not permitted", func() {
+			BeforeEach(func() {
+				client, err := notifications.Config{
+					DeniedNetworks: []string{"127.0.0.0/8"},
+					Timeout:        time.Second,
+					MaxInFlight:    1,
+				}.HTTPClient()
+				Expect(err).ToNot(HaveOccurred())
+
+				notifier = notifications.NewNotifier(
+					fakeNotificationFactory,
+					fakePipelineFactory,
+					fakeVariablesFactory,
+					client,
+					fakeClock,
+					"https://concourse.example.com",
+					100,
+					1,
+				)
+			})
+
+			It("fails the attempt without making the request", func() {
+				Expect(runErr).ToNot(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+
+				_, attempt := fakeNotificationFactory.SaveDeliveryAttemptArgsForCall(0)
+				Expect(attempt.Succeeded).To(BeFalse())
+				Expect(attempt.ResponseCode).To(BeZero())
+				Expect(attempt.Error).To(Equal(notifications.ErrDestinationNotPermitted.Error()))
+			})
+		})
+	})
+
+	Describe("delivering to slow destinations", func() {
+		var slowServer *httptest.Server
+
+		BeforeEach(func() {
+			var (
+				lock     sync.Mutex
+				received int
+			)
+
+			allReceived := make(chan struct{})
+
+			// responds only once both requests are in flight
+			slowServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				lock.Lock()
+				received++
+				if received == 2 {
+					close(allReceived)
+				}
+				lock.Unlock()
+
+				select {
+				case <-allReceived:
+					w.WriteHeader(http.StatusNoContent)
+				case <-time.After(5 * time.Second):
+					w.WriteHeader(http.StatusGatewayTimeout)
+				}
+			}))
+
+			fakeNotificationFactory.PendingDeliveriesReturns([]db.NotificationDelivery{
+				{ID: 1, Request: db.NotificationRequest{Method: "POST", URL: slowServer.URL}},
+				{ID: 2, Request: db.NotificationRequest{Method: "POST", URL: slowServer.URL}},
+			}, nil)
+		})
+
+		AfterEach(func() {
+			slowServer.Close()
+		})
+
+		It("makes the deliveries at the same time", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+
+			Expect(fakeNotificationFactory.SaveDeliveryAttemptCallCount()).To(Equal(2))
+			for i := 0; i < 2; i++ {
+				_, attempt := fakeNotificationFactory.SaveDeliveryAttemptArgsForCall(i)
+				Expect(attempt.Succeeded).To(BeTrue())
+			}
+		})
+	})
+})
@@ -12,3 +12,12 @@ type Pipeline struct {
 type RenameRequest struct {
 	NewName string `json:"name"`
 }
+
+// CredentialReference is a variable referenced by a pipeline's config and
+// whether it currently resolves. The variable's value is never included.
+type CredentialReference struct {
+	Name      string   `json:"name"`
+	Locations []string `json:"locations"`
+	Resolved  bool     `json:"resolved"`
+	Error     string   `json:"error,omitempty"`
+}
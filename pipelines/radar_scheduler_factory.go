@@ -91,5 +91,6 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipeline db.Pipeline, externalU
 			rsf.engine,
 		),
 		Scanner: scanner,
+		Clock:   clock.NewClock(),
 	}
 }
@@ -17,6 +17,11 @@ type Plan struct {
 	Timeout   *TimeoutPlan   `json:"timeout,omitempty"`
 	Retry     *RetryPlan     `json:"retry,omitempty"`
 
+	// only set alongside Retry
+	RetryPolicy *RetryPolicy `json:"retry_policy,omitempty"`
+
+	SetPipeline *SetPipelinePlan `json:"set_pipeline,omitempty"`
+
 	// used for 'fly execute'
 	UserArtifact   *UserArtifactPlan   `json:"user_artifact,omitempty"`
 	ArtifactOutput *ArtifactOutputPlan `json:"artifact_output,omitempty"`
@@ -111,6 +116,13 @@ type TaskPlan struct {
 
 type RetryPlan []Plan
 
+type SetPipelinePlan struct {
+	Name     string                 `json:"name"`
+	File     string                 `json:"file"`
+	Vars     map[string]interface{} `json:"vars,omitempty"`
+	VarFiles []string               `json:"var_files,omitempty"`
+}
+
 type DependentGetPlan struct {
 	Type     string `json:"type"`
 	Name     string `json:"name,omitempty"`
@@ -49,6 +49,8 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 		plan.Timeout = &t
 	case RetryPlan:
 		plan.Retry = &t
+	case SetPipelinePlan:
+		plan.SetPipeline = &t
 	case UserArtifactPlan:
 		plan.UserArtifact = &t
 	case ArtifactOutputPlan:
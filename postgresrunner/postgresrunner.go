@@ -152,8 +152,7 @@ func (runner *Runner) OpenConn() db.Conn {
 		lagertest.NewTestLogger("postgres-runner"),
 		"postgres",
 		runner.DataSourceName(),
-		nil,
-		nil,
+		encryption.NewNoEncryption(),
 		"postgresrunner",
 		nil,
 	)
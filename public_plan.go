@@ -19,6 +19,7 @@ func (plan Plan) Public() *json.RawMessage {
 		DependentGet   *json.RawMessage `json:"dependent_get,omitempty"`
 		Timeout        *json.RawMessage `json:"timeout,omitempty"`
 		Retry          *json.RawMessage `json:"retry,omitempty"`
+		SetPipeline    *json.RawMessage `json:"set_pipeline,omitempty"`
 		UserArtifact   *json.RawMessage `json:"user_artifact,omitempty"`
 		ArtifactOutput *json.RawMessage `json:"artifact_output,omitempty"`
 	}
@@ -73,6 +74,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Retry = plan.Retry.Public()
 	}
 
+	if plan.SetPipeline != nil {
+		public.SetPipeline = plan.SetPipeline.Public()
+	}
+
 	if plan.UserArtifact != nil {
 		public.UserArtifact = plan.UserArtifact.Public()
 	}
@@ -224,6 +229,14 @@ func (plan RetryPlan) Public() *json.RawMessage {
 	return enc(public)
 }
 
+func (plan SetPipelinePlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+	}{
+		Name: plan.Name,
+	})
+}
+
 func (plan UserArtifactPlan) Public() *json.RawMessage {
 	return enc(plan)
 }
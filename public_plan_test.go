@@ -295,6 +295,16 @@ var _ = Describe("Plan", func() {
 							Name: "some-name",
 						},
 					},
+
+					atc.Plan{
+						ID: "33",
+						SetPipeline: &atc.SetPipelinePlan{
+							Name:     "some-pipeline",
+							File:     "some-artifact/pipeline.yml",
+							Vars:     map[string]interface{}{"some": "secret"},
+							VarFiles: []string{"some-artifact/vars.yml"},
+						},
+					},
 				},
 			}
 
@@ -510,6 +520,12 @@ var _ = Describe("Plan", func() {
 			"artifact_output": {
 				"name": "some-name"
 			}
+		},
+		{
+			"id": "33",
+			"set_pipeline": {
+				"name": "some-pipeline"
+			}
 		}
   ]
 }
@@ -51,10 +51,15 @@ func NewResourceScanner(
 
 var ErrFailedToAcquireLock = errors.New("failed-to-acquire-lock")
 
+// ErrResourcePinned is returned when checking a resource that is pinned to a
+// version, as it is not checked until it's unpinned.
+var ErrResourcePinned = errors.New("resource is pinned to a version")
+
 func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (time.Duration, error) {
 	interval, err := scanner.scan(logger.Session("tick"), resourceName, nil, false)
 
 	err = swallowErrResourceScriptFailed(err)
+	err = swallowErrResourcePinned(err)
 
 	return interval, err
 }
@@ -69,6 +74,7 @@ func (scanner *resourceScanner) Scan(logger lager.Logger, resourceName string) e
 	_, err := scanner.scan(logger, resourceName, nil, true)
 
 	err = swallowErrResourceScriptFailed(err)
+	err = swallowErrResourcePinned(err)
 
 	return err
 }
@@ -167,6 +173,7 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 			lockLogger.Error("failed-to-get-lock", err, lager.Data{
 				"resource": resourceName,
 			})
+			scanner.emitLockFailed(logger, resourceName, metric.CheckLockKindResource)
 			return interval, ErrFailedToAcquireLock
 		}
 
@@ -176,6 +183,7 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 				scanner.clock.Sleep(time.Second)
 				continue
 			} else {
+				scanner.emitLockFailed(logger, resourceName, metric.CheckLockKindResource)
 				return interval, ErrFailedToAcquireLock
 			}
 		}
@@ -228,6 +236,11 @@ func (scanner *resourceScanner) check(
 		return nil
 	}
 
+	if savedResource.PinnedVersionID() != 0 {
+		logger.Debug("resource-pinned")
+		return ErrResourcePinned
+	}
+
 	found, err := scanner.dbPipeline.Reload()
 	if err != nil {
 		logger.Error("failed-to-reload-scannerdb", err)
@@ -274,6 +287,8 @@ func (scanner *resourceScanner) check(
 		"from": fromVersion,
 	})
 
+	checkStart := scanner.clock.Now()
+
 	newVersions, err := res.Check(source, fromVersion)
 
 	scanner.setResourceCheckError(logger, savedResource, err)
@@ -284,6 +299,15 @@ func (scanner *resourceScanner) check(
 		Success:      err == nil,
 	}.Emit(logger)
 
+	metric.ResourceCheckDuration{
+		PipelineName: scanner.dbPipeline.Name(),
+		ResourceName: savedResource.Name(),
+		ResourceType: savedResource.Type(),
+		TeamName:     scanner.dbPipeline.TeamName(),
+		Success:      err == nil,
+		Duration:     scanner.clock.Now().Sub(checkStart),
+	}.Emit(logger)
+
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
@@ -324,6 +348,13 @@ func swallowErrResourceScriptFailed(err error) error {
 	return err
 }
 
+func swallowErrResourcePinned(err error) error {
+	if err == ErrResourcePinned {
+		return nil
+	}
+	return err
+}
+
 func (scanner *resourceScanner) checkInterval(checkEvery string) (time.Duration, error) {
 	interval := scanner.defaultInterval
 	if checkEvery != "" {
@@ -345,4 +376,13 @@ func (scanner *resourceScanner) setResourceCheckError(logger lager.Logger, saved
 	}
 }
 
+func (scanner *resourceScanner) emitLockFailed(logger lager.Logger, resourceName string, kind string) {
+	metric.ResourceCheckLockFailed{
+		PipelineName: scanner.dbPipeline.Name(),
+		TeamName:     scanner.dbPipeline.TeamName(),
+		ResourceName: resourceName,
+		Kind:         kind,
+	}.Emit(logger)
+}
+
 var errPipelineRemoved = errors.New("pipeline removed")
@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/atc/metric/metrictest"
 	"github.com/concourse/atc/radar/radarfakes"
 	"github.com/concourse/atc/worker"
 
@@ -140,10 +141,26 @@ var _ = Describe("ResourceScanner", func() {
 		})
 
 		Context("when the lock cannot be acquired", func() {
+			var recorder *metrictest.Recorder
+
 			BeforeEach(func() {
+				recorder = metrictest.Install()
+
+				fakeDBPipeline.TeamNameReturns("some-team")
 				fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckReturns(nil, false, nil)
 			})
 
+			It("emits that the check could not lock", func() {
+				Eventually(func() []map[string]string {
+					return recorder.Attributes("resource check lock failed")
+				}).Should(ContainElement(Equal(map[string]string{
+					"pipeline": "some-pipeline",
+					"resource": "some-resource",
+					"team":     "some-team",
+					"kind":     "resource",
+				})))
+			})
+
 			It("does not check", func() {
 				Expect(fakeResource.CheckCallCount()).To(Equal(0))
 			})
@@ -444,6 +461,28 @@ var _ = Describe("ResourceScanner", func() {
 				})
 			})
 
+			Context("when the resource is pinned to a version", func() {
+				var anotherFakeResource *dbfakes.FakeResource
+				BeforeEach(func() {
+					anotherFakeResource = new(dbfakes.FakeResource)
+					anotherFakeResource.NameReturns("some-resource")
+					anotherFakeResource.PinnedVersionIDReturns(42)
+					fakeDBPipeline.ResourceReturns(anotherFakeResource, true, nil)
+				})
+
+				It("does not check", func() {
+					Expect(fakeResource.CheckCallCount()).To(BeZero())
+				})
+
+				It("returns the default interval", func() {
+					Expect(actualInterval).To(Equal(interval))
+				})
+
+				It("does not return an error", func() {
+					Expect(runErr).NotTo(HaveOccurred())
+				})
+			})
+
 			Context("when checking if the resource is paused fails", func() {
 				disaster := errors.New("disaster")
 
@@ -936,6 +975,20 @@ var _ = Describe("ResourceScanner", func() {
 					Expect(scanErr.Error()).To(ContainSubstring("resource 'some-resource' not found"))
 				})
 			})
+
+			Context("when the resource is pinned to a version", func() {
+				BeforeEach(func() {
+					fakeDBResource.PinnedVersionIDReturns(42)
+				})
+
+				It("does not check", func() {
+					Expect(fakeResource.CheckCallCount()).To(BeZero())
+				})
+
+				It("returns ErrResourcePinned", func() {
+					Expect(scanErr).To(Equal(ErrResourcePinned))
+				})
+			})
 		})
 	})
 })
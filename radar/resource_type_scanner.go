@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/metric"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
 )
@@ -146,6 +147,7 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 			lockLogger.Error("failed-to-get-lock", err, lager.Data{
 				"resource-type": resourceTypeName,
 			})
+			scanner.emitLockFailed(logger, resourceTypeName, metric.CheckLockKindResourceType)
 			return interval, ErrFailedToAcquireLock
 		}
 
@@ -155,6 +157,7 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 				scanner.clock.Sleep(time.Second)
 				continue
 			} else {
+				scanner.emitLockFailed(logger, resourceTypeName, metric.CheckLockKindResourceType)
 				return interval, ErrFailedToAcquireLock
 			}
 		}
@@ -253,3 +256,12 @@ func (scanner *resourceTypeScanner) check(
 
 	return nil
 }
+
+func (scanner *resourceTypeScanner) emitLockFailed(logger lager.Logger, resourceTypeName string, kind string) {
+	metric.ResourceCheckLockFailed{
+		PipelineName: scanner.dbPipeline.Name(),
+		TeamName:     scanner.dbPipeline.TeamName(),
+		ResourceName: resourceTypeName,
+		Kind:         kind,
+	}.Emit(logger)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/atc/metric/metrictest"
 	. "github.com/concourse/atc/radar"
 	"github.com/concourse/atc/worker"
 
@@ -114,10 +115,26 @@ var _ = Describe("ResourceTypeScanner", func() {
 		})
 
 		Context("when the lock cannot be acquired", func() {
+			var recorder *metrictest.Recorder
+
 			BeforeEach(func() {
+				recorder = metrictest.Install()
+
+				fakeDBPipeline.TeamNameReturns("some-team")
 				fakeDBPipeline.AcquireResourceTypeCheckingLockWithIntervalCheckReturns(nil, false, nil)
 			})
 
+			It("emits that the check could not lock", func() {
+				Eventually(func() []map[string]string {
+					return recorder.Attributes("resource check lock failed")
+				}).Should(ContainElement(Equal(map[string]string{
+					"pipeline": "some-pipeline",
+					"resource": "some-custom-resource",
+					"team":     "some-team",
+					"kind":     "resource_type",
+				})))
+			})
+
 			It("does not check", func() {
 				Expect(fakeResource.CheckCallCount()).To(Equal(0))
 			})
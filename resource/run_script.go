@@ -8,6 +8,7 @@ import (
 	"io"
 
 	"code.cloudfoundry.org/garden"
+	"github.com/concourse/atc/tracing"
 )
 
 const resourceProcessIDPropertyName = "concourse:resource-process"
@@ -44,7 +45,15 @@ func (resource *resource) runScript(
 	output interface{},
 	logDest io.Writer,
 	recoverable bool,
-) error {
+) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "resource.RunScript", tracing.Attrs{
+		"path":   path,
+		"handle": resource.container.Handle(),
+	})
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	request, err := json.Marshal(input)
 	if err != nil {
 		return err
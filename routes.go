@@ -3,8 +3,11 @@ package atc
 import "github.com/tedsuo/rata"
 
 const (
-	SaveConfig = "SaveConfig"
-	GetConfig  = "GetConfig"
+	SaveConfig         = "SaveConfig"
+	GetConfig          = "GetConfig"
+	ListConfigVersions = "ListConfigVersions"
+	GetConfigVersion   = "GetConfigVersion"
+	RollbackConfig     = "RollbackConfig"
 
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
@@ -15,18 +18,19 @@ const (
 	AbortBuild          = "AbortBuild"
 	GetBuildPreparation = "GetBuildPreparation"
 
-	GetJob         = "GetJob"
-	CreateJobBuild = "CreateJobBuild"
-	ListAllJobs    = "ListAllJobs"
-	ListJobs       = "ListJobs"
-	ListJobBuilds  = "ListJobBuilds"
-	ListJobInputs  = "ListJobInputs"
-	GetJobBuild    = "GetJobBuild"
-	PauseJob       = "PauseJob"
-	UnpauseJob     = "UnpauseJob"
-	GetVersionsDB  = "GetVersionsDB"
-	JobBadge       = "JobBadge"
-	MainJobBadge   = "MainJobBadge"
+	GetJob                = "GetJob"
+	CreateJobBuild        = "CreateJobBuild"
+	ListAllJobs           = "ListAllJobs"
+	ListJobs              = "ListJobs"
+	ListJobBuilds         = "ListJobBuilds"
+	ListJobInputs         = "ListJobInputs"
+	GetJobBuild           = "GetJobBuild"
+	PauseJob              = "PauseJob"
+	UnpauseJob            = "UnpauseJob"
+	GetVersionsDB         = "GetVersionsDB"
+	JobBadge              = "JobBadge"
+	MainJobBadge          = "MainJobBadge"
+	GetJobInputResolution = "GetJobInputResolution"
 
 	ListAllResources     = "ListAllResources"
 	ListResources        = "ListResources"
@@ -34,30 +38,34 @@ const (
 	GetResource          = "GetResource"
 	PauseResource        = "PauseResource"
 	UnpauseResource      = "UnpauseResource"
+	UnpinResource        = "UnpinResource"
 	CheckResource        = "CheckResource"
 	CheckResourceWebHook = "CheckResourceWebHook"
+	CheckTeamWebHook     = "CheckTeamWebHook"
 
 	ListResourceVersions          = "ListResourceVersions"
 	GetResourceVersion            = "GetResourceVersion"
 	EnableResourceVersion         = "EnableResourceVersion"
 	DisableResourceVersion        = "DisableResourceVersion"
+	PinResourceVersion            = "PinResourceVersion"
 	ListBuildsWithVersionAsInput  = "ListBuildsWithVersionAsInput"
 	ListBuildsWithVersionAsOutput = "ListBuildsWithVersionAsOutput"
 	GetResourceCausality          = "GetResourceCausality"
 
-	ListAllPipelines    = "ListAllPipelines"
-	ListPipelines       = "ListPipelines"
-	GetPipeline         = "GetPipeline"
-	DeletePipeline      = "DeletePipeline"
-	OrderPipelines      = "OrderPipelines"
-	PausePipeline       = "PausePipeline"
-	UnpausePipeline     = "UnpausePipeline"
-	ExposePipeline      = "ExposePipeline"
-	HidePipeline        = "HidePipeline"
-	RenamePipeline      = "RenamePipeline"
-	ListPipelineBuilds  = "ListPipelineBuilds"
-	CreatePipelineBuild = "CreatePipelineBuild"
-	PipelineBadge       = "PipelineBadge"
+	ListAllPipelines        = "ListAllPipelines"
+	ListPipelines           = "ListPipelines"
+	GetPipeline             = "GetPipeline"
+	DeletePipeline          = "DeletePipeline"
+	OrderPipelines          = "OrderPipelines"
+	PausePipeline           = "PausePipeline"
+	UnpausePipeline         = "UnpausePipeline"
+	ExposePipeline          = "ExposePipeline"
+	HidePipeline            = "HidePipeline"
+	RenamePipeline          = "RenamePipeline"
+	ListPipelineBuilds      = "ListPipelineBuilds"
+	CreatePipelineBuild     = "CreatePipelineBuild"
+	PipelineBadge           = "PipelineBadge"
+	ListPipelineCredentials = "ListPipelineCredentials"
 
 	RegisterWorker  = "RegisterWorker"
 	LandWorker      = "LandWorker"
@@ -83,11 +91,17 @@ const (
 	ListDestroyingVolumes = "ListDestroyingVolumes"
 	ReportWorkerVolumes   = "ReportWorkerVolumes"
 
-	ListTeams      = "ListTeams"
-	SetTeam        = "SetTeam"
-	RenameTeam     = "RenameTeam"
-	DestroyTeam    = "DestroyTeam"
-	ListTeamBuilds = "ListTeamBuilds"
+	ListTeams                  = "ListTeams"
+	SetTeam                    = "SetTeam"
+	RenameTeam                 = "RenameTeam"
+	DestroyTeam                = "DestroyTeam"
+	ListTeamBuilds             = "ListTeamBuilds"
+	ListNotificationDeliveries = "ListNotificationDeliveries"
+	TeamEvents                 = "TeamEvents"
+
+	GetUserRoles = "GetUserRoles"
+
+	GetEncryptionKeyRotation = "GetEncryptionKeyRotation"
 
 	SendInputToBuildPlan    = "SendInputToBuildPlan"
 	ReadOutputFromBuildPlan = "ReadOutputFromBuildPlan"
@@ -96,6 +110,9 @@ const (
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions", Method: "GET", Name: ListConfigVersions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version", Method: "GET", Name: GetConfigVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version/rollback", Method: "PUT", Name: RollbackConfig},
 
 	{Path: "/api/v1/teams/:team_name/builds", Method: "POST", Name: CreateBuild},
 
@@ -115,6 +132,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "GET", Name: ListJobBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "POST", Name: CreateJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", Method: "GET", Name: ListJobInputs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/input-resolution", Method: "GET", Name: GetJobInputResolution},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
@@ -135,6 +153,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "GET", Name: ListPipelineBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "POST", Name: CreatePipelineBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/badge", Method: "GET", Name: PipelineBadge},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/credentials", Method: "GET", Name: ListPipelineCredentials},
 
 	{Path: "/api/v1/resources", Method: "GET", Name: ListAllResources},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources", Method: "GET", Name: ListResources},
@@ -142,13 +161,16 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name", Method: "GET", Name: GetResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pause", Method: "PUT", Name: PauseResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpause", Method: "PUT", Name: UnpauseResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", Method: "PUT", Name: UnpinResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebHook},
+	{Path: "/api/v1/teams/:team_name/webhook", Method: "POST", Name: CheckTeamWebHook},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id", Method: "GET", Name: GetResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", Method: "PUT", Name: PinResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", Method: "GET", Name: ListBuildsWithVersionAsInput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/output_of", Method: "GET", Name: ListBuildsWithVersionAsOutput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/causality", Method: "GET", Name: GetResourceCausality},
@@ -167,6 +189,8 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/cli", Method: "GET", Name: DownloadCLI},
 	{Path: "/api/v1/info", Method: "GET", Name: GetInfo},
 
+	{Path: "/api/v1/encryption/key-rotation", Method: "GET", Name: GetEncryptionKeyRotation},
+
 	{Path: "/api/v1/containers/destroying", Method: "GET", Name: ListDestroyingContainers},
 	{Path: "/api/v1/containers/report", Method: "PUT", Name: ReportWorkerContainers},
 	{Path: "/api/v1/teams/:team_name/containers", Method: "GET", Name: ListContainers},
@@ -182,4 +206,8 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/rename", Method: "PUT", Name: RenameTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
 	{Path: "/api/v1/teams/:team_name/builds", Method: "GET", Name: ListTeamBuilds},
+	{Path: "/api/v1/teams/:team_name/notification-deliveries", Method: "GET", Name: ListNotificationDeliveries},
+	{Path: "/api/v1/teams/:team_name/events", Method: "GET", Name: TeamEvents},
+
+	{Path: "/api/v1/user/roles", Method: "GET", Name: GetUserRoles},
 })
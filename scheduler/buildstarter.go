@@ -1,6 +1,8 @@
 package scheduler
 
 import (
+	"context"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -13,6 +15,7 @@ import (
 
 type BuildStarter interface {
 	TryStartPendingBuildsForJob(
+		ctx context.Context,
 		logger lager.Logger,
 		job db.Job,
 		resources db.Resources,
@@ -55,6 +58,7 @@ type buildStarter struct {
 }
 
 func (s *buildStarter) TryStartPendingBuildsForJob(
+	ctx context.Context,
 	logger lager.Logger,
 	job db.Job,
 	resources db.Resources,
@@ -62,7 +66,7 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 	nextPendingBuildsForJob []db.Build,
 ) error {
 	for _, nextPendingBuild := range nextPendingBuildsForJob {
-		started, err := s.tryStartNextPendingBuild(logger, nextPendingBuild, job, resources, resourceTypes)
+		started, err := s.tryStartNextPendingBuild(ctx, logger, nextPendingBuild, job, resources, resourceTypes)
 		if err != nil {
 			return err
 		}
@@ -76,6 +80,7 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 }
 
 func (s *buildStarter) tryStartNextPendingBuild(
+	ctx context.Context,
 	logger lager.Logger,
 	nextPendingBuild db.Build,
 	job db.Job,
@@ -115,7 +120,7 @@ func (s *buildStarter) tryStartNextPendingBuild(
 			return false, err
 		}
 
-		_, err = s.inputMapper.SaveNextInputMapping(logger, versions, job)
+		_, err = s.inputMapper.SaveNextInputMapping(ctx, logger, versions, job)
 		if err != nil {
 			return false, err
 		}
@@ -238,7 +238,7 @@ var _ = Describe("I'm a BuildStarter", func() {
 
 							It("saved the next input mapping for the right job and versions", func() {
 								Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(Equal(1))
-								_, actualVersionsDB, actualJob := fakeInputMapper.SaveNextInputMappingArgsForCall(0)
+								_, _, actualVersionsDB, actualJob := fakeInputMapper.SaveNextInputMappingArgsForCall(0)
 								Expect(actualVersionsDB).To(Equal(versionsDB))
 								Expect(actualJob.Name()).To(Equal(job.Name()))
 							})
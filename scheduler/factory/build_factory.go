@@ -120,6 +120,7 @@ func (factory *buildFactory) constructPlanFromConfig(
 		}
 
 		plan = factory.planFactory.NewPlan(retryStep)
+		plan.RetryPolicy = planConfig.RetryPolicy
 	}
 
 	return factory.applyHooks(constructionParams{
@@ -240,6 +241,13 @@ func (factory *buildFactory) constructUnhookedPlan(
 
 			VersionedResourceTypes: resourceTypes,
 		})
+	case planConfig.SetPipeline != "":
+		plan = factory.planFactory.NewPlan(atc.SetPipelinePlan{
+			Name:     planConfig.SetPipeline,
+			File:     planConfig.TaskConfigPath,
+			Vars:     planConfig.Vars,
+			VarFiles: planConfig.VarFiles,
+		})
 	case planConfig.Try != nil:
 		nextStep, err := factory.constructPlanFromConfig(
 			*planConfig.Try,
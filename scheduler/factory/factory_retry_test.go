@@ -66,6 +66,40 @@ var _ = Describe("Factory Retry Step", func() {
 		})
 	})
 
+	Context("when there is a task annotated with 'attempts' and 'retry_policy'", func() {
+		It("builds correctly", func() {
+			policy := &atc.RetryPolicy{
+				Backoff: atc.BackoffExponential,
+				Delay:   "10s",
+			}
+
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:        "second task",
+						Attempts:    2,
+						RetryPolicy: policy,
+					},
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.RetryPlan{
+				expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name: "second task",
+					VersionedResourceTypes: resourceTypes,
+				}),
+				expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name: "second task",
+					VersionedResourceTypes: resourceTypes,
+				}),
+			})
+			expected.RetryPolicy = policy
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
 	Context("when there is a task annotated with 'attempts' and 'on_success'", func() {
 		It("builds correctly", func() {
 			actual, err := buildFactory.Create(atc.JobConfig{
@@ -0,0 +1,71 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory SetPipeline", func() {
+	Describe("SetPipelinePlan", func() {
+		var (
+			buildFactory factory.BuildFactory
+
+			resources           atc.ResourceConfigs
+			resourceTypes       atc.VersionedResourceTypes
+			input               atc.JobConfig
+			actualPlanFactory   atc.PlanFactory
+			expectedPlanFactory atc.PlanFactory
+		)
+
+		BeforeEach(func() {
+			actualPlanFactory = atc.NewPlanFactory(123)
+			expectedPlanFactory = atc.NewPlanFactory(123)
+			buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+			resources = atc.ResourceConfigs{
+				{
+					Name:   "some-resource",
+					Type:   "git",
+					Source: atc.Source{"uri": "git://some-resource"},
+				},
+			}
+
+			resourceTypes = atc.VersionedResourceTypes{}
+		})
+
+		Context("with a set_pipeline at the top-level", func() {
+			BeforeEach(func() {
+				input = atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							SetPipeline:    "some-pipeline",
+							TaskConfigPath: "some-resource/pipeline.yml",
+							Vars: map[string]interface{}{
+								"some": "var",
+							},
+							VarFiles: []string{"some-resource/vars.yml"},
+						},
+					},
+				}
+			})
+
+			It("returns the correct plan", func() {
+				actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.SetPipelinePlan{
+					Name: "some-pipeline",
+					File: "some-resource/pipeline.yml",
+					Vars: map[string]interface{}{
+						"some": "var",
+					},
+					VarFiles: []string{"some-resource/vars.yml"},
+				})
+				Expect(actual).To(testhelpers.MatchPlan(expected))
+			})
+		})
+	})
+})
@@ -42,17 +42,39 @@ func (i *transformer) TransformInputConfigs(db *algorithm.VersionsDB, jobName st
 			pinnedVersionID = savedVersion.ID
 		}
 
+		var versionFilter algorithm.VersionFilter
+		if input.Version.IsFiltered() {
+			filter, err := input.Version.Filter()
+			if err != nil {
+				return nil, err
+			}
+
+			versionFilter = func(fields map[string]string) bool {
+				return filter.Allows(atc.Version(fields))
+			}
+		}
+
+		// jobs in other pipelines are not validated, so they may not exist
 		jobs := algorithm.JobSet{}
+		var unknownJobs []string
 		for _, passedJobName := range input.Passed {
-			jobs[db.JobIDs[passedJobName]] = struct{}{}
+			jobID, found := db.JobIDs[passedJobName]
+			if !found {
+				unknownJobs = append(unknownJobs, passedJobName)
+				continue
+			}
+
+			jobs[jobID] = struct{}{}
 		}
 
 		inputConfigs = append(inputConfigs, algorithm.InputConfig{
 			Name:            input.Name,
 			UseEveryVersion: input.Version.Every,
 			PinnedVersionID: pinnedVersionID,
+			VersionFilter:   versionFilter,
 			ResourceID:      db.ResourceIDs[input.Resource],
 			Passed:          jobs,
+			UnknownPassed:   unknownJobs,
 			JobID:           db.JobIDs[jobName],
 		})
 	}
@@ -107,6 +107,40 @@ var _ = Describe("Transformer", func() {
 				})
 			})
 
+			Context("when an input has a version filter", func() {
+				BeforeEach(func() {
+					jobInputs = []atc.JobInput{{
+						Name:     "job-input-1",
+						Resource: "r1",
+						Version: &atc.VersionConfig{
+							Matches: map[string]string{"tag": "^v"},
+							Semver:  map[string]string{"tag": ">=1.2 <2"},
+						},
+					}}
+				})
+
+				It("filters versions by their fields", func() {
+					Expect(tranformErr).ToNot(HaveOccurred())
+					Expect(algorithmInputs).To(HaveLen(1))
+
+					filter := algorithmInputs[0].VersionFilter
+					Expect(filter).ToNot(BeNil())
+					Expect(filter(map[string]string{"tag": "v1.2.0"})).To(BeTrue())
+					Expect(filter(map[string]string{"tag": "1.2.0"})).To(BeFalse())
+					Expect(filter(map[string]string{"tag": "v2.0.0"})).To(BeFalse())
+				})
+
+				Context("when the filter is invalid", func() {
+					BeforeEach(func() {
+						jobInputs[0].Version.Matches = map[string]string{"tag": "(("}
+					})
+
+					It("returns an error", func() {
+						Expect(tranformErr).To(HaveOccurred())
+					})
+				})
+			})
+
 			Context("when an input has a pinned version", func() {
 				BeforeEach(func() {
 					jobInputs = []atc.JobInput{
@@ -190,7 +224,7 @@ var _ = Describe("Transformer", func() {
 		})
 
 		Context("when an input has things that don't exist", func() {
-			It("at least doesn't panic", func() {
+			It("records the jobs which don't exist", func() {
 				algorithmInputs, transformErr := transformer.TransformInputConfigs(
 					&algorithm.VersionsDB{},
 					"no",
@@ -207,7 +241,8 @@ var _ = Describe("Transformer", func() {
 					UseEveryVersion: false,
 					PinnedVersionID: 0,
 					ResourceID:      0,
-					Passed:          algorithm.JobSet{0: struct{}{}},
+					Passed:          algorithm.JobSet{},
+					UnknownPassed:   []string{"nope", "gone"},
 					JobID:           0,
 				}))
 			})
@@ -1,16 +1,21 @@
 package inputmapper
 
 import (
+	"context"
+	"encoding/json"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/scheduler/inputmapper/inputconfig"
+	"github.com/concourse/atc/tracing"
 )
 
 //go:generate counterfeiter . InputMapper
 
 type InputMapper interface {
 	SaveNextInputMapping(
+		ctx context.Context,
 		logger lager.Logger,
 		versions *algorithm.VersionsDB,
 		job db.Job,
@@ -27,12 +32,18 @@ type inputMapper struct {
 }
 
 func (i *inputMapper) SaveNextInputMapping(
+	ctx context.Context,
 	logger lager.Logger,
 	versions *algorithm.VersionsDB,
 	job db.Job,
 ) (algorithm.InputMapping, error) {
 	logger = logger.Session("save-next-input-mapping")
 
+	ctx, span := tracing.StartSpan(ctx, "inputmapper.SaveNextInputMapping", tracing.Attrs{
+		"job": job.Name(),
+	})
+	defer span.End()
+
 	inputConfigs := job.Config().Inputs()
 
 	algorithmInputConfigs, err := i.transformer.TransformInputConfigs(versions, job.Name(), inputConfigs)
@@ -41,11 +52,35 @@ func (i *inputMapper) SaveNextInputMapping(
 		return nil, err
 	}
 
+	missingInputReasons := db.MissingInputReasons{}
+
+	for _, input := range inputConfigs {
+		transformed := false
+		for _, inputConfig := range algorithmInputConfigs {
+			if inputConfig.Name == input.Name {
+				transformed = true
+				break
+			}
+		}
+
+		// inputs are left out when their pinned version cannot be found
+		if !transformed && input.Version != nil && input.Version.Pinned != nil {
+			versionJSON, err := json.Marshal(input.Version.Pinned)
+			if err != nil {
+				return nil, err
+			}
+
+			missingInputReasons.RegisterPinnedVersionUnavailable(input.Name, string(versionJSON))
+		}
+	}
+
 	independentMapping := algorithm.InputMapping{}
 	for _, inputConfig := range algorithmInputConfigs {
-		singletonMapping, ok := algorithm.InputConfigs{inputConfig}.Resolve(versions)
+		singletonMapping, failures, ok := algorithm.InputConfigs{inputConfig}.Resolve(ctx, versions)
 		if ok {
 			independentMapping[inputConfig.Name] = singletonMapping[inputConfig.Name]
+		} else {
+			missingInputReasons.RegisterResolutionFailures(failures)
 		}
 	}
 
@@ -56,6 +91,8 @@ func (i *inputMapper) SaveNextInputMapping(
 	}
 
 	if len(independentMapping) < len(inputConfigs) {
+		i.saveMissingInputReasons(logger, job, missingInputReasons)
+
 		// this is necessary to prevent builds from running with missing pinned versions
 		err := job.DeleteNextInputMapping()
 		if err != nil {
@@ -65,8 +102,11 @@ func (i *inputMapper) SaveNextInputMapping(
 		return nil, err
 	}
 
-	resolvedMapping, ok := algorithmInputConfigs.Resolve(versions)
+	resolvedMapping, failures, ok := algorithmInputConfigs.Resolve(ctx, versions)
 	if !ok {
+		missingInputReasons.RegisterResolutionFailures(failures)
+		i.saveMissingInputReasons(logger, job, missingInputReasons)
+
 		err := job.DeleteNextInputMapping()
 		if err != nil {
 			logger.Error("failed-to-delete-next-input-mapping-after-failed-resolve", err)
@@ -75,6 +115,8 @@ func (i *inputMapper) SaveNextInputMapping(
 		return nil, err
 	}
 
+	i.saveMissingInputReasons(logger, job, missingInputReasons)
+
 	err = job.SaveNextInputMapping(resolvedMapping)
 	if err != nil {
 		logger.Error("failed-to-save-next-input-mapping", err)
@@ -83,3 +125,12 @@ func (i *inputMapper) SaveNextInputMapping(
 
 	return resolvedMapping, nil
 }
+
+// saveMissingInputReasons only logs failures, as the reasons are informational
+// and should not prevent the job from being scheduled.
+func (i *inputMapper) saveMissingInputReasons(logger lager.Logger, job db.Job, reasons db.MissingInputReasons) {
+	err := job.SaveMissingInputReasons(reasons)
+	if err != nil {
+		logger.Error("failed-to-save-missing-input-reasons", err)
+	}
+}
@@ -1,10 +1,12 @@
 package inputmapper_test
 
 import (
+	"context"
 	"errors"
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/scheduler/inputmapper"
@@ -66,6 +68,7 @@ var _ = Describe("Inputmapper", func() {
 
 		JustBeforeEach(func() {
 			inputMapping, mappingErr = inputMapper.SaveNextInputMapping(
+				context.Background(),
 				lagertest.NewTestLogger("test"),
 				versionsDB,
 				fakeJob,
@@ -190,6 +193,11 @@ var _ = Describe("Inputmapper", func() {
 						It("didn't delete the mapping", func() {
 							Expect(fakeJob.DeleteNextInputMappingCallCount()).To(BeZero())
 						})
+
+						It("clears the missing input reasons", func() {
+							Expect(fakeJob.SaveMissingInputReasonsCallCount()).To(Equal(1))
+							Expect(fakeJob.SaveMissingInputReasonsArgsForCall(0)).To(BeEmpty())
+						})
 					})
 				})
 			})
@@ -251,6 +259,14 @@ var _ = Describe("Inputmapper", func() {
 					Expect(fakeJob.SaveNextInputMappingCallCount()).To(BeZero())
 				})
 
+				It("saves why the inputs don't resolve together", func() {
+					Expect(fakeJob.SaveMissingInputReasonsCallCount()).To(Equal(1))
+					Expect(fakeJob.SaveMissingInputReasonsArgsForCall(0)).To(Equal(db.MissingInputReasons{
+						"a": string(algorithm.NoCompatibleVersions),
+						"b": string(algorithm.NoCompatibleVersions),
+					}))
+				})
+
 				It("returns an empty mapping and no error", func() {
 					Expect(mappingErr).NotTo(HaveOccurred())
 					Expect(inputMapping).To(BeEmpty())
@@ -294,6 +310,13 @@ var _ = Describe("Inputmapper", func() {
 				}))
 			})
 
+			It("saves why they don't resolve", func() {
+				Expect(fakeJob.SaveMissingInputReasonsCallCount()).To(Equal(1))
+				Expect(fakeJob.SaveMissingInputReasonsArgsForCall(0)).To(Equal(db.MissingInputReasons{
+					"no-versions": string(algorithm.NoVersions),
+				}))
+			})
+
 			It("deleted the next input mapping", func() {
 				Expect(fakeJob.DeleteNextInputMappingCallCount()).To(Equal(1))
 				Expect(fakeJob.SaveNextInputMappingCallCount()).To(BeZero())
@@ -335,6 +358,13 @@ var _ = Describe("Inputmapper", func() {
 				}))
 			})
 
+			It("saves that the pinned version is unavailable", func() {
+				Expect(fakeJob.SaveMissingInputReasonsCallCount()).To(Equal(1))
+				Expect(fakeJob.SaveMissingInputReasonsArgsForCall(0)).To(Equal(db.MissingInputReasons{
+					"a": `pinned version {"doesn't":"exist"} is not available`,
+				}))
+			})
+
 			It("deleted the next input mapping", func() {
 				Expect(fakeJob.DeleteNextInputMappingCallCount()).To(Equal(1))
 				Expect(fakeJob.SaveNextInputMappingCallCount()).To(BeZero())
@@ -2,6 +2,7 @@
 package inputmapperfakes
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/lager"
@@ -11,9 +12,10 @@ import (
 )
 
 type FakeInputMapper struct {
-	SaveNextInputMappingStub        func(logger lager.Logger, versions *algorithm.VersionsDB, job db.Job) (algorithm.InputMapping, error)
+	SaveNextInputMappingStub        func(ctx context.Context, logger lager.Logger, versions *algorithm.VersionsDB, job db.Job) (algorithm.InputMapping, error)
 	saveNextInputMappingMutex       sync.RWMutex
 	saveNextInputMappingArgsForCall []struct {
+		ctx      context.Context
 		logger   lager.Logger
 		versions *algorithm.VersionsDB
 		job      db.Job
@@ -30,18 +32,19 @@ type FakeInputMapper struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeInputMapper) SaveNextInputMapping(logger lager.Logger, versions *algorithm.VersionsDB, job db.Job) (algorithm.InputMapping, error) {
+func (fake *FakeInputMapper) SaveNextInputMapping(ctx context.Context, logger lager.Logger, versions *algorithm.VersionsDB, job db.Job) (algorithm.InputMapping, error) {
 	fake.saveNextInputMappingMutex.Lock()
 	ret, specificReturn := fake.saveNextInputMappingReturnsOnCall[len(fake.saveNextInputMappingArgsForCall)]
 	fake.saveNextInputMappingArgsForCall = append(fake.saveNextInputMappingArgsForCall, struct {
+		ctx      context.Context
 		logger   lager.Logger
 		versions *algorithm.VersionsDB
 		job      db.Job
-	}{logger, versions, job})
-	fake.recordInvocation("SaveNextInputMapping", []interface{}{logger, versions, job})
+	}{ctx, logger, versions, job})
+	fake.recordInvocation("SaveNextInputMapping", []interface{}{ctx, logger, versions, job})
 	fake.saveNextInputMappingMutex.Unlock()
 	if fake.SaveNextInputMappingStub != nil {
-		return fake.SaveNextInputMappingStub(logger, versions, job)
+		return fake.SaveNextInputMappingStub(ctx, logger, versions, job)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -55,10 +58,10 @@ func (fake *FakeInputMapper) SaveNextInputMappingCallCount() int {
 	return len(fake.saveNextInputMappingArgsForCall)
 }
 
-func (fake *FakeInputMapper) SaveNextInputMappingArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, db.Job) {
+func (fake *FakeInputMapper) SaveNextInputMappingArgsForCall(i int) (context.Context, lager.Logger, *algorithm.VersionsDB, db.Job) {
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
-	return fake.saveNextInputMappingArgsForCall[i].logger, fake.saveNextInputMappingArgsForCall[i].versions, fake.saveNextInputMappingArgsForCall[i].job
+	return fake.saveNextInputMappingArgsForCall[i].ctx, fake.saveNextInputMappingArgsForCall[i].logger, fake.saveNextInputMappingArgsForCall[i].versions, fake.saveNextInputMappingArgsForCall[i].job
 }
 
 func (fake *FakeInputMapper) SaveNextInputMappingReturns(result1 algorithm.InputMapping, result2 error) {
@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"context"
 	"errors"
 	"os"
 	"time"
@@ -10,12 +11,14 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/tracing"
 )
 
 //go:generate counterfeiter . BuildScheduler
 
 type BuildScheduler interface {
 	Schedule(
+		ctx context.Context,
 		logger lager.Logger,
 		versions *algorithm.VersionsDB,
 		jobs []db.Job,
@@ -30,7 +33,7 @@ type BuildScheduler interface {
 		resourceTypes atc.VersionedResourceTypes,
 	) (db.Build, Waiter, error)
 
-	SaveNextInputMapping(logger lager.Logger, job db.Job) error
+	SaveNextInputMapping(ctx context.Context, logger lager.Logger, job db.Job) error
 }
 
 var errPipelineRemoved = errors.New("pipeline removed")
@@ -90,6 +93,12 @@ func (runner *Runner) tick(logger lager.Logger) error {
 
 	defer schedulingLock.Release()
 
+	ctx, span := tracing.StartSpan(context.Background(), "scheduler.Tick", tracing.Attrs{
+		"team":     runner.Pipeline.TeamName(),
+		"pipeline": runner.Pipeline.Name(),
+	})
+	defer span.End()
+
 	start := time.Now()
 
 	defer func() {
@@ -141,6 +150,7 @@ func (runner *Runner) tick(logger lager.Logger) error {
 	sLog := logger.Session("scheduling")
 
 	schedulingTimes, err := runner.Scheduler.Schedule(
+		ctx,
 		sLog,
 		versions,
 		jobs,
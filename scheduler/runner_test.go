@@ -196,7 +196,7 @@ var _ = Describe("Runner", func() {
 	It("schedules pending builds", func() {
 		Eventually(scheduler.ScheduleCallCount).Should(Equal(2))
 
-		_, versions, jobs, resources, resourceTypes := scheduler.ScheduleArgsForCall(0)
+		_, _, versions, jobs, resources, resourceTypes := scheduler.ScheduleArgsForCall(0)
 		Expect(versions).To(Equal(someVersions))
 		Expect(jobs).To(Equal([]db.Job{fakeJob1, fakeJob2}))
 		Expect(resources).To(Equal(db.Resources{fakeResource1, fakeResource2}))
@@ -1,14 +1,17 @@
 package scheduler
 
 import (
+	"context"
 	"sync"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/scheduler/inputmapper"
+	"github.com/concourse/atc/tracing"
 )
 
 type Scheduler struct {
@@ -16,6 +19,7 @@ type Scheduler struct {
 	InputMapper  inputmapper.InputMapper
 	BuildStarter BuildStarter
 	Scanner      Scanner
+	Clock        clock.Clock
 }
 
 //go:generate counterfeiter . Scanner
@@ -25,6 +29,26 @@ type Scanner interface {
 }
 
 func (s *Scheduler) Schedule(
+	ctx context.Context,
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	jobs []db.Job,
+	resources db.Resources,
+	resourceTypes atc.VersionedResourceTypes,
+) (map[string]time.Duration, error) {
+	ctx, span := tracing.StartSpan(ctx, "scheduler.Schedule", tracing.Attrs{
+		"team":     s.Pipeline.TeamName(),
+		"pipeline": s.Pipeline.Name(),
+	})
+
+	jobSchedulingTime, err := s.schedule(ctx, logger, versions, jobs, resources, resourceTypes)
+	tracing.End(span, err)
+
+	return jobSchedulingTime, err
+}
+
+func (s *Scheduler) schedule(
+	ctx context.Context,
 	logger lager.Logger,
 	versions *algorithm.VersionsDB,
 	jobs []db.Job,
@@ -34,10 +58,21 @@ func (s *Scheduler) Schedule(
 	jobSchedulingTime := map[string]time.Duration{}
 
 	for _, job := range jobs {
+		jobCtx, span := tracing.StartSpan(ctx, "scheduler.ensurePendingBuildExists", tracing.Attrs{
+			"job": job.Name(),
+		})
+
 		jStart := time.Now()
-		err := s.ensurePendingBuildExists(logger, versions, job)
+		err := s.ensurePendingBuildExists(jobCtx, logger, versions, job)
 		jobSchedulingTime[job.Name()] = time.Since(jStart)
 
+		tracing.End(span, err)
+
+		if err != nil {
+			return jobSchedulingTime, err
+		}
+
+		err = s.evaluateTriggers(logger, job)
 		if err != nil {
 			return jobSchedulingTime, err
 		}
@@ -56,9 +91,15 @@ func (s *Scheduler) Schedule(
 			continue
 		}
 
-		err := s.BuildStarter.TryStartPendingBuildsForJob(logger, job, resources, resourceTypes, nextPendingBuildsForJob)
+		jobCtx, span := tracing.StartSpan(ctx, "scheduler.TryStartPendingBuildsForJob", tracing.Attrs{
+			"job": job.Name(),
+		})
+
+		err := s.BuildStarter.TryStartPendingBuildsForJob(jobCtx, logger, job, resources, resourceTypes, nextPendingBuildsForJob)
 		jobSchedulingTime[job.Name()] = jobSchedulingTime[job.Name()] + time.Since(jStart)
 
+		tracing.End(span, err)
+
 		if err != nil {
 			return jobSchedulingTime, err
 		}
@@ -68,11 +109,12 @@ func (s *Scheduler) Schedule(
 }
 
 func (s *Scheduler) ensurePendingBuildExists(
+	ctx context.Context,
 	logger lager.Logger,
 	versions *algorithm.VersionsDB,
 	job db.Job,
 ) error {
-	inputMapping, err := s.InputMapper.SaveNextInputMapping(logger, versions, job)
+	inputMapping, err := s.InputMapper.SaveNextInputMapping(ctx, logger, versions, job)
 	if err != nil {
 		return err
 	}
@@ -95,6 +137,68 @@ func (s *Scheduler) ensurePendingBuildExists(
 	return nil
 }
 
+// evaluateTriggers ensures a pending build exists for the job when one of its
+// triggers has fired since they were last evaluated. Missed firings, e.g.
+// while the pipeline was paused, result in a single build.
+func (s *Scheduler) evaluateTriggers(logger lager.Logger, job db.Job) error {
+	triggers := job.Config().Triggers
+	evaluatedAt := job.TriggersEvaluatedAt()
+
+	if len(triggers) == 0 {
+		if evaluatedAt.IsZero() {
+			return nil
+		}
+
+		// forget about the triggers so that ones configured later don't fire
+		// for the time in between
+		_, err := job.SaveTriggersEvaluated(time.Time{}, nil)
+		if err != nil {
+			logger.Error("failed-to-clear-triggers-evaluated", err)
+			return err
+		}
+
+		return nil
+	}
+
+	now := s.Clock.Now()
+
+	// newly configured triggers only fire from now on
+	if evaluatedAt.IsZero() {
+		_, err := job.SaveTriggersEvaluated(now, nil)
+		if err != nil {
+			logger.Error("failed-to-save-triggers-evaluated", err)
+			return err
+		}
+
+		return nil
+	}
+
+	trigger, scheduledTime, found := triggers.Next(evaluatedAt)
+	if !found || scheduledTime.After(now) {
+		return nil
+	}
+
+	saved, err := job.SaveTriggersEvaluated(now, &atc.TriggerCause{
+		Cron:          trigger.Cron,
+		Timezone:      trigger.Timezone,
+		ScheduledTime: scheduledTime.Unix(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-triggers-evaluated", err)
+		return err
+	}
+
+	if saved {
+		logger.Info("triggered", lager.Data{
+			"job":            job.Name(),
+			"cron":           trigger.Cron,
+			"scheduled-time": scheduledTime,
+		})
+	}
+
+	return nil
+}
+
 type Waiter interface {
 	Wait()
 }
@@ -124,7 +228,14 @@ func (s *Scheduler) TriggerImmediately(
 			return
 		}
 
-		err = s.BuildStarter.TryStartPendingBuildsForJob(logger, job, resources, resourceTypes, nextPendingBuilds)
+		ctx, span := tracing.StartSpan(context.Background(), "scheduler.TriggerImmediately", tracing.Attrs{
+			"team":     job.TeamName(),
+			"pipeline": job.PipelineName(),
+			"job":      job.Name(),
+		})
+
+		err = s.BuildStarter.TryStartPendingBuildsForJob(ctx, logger, job, resources, resourceTypes, nextPendingBuilds)
+		tracing.End(span, err)
 		if err != nil {
 			logger.Error("failed-to-start-next-pending-build-for-job", err, lager.Data{"job-name": job.Name()})
 			return
@@ -134,13 +245,13 @@ func (s *Scheduler) TriggerImmediately(
 	return build, wg, nil
 }
 
-func (s *Scheduler) SaveNextInputMapping(logger lager.Logger, job db.Job) error {
+func (s *Scheduler) SaveNextInputMapping(ctx context.Context, logger lager.Logger, job db.Job) error {
 	versions, err := s.Pipeline.LoadVersionsDB()
 	if err != nil {
 		logger.Error("failed-to-load-versions-db", err)
 		return err
 	}
 
-	_, err = s.InputMapper.SaveNextInputMapping(logger, versions, job)
+	_, err = s.InputMapper.SaveNextInputMapping(ctx, logger, versions, job)
 	return err
 }
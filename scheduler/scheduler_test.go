@@ -1,8 +1,11 @@
 package scheduler_test
 
 import (
+	"context"
 	"errors"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -21,6 +24,7 @@ var _ = Describe("Scheduler", func() {
 		fakeInputMapper  *inputmapperfakes.FakeInputMapper
 		fakeBuildStarter *schedulerfakes.FakeBuildStarter
 		fakeScanner      *schedulerfakes.FakeScanner
+		fakeClock        *fakeclock.FakeClock
 
 		scheduler *Scheduler
 
@@ -32,12 +36,14 @@ var _ = Describe("Scheduler", func() {
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
 		fakeBuildStarter = new(schedulerfakes.FakeBuildStarter)
 		fakeScanner = new(schedulerfakes.FakeScanner)
+		fakeClock = fakeclock.NewFakeClock(time.Date(2018, 7, 1, 2, 0, 30, 0, time.UTC))
 
 		scheduler = &Scheduler{
 			Pipeline:     fakePipeline,
 			InputMapper:  fakeInputMapper,
 			BuildStarter: fakeBuildStarter,
 			Scanner:      fakeScanner,
+			Clock:        fakeClock,
 		}
 
 		disaster = errors.New("bad thing")
@@ -83,6 +89,7 @@ var _ = Describe("Scheduler", func() {
 
 			var waiter Waiter
 			_, scheduleErr = scheduler.Schedule(
+				context.Background(),
 				lagertest.NewTestLogger("test"),
 				versionsDB,
 				fakeJobs,
@@ -122,11 +129,11 @@ var _ = Describe("Scheduler", func() {
 
 				It("saved the next input mapping for the right job and versions", func() {
 					Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(Equal(2))
-					_, actualVersionsDB, actualJob := fakeInputMapper.SaveNextInputMappingArgsForCall(0)
+					_, _, actualVersionsDB, actualJob := fakeInputMapper.SaveNextInputMappingArgsForCall(0)
 					Expect(actualVersionsDB).To(Equal(versionsDB))
 					Expect(actualJob.Name()).To(Equal(fakeJob.Name()))
 
-					_, actualVersionsDB, actualJob = fakeInputMapper.SaveNextInputMappingArgsForCall(1)
+					_, _, actualVersionsDB, actualJob = fakeInputMapper.SaveNextInputMappingArgsForCall(1)
 					Expect(actualVersionsDB).To(Equal(versionsDB))
 					Expect(actualJob.Name()).To(Equal(fakeJob2.Name()))
 				})
@@ -142,7 +149,7 @@ var _ = Describe("Scheduler", func() {
 
 					It("started all pending builds for the right job", func() {
 						Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
-						_, actualJob, actualResources, actualResourceTypes, actualPendingBuilds := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
+						_, _, actualJob, actualResources, actualResourceTypes, actualPendingBuilds := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
 						Expect(actualJob.Name()).To(Equal(fakeJob.Name()))
 						Expect(actualResources).To(Equal(db.Resources{fakeResource}))
 						Expect(actualResourceTypes).To(Equal(versionedResourceTypes))
@@ -251,6 +258,98 @@ var _ = Describe("Scheduler", func() {
 				})
 			})
 		})
+
+		Context("when the job has triggers", func() {
+			BeforeEach(func() {
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.NameReturns("some-job")
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Triggers: atc.TriggerConfigs{
+						{Cron: "0 2 * * *"},
+						{Cron: "0 12 * * *", Timezone: "Europe/London"},
+					},
+				})
+
+				fakeJobs = []db.Job{fakeJob}
+
+				fakeInputMapper.SaveNextInputMappingReturns(algorithm.InputMapping{}, nil)
+				fakeJob.SaveTriggersEvaluatedReturns(true, nil)
+			})
+
+			Context("when the triggers have not been evaluated", func() {
+				It("records that they were evaluated now without firing", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeJob.SaveTriggersEvaluatedCallCount()).To(Equal(1))
+
+					evaluatedAt, cause := fakeJob.SaveTriggersEvaluatedArgsForCall(0)
+					Expect(evaluatedAt).To(Equal(fakeClock.Now()))
+					Expect(cause).To(BeNil())
+				})
+			})
+
+			Context("when a trigger has fired since they were evaluated", func() {
+				BeforeEach(func() {
+					fakeJob.TriggersEvaluatedAtReturns(time.Date(2018, 7, 1, 1, 59, 50, 0, time.UTC))
+				})
+
+				It("records the evaluation along with the trigger", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeJob.SaveTriggersEvaluatedCallCount()).To(Equal(1))
+
+					evaluatedAt, cause := fakeJob.SaveTriggersEvaluatedArgsForCall(0)
+					Expect(evaluatedAt).To(Equal(fakeClock.Now()))
+					Expect(cause).To(Equal(&atc.TriggerCause{
+						Cron:          "0 2 * * *",
+						ScheduledTime: time.Date(2018, 7, 1, 2, 0, 0, 0, time.UTC).Unix(),
+					}))
+				})
+
+				It("starts all pending builds", func() {
+					Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
+				})
+
+				Context("when recording the evaluation fails", func() {
+					BeforeEach(func() {
+						fakeJob.SaveTriggersEvaluatedReturns(false, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(scheduleErr).To(Equal(disaster))
+					})
+
+					It("does not start pending builds", func() {
+						Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when no trigger has fired since they were evaluated", func() {
+				BeforeEach(func() {
+					fakeJob.TriggersEvaluatedAtReturns(time.Date(2018, 7, 1, 2, 0, 10, 0, time.UTC))
+				})
+
+				It("does not record anything", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeJob.SaveTriggersEvaluatedCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the triggers have been removed since they were evaluated", func() {
+				BeforeEach(func() {
+					fakeJob.ConfigReturns(atc.JobConfig{})
+					fakeJob.TriggersEvaluatedAtReturns(time.Date(2018, 7, 1, 1, 0, 0, 0, time.UTC))
+				})
+
+				It("clears the evaluation", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeJob.SaveTriggersEvaluatedCallCount()).To(Equal(1))
+
+					evaluatedAt, cause := fakeJob.SaveTriggersEvaluatedArgsForCall(0)
+					Expect(evaluatedAt.IsZero()).To(BeTrue())
+					Expect(cause).To(BeNil())
+				})
+			})
+		})
 	})
 
 	Describe("TriggerImmediately", func() {
@@ -339,7 +438,7 @@ var _ = Describe("Scheduler", func() {
 
 					It("tries to start builds for the right job", func() {
 						Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
-						_, _, _, _, b := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
+						_, _, _, _, _, b := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
 						Expect(b).To(Equal(nextPendingBuilds))
 					})
 				})
@@ -355,7 +454,7 @@ var _ = Describe("Scheduler", func() {
 			fakeJob = new(dbfakes.FakeJob)
 			fakeJob.NameReturns("some-job")
 
-			saveErr = scheduler.SaveNextInputMapping(lagertest.NewTestLogger("test"), fakeJob)
+			saveErr = scheduler.SaveNextInputMapping(context.Background(), lagertest.NewTestLogger("test"), fakeJob)
 		})
 
 		Context("when loading the versions DB fails", func() {
@@ -387,7 +486,7 @@ var _ = Describe("Scheduler", func() {
 
 				It("saved the next input mapping for the right job and versions", func() {
 					Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(Equal(1))
-					_, actualVersionsDB, actualJob := fakeInputMapper.SaveNextInputMappingArgsForCall(0)
+					_, _, actualVersionsDB, actualJob := fakeInputMapper.SaveNextInputMappingArgsForCall(0)
 					Expect(actualVersionsDB).To(Equal(versionsDB))
 					Expect(actualJob.Name()).To(Equal(fakeJob.Name()))
 				})
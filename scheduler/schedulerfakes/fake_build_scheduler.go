@@ -2,6 +2,7 @@
 package schedulerfakes
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -13,9 +14,10 @@ import (
 )
 
 type FakeBuildScheduler struct {
-	ScheduleStub        func(logger lager.Logger, versions *algorithm.VersionsDB, jobs []db.Job, resources db.Resources, resourceTypes atc.VersionedResourceTypes) (map[string]time.Duration, error)
+	ScheduleStub        func(ctx context.Context, logger lager.Logger, versions *algorithm.VersionsDB, jobs []db.Job, resources db.Resources, resourceTypes atc.VersionedResourceTypes) (map[string]time.Duration, error)
 	scheduleMutex       sync.RWMutex
 	scheduleArgsForCall []struct {
+		ctx           context.Context
 		logger        lager.Logger
 		versions      *algorithm.VersionsDB
 		jobs          []db.Job
@@ -48,9 +50,10 @@ type FakeBuildScheduler struct {
 		result2 scheduler.Waiter
 		result3 error
 	}
-	SaveNextInputMappingStub        func(logger lager.Logger, job db.Job) error
+	SaveNextInputMappingStub        func(ctx context.Context, logger lager.Logger, job db.Job) error
 	saveNextInputMappingMutex       sync.RWMutex
 	saveNextInputMappingArgsForCall []struct {
+		ctx    context.Context
 		logger lager.Logger
 		job    db.Job
 	}
@@ -64,7 +67,7 @@ type FakeBuildScheduler struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeBuildScheduler) Schedule(logger lager.Logger, versions *algorithm.VersionsDB, jobs []db.Job, resources db.Resources, resourceTypes atc.VersionedResourceTypes) (map[string]time.Duration, error) {
+func (fake *FakeBuildScheduler) Schedule(ctx context.Context, logger lager.Logger, versions *algorithm.VersionsDB, jobs []db.Job, resources db.Resources, resourceTypes atc.VersionedResourceTypes) (map[string]time.Duration, error) {
 	var jobsCopy []db.Job
 	if jobs != nil {
 		jobsCopy = make([]db.Job, len(jobs))
@@ -73,16 +76,17 @@ func (fake *FakeBuildScheduler) Schedule(logger lager.Logger, versions *algorith
 	fake.scheduleMutex.Lock()
 	ret, specificReturn := fake.scheduleReturnsOnCall[len(fake.scheduleArgsForCall)]
 	fake.scheduleArgsForCall = append(fake.scheduleArgsForCall, struct {
+		ctx           context.Context
 		logger        lager.Logger
 		versions      *algorithm.VersionsDB
 		jobs          []db.Job
 		resources     db.Resources
 		resourceTypes atc.VersionedResourceTypes
-	}{logger, versions, jobsCopy, resources, resourceTypes})
-	fake.recordInvocation("Schedule", []interface{}{logger, versions, jobsCopy, resources, resourceTypes})
+	}{ctx, logger, versions, jobsCopy, resources, resourceTypes})
+	fake.recordInvocation("Schedule", []interface{}{ctx, logger, versions, jobsCopy, resources, resourceTypes})
 	fake.scheduleMutex.Unlock()
 	if fake.ScheduleStub != nil {
-		return fake.ScheduleStub(logger, versions, jobs, resources, resourceTypes)
+		return fake.ScheduleStub(ctx, logger, versions, jobs, resources, resourceTypes)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -96,10 +100,10 @@ func (fake *FakeBuildScheduler) ScheduleCallCount() int {
 	return len(fake.scheduleArgsForCall)
 }
 
-func (fake *FakeBuildScheduler) ScheduleArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, []db.Job, db.Resources, atc.VersionedResourceTypes) {
+func (fake *FakeBuildScheduler) ScheduleArgsForCall(i int) (context.Context, lager.Logger, *algorithm.VersionsDB, []db.Job, db.Resources, atc.VersionedResourceTypes) {
 	fake.scheduleMutex.RLock()
 	defer fake.scheduleMutex.RUnlock()
-	return fake.scheduleArgsForCall[i].logger, fake.scheduleArgsForCall[i].versions, fake.scheduleArgsForCall[i].jobs, fake.scheduleArgsForCall[i].resources, fake.scheduleArgsForCall[i].resourceTypes
+	return fake.scheduleArgsForCall[i].ctx, fake.scheduleArgsForCall[i].logger, fake.scheduleArgsForCall[i].versions, fake.scheduleArgsForCall[i].jobs, fake.scheduleArgsForCall[i].resources, fake.scheduleArgsForCall[i].resourceTypes
 }
 
 func (fake *FakeBuildScheduler) ScheduleReturns(result1 map[string]time.Duration, result2 error) {
@@ -181,17 +185,18 @@ func (fake *FakeBuildScheduler) TriggerImmediatelyReturnsOnCall(i int, result1 d
 	}{result1, result2, result3}
 }
 
-func (fake *FakeBuildScheduler) SaveNextInputMapping(logger lager.Logger, job db.Job) error {
+func (fake *FakeBuildScheduler) SaveNextInputMapping(ctx context.Context, logger lager.Logger, job db.Job) error {
 	fake.saveNextInputMappingMutex.Lock()
 	ret, specificReturn := fake.saveNextInputMappingReturnsOnCall[len(fake.saveNextInputMappingArgsForCall)]
 	fake.saveNextInputMappingArgsForCall = append(fake.saveNextInputMappingArgsForCall, struct {
+		ctx    context.Context
 		logger lager.Logger
 		job    db.Job
-	}{logger, job})
-	fake.recordInvocation("SaveNextInputMapping", []interface{}{logger, job})
+	}{ctx, logger, job})
+	fake.recordInvocation("SaveNextInputMapping", []interface{}{ctx, logger, job})
 	fake.saveNextInputMappingMutex.Unlock()
 	if fake.SaveNextInputMappingStub != nil {
-		return fake.SaveNextInputMappingStub(logger, job)
+		return fake.SaveNextInputMappingStub(ctx, logger, job)
 	}
 	if specificReturn {
 		return ret.result1
@@ -205,10 +210,10 @@ func (fake *FakeBuildScheduler) SaveNextInputMappingCallCount() int {
 	return len(fake.saveNextInputMappingArgsForCall)
 }
 
-func (fake *FakeBuildScheduler) SaveNextInputMappingArgsForCall(i int) (lager.Logger, db.Job) {
+func (fake *FakeBuildScheduler) SaveNextInputMappingArgsForCall(i int) (context.Context, lager.Logger, db.Job) {
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
-	return fake.saveNextInputMappingArgsForCall[i].logger, fake.saveNextInputMappingArgsForCall[i].job
+	return fake.saveNextInputMappingArgsForCall[i].ctx, fake.saveNextInputMappingArgsForCall[i].logger, fake.saveNextInputMappingArgsForCall[i].job
 }
 
 func (fake *FakeBuildScheduler) SaveNextInputMappingReturns(result1 error) {
@@ -0,0 +1,238 @@
+package atc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A SemverRange is a set of semantic version constraints, such as
+// `>=1.2 <2`. Space-separated (or comma-separated) comparisons must all be
+// satisfied, and alternatives are separated by `||`, e.g. `<1 || >=1.4`.
+//
+// Versions are parsed leniently so that they can be taken from tags: a
+// leading `v` is ignored, as is build metadata, and a missing minor or patch
+// number is treated as 0.
+//
+// As with npm's ranges, a pre-release version is only within a set of
+// comparisons if one of them is against a pre-release of the same
+// major.minor.patch, so `>=1.2 <2` does not contain `2.0.0-rc.1` but
+// `>=1.2.0-rc.0` contains `1.2.0-rc.1`.
+type SemverRange [][]semverComparison
+
+type semverComparison struct {
+	operator string
+	version  semver
+}
+
+// ParseSemverRange parses a range such as `>=1.2 <2`.
+func ParseSemverRange(str string) (SemverRange, error) {
+	semverRange := SemverRange{}
+
+	for _, alternative := range strings.Split(str, "||") {
+		tokens := strings.Fields(strings.Replace(alternative, ",", " ", -1))
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("empty constraint in '%s'", str)
+		}
+
+		comparisons := []semverComparison{}
+		for i := 0; i < len(tokens); i++ {
+			token := tokens[i]
+
+			// allow whitespace between an operator and its version, e.g. `>= 1.2`
+			if semverOperatorLength(token) == len(token) && i+1 < len(tokens) {
+				i++
+				token += tokens[i]
+			}
+
+			comparison, err := parseSemverComparison(token)
+			if err != nil {
+				return nil, err
+			}
+
+			comparisons = append(comparisons, comparison)
+		}
+
+		semverRange = append(semverRange, comparisons)
+	}
+
+	return semverRange, nil
+}
+
+// Contains returns true if the version is a semantic version within the
+// range. Versions which cannot be parsed are never within it.
+func (r SemverRange) Contains(version string) bool {
+	v, ok := parseSemver(version)
+	if !ok {
+		return false
+	}
+
+	for _, comparisons := range r {
+		if len(v.prerelease) > 0 && !allowsPrerelease(comparisons, v) {
+			continue
+		}
+
+		satisfied := true
+		for _, comparison := range comparisons {
+			if !comparison.satisfiedBy(v) {
+				satisfied = false
+				break
+			}
+		}
+
+		if satisfied {
+			return true
+		}
+	}
+
+	return false
+}
+
+func allowsPrerelease(comparisons []semverComparison, v semver) bool {
+	for _, comparison := range comparisons {
+		if len(comparison.version.prerelease) > 0 && comparison.version.numbers == v.numbers {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseSemverComparison(token string) (semverComparison, error) {
+	operatorLength := semverOperatorLength(token)
+
+	version, ok := parseSemver(token[operatorLength:])
+	if !ok {
+		return semverComparison{}, fmt.Errorf("invalid constraint '%s'", token)
+	}
+
+	return semverComparison{
+		operator: token[:operatorLength],
+		version:  version,
+	}, nil
+}
+
+func semverOperatorLength(token string) int {
+	for _, operator := range []string{">=", "<=", "!=", ">", "<", "="} {
+		if strings.HasPrefix(token, operator) {
+			return len(operator)
+		}
+	}
+
+	return 0
+}
+
+func (comparison semverComparison) satisfiedBy(v semver) bool {
+	cmp := v.compare(comparison.version)
+
+	switch comparison.operator {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	default:
+		return cmp == 0
+	}
+}
+
+type semver struct {
+	numbers    [3]int
+	prerelease []string
+}
+
+func parseSemver(str string) (semver, bool) {
+	str = strings.TrimPrefix(strings.TrimSpace(str), "v")
+
+	if i := strings.Index(str, "+"); i != -1 {
+		str = str[:i]
+	}
+
+	var v semver
+
+	if i := strings.Index(str, "-"); i != -1 {
+		v.prerelease = strings.Split(str[i+1:], ".")
+		str = str[:i]
+
+		for _, identifier := range v.prerelease {
+			if identifier == "" {
+				return semver{}, false
+			}
+		}
+	}
+
+	parts := strings.Split(str, ".")
+	if len(parts) > 3 {
+		return semver{}, false
+	}
+
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return semver{}, false
+		}
+
+		v.numbers[i] = number
+	}
+
+	return v, true
+}
+
+// compare orders versions as in the semver spec: a pre-release is lower than
+// the release it precedes.
+func (v semver) compare(other semver) int {
+	for i := range v.numbers {
+		if v.numbers[i] != other.numbers[i] {
+			return compareInts(v.numbers[i], other.numbers[i])
+		}
+	}
+
+	switch {
+	case len(v.prerelease) == 0 && len(other.prerelease) == 0:
+		return 0
+	case len(v.prerelease) == 0:
+		return 1
+	case len(other.prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.prerelease) && i < len(other.prerelease); i++ {
+		if cmp := comparePrereleaseIdentifiers(v.prerelease[i], other.prerelease[i]); cmp != 0 {
+			return cmp
+		}
+	}
+
+	return compareInts(len(v.prerelease), len(other.prerelease))
+}
+
+func comparePrereleaseIdentifiers(a string, b string) int {
+	aNumber, aErr := strconv.Atoi(a)
+	bNumber, bErr := strconv.Atoi(b)
+
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareInts(aNumber, bNumber)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func compareInts(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
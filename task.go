@@ -34,6 +34,43 @@ type TaskConfig struct {
 
 	// Path to cached directory that will be shared between builds for the same task.
 	Caches []CacheConfig `json:"caches,omitempty" yaml:"caches,omitempty" mapstructure:"caches"`
+
+	// Limits to apply to the task's container, overriding the ATC's defaults.
+	Limits ContainerLimits `json:"container_limits,omitempty" yaml:"container_limits,omitempty" mapstructure:"container_limits"`
+}
+
+// MinMemoryLimit is the smallest memory limit, in bytes, that can be given to
+// a container; anything lower prevents most processes from even starting.
+const MinMemoryLimit = 4 * 1024 * 1024
+
+type ContainerLimits struct {
+	// Relative CPU shares given to the container. Zero means unlimited.
+	CPU *uint64 `json:"cpu,omitempty" yaml:"cpu,omitempty" mapstructure:"cpu"`
+
+	// Memory limit in bytes. Zero means unlimited.
+	Memory *uint64 `json:"memory,omitempty" yaml:"memory,omitempty" mapstructure:"memory"`
+}
+
+// Merge returns the limits with any unset values filled in from the given
+// defaults.
+func (limits ContainerLimits) Merge(defaults ContainerLimits) ContainerLimits {
+	if limits.CPU == nil {
+		limits.CPU = defaults.CPU
+	}
+
+	if limits.Memory == nil {
+		limits.Memory = defaults.Memory
+	}
+
+	return limits
+}
+
+func (limits ContainerLimits) Validate() error {
+	if limits.Memory != nil && *limits.Memory != 0 && *limits.Memory < MinMemoryLimit {
+		return fmt.Errorf("memory limit must be at least %d bytes", MinMemoryLimit)
+	}
+
+	return nil
 }
 
 type ImageResource struct {
@@ -116,6 +153,8 @@ func (config TaskConfig) Merge(other TaskConfig) TaskConfig {
 		config.Run = other.Run
 	}
 
+	config.Limits = other.Limits.Merge(config.Limits)
+
 	return config
 }
 
@@ -132,6 +171,10 @@ func (config TaskConfig) Validate() error {
 
 	messages = append(messages, config.validateInputsAndOutputs()...)
 
+	if err := config.Limits.Validate(); err != nil {
+		messages = append(messages, "  invalid 'container_limits': "+err.Error())
+	}
+
 	if len(messages) > 0 {
 		return fmt.Errorf("invalid task configuration:\n%s", strings.Join(messages, "\n"))
 	}
@@ -118,6 +118,24 @@ run: {path: a/file}
 				})
 			})
 
+			Context("given a valid task config with container limits", func() {
+				It("works", func() {
+					data := []byte(`
+platform: beos
+
+container_limits:
+  cpu: 512
+  memory: 1073741824
+
+run: {path: a/file}
+`)
+					task, err := NewTaskConfig(data)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(*task.Limits.CPU).To(Equal(uint64(512)))
+					Expect(*task.Limits.Memory).To(Equal(uint64(1073741824)))
+				})
+			})
+
 			Context("given a valid task config with extra keys", func() {
 				It("returns an error", func() {
 					data := []byte(`
@@ -158,6 +176,28 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the memory limit is too low", func() {
+			BeforeEach(func() {
+				memory := uint64(1024)
+				invalidConfig.Limits.Memory = &memory
+			})
+
+			It("returns an error", func() {
+				Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  invalid 'container_limits': memory limit must be at least 4194304 bytes")))
+			})
+		})
+
+		Context("when the memory limit is zero", func() {
+			BeforeEach(func() {
+				memory := uint64(0)
+				validConfig.Limits.Memory = &memory
+			})
+
+			It("is valid, as it means unlimited", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+		})
+
 		Context("when the task has inputs", func() {
 			BeforeEach(func() {
 				validConfig.Inputs = append(validConfig.Inputs, TaskInputConfig{Name: "concourse"})
@@ -723,5 +763,30 @@ run: {path: a/file}
 				}))
 
 		})
+
+		It("overrides container limits that are set", func() {
+			cpu := uint64(512)
+			memory := uint64(1024 * 1024 * 1024)
+			betterMemory := uint64(2 * 1024 * 1024 * 1024)
+
+			Expect(TaskConfig{
+				Limits: ContainerLimits{
+					CPU:    &cpu,
+					Memory: &memory,
+				},
+			}.Merge(TaskConfig{
+				Limits: ContainerLimits{
+					Memory: &betterMemory,
+				},
+			})).To(
+
+				Equal(TaskConfig{
+					Limits: ContainerLimits{
+						CPU:    &cpu,
+						Memory: &betterMemory,
+					},
+				}))
+
+		})
 	})
 })
@@ -0,0 +1,103 @@
+package taskcache
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+type Config struct {
+	Dir string `long:"dir" description:"Directory in which to store task cache snapshots, e.g. a volume shared between ATCs."`
+
+	S3Bucket          string `long:"s3-bucket"            description:"S3 bucket in which to store task cache snapshots."`
+	S3Prefix          string `long:"s3-prefix"            description:"Prefix to prepend to the keys of snapshots stored in the S3 bucket."`
+	S3Region          string `long:"s3-region"            description:"AWS region of the S3 bucket."`
+	S3Endpoint        string `long:"s3-endpoint"          description:"URL of an S3-compatible API to use instead of AWS."`
+	S3ForcePathStyle  bool   `long:"s3-force-path-style"  description:"Address the bucket as part of the path rather than the host name, as required by most S3-compatible APIs."`
+	S3AccessKeyID     string `long:"s3-access-key"        description:"Access key ID for the S3 bucket. If omitted, credentials are obtained from the environment."`
+	S3SecretAccessKey string `long:"s3-secret-key"        description:"Secret access key for the S3 bucket."`
+
+	MaxBlobSize  int64         `long:"max-snapshot-size" default:"1073741824" description:"Maximum size, in bytes, of a single cache snapshot. Larger caches are not snapshotted. Zero means unlimited."`
+	MaxTotalSize int64         `long:"max-total-size"                         description:"Maximum combined size, in bytes, of all snapshots. The least recently updated snapshots are removed first. Zero means unlimited."`
+	MaxAge       time.Duration `long:"max-age"           default:"168h"       description:"Remove snapshots which have not been updated for this long. Zero means snapshots never expire."`
+
+	SnapshotTimeout time.Duration `long:"snapshot-timeout" default:"10m" description:"Give up on snapshotting a cache after this long, so that a slow store or worker doesn't tie up the ATC. Zero means no timeout."`
+}
+
+func (config Config) IsConfigured() bool {
+	return config.Dir != "" || config.S3Bucket != ""
+}
+
+func (config Config) Validate() error {
+	if config.Dir != "" && config.S3Bucket != "" {
+		return errors.New("must specify only one of --task-cache-dir and --task-cache-s3-bucket")
+	}
+
+	if config.MaxBlobSize < 0 || config.MaxTotalSize < 0 || config.MaxAge < 0 || config.SnapshotTimeout < 0 {
+		return errors.New("task cache limits must not be negative")
+	}
+
+	if config.S3AccessKeyID != "" && config.S3SecretAccessKey == "" {
+		return errors.New("must provide --task-cache-s3-secret-key with --task-cache-s3-access-key")
+	}
+
+	return nil
+}
+
+// NewStore constructs the configured Store. It returns nil if no store is
+// configured, in which case task caches are never shared between workers.
+func (config Config) NewStore() (Store, error) {
+	store, err := config.newStore()
+	if err != nil || store == nil {
+		return store, err
+	}
+
+	if config.SnapshotTimeout > 0 {
+		store = WithTimeout(store, config.SnapshotTimeout)
+	}
+
+	return store, nil
+}
+
+func (config Config) newStore() (Store, error) {
+	if config.Dir != "" {
+		err := os.MkdirAll(config.Dir, 0755)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewDirStore(config.Dir, config.MaxBlobSize), nil
+	}
+
+	if config.S3Bucket != "" {
+		awsConfig := &aws.Config{
+			S3ForcePathStyle: aws.Bool(config.S3ForcePathStyle),
+		}
+
+		if config.S3Region != "" {
+			awsConfig.Region = aws.String(config.S3Region)
+		}
+
+		if config.S3Endpoint != "" {
+			awsConfig.Endpoint = aws.String(config.S3Endpoint)
+		}
+
+		if config.S3AccessKeyID != "" {
+			awsConfig.Credentials = credentials.NewStaticCredentials(config.S3AccessKeyID, config.S3SecretAccessKey, "")
+		}
+
+		session, err := session.NewSession(awsConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewS3Store(s3.New(session), config.S3Bucket, config.S3Prefix, config.MaxBlobSize), nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,170 @@
+package taskcache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// snapshots are written here first and renamed into place once complete, so
+// that readers never observe a partially written snapshot
+const dirStoreTmpDir = ".tmp"
+
+type InvalidKeyError struct {
+	Key string
+}
+
+func (err InvalidKeyError) Error() string {
+	return fmt.Sprintf("invalid task cache key: %s", err.Key)
+}
+
+type dirStore struct {
+	dir         string
+	maxBlobSize int64
+}
+
+// NewDirStore returns a Store which keeps snapshots as files beneath the
+// given directory, e.g. a volume shared between ATCs.
+func NewDirStore(dir string, maxBlobSize int64) Store {
+	return &dirStore{
+		dir:         dir,
+		maxBlobSize: maxBlobSize,
+	}
+}
+
+func (store *dirStore) Put(ctx context.Context, key string, blob io.Reader) error {
+	blobPath, err := store.blobPath(key)
+	if err != nil {
+		return err
+	}
+
+	tmpDir := filepath.Join(store.dir, dirStoreTmpDir)
+
+	err = os.MkdirAll(tmpDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := ioutil.TempFile(tmpDir, "put-")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tmpFile, limitSize(readUntilDone(ctx, blob), store.maxBlobSize))
+	if err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+		return err
+	}
+
+	err = tmpFile.Close()
+	if err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(blobPath), 0755)
+	if err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return err
+	}
+
+	err = ctx.Err()
+	if err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), blobPath)
+}
+
+func (store *dirStore) Get(key string) (io.ReadCloser, bool, error) {
+	blobPath, err := store.blobPath(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	file, err := os.Open(blobPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return file, true, nil
+}
+
+func (store *dirStore) List() ([]Blob, error) {
+	blobs := []Blob{}
+
+	err := filepath.Walk(store.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == store.dir {
+				return filepath.SkipDir
+			}
+
+			return err
+		}
+
+		if info.IsDir() {
+			if path == filepath.Join(store.dir, dirStoreTmpDir) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		rel, err := filepath.Rel(store.dir, path)
+		if err != nil {
+			return err
+		}
+
+		blobs = append(blobs, Blob{
+			Key:     filepath.ToSlash(rel),
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return blobs, nil
+}
+
+func (store *dirStore) Delete(key string) error {
+	blobPath, err := store.blobPath(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(blobPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (store *dirStore) blobPath(key string) (string, error) {
+	segments := strings.Split(key, "/")
+	if segments[0] == dirStoreTmpDir {
+		return "", InvalidKeyError{Key: key}
+	}
+
+	for _, segment := range segments {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", InvalidKeyError{Key: key}
+		}
+	}
+
+	return filepath.Join(store.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,174 @@
+package taskcache_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/concourse/atc/taskcache"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DirStore", func() {
+	var (
+		dir   string
+		store taskcache.Store
+
+		maxBlobSize int64
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "task-cache-store")
+		Expect(err).ToNot(HaveOccurred())
+
+		maxBlobSize = 0
+	})
+
+	JustBeforeEach(func() {
+		store = taskcache.NewDirStore(dir, maxBlobSize)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Describe("Put and Get", func() {
+		var key string
+
+		BeforeEach(func() {
+			key = taskcache.Key(1, 2, "some-task", "some/path")
+		})
+
+		It("returns the blob that was put", func() {
+			Expect(store.Put(context.TODO(), key, strings.NewReader("some-snapshot"))).To(Succeed())
+
+			blob, found, err := store.Get(key)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			defer blob.Close()
+			Expect(ioutil.ReadAll(blob)).To(Equal([]byte("some-snapshot")))
+		})
+
+		It("replaces an existing blob", func() {
+			Expect(store.Put(context.TODO(), key, strings.NewReader("old-snapshot"))).To(Succeed())
+			Expect(store.Put(context.TODO(), key, strings.NewReader("new-snapshot"))).To(Succeed())
+
+			blob, found, err := store.Get(key)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			defer blob.Close()
+			Expect(ioutil.ReadAll(blob)).To(Equal([]byte("new-snapshot")))
+		})
+
+		It("does not find a blob that was never put", func() {
+			_, found, err := store.Get(key)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the blob is larger than the maximum size", func() {
+			BeforeEach(func() {
+				maxBlobSize = 4
+			})
+
+			It("returns ErrBlobTooLarge and stores nothing", func() {
+				err := store.Put(context.TODO(), key, bytes.NewReader([]byte("too-large")))
+				Expect(err).To(Equal(taskcache.ErrBlobTooLarge))
+
+				_, found, err := store.Get(key)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				Expect(ioutil.ReadDir(filepath.Join(dir, ".tmp"))).To(BeEmpty())
+			})
+		})
+
+		Context("when the context is done", func() {
+			It("returns the context's error and stores nothing", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				err := store.Put(ctx, key, strings.NewReader("some-snapshot"))
+				Expect(err).To(Equal(context.Canceled))
+
+				_, found, err := store.Get(key)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				Expect(ioutil.ReadDir(filepath.Join(dir, ".tmp"))).To(BeEmpty())
+			})
+		})
+
+		Context("when the key would escape the directory", func() {
+			It("returns an InvalidKeyError", func() {
+				err := store.Put(context.TODO(), "../escaped", strings.NewReader("some-snapshot"))
+				Expect(err).To(Equal(taskcache.InvalidKeyError{Key: "../escaped"}))
+			})
+		})
+	})
+
+	Describe("List", func() {
+		It("returns every blob with its size", func() {
+			Expect(store.Put(context.TODO(), "1/2/some-task/some-path", strings.NewReader("12345"))).To(Succeed())
+			Expect(store.Put(context.TODO(), "1/3/other-task/other-path", strings.NewReader("123"))).To(Succeed())
+
+			blobs, err := store.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(blobs).To(HaveLen(2))
+
+			sizes := map[string]int64{}
+			for _, blob := range blobs {
+				sizes[blob.Key] = blob.Size
+				Expect(blob.ModTime).ToNot(BeZero())
+			}
+
+			Expect(sizes).To(Equal(map[string]int64{
+				"1/2/some-task/some-path":   5,
+				"1/3/other-task/other-path": 3,
+			}))
+		})
+
+		Context("when the directory does not exist", func() {
+			BeforeEach(func() {
+				Expect(os.RemoveAll(dir)).To(Succeed())
+			})
+
+			It("returns no blobs", func() {
+				Expect(store.List()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Delete", func() {
+		It("removes the blob", func() {
+			Expect(store.Put(context.TODO(), "1/2/some-task/some-path", strings.NewReader("12345"))).To(Succeed())
+			Expect(store.Delete("1/2/some-task/some-path")).To(Succeed())
+
+			_, found, err := store.Get("1/2/some-task/some-path")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("succeeds if the blob does not exist", func() {
+			Expect(store.Delete("1/2/some-task/some-path")).To(Succeed())
+		})
+	})
+})
+
+var _ = Describe("Key", func() {
+	It("escapes the step name and path so that each is a single segment", func() {
+		Expect(taskcache.Key(1, 2, "some/task", "some/path")).To(Equal("1/2/some%2Ftask/some%2Fpath"))
+	})
+
+	It("escapes dots so that no segment refers to a parent directory", func() {
+		Expect(taskcache.Key(1, 2, "some-task", "..")).To(Equal("1/2/some-task/%2E%2E"))
+	})
+})
@@ -0,0 +1,111 @@
+package taskcache
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+type s3Store struct {
+	client      s3iface.S3API
+	bucket      string
+	prefix      string
+	maxBlobSize int64
+}
+
+// NewS3Store returns a Store which keeps snapshots as objects in an S3 (or
+// S3-compatible) bucket, with every key prepended by the given prefix.
+func NewS3Store(client s3iface.S3API, bucket string, prefix string, maxBlobSize int64) Store {
+	return &s3Store{
+		client:      client,
+		bucket:      bucket,
+		prefix:      prefix,
+		maxBlobSize: maxBlobSize,
+	}
+}
+
+func (store *s3Store) Put(ctx context.Context, key string, blob io.Reader) error {
+	// uploads need a seekable body, and spooling to disk first means an
+	// oversized snapshot is detected before anything is uploaded
+	spool, err := ioutil.TempFile("", "task-cache-")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	_, err = io.Copy(spool, limitSize(readUntilDone(ctx, blob), store.maxBlobSize))
+	if err != nil {
+		return err
+	}
+
+	_, err = spool.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(store.prefix + key),
+		Body:   spool,
+	})
+
+	return err
+}
+
+func (store *s3Store) Get(key string) (io.ReadCloser, bool, error) {
+	output, err := store.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(store.prefix + key),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return output.Body, true, nil
+}
+
+func (store *s3Store) List() ([]Blob, error) {
+	blobs := []Blob{}
+
+	err := store.client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(store.bucket),
+		Prefix: aws.String(store.prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			blobs = append(blobs, Blob{
+				Key:     strings.TrimPrefix(aws.StringValue(object.Key), store.prefix),
+				Size:    aws.Int64Value(object.Size),
+				ModTime: aws.TimeValue(object.LastModified),
+			})
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return blobs, nil
+}
+
+func (store *s3Store) Delete(key string) error {
+	_, err := store.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(store.prefix + key),
+	})
+
+	return err
+}
@@ -0,0 +1,188 @@
+package taskcache_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/concourse/atc/taskcache"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type mockS3 struct {
+	s3iface.S3API
+
+	objects map[string]string
+	times   map[string]time.Time
+}
+
+func (mock *mockS3) PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error) {
+	Expect(aws.StringValue(input.Bucket)).To(Equal("some-bucket"))
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	contents, err := ioutil.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	mock.objects[aws.StringValue(input.Key)] = string(contents)
+
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (mock *mockS3) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	Expect(aws.StringValue(input.Bucket)).To(Equal("some-bucket"))
+
+	contents, found := mock.objects[aws.StringValue(input.Key)]
+	if !found {
+		return nil, awserr.New(s3.ErrCodeNoSuchKey, "not found", nil)
+	}
+
+	return &s3.GetObjectOutput{
+		Body: ioutil.NopCloser(strings.NewReader(contents)),
+	}, nil
+}
+
+func (mock *mockS3) ListObjectsV2Pages(input *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool) error {
+	Expect(aws.StringValue(input.Bucket)).To(Equal("some-bucket"))
+
+	page := &s3.ListObjectsV2Output{}
+	for key, contents := range mock.objects {
+		if !strings.HasPrefix(key, aws.StringValue(input.Prefix)) {
+			continue
+		}
+
+		page.Contents = append(page.Contents, &s3.Object{
+			Key:          aws.String(key),
+			Size:         aws.Int64(int64(len(contents))),
+			LastModified: aws.Time(mock.times[key]),
+		})
+	}
+
+	fn(page, true)
+
+	return nil
+}
+
+func (mock *mockS3) DeleteObject(input *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+	Expect(aws.StringValue(input.Bucket)).To(Equal("some-bucket"))
+
+	delete(mock.objects, aws.StringValue(input.Key))
+
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+var _ = Describe("S3Store", func() {
+	var (
+		client *mockS3
+		store  taskcache.Store
+
+		maxBlobSize int64
+	)
+
+	BeforeEach(func() {
+		client = &mockS3{
+			objects: map[string]string{},
+			times:   map[string]time.Time{},
+		}
+
+		maxBlobSize = 0
+	})
+
+	JustBeforeEach(func() {
+		store = taskcache.NewS3Store(client, "some-bucket", "some-prefix/", maxBlobSize)
+	})
+
+	It("puts blobs beneath the prefix", func() {
+		Expect(store.Put(context.TODO(), "1/2/some-task/some-path", strings.NewReader("some-snapshot"))).To(Succeed())
+		Expect(client.objects).To(Equal(map[string]string{
+			"some-prefix/1/2/some-task/some-path": "some-snapshot",
+		}))
+	})
+
+	It("gets blobs that were put", func() {
+		Expect(store.Put(context.TODO(), "1/2/some-task/some-path", strings.NewReader("some-snapshot"))).To(Succeed())
+
+		blob, found, err := store.Get("1/2/some-task/some-path")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(ioutil.ReadAll(blob)).To(Equal([]byte("some-snapshot")))
+	})
+
+	It("does not find missing blobs", func() {
+		_, found, err := store.Get("1/2/some-task/some-path")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("lists blobs with the prefix removed", func() {
+		modTime := time.Unix(1234, 0)
+		client.objects["some-prefix/1/2/some-task/some-path"] = "12345"
+		client.times["some-prefix/1/2/some-task/some-path"] = modTime
+		client.objects["other-prefix/1/2/some-task/some-path"] = "123"
+
+		Expect(store.List()).To(Equal([]taskcache.Blob{
+			{Key: "1/2/some-task/some-path", Size: 5, ModTime: modTime},
+		}))
+	})
+
+	It("deletes blobs", func() {
+		client.objects["some-prefix/1/2/some-task/some-path"] = "12345"
+
+		Expect(store.Delete("1/2/some-task/some-path")).To(Succeed())
+		Expect(client.objects).To(BeEmpty())
+	})
+
+	Context("when the blob is larger than the maximum size", func() {
+		BeforeEach(func() {
+			maxBlobSize = 4
+		})
+
+		It("returns ErrBlobTooLarge without uploading anything", func() {
+			err := store.Put(context.TODO(), "1/2/some-task/some-path", strings.NewReader("too-large"))
+			Expect(err).To(Equal(taskcache.ErrBlobTooLarge))
+			Expect(client.objects).To(BeEmpty())
+		})
+	})
+
+	Context("when the context is done", func() {
+		It("returns the context's error without uploading anything", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err := store.Put(ctx, "1/2/some-task/some-path", strings.NewReader("some-snapshot"))
+			Expect(err).To(Equal(context.Canceled))
+			Expect(client.objects).To(BeEmpty())
+		})
+	})
+
+	Context("when reading the blob fails", func() {
+		It("returns the error without uploading anything", func() {
+			disaster := errors.New("nope")
+
+			err := store.Put(context.TODO(), "1/2/some-task/some-path", &failingReader{disaster})
+			Expect(err).To(Equal(disaster))
+			Expect(client.objects).To(BeEmpty())
+		})
+	})
+})
+
+type failingReader struct {
+	err error
+}
+
+func (r *failingReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,107 @@
+package taskcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrBlobTooLarge is returned by Put when a snapshot exceeds the store's
+// maximum blob size. Nothing is stored in this case.
+var ErrBlobTooLarge = errors.New("task cache snapshot exceeds maximum size")
+
+//go:generate counterfeiter . Store
+
+// Store holds snapshots of task cache volumes so that a cache populated on
+// one worker can be restored on another.
+//
+// Snapshots are opaque blobs, in practice the tar stream produced by
+// streaming a volume out of its worker. Put stops reading the blob once the
+// context is done and discards what it had read.
+type Store interface {
+	Put(ctx context.Context, key string, blob io.Reader) error
+	Get(key string) (io.ReadCloser, bool, error)
+	List() ([]Blob, error)
+	Delete(key string) error
+}
+
+// Blob describes a snapshot held in a Store.
+type Blob struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// Key identifies the snapshot of a single cache path of a job's task step.
+func Key(teamID int, jobID int, stepName string, path string) string {
+	return fmt.Sprintf(
+		"%d/%d/%s/%s",
+		teamID,
+		jobID,
+		escapeKeySegment(stepName),
+		escapeKeySegment(path),
+	)
+}
+
+// escapeKeySegment escapes slashes and dots so that the segment can be used
+// as a single file name, e.g. a cache path of ".." or "some/path"
+func escapeKeySegment(segment string) string {
+	return strings.Replace(url.PathEscape(segment), ".", "%2E", -1)
+}
+
+type sizeLimitedReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+// limitSize wraps the reader such that it fails with ErrBlobTooLarge once
+// more than maxSize bytes have been read. A maxSize of zero means unlimited.
+func limitSize(reader io.Reader, maxSize int64) io.Reader {
+	if maxSize <= 0 {
+		return reader
+	}
+
+	return &sizeLimitedReader{
+		reader:    reader,
+		remaining: maxSize,
+	}
+}
+
+func (r *sizeLimitedReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return n, ErrBlobTooLarge
+	}
+
+	return n, err
+}
+
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+// readUntilDone wraps the reader such that it fails with the context's error
+// once the context is done. A Read which is already blocked is not
+// interrupted.
+func readUntilDone(ctx context.Context, reader io.Reader) io.Reader {
+	return &contextReader{
+		ctx:    ctx,
+		reader: reader,
+	}
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	err := r.ctx.Err()
+	if err != nil {
+		return 0, err
+	}
+
+	return r.reader.Read(p)
+}
@@ -0,0 +1,59 @@
+package taskcache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrSnapshotTimedOut is returned by Put when storing a snapshot takes longer
+// than the store's timeout. The store stops reading the blob and discards
+// what it had read, though a snapshot which finished storing just as the
+// timeout passed may still be kept.
+var ErrSnapshotTimedOut = errors.New("timed out storing task cache snapshot")
+
+type timeoutStore struct {
+	Store
+
+	timeout time.Duration
+}
+
+// WithTimeout wraps the store such that Put gives up after the timeout, so
+// that a slow store or worker can't hold up snapshotting caches indefinitely.
+//
+// The timeout is applied through the context passed to the wrapped store,
+// which stops reading the blob once it is done. If the blob is an io.Closer it
+// is also closed at that point, interrupting a Read that is blocked on a
+// stalled worker.
+func WithTimeout(store Store, timeout time.Duration) Store {
+	return timeoutStore{
+		Store:   store,
+		timeout: timeout,
+	}
+}
+
+func (store timeoutStore) Put(ctx context.Context, key string, blob io.Reader) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, store.timeout)
+	defer cancel()
+
+	if closer, ok := blob.(io.Closer); ok {
+		stored := make(chan struct{})
+		defer close(stored)
+
+		go func() {
+			select {
+			case <-timeoutCtx.Done():
+				_ = closer.Close()
+			case <-stored:
+			}
+		}()
+	}
+
+	err := store.Store.Put(timeoutCtx, key, blob)
+	if err != nil && ctx.Err() == nil && timeoutCtx.Err() == context.DeadlineExceeded {
+		return ErrSnapshotTimedOut
+	}
+
+	return err
+}
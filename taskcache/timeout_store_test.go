@@ -0,0 +1,107 @@
+package taskcache_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/concourse/atc/taskcache"
+	"github.com/concourse/atc/taskcache/taskcachefakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WithTimeout", func() {
+	var (
+		fakeStore *taskcachefakes.FakeStore
+		store     taskcache.Store
+	)
+
+	BeforeEach(func() {
+		fakeStore = new(taskcachefakes.FakeStore)
+		store = taskcache.WithTimeout(fakeStore, 100*time.Millisecond)
+	})
+
+	Describe("Put", func() {
+		Context("when the store finishes in time", func() {
+			var stored []byte
+
+			BeforeEach(func() {
+				fakeStore.PutStub = func(ctx context.Context, key string, blob io.Reader) error {
+					var err error
+					stored, err = ioutil.ReadAll(blob)
+					return err
+				}
+			})
+
+			It("stores the blob", func() {
+				Expect(store.Put(context.TODO(), "some-key", strings.NewReader("some-snapshot"))).To(Succeed())
+				_, key, _ := fakeStore.PutArgsForCall(0)
+				Expect(key).To(Equal("some-key"))
+				Expect(stored).To(Equal([]byte("some-snapshot")))
+			})
+		})
+
+		Context("when the store fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeStore.PutReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(store.Put(context.TODO(), "some-key", strings.NewReader("some-snapshot"))).To(Equal(disaster))
+			})
+		})
+
+		Context("when the store takes too long", func() {
+			BeforeEach(func() {
+				fakeStore.PutStub = func(ctx context.Context, key string, blob io.Reader) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+			})
+
+			It("gives up once the store has stopped", func() {
+				Expect(store.Put(context.TODO(), "some-key", strings.NewReader("some-snapshot"))).To(Equal(taskcache.ErrSnapshotTimedOut))
+				Expect(fakeStore.PutCallCount()).To(Equal(1))
+			})
+
+			Context("when reading the blob is blocked", func() {
+				BeforeEach(func() {
+					fakeStore.PutStub = func(ctx context.Context, key string, blob io.Reader) error {
+						_, err := ioutil.ReadAll(blob)
+						return err
+					}
+				})
+
+				It("closes the blob to interrupt the read", func() {
+					blob, _ := io.Pipe()
+					Expect(store.Put(context.TODO(), "some-key", blob)).To(Equal(taskcache.ErrSnapshotTimedOut))
+				})
+			})
+
+			Context("when the context passed in is canceled first", func() {
+				It("returns the context's error", func() {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+
+					Expect(store.Put(ctx, "some-key", strings.NewReader("some-snapshot"))).To(Equal(context.Canceled))
+				})
+			})
+		})
+	})
+
+	It("passes other calls through to the store", func() {
+		fakeStore.GetReturns(ioutil.NopCloser(strings.NewReader("some-snapshot")), true, nil)
+
+		blob, found, err := store.Get("some-key")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(ioutil.ReadAll(blob)).To(Equal([]byte("some-snapshot")))
+	})
+})
@@ -1,7 +1,128 @@
 package atc
 
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Roles a user can have within a team, from most to least privileged.
+const (
+	OwnerRole    = "owner"
+	MemberRole   = "member"
+	OperatorRole = "operator"
+	ViewerRole   = "viewer"
+)
+
+var roleRanks = map[string]int{
+	OwnerRole:    4,
+	MemberRole:   3,
+	OperatorRole: 2,
+	ViewerRole:   1,
+}
+
+// RoleSatisfies returns true if the given role grants at least the access of
+// the required role, e.g. an owner satisfies anything a member may do.
+func RoleSatisfies(role string, required string) bool {
+	rank, found := roleRanks[role]
+	if !found {
+		return false
+	}
+
+	return rank >= roleRanks[required]
+}
+
+// TeamAuth maps each role to the users and groups that have it, e.g.
+// {"owner": {"users": ["github:alice"], "groups": ["github:org:team"]}}.
+type TeamAuth map[string]map[string][]string
+
+// UnmarshalJSON also accepts the flat {"users": [...], "groups": [...]} form
+// teams were configured with before roles existed, granting them the owner
+// role as they had before.
+func (auth *TeamAuth) UnmarshalJSON(data []byte) error {
+	var roles map[string]map[string][]string
+	err := json.Unmarshal(data, &roles)
+	if err == nil {
+		*auth = roles
+		return nil
+	}
+
+	var legacy map[string][]string
+	if json.Unmarshal(data, &legacy) != nil {
+		return err
+	}
+
+	*auth = TeamAuth{OwnerRole: legacy}
+
+	return nil
+}
+
+// Roles returns the roles granted to the user, either directly or through
+// one of their groups. Tokens list these for each team in their 'teams'
+// claim.
+func (auth TeamAuth) Roles(userID string, groupIDs []string) []string {
+	roles := []string{}
+
+	for role, members := range auth {
+		if auth.grants(members, userID, groupIDs) {
+			roles = append(roles, role)
+		}
+	}
+
+	sort.Slice(roles, func(i, j int) bool {
+		return roleRanks[roles[i]] > roleRanks[roles[j]]
+	})
+
+	return roles
+}
+
+func (auth TeamAuth) grants(members map[string][]string, userID string, groupIDs []string) bool {
+	for _, user := range members["users"] {
+		if user == userID {
+			return true
+		}
+	}
+
+	for _, group := range members["groups"] {
+		for _, groupID := range groupIDs {
+			if group == groupID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Validate returns an error if any of the roles are unknown.
+func (auth TeamAuth) Validate() error {
+	unknown := []string{}
+	for role := range auth {
+		if _, found := roleRanks[role]; !found {
+			unknown = append(unknown, role)
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown roles: %v", unknown)
+	}
+
+	return nil
+}
+
 type Team struct {
-	ID   int                 `json:"id,omitempty"`
-	Name string              `json:"name,omitempty"`
-	Auth map[string][]string `json:"auth,omitempty"`
+	ID   int      `json:"id,omitempty"`
+	Name string   `json:"name,omitempty"`
+	Auth TeamAuth `json:"auth,omitempty"`
+
+	// RedactSecrets masks credentials in the output of the team's builds. It
+	// is left as it was when a team is updated without it.
+	RedactSecrets *bool `json:"redact_secrets,omitempty"`
+}
+
+// UserRoles reports the role the requester has in each of their teams.
+type UserRoles struct {
+	IsAdmin bool              `json:"is_admin"`
+	Teams   map[string]string `json:"teams"`
 }
@@ -0,0 +1,89 @@
+package atc_test
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamAuth", func() {
+	Describe("UnmarshalJSON", func() {
+		var (
+			payload string
+			auth    atc.TeamAuth
+			err     error
+		)
+
+		JustBeforeEach(func() {
+			auth = nil
+			err = json.Unmarshal([]byte(payload), &auth)
+		})
+
+		Context("when the users and groups are given per role", func() {
+			BeforeEach(func() {
+				payload = `{"owner":{"users":["local:alice"]},"viewer":{"groups":["github:org"]}}`
+			})
+
+			It("keeps them per role", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(auth).To(Equal(atc.TeamAuth{
+					"owner":  {"users": {"local:alice"}},
+					"viewer": {"groups": {"github:org"}},
+				}))
+			})
+		})
+
+		Context("when the users and groups are given without roles", func() {
+			BeforeEach(func() {
+				payload = `{"users":["local:alice"],"groups":["github:org"]}`
+			})
+
+			It("makes them owners", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(auth).To(Equal(atc.TeamAuth{
+					"owner": {
+						"users":  {"local:alice"},
+						"groups": {"github:org"},
+					},
+				}))
+			})
+		})
+
+		Context("when the payload is neither form", func() {
+			BeforeEach(func() {
+				payload = `{"owner":"local:alice"}`
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Roles", func() {
+		var auth atc.TeamAuth
+
+		BeforeEach(func() {
+			auth = atc.TeamAuth{
+				"viewer": {"groups": {"github:org"}},
+				"owner":  {"users": {"local:alice"}},
+				"member": {"users": {"local:bob"}},
+			}
+		})
+
+		It("returns the roles granted to the user or their groups, most privileged first", func() {
+			Expect(auth.Roles("local:alice", []string{"github:org"})).To(Equal([]string{"owner", "viewer"}))
+		})
+
+		It("returns the roles granted to the user alone", func() {
+			Expect(auth.Roles("local:bob", nil)).To(Equal([]string{"member"}))
+		})
+
+		It("returns no roles for anyone else", func() {
+			Expect(auth.Roles("local:eve", []string{"github:other-org"})).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+type Config struct {
+	ServiceName string            `long:"service-name" default:"concourse-atc" description:"Service name to attach to exported spans."`
+	OTLPAddress string            `long:"otlp-address"                         description:"host:port of an OTLP collector to export spans to over gRPC."`
+	OTLPHeaders map[string]string `long:"otlp-header"                          description:"A header to send with exported spans, e.g. for authentication. Can be specified multiple times." value-name:"NAME:VALUE"`
+	OTLPUseTLS  bool              `long:"otlp-use-tls"                         description:"Whether to use TLS when connecting to the OTLP collector."`
+}
+
+func (config Config) IsConfigured() bool {
+	return config.OTLPAddress != ""
+}
+
+// Prepare configures spans to be batched and exported to the OTLP collector.
+// If no collector is configured, spans are not recorded at all.
+func (config Config) Prepare() error {
+	if !config.IsConfigured() {
+		return nil
+	}
+
+	options := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(config.OTLPAddress),
+		otlptracegrpc.WithHeaders(config.OTLPHeaders),
+	}
+
+	if !config.OTLPUseTLS {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(context.Background(), options...)
+	if err != nil {
+		return err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(config.ServiceName),
+		)),
+	)
+
+	ConfigureTraceProvider(provider)
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/concourse/atc"
+
+// Attrs are string attributes to set on a span, e.g. the team and pipeline
+// of a build.
+type Attrs map[string]string
+
+// StartSpan creates a span named after the component doing the work, as a
+// child of any span already in the context.
+//
+// Until a trace provider is configured the span is a no-op, so it's cheap to
+// instrument code paths unconditionally.
+func StartSpan(ctx context.Context, component string, attrs Attrs) (context.Context, trace.Span) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, component)
+
+	for key, value := range attrs {
+		span.SetAttributes(attribute.String(key, value))
+	}
+
+	return ctx, span
+}
+
+// StartChildSpan is like StartSpan, but only records a span if ctx already
+// carries one. Transports use it so that requests made outside of a build or
+// scheduling tick don't each become their own trace.
+func StartChildSpan(ctx context.Context, component string, attrs Attrs) (context.Context, trace.Span) {
+	parent := trace.SpanFromContext(ctx)
+	if !parent.SpanContext().IsValid() {
+		return ctx, parent
+	}
+
+	return StartSpan(ctx, component, attrs)
+}
+
+// End ends the span, marking it as errored if err is non-nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// ConfigureTraceProvider sets the provider used by StartSpan.
+func ConfigureTraceProvider(provider trace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+}
@@ -0,0 +1,97 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/atc/tracing"
+	"github.com/concourse/atc/tracing/tracingtest"
+	"go.opentelemetry.io/otel/trace"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tracing", func() {
+	var recorder *tracingtest.Recorder
+
+	BeforeEach(func() {
+		recorder = tracingtest.Install()
+	})
+
+	AfterEach(func() {
+		tracing.ConfigureTraceProvider(trace.NewNoopTracerProvider())
+	})
+
+	Describe("StartSpan", func() {
+		It("records the span with its attributes once ended", func() {
+			_, span := tracing.StartSpan(context.Background(), "some-component", tracing.Attrs{
+				"some": "attr",
+			})
+
+			Expect(recorder.SpanNames()).To(BeEmpty())
+
+			tracing.End(span, nil)
+
+			Expect(recorder.SpanNames()).To(Equal([]string{"some-component"}))
+			Expect(recorder.Attributes("some-component")).To(Equal(map[string]string{
+				"some": "attr",
+			}))
+		})
+
+		It("creates children of the span in the context", func() {
+			ctx, parent := tracing.StartSpan(context.Background(), "parent", nil)
+			_, child := tracing.StartSpan(ctx, "child", nil)
+
+			Expect(child.SpanContext().TraceID()).To(Equal(parent.SpanContext().TraceID()))
+
+			tracing.End(child, nil)
+			tracing.End(parent, nil)
+
+			Expect(recorder.SpanNames()).To(Equal([]string{"child", "parent"}))
+		})
+	})
+
+	Describe("StartChildSpan", func() {
+		It("records a child of the span in the context", func() {
+			ctx, parent := tracing.StartSpan(context.Background(), "parent", nil)
+			_, child := tracing.StartChildSpan(ctx, "child", nil)
+
+			Expect(child.SpanContext().TraceID()).To(Equal(parent.SpanContext().TraceID()))
+
+			tracing.End(child, nil)
+			tracing.End(parent, nil)
+
+			Expect(recorder.SpanNames()).To(Equal([]string{"child", "parent"}))
+		})
+
+		It("records nothing without a span in the context", func() {
+			_, span := tracing.StartChildSpan(context.Background(), "orphan", nil)
+
+			tracing.End(span, nil)
+
+			Expect(recorder.SpanNames()).To(BeEmpty())
+		})
+	})
+
+	Describe("End", func() {
+		It("ends the span even when given an error", func() {
+			_, span := tracing.StartSpan(context.Background(), "some-component", nil)
+
+			tracing.End(span, errors.New("disaster"))
+
+			Expect(recorder.SpanNames()).To(Equal([]string{"some-component"}))
+		})
+	})
+})
+
+var _ = Describe("Config", func() {
+	It("is not configured without an OTLP address", func() {
+		Expect(tracing.Config{}.IsConfigured()).To(BeFalse())
+		Expect(tracing.Config{}.Prepare()).To(Succeed())
+	})
+
+	It("is configured with an OTLP address", func() {
+		Expect(tracing.Config{OTLPAddress: "127.0.0.1:4317"}.IsConfigured()).To(BeTrue())
+	})
+})
@@ -0,0 +1,55 @@
+package tracingtest
+
+import (
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/concourse/atc/tracing"
+)
+
+// Recorder collects spans in-process so that tests can assert on what was
+// traced without running an OTLP collector.
+type Recorder struct {
+	spans *tracetest.SpanRecorder
+}
+
+// Install configures tracing to record spans into the returned Recorder.
+func Install() *Recorder {
+	recorder := tracetest.NewSpanRecorder()
+
+	tracing.ConfigureTraceProvider(trace.NewTracerProvider(
+		trace.WithSpanProcessor(recorder),
+	))
+
+	return &Recorder{spans: recorder}
+}
+
+// SpanNames returns the names of every span that has ended, in the order
+// they ended.
+func (recorder *Recorder) SpanNames() []string {
+	names := []string{}
+	for _, span := range recorder.spans.Ended() {
+		names = append(names, span.Name())
+	}
+
+	return names
+}
+
+// Attributes returns the string attributes of the first ended span with the
+// given name.
+func (recorder *Recorder) Attributes(name string) map[string]string {
+	for _, span := range recorder.spans.Ended() {
+		if span.Name() != name {
+			continue
+		}
+
+		attrs := map[string]string{}
+		for _, attr := range span.Attributes() {
+			attrs[string(attr.Key)] = attr.Value.AsString()
+		}
+
+		return attrs
+	}
+
+	return nil
+}
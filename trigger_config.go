@@ -0,0 +1,63 @@
+package atc
+
+import "time"
+
+// TriggerConfig triggers a job on a cron schedule, e.g. `0 2 * * *` for 2am
+// every day. Timezone is a name from the IANA Time Zone database, e.g.
+// `Europe/London`, and defaults to UTC.
+type TriggerConfig struct {
+	Cron     string `yaml:"cron" json:"cron" mapstructure:"cron"`
+	Timezone string `yaml:"timezone,omitempty" json:"timezone,omitempty" mapstructure:"timezone"`
+}
+
+func (config TriggerConfig) Schedule() (CronSchedule, error) {
+	location := time.UTC
+	if config.Timezone != "" {
+		var err error
+		location, err = time.LoadLocation(config.Timezone)
+		if err != nil {
+			return CronSchedule{}, err
+		}
+	}
+
+	return ParseCronSchedule(config.Cron, location)
+}
+
+type TriggerConfigs []TriggerConfig
+
+// Next returns the trigger which fires first after the given time, along
+// with the time it fires. Triggers which are invalid or never fire are
+// skipped.
+func (configs TriggerConfigs) Next(after time.Time) (TriggerConfig, time.Time, bool) {
+	var (
+		next     TriggerConfig
+		nextTime time.Time
+		found    bool
+	)
+
+	for _, config := range configs {
+		schedule, err := config.Schedule()
+		if err != nil {
+			continue
+		}
+
+		fireTime := schedule.Next(after)
+		if fireTime.IsZero() {
+			continue
+		}
+
+		if !found || fireTime.Before(nextTime) {
+			next, nextTime, found = config, fireTime, true
+		}
+	}
+
+	return next, nextTime, found
+}
+
+// TriggerCause is the trigger which a build was created for, and the time
+// it was scheduled to fire.
+type TriggerCause struct {
+	Cron          string `json:"cron"`
+	Timezone      string `json:"timezone,omitempty"`
+	ScheduledTime int64  `json:"scheduled_time"`
+}
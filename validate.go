@@ -49,6 +49,11 @@ func (c Config) Validate() ([]Warning, []string) {
 	}
 	warnings = append(warnings, jobWarnings...)
 
+	notificationsErr := validateNotifications(c)
+	if notificationsErr != nil {
+		errorMessages = append(errorMessages, formatErr("notifications", notificationsErr))
+	}
+
 	return warnings, errorMessages
 }
 
@@ -161,6 +166,77 @@ func validateResourceTypes(c Config) error {
 	return compositeErr(errorMessages)
 }
 
+func validateNotifications(c Config) error {
+	errorMessages := []string{}
+
+	names := map[string]int{}
+
+	for i, notification := range c.Notifications {
+		var identifier string
+		if notification.Name == "" {
+			identifier = fmt.Sprintf("notifications[%d]", i)
+		} else {
+			identifier = fmt.Sprintf("notifications.%s", notification.Name)
+		}
+
+		if other, exists := names[notification.Name]; exists {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf(
+					"notifications[%d] and notifications[%d] have the same name ('%s')",
+					other, i, notification.Name))
+		} else if notification.Name != "" {
+			names[notification.Name] = i
+		}
+
+		if notification.Name == "" {
+			errorMessages = append(errorMessages, identifier+" has no name")
+		}
+
+		if notification.URL == "" {
+			errorMessages = append(errorMessages, identifier+" has no url")
+		}
+
+		for _, job := range notification.Jobs {
+			_, exists := c.Jobs.Lookup(job)
+			if !exists {
+				errorMessages = append(errorMessages,
+					fmt.Sprintf("%s has unknown job '%s'", identifier, job))
+			}
+		}
+
+		for _, status := range notification.On {
+			if !validNotificationStatus(status) {
+				errorMessages = append(errorMessages,
+					fmt.Sprintf("%s has invalid status '%s' in on", identifier, status))
+			}
+		}
+
+		for _, status := range notification.From {
+			if !validNotificationStatus(status) {
+				errorMessages = append(errorMessages,
+					fmt.Sprintf("%s has invalid status '%s' in from", identifier, status))
+			}
+		}
+
+		_, err := notification.bodyTemplate()
+		if err != nil {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf("%s has an invalid body template: %s", identifier, err))
+		}
+	}
+
+	return compositeErr(errorMessages)
+}
+
+func validNotificationStatus(status BuildStatus) bool {
+	switch status {
+	case StatusSucceeded, StatusFailed, StatusErrored, StatusAborted:
+		return true
+	default:
+		return false
+	}
+}
+
 func validateResourcesUnused(c Config) []string {
 	usedResources := usedResources(c)
 
@@ -224,6 +300,23 @@ func validateJobs(c Config) ([]Warning, error) {
 			)
 		}
 
+		for j, trigger := range job.Triggers {
+			subIdentifier := fmt.Sprintf("%s.triggers[%d]", identifier, j)
+
+			schedule, err := trigger.Schedule()
+			if err != nil {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf("%s has an invalid schedule: %s", subIdentifier, err),
+				)
+			} else if schedule.Next(time.Now()).IsZero() {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf("%s has a schedule which never fires: '%s'", subIdentifier, trigger.Cron),
+				)
+			}
+		}
+
 		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
@@ -388,9 +481,25 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 			}
 		}
 
+		if plan.Version != nil && plan.Version.IsFiltered() {
+			_, err := plan.Version.Filter()
+			if err != nil {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf("%s.version.%s", identifier, err),
+				)
+			}
+		}
+
 		for _, job := range plan.Passed {
 			jobConfig, found := c.Jobs.Lookup(job)
 			if !found {
+				// jobs in other pipelines can only be checked once the
+				// pipeline is scheduled
+				if _, _, crossPipeline := ParseCrossPipelinePassed(job); crossPipeline {
+					continue
+				}
+
 				errorMessages = append(
 					errorMessages,
 					fmt.Sprintf(
@@ -495,6 +604,18 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 			plan, identifier)...,
 		)
 
+	case plan.SetPipeline != "":
+		identifier = fmt.Sprintf("%s.set_pipeline.%s", identifier, plan.SetPipeline)
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify any pipeline configuration `file`")
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config"},
+			plan, identifier)...,
+		)
+
 	case plan.Try != nil:
 		subIdentifier := fmt.Sprintf("%s.try", identifier)
 		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Try)
@@ -543,9 +664,49 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", plan.Attempts))
 	}
 
+	if plan.RetryPolicy != nil {
+		subIdentifier := fmt.Sprintf("%s.retry_policy", identifier)
+
+		if plan.Attempts == 0 {
+			errorMessages = append(errorMessages, subIdentifier+" is specified without any attempts")
+		}
+
+		errorMessages = append(errorMessages, validateRetryPolicy(subIdentifier, *plan.RetryPolicy)...)
+	}
+
 	return warnings, errorMessages
 }
 
+func validateRetryPolicy(identifier string, policy RetryPolicy) []string {
+	errorMessages := []string{}
+
+	switch policy.Backoff {
+	case "", BackoffFixed, BackoffExponential:
+	default:
+		errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has an unknown backoff ('%s')", policy.Backoff))
+	}
+
+	if policy.Delay != "" {
+		_, err := time.ParseDuration(policy.Delay)
+		if err != nil {
+			errorMessages = append(errorMessages, identifier+fmt.Sprintf(".delay refers to a duration that could not be parsed ('%s')", policy.Delay))
+		}
+	}
+
+	if policy.MaxDelay != "" {
+		_, err := time.ParseDuration(policy.MaxDelay)
+		if err != nil {
+			errorMessages = append(errorMessages, identifier+fmt.Sprintf(".max_delay refers to a duration that could not be parsed ('%s')", policy.MaxDelay))
+		}
+
+		if policy.Backoff != BackoffExponential {
+			errorMessages = append(errorMessages, identifier+".max_delay is only applicable to exponential backoff")
+		}
+	}
+
+	return errorMessages
+}
+
 func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, identifier string) []string {
 	errorMessages := []string{}
 	foundInapplicableFields := []string{}
@@ -423,6 +423,95 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("invalid notifications", func() {
+		var notification NotificationConfig
+
+		BeforeEach(func() {
+			notification = NotificationConfig{
+				Name: "some-notification",
+				URL:  "https://example.com/hook",
+				Jobs: []string{"some-job"},
+				On:   []BuildStatus{StatusFailed},
+				From: []BuildStatus{StatusSucceeded},
+				Body: `{"text": {{json .JobName}}}`,
+			}
+		})
+
+		Context("when the notification is valid", func() {
+			BeforeEach(func() {
+				config.Notifications = append(config.Notifications, notification)
+			})
+
+			It("returns no error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a notification has no name or url", func() {
+			BeforeEach(func() {
+				notification.Name = ""
+				notification.URL = ""
+				config.Notifications = append(config.Notifications, notification)
+			})
+
+			It("returns an error describing both errors", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid notifications:"))
+				Expect(errorMessages[0]).To(ContainSubstring("notifications[0] has no name"))
+				Expect(errorMessages[0]).To(ContainSubstring("notifications[0] has no url"))
+			})
+		})
+
+		Context("when two notifications have the same name", func() {
+			BeforeEach(func() {
+				config.Notifications = append(config.Notifications, notification, notification)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("notifications[0] and notifications[1] have the same name ('some-notification')"))
+			})
+		})
+
+		Context("when a notification references a bogus job", func() {
+			BeforeEach(func() {
+				notification.Jobs = []string{"bogus-job"}
+				config.Notifications = append(config.Notifications, notification)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("notifications.some-notification has unknown job 'bogus-job'"))
+			})
+		})
+
+		Context("when a notification filters on an invalid status", func() {
+			BeforeEach(func() {
+				notification.On = []BuildStatus{"started"}
+				notification.From = []BuildStatus{"bogus"}
+				config.Notifications = append(config.Notifications, notification)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("notifications.some-notification has invalid status 'started' in on"))
+				Expect(errorMessages[0]).To(ContainSubstring("notifications.some-notification has invalid status 'bogus' in from"))
+			})
+		})
+
+		Context("when a notification has an invalid body template", func() {
+			BeforeEach(func() {
+				notification.Body = "{{.JobName"
+				config.Notifications = append(config.Notifications, notification)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("notifications.some-notification has an invalid body template"))
+			})
+		})
+	})
+
 	Describe("validating a job", func() {
 		var job JobConfig
 
@@ -459,6 +548,54 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has triggers", func() {
+			BeforeEach(func() {
+				job.Triggers = TriggerConfigs{
+					{Cron: "0 2 * * *"},
+					{Cron: "*/15 9-17 * * mon-fri", Timezone: "Europe/London"},
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+
+			Context("when a trigger's cron is invalid", func() {
+				BeforeEach(func() {
+					config.Jobs[len(config.Jobs)-1].Triggers[0].Cron = "0 25 * * *"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.triggers[0] has an invalid schedule: invalid hour '25'"))
+				})
+			})
+
+			Context("when a trigger's timezone is unknown", func() {
+				BeforeEach(func() {
+					config.Jobs[len(config.Jobs)-1].Triggers[1].Timezone = "Nowhere/Special"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.triggers[1] has an invalid schedule"))
+				})
+			})
+
+			Context("when a trigger never fires", func() {
+				BeforeEach(func() {
+					config.Jobs[len(config.Jobs)-1].Triggers[0].Cron = "0 0 30 feb *"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.triggers[0] has a schedule which never fires: '0 0 30 feb *'"))
+				})
+			})
+		})
+
 		Context("when a job has duplicate inputs", func() {
 			BeforeEach(func() {
 				job.Plan = append(job.Plan, PlanConfig{
@@ -696,6 +833,64 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a set_pipeline plan has no file", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						SetPipeline: "some-pipeline",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline does not specify any pipeline configuration `file`"))
+				})
+			})
+
+			Context("when a set_pipeline plan has invalid fields specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						SetPipeline:    "some-pipeline",
+						TaskConfigPath: "some-artifact/pipeline.yml",
+						Privileged:     true,
+						Trigger:        true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline has invalid fields specified (trigger, privileged)"))
+				})
+			})
+
+			Context("when a task plan has invalid container limits", func() {
+				BeforeEach(func() {
+					memory := uint64(1)
+
+					job.Plan = append(job.Plan, PlanConfig{
+						Task: "some-resource",
+						TaskConfig: &TaskConfig{
+							Platform: "linux",
+							Run:      TaskRunConfig{Path: "ls"},
+							Limits:   ContainerLimits{Memory: &memory},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-resource invalid 'container_limits': memory limit must be at least 4194304 bytes"))
+				})
+			})
+
 			Context("when a put plan has invalid fields specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1116,6 +1311,66 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a retry plan has a valid retry policy", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:      "some-resource",
+						Attempts: 3,
+						RetryPolicy: &RetryPolicy{
+							Backoff:     BackoffExponential,
+							Delay:       "10s",
+							MaxDelay:    "1m",
+							ErroredOnly: true,
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a retry policy is specified without attempts", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:         "some-resource",
+						RetryPolicy: &RetryPolicy{Delay: "10s"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.retry_policy is specified without any attempts"))
+				})
+			})
+
+			Context("when a retry policy is invalid", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:      "some-resource",
+						Attempts: 3,
+						RetryPolicy: &RetryPolicy{
+							Backoff:  "bogus",
+							Delay:    "nope",
+							MaxDelay: "1m",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.retry_policy has an unknown backoff ('bogus')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.retry_policy.delay refers to a duration that could not be parsed ('nope')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.retry_policy.max_delay is only applicable to exponential backoff"))
+				})
+			})
+
 			Context("when a put plan has a custom name but refers to a resource that does not exist", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1148,6 +1403,91 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a get plan filters versions with an invalid regular expression", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get: "some-resource",
+						Version: &VersionConfig{
+							Matches: map[string]string{"tag": "^v(("},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.version.matches.tag is not a valid regular expression"))
+				})
+			})
+
+			Context("when a get plan filters versions with an invalid semver range", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get: "some-resource",
+						Version: &VersionConfig{
+							Semver: map[string]string{"tag": ">=1.2 <two"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.version.semver.tag is not a valid semver range: invalid constraint '<two'"))
+				})
+			})
+
+			Context("when a get plan filters versions with valid filters", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get: "some-resource",
+						Version: &VersionConfig{
+							Matches: map[string]string{"tag": "^v"},
+							Semver:  map[string]string{"tag": ">=1.2 <2"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a job's input's passed constraints reference a job in another pipeline", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"other-pipeline/some-job"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a job's input's passed constraints reference a malformed cross-pipeline job", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"other-pipeline/"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references an unknown job ('other-pipeline/')"))
+				})
+			})
+
 			Context("when a job's input's passed constraints references a valid job that has the resource as an output", func() {
 				BeforeEach(func() {
 					config.Jobs[0].Plan = append(config.Jobs[0].Plan, PlanConfig{
@@ -0,0 +1,74 @@
+package atc
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// A VersionFilter is the compiled form of a VersionConfig's Matches and
+// Semver.
+type VersionFilter struct {
+	matches map[string]*regexp.Regexp
+	ranges  map[string]SemverRange
+}
+
+// Filter compiles the config's Matches and Semver, returning an error
+// naming the first field which is invalid.
+func (c VersionConfig) Filter() (VersionFilter, error) {
+	filter := VersionFilter{
+		matches: map[string]*regexp.Regexp{},
+		ranges:  map[string]SemverRange{},
+	}
+
+	for _, field := range sortedFields(c.Matches) {
+		re, err := regexp.Compile(c.Matches[field])
+		if err != nil {
+			return VersionFilter{}, fmt.Errorf("matches.%s is not a valid regular expression: %s", field, err)
+		}
+
+		filter.matches[field] = re
+	}
+
+	for _, field := range sortedFields(c.Semver) {
+		semverRange, err := ParseSemverRange(c.Semver[field])
+		if err != nil {
+			return VersionFilter{}, fmt.Errorf("semver.%s is not a valid semver range: %s", field, err)
+		}
+
+		filter.ranges[field] = semverRange
+	}
+
+	return filter, nil
+}
+
+// Allows returns true if the version has every field the filter refers to,
+// and each one satisfies it.
+func (filter VersionFilter) Allows(version Version) bool {
+	for field, re := range filter.matches {
+		value, found := version[field]
+		if !found || !re.MatchString(value) {
+			return false
+		}
+	}
+
+	for field, semverRange := range filter.ranges {
+		value, found := version[field]
+		if !found || !semverRange.Contains(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sortedFields(fields map[string]string) []string {
+	names := []string{}
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
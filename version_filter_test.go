@@ -0,0 +1,113 @@
+package atc_test
+
+import (
+	. "github.com/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VersionFilter", func() {
+	DescribeTable("Allows",
+		func(config VersionConfig, version Version, allowed bool) {
+			filter, err := config.Filter()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(filter.Allows(version)).To(Equal(allowed))
+		},
+
+		Entry("a matching field",
+			VersionConfig{Matches: map[string]string{"tag": "^v[0-9]"}},
+			Version{"tag": "v1.2.3"},
+			true,
+		),
+		Entry("a field which does not match",
+			VersionConfig{Matches: map[string]string{"tag": "^v[0-9]"}},
+			Version{"tag": "nightly"},
+			false,
+		),
+		Entry("a missing field",
+			VersionConfig{Matches: map[string]string{"tag": "^v[0-9]"}},
+			Version{"ref": "abcdef"},
+			false,
+		),
+		Entry("a version within a range",
+			VersionConfig{Semver: map[string]string{"tag": ">=1.2 <2"}},
+			Version{"tag": "v1.10.0"},
+			true,
+		),
+		Entry("a version below a range",
+			VersionConfig{Semver: map[string]string{"tag": ">=1.2 <2"}},
+			Version{"tag": "1.1.9"},
+			false,
+		),
+		Entry("a version above a range",
+			VersionConfig{Semver: map[string]string{"tag": ">=1.2 <2"}},
+			Version{"tag": "2.0.0"},
+			false,
+		),
+		Entry("a pre-release of the upper bound",
+			VersionConfig{Semver: map[string]string{"tag": ">=1.2 <2"}},
+			Version{"tag": "2.0.0-rc.1"},
+			false,
+		),
+		Entry("a pre-release of a pre-release bound",
+			VersionConfig{Semver: map[string]string{"tag": ">=1.2.0-rc.0 <2"}},
+			Version{"tag": "1.2.0-rc.1"},
+			true,
+		),
+		Entry("a pre-release of another version than a pre-release bound",
+			VersionConfig{Semver: map[string]string{"tag": ">=1.2.0-rc.0 <2"}},
+			Version{"tag": "1.3.0-rc.1"},
+			false,
+		),
+		Entry("a pre-release of the lower bound",
+			VersionConfig{Semver: map[string]string{"tag": ">=1.2"}},
+			Version{"tag": "1.2.0-rc.1"},
+			false,
+		),
+		Entry("a version which is not semver",
+			VersionConfig{Semver: map[string]string{"tag": ">=1.2"}},
+			Version{"tag": "latest"},
+			false,
+		),
+		Entry("a version within one of several alternatives",
+			VersionConfig{Semver: map[string]string{"tag": "<1 || >= 1.4, != 1.4.2"}},
+			Version{"tag": "1.5"},
+			true,
+		),
+		Entry("a version excluded from an alternative",
+			VersionConfig{Semver: map[string]string{"tag": "<1 || >= 1.4, != 1.4.2"}},
+			Version{"tag": "1.4.2+build.7"},
+			false,
+		),
+		Entry("a version satisfying both filters",
+			VersionConfig{
+				Matches: map[string]string{"tag": "^v"},
+				Semver:  map[string]string{"tag": ">=1"},
+			},
+			Version{"tag": "v1.0.0"},
+			true,
+		),
+		Entry("a version satisfying only one filter",
+			VersionConfig{
+				Matches: map[string]string{"tag": "^v"},
+				Semver:  map[string]string{"tag": ">=1"},
+			},
+			Version{"tag": "1.0.0"},
+			false,
+		),
+	)
+
+	Describe("Filter", func() {
+		It("fails on an invalid regular expression", func() {
+			_, err := VersionConfig{Matches: map[string]string{"tag": "(("}}.Filter()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("matches.tag is not a valid regular expression"))
+		})
+
+		It("fails on an invalid semver range", func() {
+			_, err := VersionConfig{Semver: map[string]string{"tag": ">=1.2 ||"}}.Filter()
+			Expect(err).To(MatchError("semver.tag is not a valid semver range: empty constraint in '>=1.2 ||'"))
+		})
+	})
+})
@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/baggageclaim"
 )
 
@@ -196,7 +197,13 @@ func (p *containerProvider) FindOrCreateContainer(
 
 			logger.Debug("fetching-image")
 
-			fetchedImage, err := image.FetchForContainer(ctx, logger, creatingContainer)
+			fetchCtx, span := tracing.StartSpan(ctx, "worker.FetchImage", tracing.Attrs{
+				"worker":    p.worker.Name(),
+				"container": creatingContainer.Handle(),
+			})
+
+			fetchedImage, err := image.FetchForContainer(fetchCtx, logger, creatingContainer)
+			tracing.End(span, err)
 			if err != nil {
 				creatingContainer.Failed()
 				logger.Error("failed-to-fetch-image-for-container", err)
@@ -205,12 +212,18 @@ func (p *containerProvider) FindOrCreateContainer(
 
 			logger.Debug("creating-container-in-garden")
 
+			_, span = tracing.StartSpan(ctx, "worker.CreateContainer", tracing.Attrs{
+				"worker":    p.worker.Name(),
+				"container": creatingContainer.Handle(),
+			})
+
 			gardenContainer, err = p.createGardenContainer(
 				logger,
 				creatingContainer,
 				spec,
 				fetchedImage,
 			)
+			tracing.End(span, err)
 			if err != nil {
 				_, failedErr := creatingContainer.Failed()
 				if failedErr != nil {
@@ -489,6 +502,16 @@ func (p *containerProvider) createGardenContainer(
 		env = append(env, fmt.Sprintf("no_proxy=%s", p.noProxy))
 	}
 
+	limits := garden.Limits{}
+
+	if spec.Limits.CPU != nil {
+		limits.CPU = garden.CPULimits{LimitInShares: *spec.Limits.CPU}
+	}
+
+	if spec.Limits.Memory != nil {
+		limits.Memory = garden.MemoryLimits{LimitInBytes: *spec.Limits.Memory}
+	}
+
 	return p.gardenClient.Create(garden.ContainerSpec{
 		Handle:     creatingContainer.Handle(),
 		RootFSPath: fetchedImage.URL,
@@ -496,6 +519,7 @@ func (p *containerProvider) createGardenContainer(
 		BindMounts: bindMounts,
 		Env:        env,
 		Properties: gardenProperties,
+		Limits:     limits,
 	})
 }
 
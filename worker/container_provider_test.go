@@ -19,12 +19,15 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/atc/tracing"
+	"github.com/concourse/atc/tracing/tracingtest"
 	. "github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/workerfakes"
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/baggageclaim/baggageclaimfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var _ = Describe("ContainerProvider", func() {
@@ -517,6 +520,27 @@ var _ = Describe("ContainerProvider", func() {
 
 		})
 
+		Context("when the spec has container limits", func() {
+			BeforeEach(func() {
+				cpu := uint64(512)
+				memory := uint64(1024 * 1024 * 1024)
+				containerSpec.Limits = ContainerLimits{
+					CPU:    &cpu,
+					Memory: &memory,
+				}
+			})
+
+			It("creates the container with the limits", func() {
+				Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+				actualSpec := fakeGardenClient.CreateArgsForCall(0)
+				Expect(actualSpec.Limits).To(Equal(garden.Limits{
+					CPU:    garden.CPULimits{LimitInShares: 512},
+					Memory: garden.MemoryLimits{LimitInBytes: 1024 * 1024 * 1024},
+				}))
+			})
+		})
+
 		Context("when an input has the path set to the workdir itself", func() {
 			BeforeEach(func() {
 				fakeLocalInput.DestinationPathReturns("/some/work-dir")
@@ -637,6 +661,27 @@ var _ = Describe("ContainerProvider", func() {
 			ItHandlesNonExistentContainer(func() int {
 				return fakeDBTeam.CreateContainerCallCount()
 			})
+
+			Context("when tracing is configured", func() {
+				var recorder *tracingtest.Recorder
+
+				BeforeEach(func() {
+					recorder = tracingtest.Install()
+
+					fakeGardenClient.LookupReturns(nil, garden.ContainerNotFoundError{})
+				})
+
+				BeforeEach(CertsVolumeExists)
+
+				AfterEach(func() {
+					tracing.ConfigureTraceProvider(trace.NewNoopTracerProvider())
+				})
+
+				It("records spans for fetching the image and creating the container", func() {
+					Expect(recorder.SpanNames()).To(Equal([]string{"worker.FetchImage", "worker.CreateContainer"}))
+					Expect(recorder.Attributes("worker.CreateContainer")).To(HaveKeyWithValue("container", "some-handle"))
+				})
+			})
 		})
 	})
 
@@ -38,6 +38,14 @@ type ContainerSpec struct {
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Resource limits to apply to the container.
+	Limits ContainerLimits
+}
+
+type ContainerLimits struct {
+	CPU    *uint64
+	Memory *uint64
 }
 
 // OutputPaths is a mapping from output name to its path in the container.
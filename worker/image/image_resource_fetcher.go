@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 )
 
@@ -117,6 +118,22 @@ func (i *imageResourceFetcher) Fetch(
 	logger lager.Logger,
 	container db.CreatingContainer,
 	privileged bool,
+) (worker.Volume, io.ReadCloser, atc.Version, error) {
+	ctx, span := tracing.StartSpan(ctx, "image.Fetch", tracing.Attrs{
+		"type": i.imageResource.Type,
+	})
+
+	volume, metadataReader, version, err := i.fetch(ctx, logger, container, privileged)
+	tracing.End(span, err)
+
+	return volume, metadataReader, version, err
+}
+
+func (i *imageResourceFetcher) fetch(
+	ctx context.Context,
+	logger lager.Logger,
+	container db.CreatingContainer,
+	privileged bool,
 ) (worker.Volume, io.ReadCloser, atc.Version, error) {
 	version := i.version
 	if version == nil {
@@ -1,14 +1,65 @@
 package worker
 
 import (
+	"fmt"
 	"math/rand"
+	"strings"
+	"sync"
 	"time"
+
+	"code.cloudfoundry.org/clock"
 )
 
 type ContainerPlacementStrategy interface {
 	Choose([]Worker, ContainerSpec) (Worker, error)
 }
 
+// candidateStrategy is implemented by placement strategies which can narrow
+// down a set of workers to the ones they consider equally good, allowing them
+// to be chained together with NewChainedPlacementStrategy.
+type candidateStrategy interface {
+	Candidates([]Worker, ContainerSpec) ([]Worker, error)
+}
+
+// placementRecorder is implemented by placement strategies which need to know
+// which worker was eventually chosen.
+type placementRecorder interface {
+	Placed(Worker)
+}
+
+type UnknownPlacementStrategyError struct {
+	Name string
+}
+
+func (err UnknownPlacementStrategyError) Error() string {
+	return fmt.Sprintf("unknown container placement strategy: %s", err.Name)
+}
+
+// NewContainerPlacementStrategy constructs the strategy named by the given
+// comma-separated list, e.g. "fewest-build-containers,volume-locality". Each
+// strategy in the list only breaks ties left by the ones before it.
+func NewContainerPlacementStrategy(names string) (ContainerPlacementStrategy, error) {
+	strategies := []ContainerPlacementStrategy{}
+	for _, name := range strings.Split(names, ",") {
+		switch strings.TrimSpace(name) {
+		case "volume-locality":
+			strategies = append(strategies, NewVolumeLocalityPlacementStrategy())
+		case "random":
+			strategies = append(strategies, NewRandomPlacementStrategy())
+		case "fewest-build-containers":
+			strategies = append(strategies, NewFewestBuildContainersPlacementStrategy())
+		default:
+			return nil, UnknownPlacementStrategyError{Name: name}
+		}
+	}
+
+	if len(strategies) == 1 {
+		return strategies[0], nil
+	}
+
+	return NewChainedPlacementStrategy(strategies...), nil
+}
+
 type VolumeLocalityPlacementStrategy struct {
 	rand *rand.Rand
 }
@@ -20,6 +71,15 @@ func NewVolumeLocalityPlacementStrategy() ContainerPlacementStrategy {
 }
 
 func (strategy *VolumeLocalityPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+	highestLocalityWorkers, err := strategy.Candidates(workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return highestLocalityWorkers[strategy.rand.Intn(len(highestLocalityWorkers))], nil
+}
+
+func (strategy *VolumeLocalityPlacementStrategy) Candidates(workers []Worker, spec ContainerSpec) ([]Worker, error) {
 	workersByCount := map[int][]Worker{}
 	var highestCount int
 	for _, w := range workers {
@@ -43,9 +103,7 @@ func (strategy *VolumeLocalityPlacementStrategy) Choose(workers []Worker, spec C
 		}
 	}
 
-	highestLocalityWorkers := workersByCount[highestCount]
-
-	return highestLocalityWorkers[strategy.rand.Intn(len(highestLocalityWorkers))], nil
+	return workersByCount[highestCount], nil
 }
 
 type RandomPlacementStrategy struct {
@@ -61,3 +119,206 @@ func NewRandomPlacementStrategy() ContainerPlacementStrategy {
 func (strategy *RandomPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
 	return workers[strategy.rand.Intn(len(workers))], nil
 }
+
+func (strategy *RandomPlacementStrategy) Candidates(workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	return workers, nil
+}
+
+// InFlightPlacementTTL is the longest a placement made by the
+// FewestBuildContainersPlacementStrategy is counted against a worker. Worker
+// container counts are only refreshed on heartbeat, so placements made in
+// between would otherwise all land on the same worker.
+//
+// A placement stops being counted as soon as the worker's reported count
+// grows to include it, so that its container is not counted twice. The TTL
+// covers placements whose containers never show up, e.g. because creating
+// them failed.
+const InFlightPlacementTTL = time.Minute
+
+type FewestBuildContainersPlacementStrategy struct {
+	rand  *rand.Rand
+	clock clock.Clock
+
+	inFlightL sync.Mutex
+	inFlight  map[string]*inFlightPlacements
+}
+
+// inFlightPlacements are the placements on a worker which its reported
+// container count does not include yet.
+type inFlightPlacements struct {
+	// the worker's reported count when the oldest placement was made
+	reported int
+
+	placedAt []time.Time
+}
+
+func NewFewestBuildContainersPlacementStrategy() ContainerPlacementStrategy {
+	return &FewestBuildContainersPlacementStrategy{
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		clock:    clock.NewClock(),
+		inFlight: map[string]*inFlightPlacements{},
+	}
+}
+
+func (strategy *FewestBuildContainersPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+	leastLoadedWorkers, err := strategy.Candidates(workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	chosenWorker := leastLoadedWorkers[strategy.rand.Intn(len(leastLoadedWorkers))]
+
+	strategy.Placed(chosenWorker)
+
+	return chosenWorker, nil
+}
+
+func (strategy *FewestBuildContainersPlacementStrategy) Candidates(workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	strategy.inFlightL.Lock()
+	defer strategy.inFlightL.Unlock()
+
+	strategy.expireInFlight()
+
+	var leastLoadedWorkers []Worker
+	var fewestContainers, fewestVolumes int
+	for _, w := range workers {
+		containers := w.ActiveContainers() + strategy.unreported(w)
+		volumes := w.ActiveVolumes()
+
+		switch {
+		case leastLoadedWorkers == nil,
+			containers < fewestContainers,
+			containers == fewestContainers && volumes < fewestVolumes:
+			leastLoadedWorkers = []Worker{w}
+			fewestContainers = containers
+			fewestVolumes = volumes
+		case containers == fewestContainers && volumes == fewestVolumes:
+			leastLoadedWorkers = append(leastLoadedWorkers, w)
+		}
+	}
+
+	return leastLoadedWorkers, nil
+}
+
+func (strategy *FewestBuildContainersPlacementStrategy) Placed(w Worker) {
+	strategy.inFlightL.Lock()
+	defer strategy.inFlightL.Unlock()
+
+	placements, found := strategy.inFlight[w.Name()]
+	if !found {
+		placements = &inFlightPlacements{reported: w.ActiveContainers()}
+		strategy.inFlight[w.Name()] = placements
+	}
+
+	placements.placedAt = append(placements.placedAt, strategy.clock.Now())
+}
+
+// unreported returns how many placements on the worker are not yet included
+// in its reported container count, forgetting those which now are. Containers
+// may also have been removed since, so this is a best guess.
+func (strategy *FewestBuildContainersPlacementStrategy) unreported(w Worker) int {
+	placements, found := strategy.inFlight[w.Name()]
+	if !found {
+		return 0
+	}
+
+	reported := w.ActiveContainers()
+
+	if reported > placements.reported {
+		appeared := reported - placements.reported
+		if appeared > len(placements.placedAt) {
+			appeared = len(placements.placedAt)
+		}
+
+		placements.placedAt = placements.placedAt[appeared:]
+	}
+
+	placements.reported = reported
+
+	if len(placements.placedAt) == 0 {
+		delete(strategy.inFlight, w.Name())
+		return 0
+	}
+
+	return len(placements.placedAt)
+}
+
+func (strategy *FewestBuildContainersPlacementStrategy) expireInFlight() {
+	cutoff := strategy.clock.Now().Add(-InFlightPlacementTTL)
+
+	for name, placements := range strategy.inFlight {
+		live := placements.placedAt[:0]
+		for _, placedAt := range placements.placedAt {
+			if placedAt.After(cutoff) {
+				live = append(live, placedAt)
+			}
+		}
+
+		if len(live) == 0 {
+			delete(strategy.inFlight, name)
+		} else {
+			placements.placedAt = live
+		}
+	}
+}
+
+type ChainedPlacementStrategy struct {
+	rand       *rand.Rand
+	strategies []ContainerPlacementStrategy
+}
+
+// NewChainedPlacementStrategy returns a strategy which applies each of the
+// given strategies in order, with each one choosing among the workers the
+// previous ones considered equally good.
+func NewChainedPlacementStrategy(strategies ...ContainerPlacementStrategy) ContainerPlacementStrategy {
+	return &ChainedPlacementStrategy{
+		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		strategies: strategies,
+	}
+}
+
+func (strategy *ChainedPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+	candidates, err := strategy.Candidates(workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	chosenWorker := candidates[strategy.rand.Intn(len(candidates))]
+
+	for _, s := range strategy.strategies {
+		if recorder, ok := s.(placementRecorder); ok {
+			recorder.Placed(chosenWorker)
+		}
+	}
+
+	return chosenWorker, nil
+}
+
+func (strategy *ChainedPlacementStrategy) Candidates(workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	candidates := workers
+
+	for _, s := range strategy.strategies {
+		if len(candidates) == 1 {
+			break
+		}
+
+		cs, ok := s.(candidateStrategy)
+		if !ok {
+			chosenWorker, err := s.Choose(candidates, spec)
+			if err != nil {
+				return nil, err
+			}
+
+			return []Worker{chosenWorker}, nil
+		}
+
+		narrowed, err := cs.Candidates(candidates, spec)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = narrowed
+	}
+
+	return candidates, nil
+}
@@ -208,3 +208,224 @@ var _ = Describe("RandomPlacementStrategy", func() {
 		})
 	})
 })
+
+var _ = Describe("FewestBuildContainersPlacementStrategy", func() {
+	var (
+		busyWorker      *workerfakes.FakeWorker
+		idleWorker1     *workerfakes.FakeWorker
+		idleWorker2     *workerfakes.FakeWorker
+		idleFewVolumes  *workerfakes.FakeWorker
+		idleManyVolumes *workerfakes.FakeWorker
+	)
+
+	BeforeEach(func() {
+		strategy = NewFewestBuildContainersPlacementStrategy()
+
+		spec = ContainerSpec{
+			ImageSpec: ImageSpec{ResourceType: "some-type"},
+			TeamID:    4567,
+		}
+
+		busyWorker = new(workerfakes.FakeWorker)
+		busyWorker.NameReturns("busy-worker")
+		busyWorker.ActiveContainersReturns(100)
+
+		idleWorker1 = new(workerfakes.FakeWorker)
+		idleWorker1.NameReturns("idle-worker-1")
+		idleWorker1.ActiveContainersReturns(2)
+
+		idleWorker2 = new(workerfakes.FakeWorker)
+		idleWorker2.NameReturns("idle-worker-2")
+		idleWorker2.ActiveContainersReturns(2)
+
+		idleFewVolumes = new(workerfakes.FakeWorker)
+		idleFewVolumes.NameReturns("idle-few-volumes")
+		idleFewVolumes.ActiveContainersReturns(2)
+		idleFewVolumes.ActiveVolumesReturns(3)
+
+		idleManyVolumes = new(workerfakes.FakeWorker)
+		idleManyVolumes.NameReturns("idle-many-volumes")
+		idleManyVolumes.ActiveContainersReturns(2)
+		idleManyVolumes.ActiveVolumesReturns(30)
+	})
+
+	Describe("Choose", func() {
+		JustBeforeEach(func() {
+			chosenWorker, chooseErr = strategy.Choose(
+				workers,
+				spec,
+			)
+		})
+
+		Context("with one having the fewest containers", func() {
+			BeforeEach(func() {
+				workers = []Worker{busyWorker, idleWorker1}
+			})
+
+			It("creates it on the worker with the fewest containers", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(Equal(idleWorker1))
+			})
+		})
+
+		Context("with multiple having the same amount of containers", func() {
+			BeforeEach(func() {
+				workers = []Worker{idleManyVolumes, idleFewVolumes, busyWorker}
+			})
+
+			It("creates it on the one with the fewest volumes", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(Equal(idleFewVolumes))
+			})
+		})
+
+		Context("when placements have already been made", func() {
+			BeforeEach(func() {
+				workers = []Worker{idleWorker1, idleWorker2, busyWorker}
+			})
+
+			It("counts them against the worker for the in-flight placement TTL", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+
+				workerChoiceCounts := map[Worker]int{chosenWorker: 1}
+
+				for i := 0; i < 99; i++ {
+					worker, err := strategy.Choose(
+						workers,
+						spec,
+					)
+					Expect(err).ToNot(HaveOccurred())
+					workerChoiceCounts[worker]++
+				}
+
+				Expect(workerChoiceCounts[idleWorker1]).To(BeNumerically("~", 50, 1))
+				Expect(workerChoiceCounts[idleWorker2]).To(BeNumerically("~", 50, 1))
+				Expect(workerChoiceCounts[busyWorker]).To(BeZero())
+			})
+		})
+
+		Context("when a placement shows up in the worker's container count", func() {
+			BeforeEach(func() {
+				workers = []Worker{idleWorker1, busyWorker}
+			})
+
+			It("no longer counts it as in flight", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(Equal(idleWorker1))
+
+				idleWorker1.ActiveContainersReturns(3)
+				idleWorker2.ActiveContainersReturns(3)
+
+				candidates, err := strategy.(*FewestBuildContainersPlacementStrategy).Candidates(
+					[]Worker{idleWorker1, idleWorker2},
+					spec,
+				)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(candidates).To(ConsistOf(idleWorker1, idleWorker2))
+			})
+
+			It("still counts placements which have not shown up yet", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+
+				_, err := strategy.Choose(workers, spec)
+				Expect(err).ToNot(HaveOccurred())
+
+				idleWorker1.ActiveContainersReturns(3)
+				idleWorker2.ActiveContainersReturns(3)
+
+				candidates, err := strategy.(*FewestBuildContainersPlacementStrategy).Candidates(
+					[]Worker{idleWorker1, idleWorker2},
+					spec,
+				)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(candidates).To(ConsistOf(idleWorker2))
+			})
+		})
+	})
+})
+
+var _ = Describe("NewContainerPlacementStrategy", func() {
+	It("constructs a single strategy", func() {
+		strategy, err := NewContainerPlacementStrategy("random")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(strategy).To(BeAssignableToTypeOf(&RandomPlacementStrategy{}))
+	})
+
+	It("chains multiple strategies", func() {
+		strategy, err := NewContainerPlacementStrategy("fewest-build-containers,volume-locality")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(strategy).To(BeAssignableToTypeOf(&ChainedPlacementStrategy{}))
+	})
+
+	It("errors on unknown strategies", func() {
+		_, err := NewContainerPlacementStrategy("volume-locality,bogus")
+		Expect(err).To(Equal(UnknownPlacementStrategyError{Name: "bogus"}))
+	})
+})
+
+var _ = Describe("ChainedPlacementStrategy", func() {
+	var (
+		localWorker  *workerfakes.FakeWorker
+		remoteWorker *workerfakes.FakeWorker
+		busyWorker   *workerfakes.FakeWorker
+	)
+
+	BeforeEach(func() {
+		strategy = NewChainedPlacementStrategy(
+			NewFewestBuildContainersPlacementStrategy(),
+			NewVolumeLocalityPlacementStrategy(),
+		)
+
+		localWorker = new(workerfakes.FakeWorker)
+		localWorker.NameReturns("local-worker")
+		localWorker.ActiveContainersReturns(5)
+
+		remoteWorker = new(workerfakes.FakeWorker)
+		remoteWorker.NameReturns("remote-worker")
+		remoteWorker.ActiveContainersReturns(5)
+
+		busyWorker = new(workerfakes.FakeWorker)
+		busyWorker.NameReturns("busy-worker")
+		busyWorker.ActiveContainersReturns(50)
+
+		fakeInput := new(workerfakes.FakeInputSource)
+		fakeInputAS := new(workerfakes.FakeArtifactSource)
+		fakeInputAS.VolumeOnStub = func(worker Worker) (Volume, bool, error) {
+			switch worker {
+			case localWorker, busyWorker:
+				return new(workerfakes.FakeVolume), true, nil
+			default:
+				return nil, false, nil
+			}
+		}
+		fakeInput.SourceReturns(fakeInputAS)
+
+		spec = ContainerSpec{
+			ImageSpec: ImageSpec{ResourceType: "some-type"},
+			TeamID:    4567,
+			Inputs:    []InputSource{fakeInput},
+		}
+
+		workers = []Worker{busyWorker, remoteWorker, localWorker}
+	})
+
+	JustBeforeEach(func() {
+		chosenWorker, chooseErr = strategy.Choose(
+			workers,
+			spec,
+		)
+	})
+
+	It("breaks ties using the later strategies", func() {
+		Expect(chooseErr).ToNot(HaveOccurred())
+		Expect(chosenWorker).To(Equal(localWorker))
+	})
+
+	It("records the placement for in-flight tracking", func() {
+		Expect(chooseErr).ToNot(HaveOccurred())
+
+		worker, err := strategy.Choose(workers, spec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(worker).To(Equal(remoteWorker))
+	})
+})
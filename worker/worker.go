@@ -48,6 +48,7 @@ type Worker interface {
 	Client
 
 	ActiveContainers() int
+	ActiveVolumes() int
 
 	Description() string
 	Name() string
@@ -76,6 +77,7 @@ type gardenWorker struct {
 	clock clock.Clock
 
 	activeContainers int
+	activeVolumes    int
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
 	tags             atc.Tags
@@ -102,6 +104,7 @@ func NewGardenWorker(
 
 		clock:            clock,
 		activeContainers: dbWorker.ActiveContainers(),
+		activeVolumes:    dbWorker.ActiveVolumes(),
 		resourceTypes:    dbWorker.ResourceTypes(),
 		platform:         dbWorker.Platform(),
 		tags:             dbWorker.Tags(),
@@ -210,6 +213,10 @@ func (worker *gardenWorker) ActiveContainers() int {
 	return worker.activeContainers
 }
 
+func (worker *gardenWorker) ActiveVolumes() int {
+	return worker.activeVolumes
+}
+
 func (worker *gardenWorker) Satisfying(logger lager.Logger, spec WorkerSpec, resourceTypes creds.VersionedResourceTypes) (Worker, error) {
 	if spec.TeamID != worker.teamID && worker.teamID != 0 {
 		return nil, ErrTeamMismatch
@@ -39,6 +39,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		// unauthenticated / delegating to handler
 		case atc.DownloadCLI,
 			atc.CheckResourceWebHook,
+			atc.CheckTeamWebHook,
 			atc.GetInfo,
 			atc.ListTeams,
 			atc.ListAllPipelines,
@@ -95,9 +96,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			newHandler = wrappa.checkPipelineAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// authenticated
-		case atc.CreateBuild,
+		case atc.GetUserRoles,
 			atc.GetContainer,
-			atc.HijackContainer,
 			atc.ListContainers,
 			atc.ListWorkers,
 			atc.RegisterWorker,
@@ -111,31 +111,49 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
 		case atc.GetLogLevel,
-			atc.SetLogLevel:
+			atc.SetLogLevel,
+			atc.GetEncryptionKeyRotation:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
-		// authorized (requested team matches resource team)
+		// authorized with any role (requested team matches resource team)
+		case atc.GetConfig,
+			atc.GetConfigVersion,
+			atc.GetVersionsDB,
+			atc.ListConfigVersions,
+			atc.ListJobInputs,
+			atc.GetJobInputResolution,
+			atc.ListNotificationDeliveries,
+			atc.TeamEvents,
+			atc.ListPipelineCredentials:
+			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
+
+		// authorized as operator or above
 		case atc.CheckResource,
 			atc.CreateJobBuild,
-			atc.CreatePipelineBuild,
-			atc.DeletePipeline,
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
-			atc.GetConfig,
-			atc.GetVersionsDB,
-			atc.ListJobInputs,
-			atc.OrderPipelines,
 			atc.PauseJob,
 			atc.PausePipeline,
 			atc.PauseResource,
-			atc.RenamePipeline,
+			atc.PinResourceVersion,
 			atc.UnpauseJob,
 			atc.UnpausePipeline,
 			atc.UnpauseResource,
+			atc.UnpinResource:
+			newHandler = auth.CheckTeamRoleHandler(handler, rejector, atc.OperatorRole)
+
+		// authorized as member or above
+		case atc.CreateBuild,
+			atc.CreatePipelineBuild,
+			atc.DeletePipeline,
 			atc.ExposePipeline,
 			atc.HidePipeline,
+			atc.HijackContainer,
+			atc.OrderPipelines,
+			atc.RenamePipeline,
+			atc.RollbackConfig,
 			atc.SaveConfig:
-			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
+			newHandler = auth.CheckTeamRoleHandler(handler, rejector, atc.MemberRole)
 
 		// think about it!
 		default:
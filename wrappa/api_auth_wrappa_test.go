@@ -74,6 +74,22 @@ var _ = Describe("APIAuthWrappa", func() {
 		)
 	}
 
+	hasRole := func(role string) func(http.Handler) http.Handler {
+		return func(handler http.Handler) http.Handler {
+			return auth.CSRFValidationHandler(
+				auth.CheckTeamRoleHandler(
+					handler,
+					rejector,
+					role,
+				),
+				rejector,
+			)
+		}
+	}
+
+	operator := hasRole(atc.OperatorRole)
+	member := hasRole(atc.MemberRole)
+
 	openForPublicPipelineOrAuthorized := func(handler http.Handler) http.Handler {
 		return auth.CSRFValidationHandler(
 			fakeCheckPipelineAccessHandlerFactory.HandlerFor(
@@ -144,6 +160,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetInfo:              unauthenticated(inputHandlers[atc.GetInfo]),
 				atc.DownloadCLI:          unauthenticated(inputHandlers[atc.DownloadCLI]),
 				atc.CheckResourceWebHook: unauthenticated(inputHandlers[atc.CheckResourceWebHook]),
+				atc.CheckTeamWebHook:     unauthenticated(inputHandlers[atc.CheckTeamWebHook]),
 				atc.ListAllPipelines:     unauthenticated(inputHandlers[atc.ListAllPipelines]),
 				atc.ListBuilds:           unauthenticated(inputHandlers[atc.ListBuilds]),
 				atc.ListPipelines:        unauthenticated(inputHandlers[atc.ListPipelines]),
@@ -194,9 +211,8 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetResourceVersion:            openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResourceVersion]),
 
 				// authenticated
-				atc.CreateBuild:     authenticated(inputHandlers[atc.CreateBuild]),
+				atc.GetUserRoles:    authenticated(inputHandlers[atc.GetUserRoles]),
 				atc.GetContainer:    authenticated(inputHandlers[atc.GetContainer]),
-				atc.HijackContainer: authenticated(inputHandlers[atc.HijackContainer]),
 				atc.ListContainers:  authenticated(inputHandlers[atc.ListContainers]),
 				atc.ListVolumes:     authenticated(inputHandlers[atc.ListVolumes]),
 				atc.ListTeamBuilds:  authenticated(inputHandlers[atc.ListTeamBuilds]),
@@ -209,30 +225,46 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.DestroyTeam:     authenticated(inputHandlers[atc.DestroyTeam]),
 
 				// authenticated and is admin
-				atc.GetLogLevel: authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
-				atc.SetLogLevel: authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
-
-				// authorized (requested team matches resource team)
-				atc.CheckResource:          authorized(inputHandlers[atc.CheckResource]),
-				atc.CreateJobBuild:         authorized(inputHandlers[atc.CreateJobBuild]),
-				atc.DeletePipeline:         authorized(inputHandlers[atc.DeletePipeline]),
-				atc.DisableResourceVersion: authorized(inputHandlers[atc.DisableResourceVersion]),
-				atc.EnableResourceVersion:  authorized(inputHandlers[atc.EnableResourceVersion]),
-				atc.GetConfig:              authorized(inputHandlers[atc.GetConfig]),
-				atc.GetVersionsDB:          authorized(inputHandlers[atc.GetVersionsDB]),
-				atc.ListJobInputs:          authorized(inputHandlers[atc.ListJobInputs]),
-				atc.OrderPipelines:         authorized(inputHandlers[atc.OrderPipelines]),
-				atc.PauseJob:               authorized(inputHandlers[atc.PauseJob]),
-				atc.PausePipeline:          authorized(inputHandlers[atc.PausePipeline]),
-				atc.PauseResource:          authorized(inputHandlers[atc.PauseResource]),
-				atc.RenamePipeline:         authorized(inputHandlers[atc.RenamePipeline]),
-				atc.SaveConfig:             authorized(inputHandlers[atc.SaveConfig]),
-				atc.UnpauseJob:             authorized(inputHandlers[atc.UnpauseJob]),
-				atc.UnpausePipeline:        authorized(inputHandlers[atc.UnpausePipeline]),
-				atc.UnpauseResource:        authorized(inputHandlers[atc.UnpauseResource]),
-				atc.ExposePipeline:         authorized(inputHandlers[atc.ExposePipeline]),
-				atc.HidePipeline:           authorized(inputHandlers[atc.HidePipeline]),
-				atc.CreatePipelineBuild:    authorized(inputHandlers[atc.CreatePipelineBuild]),
+				atc.GetLogLevel:              authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
+				atc.SetLogLevel:              authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
+				atc.GetEncryptionKeyRotation: authenticatedAndAdmin(inputHandlers[atc.GetEncryptionKeyRotation]),
+
+				// authorized with any role (requested team matches resource team)
+				atc.GetConfig:                  authorized(inputHandlers[atc.GetConfig]),
+				atc.GetConfigVersion:           authorized(inputHandlers[atc.GetConfigVersion]),
+				atc.GetVersionsDB:              authorized(inputHandlers[atc.GetVersionsDB]),
+				atc.ListConfigVersions:         authorized(inputHandlers[atc.ListConfigVersions]),
+				atc.ListJobInputs:              authorized(inputHandlers[atc.ListJobInputs]),
+				atc.GetJobInputResolution:      authorized(inputHandlers[atc.GetJobInputResolution]),
+				atc.ListNotificationDeliveries: authorized(inputHandlers[atc.ListNotificationDeliveries]),
+				atc.TeamEvents:                 authorized(inputHandlers[atc.TeamEvents]),
+				atc.ListPipelineCredentials:    authorized(inputHandlers[atc.ListPipelineCredentials]),
+
+				// authorized as operator or above
+				atc.CheckResource:          operator(inputHandlers[atc.CheckResource]),
+				atc.CreateJobBuild:         operator(inputHandlers[atc.CreateJobBuild]),
+				atc.DisableResourceVersion: operator(inputHandlers[atc.DisableResourceVersion]),
+				atc.EnableResourceVersion:  operator(inputHandlers[atc.EnableResourceVersion]),
+				atc.PauseJob:               operator(inputHandlers[atc.PauseJob]),
+				atc.PausePipeline:          operator(inputHandlers[atc.PausePipeline]),
+				atc.PauseResource:          operator(inputHandlers[atc.PauseResource]),
+				atc.PinResourceVersion:     operator(inputHandlers[atc.PinResourceVersion]),
+				atc.UnpauseJob:             operator(inputHandlers[atc.UnpauseJob]),
+				atc.UnpausePipeline:        operator(inputHandlers[atc.UnpausePipeline]),
+				atc.UnpauseResource:        operator(inputHandlers[atc.UnpauseResource]),
+				atc.UnpinResource:          operator(inputHandlers[atc.UnpinResource]),
+
+				// authorized as member or above
+				atc.CreateBuild:         member(inputHandlers[atc.CreateBuild]),
+				atc.CreatePipelineBuild: member(inputHandlers[atc.CreatePipelineBuild]),
+				atc.DeletePipeline:      member(inputHandlers[atc.DeletePipeline]),
+				atc.ExposePipeline:      member(inputHandlers[atc.ExposePipeline]),
+				atc.HidePipeline:        member(inputHandlers[atc.HidePipeline]),
+				atc.HijackContainer:     member(inputHandlers[atc.HijackContainer]),
+				atc.OrderPipelines:      member(inputHandlers[atc.OrderPipelines]),
+				atc.RenamePipeline:      member(inputHandlers[atc.RenamePipeline]),
+				atc.RollbackConfig:      member(inputHandlers[atc.RollbackConfig]),
+				atc.SaveConfig:          member(inputHandlers[atc.SaveConfig]),
 			}
 		})
 
@@ -0,0 +1,48 @@
+package wrappa
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/tracing"
+	"github.com/tedsuo/rata"
+)
+
+type APITracingWrappa struct{}
+
+func NewAPITracingWrappa() Wrappa {
+	return APITracingWrappa{}
+}
+
+func (wrappa APITracingWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	wrapped := rata.Handlers{}
+
+	for name, handler := range handlers {
+		switch name {
+		case atc.BuildEvents, atc.DownloadCLI, atc.HijackContainer:
+			wrapped[name] = handler
+		default:
+			wrapped[name] = tracingHandler{
+				route:   name,
+				handler: handler,
+			}
+		}
+	}
+
+	return wrapped
+}
+
+type tracingHandler struct {
+	route   string
+	handler http.Handler
+}
+
+func (handler tracingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(r.Context(), handler.route, tracing.Attrs{
+		"method": r.Method,
+		"path":   r.URL.Path,
+	})
+	defer span.End()
+
+	handler.handler.ServeHTTP(w, r.WithContext(ctx))
+}
@@ -0,0 +1,65 @@
+package wrappa_test
+
+import (
+	"net/http/httptest"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/tracing"
+	"github.com/concourse/atc/tracing/tracingtest"
+	"github.com/concourse/atc/wrappa"
+	"github.com/tedsuo/rata"
+	"go.opentelemetry.io/otel/trace"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APITracingWrappa", func() {
+	var (
+		recorder *tracingtest.Recorder
+
+		inputHandlers   rata.Handlers
+		wrappedHandlers rata.Handlers
+	)
+
+	BeforeEach(func() {
+		recorder = tracingtest.Install()
+
+		inputHandlers = rata.Handlers{}
+
+		for _, route := range atc.Routes {
+			inputHandlers[route.Name] = &stupidHandler{}
+		}
+	})
+
+	AfterEach(func() {
+		tracing.ConfigureTraceProvider(trace.NewNoopTracerProvider())
+	})
+
+	JustBeforeEach(func() {
+		wrappedHandlers = wrappa.NewAPITracingWrappa().Wrap(inputHandlers)
+	})
+
+	It("records a span named after the route", func() {
+		request := httptest.NewRequest("GET", "/api/v1/teams/main/pipelines", nil)
+		wrappedHandlers[atc.ListPipelines].ServeHTTP(httptest.NewRecorder(), request)
+
+		Expect(recorder.SpanNames()).To(Equal([]string{atc.ListPipelines}))
+		Expect(recorder.Attributes(atc.ListPipelines)).To(Equal(map[string]string{
+			"method": "GET",
+			"path":   "/api/v1/teams/main/pipelines",
+		}))
+	})
+
+	It("does not wrap long-lived routes", func() {
+		for _, name := range []string{atc.BuildEvents, atc.DownloadCLI, atc.HijackContainer} {
+			Expect(descriptiveRoute{
+				route:   name,
+				handler: wrappedHandlers[name],
+			}).To(Equal(descriptiveRoute{
+				route:   name,
+				handler: inputHandlers[name],
+			}))
+		}
+	})
+})